@@ -11,6 +11,7 @@ var (
 	ProductCode    string
 	CandleDuration time.Duration
 	TradeHour      int
+	CommissionRate float64
 )
 
 func init() {
@@ -19,4 +20,6 @@ func init() {
 	ProductCode = os.Getenv("PRODUCT_CODE")
 	CandleDuration = 24 * time.Hour
 	TradeHour = 9
+	// 取引手数料率（bitFlyer: 0.15%）
+	CommissionRate = 0.0015
 }
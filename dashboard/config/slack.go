@@ -1,13 +1,52 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strings"
+	"time"
+)
 
 var (
 	SlackBotToken  string
 	SlackChannelID string
+	// 重要度ごとの通知先（指定がなければSlackChannelID）
+	SlackInfoChannelID  string
+	SlackWarnChannelID  string
+	SlackErrorChannelID string
+	// 同じ内容の失敗・見送りを通知しない間隔
+	NotificationDedupInterval time.Duration
+	// スラッシュコマンドの署名を検証するSigning Secret
+	SlackSigningSecret string
+	// スラッシュコマンドを使えるユーザID（operatorは取引の停止・再開も，viewerは閲覧だけ）
+	SlackCommandOperators []string
+	SlackCommandViewers   []string
 )
 
 func init() {
 	SlackBotToken = os.Getenv("SLACK_BOT_TOKEN")
 	SlackChannelID = os.Getenv("SLACK_CHANNEL_ID")
+	SlackInfoChannelID = os.Getenv("SLACK_INFO_CHANNEL_ID")
+	SlackWarnChannelID = os.Getenv("SLACK_WARN_CHANNEL_ID")
+	SlackErrorChannelID = os.Getenv("SLACK_ERROR_CHANNEL_ID")
+
+	NotificationDedupInterval = time.Hour
+	if interval, err := time.ParseDuration(os.Getenv("NOTIFICATION_DEDUP_INTERVAL")); err == nil && interval >= 0 {
+		NotificationDedupInterval = interval
+	}
+
+	SlackSigningSecret = os.Getenv("SLACK_SIGNING_SECRET")
+	SlackCommandOperators = splitSlackUserIDs(os.Getenv("SLACK_COMMAND_OPERATORS"))
+	SlackCommandViewers = splitSlackUserIDs(os.Getenv("SLACK_COMMAND_VIEWERS"))
+}
+
+// カンマ区切りのユーザID
+func splitSlackUserIDs(s string) []string {
+	userIDs := make([]string, 0)
+	for _, userID := range strings.Split(s, ",") {
+		userID = strings.TrimSpace(userID)
+		if userID != "" {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return userIDs
 }
@@ -0,0 +1,242 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// アラートの条件の種類
+type AlertKind string
+
+const (
+	AlertKindPriceAbove  AlertKind = "price_above"  // 価格がthreshold以上になった
+	AlertKindPriceBelow  AlertKind = "price_below"  // 価格がthreshold以下になった
+	AlertKindPriceChange AlertKind = "price_change" // hours時間前からの変化率の絶対値がthreshold（%）以上になった
+	AlertKindIndicator   AlertKind = "indicator"    // 日足でexpressionの式が成り立った（例: rsi(14) < 25）
+)
+
+func (ak AlertKind) Valid() bool {
+	switch ak {
+	case AlertKindPriceAbove, AlertKindPriceBelow, AlertKindPriceChange, AlertKindIndicator:
+		return true
+	}
+	return false
+}
+
+// 価格の変化を見る時間の上限（価格の履歴はこれより古いものを消す）
+const MaxAlertHours = 7 * 24
+
+// 価格や指標が条件を満たしたら通知するルール
+// 条件が成り立たない状態から成り立つ状態に変わったときだけ通知し，
+// 前回の通知からcooldownが経つまでは通知しない
+type AlertRule struct {
+	id          int64 // 保存前は0
+	productCode string
+	kind        AlertKind
+	threshold   float64
+	hours       int
+	expression  *Rule
+	cooldown    time.Duration
+	enable      bool
+	active      bool      // 前回の評価で条件が成り立っていたか
+	notifiedAt  time.Time // 最後に通知した時刻（通知したことがなければゼロ値）
+}
+
+func NewAlertRule(id int64, productCode string, kind AlertKind, threshold float64, hours int, expression string, cooldown time.Duration, enable, active bool, notifiedAt time.Time) *AlertRule {
+	if id < 0 {
+		return nil
+	}
+
+	if productCode == "" {
+		return nil
+	}
+
+	if cooldown < 0 {
+		return nil
+	}
+
+	var rule *Rule
+	switch kind {
+	case AlertKindPriceAbove, AlertKindPriceBelow:
+		if threshold <= 0 {
+			return nil
+		}
+	case AlertKindPriceChange:
+		if threshold <= 0 || hours <= 0 || MaxAlertHours < hours {
+			return nil
+		}
+	case AlertKindIndicator:
+		var err error
+		rule, err = ParseRule(expression)
+		if err != nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	if !notifiedAt.IsZero() {
+		notifiedAt = notifiedAt.In(time.UTC)
+	}
+
+	return &AlertRule{
+		id:          id,
+		productCode: productCode,
+		kind:        kind,
+		threshold:   threshold,
+		hours:       hours,
+		expression:  rule,
+		cooldown:    cooldown,
+		enable:      enable,
+		active:      active,
+		notifiedAt:  notifiedAt,
+	}
+}
+
+func (ar *AlertRule) ID() int64 {
+	return ar.id
+}
+
+func (ar *AlertRule) ProductCode() string {
+	return ar.productCode
+}
+
+func (ar *AlertRule) Kind() AlertKind {
+	return ar.kind
+}
+
+func (ar *AlertRule) Threshold() float64 {
+	return ar.threshold
+}
+
+func (ar *AlertRule) Hours() int {
+	return ar.hours
+}
+
+// indicator以外では空文字
+func (ar *AlertRule) Expression() string {
+	if ar.expression == nil {
+		return ""
+	}
+	return ar.expression.String()
+}
+
+func (ar *AlertRule) Cooldown() time.Duration {
+	return ar.cooldown
+}
+
+func (ar *AlertRule) Enable() bool {
+	return ar.enable
+}
+
+func (ar *AlertRule) Active() bool {
+	return ar.active
+}
+
+func (ar *AlertRule) NotifiedAt() time.Time {
+	return ar.notifiedAt
+}
+
+// 指標の式を評価できるか確かめる（価格のアラートは常に評価できる）
+func (ar *AlertRule) Check() error {
+	if ar.expression == nil {
+		return nil
+	}
+	return ar.expression.Check()
+}
+
+// 通知の見出しに使う条件の説明
+func (ar *AlertRule) Description() string {
+	switch ar.kind {
+	case AlertKindPriceAbove:
+		return fmt.Sprintf("price >= %g", ar.threshold)
+	case AlertKindPriceBelow:
+		return fmt.Sprintf("price <= %g", ar.threshold)
+	case AlertKindPriceChange:
+		return fmt.Sprintf("|change in %dh| >= %g%%", ar.hours, ar.threshold)
+	default:
+		return ar.Expression()
+	}
+}
+
+// 条件が成り立つかどうか
+// pastPriceはhours時間前の価格（分からなければ0），ctxとatは日足のDataFrameと最新の足の位置
+func (ar *AlertRule) Match(price, pastPrice float64, ctx *RuleContext, at int) bool {
+	switch ar.kind {
+	case AlertKindPriceAbove:
+		return price >= ar.threshold
+	case AlertKindPriceBelow:
+		return price <= ar.threshold
+	case AlertKindPriceChange:
+		if pastPrice <= 0 {
+			return false
+		}
+		return math.Abs(price-pastPrice)/pastPrice*100 >= ar.threshold
+	case AlertKindIndicator:
+		return ar.expression.Evaluate(ctx, at)
+	}
+	return false
+}
+
+// 時刻timeTimeに条件がmatchedだったときの次の状態と，通知するかどうか
+func (ar *AlertRule) Next(timeTime time.Time, matched bool) (*AlertRule, bool) {
+	notify := matched && !ar.active &&
+		(ar.notifiedAt.IsZero() || timeTime.Sub(ar.notifiedAt) >= ar.cooldown)
+
+	next := *ar
+	next.active = matched
+	if notify {
+		next.notifiedAt = timeTime.In(time.UTC)
+	}
+	return &next, notify
+}
+
+// 条件を満たしたアラート
+type Alert struct {
+	time      time.Time
+	rule      AlertRule
+	price     float64
+	pastPrice float64
+}
+
+func NewAlert(timeTime time.Time, rule AlertRule, price, pastPrice float64) *Alert {
+	if price <= 0 {
+		return nil
+	}
+
+	timeTime = timeTime.In(time.UTC)
+
+	return &Alert{
+		time:      timeTime,
+		rule:      rule,
+		price:     price,
+		pastPrice: pastPrice,
+	}
+}
+
+func (a *Alert) Time() time.Time {
+	return a.time
+}
+
+func (a *Alert) Rule() AlertRule {
+	return a.rule
+}
+
+func (a *Alert) Price() float64 {
+	return a.price
+}
+
+// price_change以外では0
+func (a *Alert) PastPrice() float64 {
+	return a.pastPrice
+}
+
+func NewAlertNotification(alert Alert) *Notification {
+	rule := alert.Rule()
+	message := fmt.Sprintf("Price: %f", alert.Price())
+	if rule.Kind() == AlertKindPriceChange && alert.PastPrice() > 0 {
+		message += fmt.Sprintf("\n%dh ago: %f (%+.2f%%)", rule.Hours(), alert.PastPrice(), (alert.Price()-alert.PastPrice())/alert.PastPrice()*100)
+	}
+	return NewNotification(alert.Time(), NotificationLevelWarn, rule.ProductCode(), "アラート: "+rule.Description(), message)
+}
@@ -0,0 +1,305 @@
+package model
+
+import (
+	"time"
+)
+
+type BacktestJobStatus string
+
+const (
+	BacktestJobStatusQueued   = BacktestJobStatus("queued")
+	BacktestJobStatusRunning  = BacktestJobStatus("running")
+	BacktestJobStatusDone     = BacktestJobStatus("done")
+	BacktestJobStatusFailed   = BacktestJobStatus("failed")
+	BacktestJobStatusCanceled = BacktestJobStatus("canceled")
+)
+
+// 終了した（これ以上状態が変わらない）か
+func (s BacktestJobStatus) IsFinished() bool {
+	return s == BacktestJobStatusDone ||
+		s == BacktestJobStatusFailed ||
+		s == BacktestJobStatusCanceled
+}
+
+// 非同期に実行するバックテスト
+type BacktestJob struct {
+	id           string
+	strategy     string
+	params       TradeParams
+	start        time.Time
+	end          time.Time
+	status       BacktestJobStatus
+	errorMessage string
+	createdAt    time.Time
+	finishedAt   time.Time
+	result       *BacktestResult
+}
+
+func NewBacktestJob(id, strategy string, params TradeParams, start, end time.Time, createdAt time.Time) *BacktestJob {
+	if id == "" {
+		return nil
+	}
+
+	if strategy == "" {
+		return nil
+	}
+
+	if !start.Before(end) {
+		return nil
+	}
+
+	return &BacktestJob{
+		id:        id,
+		strategy:  strategy,
+		params:    params,
+		start:     start.In(time.UTC),
+		end:       end.In(time.UTC),
+		status:    BacktestJobStatusQueued,
+		createdAt: createdAt.In(time.UTC),
+	}
+}
+
+// DBから復元する
+func RestoreBacktestJob(id, strategy string, params TradeParams, start, end time.Time, status BacktestJobStatus, errorMessage string, createdAt, finishedAt time.Time, result *BacktestResult) *BacktestJob {
+	job := NewBacktestJob(id, strategy, params, start, end, createdAt)
+	if job == nil {
+		return nil
+	}
+
+	switch status {
+	case BacktestJobStatusQueued, BacktestJobStatusRunning, BacktestJobStatusDone, BacktestJobStatusFailed, BacktestJobStatusCanceled:
+	default:
+		return nil
+	}
+
+	job.status = status
+	job.errorMessage = errorMessage
+	job.finishedAt = finishedAt.In(time.UTC)
+	job.result = result
+	return job
+}
+
+func (job *BacktestJob) ID() string {
+	return job.id
+}
+
+func (job *BacktestJob) ProductCode() string {
+	return job.params.ProductCode()
+}
+
+func (job *BacktestJob) Strategy() string {
+	return job.strategy
+}
+
+func (job *BacktestJob) Params() TradeParams {
+	return job.params
+}
+
+func (job *BacktestJob) Start() time.Time {
+	return job.start
+}
+
+func (job *BacktestJob) End() time.Time {
+	return job.end
+}
+
+func (job *BacktestJob) Status() BacktestJobStatus {
+	return job.status
+}
+
+func (job *BacktestJob) ErrorMessage() string {
+	return job.errorMessage
+}
+
+func (job *BacktestJob) CreatedAt() time.Time {
+	return job.createdAt
+}
+
+func (job *BacktestJob) FinishedAt() time.Time {
+	return job.finishedAt
+}
+
+func (job *BacktestJob) Result() *BacktestResult {
+	return job.result
+}
+
+// 待機中のジョブを実行中にする
+func (job *BacktestJob) Run() bool {
+	if job.status != BacktestJobStatusQueued {
+		return false
+	}
+	job.status = BacktestJobStatusRunning
+	return true
+}
+
+func (job *BacktestJob) Complete(result *BacktestResult, finishedAt time.Time) bool {
+	if job.status != BacktestJobStatusRunning || result == nil {
+		return false
+	}
+	job.status = BacktestJobStatusDone
+	job.result = result
+	job.finishedAt = finishedAt.In(time.UTC)
+	return true
+}
+
+func (job *BacktestJob) Fail(err error, finishedAt time.Time) bool {
+	if job.status.IsFinished() || err == nil {
+		return false
+	}
+	job.status = BacktestJobStatusFailed
+	job.errorMessage = err.Error()
+	job.finishedAt = finishedAt.In(time.UTC)
+	return true
+}
+
+func (job *BacktestJob) Cancel(finishedAt time.Time) bool {
+	if job.status.IsFinished() {
+		return false
+	}
+	job.status = BacktestJobStatusCanceled
+	job.finishedAt = finishedAt.In(time.UTC)
+	return true
+}
+
+// 資産推移の1点
+type EquityPoint struct {
+	time   time.Time
+	equity float64
+}
+
+func NewEquityPoint(timeTime time.Time, equity float64) EquityPoint {
+	return EquityPoint{
+		time:   timeTime.In(time.UTC),
+		equity: equity,
+	}
+}
+
+func (p EquityPoint) Time() time.Time {
+	return p.time
+}
+
+func (p EquityPoint) Equity() float64 {
+	return p.equity
+}
+
+// バックテストの結果
+type BacktestResult struct {
+	signals     []SignalEvent
+	profit      float64
+	tradeCount  int
+	winCount    int
+	maxDrawdown float64
+	equityCurve []EquityPoint
+}
+
+// 売買履歴とキャンドルから結果を集計する
+// 資産推移は，元手を0として確定損益と保有分の評価損益を足したもの
+func NewBacktestResult(candles []Candle, events *SignalEvents) *BacktestResult {
+	if events == nil {
+		return nil
+	}
+
+	signals := events.Signals()
+	profit := events.EstimateProfit()
+
+	var tradeCount, winCount int
+	var buyPrice float64
+	for _, signal := range signals {
+		switch signal.Side() {
+		case OrderSideBuy:
+			buyPrice = signal.Price()
+		case OrderSideSell:
+			tradeCount++
+			if signal.Price() > buyPrice {
+				winCount++
+			}
+		}
+	}
+
+	equityCurve := make([]EquityPoint, 0, len(candles))
+	var cash, position, peak, maxDrawdown float64
+	i := 0
+	for _, candle := range candles {
+		for ; i < len(signals) && !signals[i].Time().After(candle.Time().Time()); i++ {
+			amount := signals[i].Price() * signals[i].Size()
+			switch signals[i].Side() {
+			case OrderSideBuy:
+				cash -= amount
+				position += signals[i].Size()
+			case OrderSideSell:
+				cash += amount
+				position -= signals[i].Size()
+			}
+		}
+
+		equity := cash + position*candle.Close()
+		equityCurve = append(equityCurve, NewEquityPoint(candle.Time().Time(), equity))
+
+		if equity > peak {
+			peak = equity
+		}
+		if peak-equity > maxDrawdown {
+			maxDrawdown = peak - equity
+		}
+	}
+
+	return &BacktestResult{
+		signals:     signals,
+		profit:      profit,
+		tradeCount:  tradeCount,
+		winCount:    winCount,
+		maxDrawdown: maxDrawdown,
+		equityCurve: equityCurve,
+	}
+}
+
+// DBから復元する
+func RestoreBacktestResult(signals []SignalEvent, profit float64, tradeCount, winCount int, maxDrawdown float64, equityCurve []EquityPoint) *BacktestResult {
+	if signals == nil || equityCurve == nil {
+		return nil
+	}
+
+	if tradeCount < 0 || winCount < 0 || tradeCount < winCount {
+		return nil
+	}
+
+	return &BacktestResult{
+		signals:     signals,
+		profit:      profit,
+		tradeCount:  tradeCount,
+		winCount:    winCount,
+		maxDrawdown: maxDrawdown,
+		equityCurve: equityCurve,
+	}
+}
+
+func (r *BacktestResult) Signals() []SignalEvent {
+	return r.signals
+}
+
+func (r *BacktestResult) Profit() float64 {
+	return r.profit
+}
+
+func (r *BacktestResult) TradeCount() int {
+	return r.tradeCount
+}
+
+func (r *BacktestResult) WinCount() int {
+	return r.winCount
+}
+
+func (r *BacktestResult) WinRate() float64 {
+	if r.tradeCount == 0 {
+		return 0
+	}
+	return float64(r.winCount) / float64(r.tradeCount)
+}
+
+func (r *BacktestResult) MaxDrawdown() float64 {
+	return r.maxDrawdown
+}
+
+func (r *BacktestResult) EquityCurve() []EquityPoint {
+	return r.equityCurve
+}
@@ -0,0 +1,110 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+)
+
+func TestBacktestJob(t *testing.T) {
+	params := model.NewBasicTradeParams(config.ProductCode, 0.01)
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+
+	t.Run("NewBacktestJob", func(t *testing.T) {
+		if model.NewBacktestJob("", "default", *params, start, end, now) != nil {
+			t.Fatal("NewBacktestJob() returns not nil")
+		}
+		if model.NewBacktestJob("job", "default", *params, end, start, now) != nil {
+			t.Fatal("NewBacktestJob() returns not nil")
+		}
+		job := model.NewBacktestJob("job", "default", *params, start, end, now)
+		if job == nil {
+			t.Fatal("NewBacktestJob() returns nil")
+		}
+		if job.Status() != model.BacktestJobStatusQueued {
+			t.Fatalf("%s != %s", job.Status(), model.BacktestJobStatusQueued)
+		}
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		job := model.NewBacktestJob("job", "default", *params, start, end, now)
+		result := model.NewBacktestResult(nil, model.NewSignalEvents(make([]model.SignalEvent, 0)))
+		if job.Complete(result, now) {
+			t.Fatal("queued job is completed")
+		}
+		if !job.Run() {
+			t.Fatal("Run() returns false")
+		}
+		if !job.Complete(result, now) {
+			t.Fatal("Complete() returns false")
+		}
+		if job.Cancel(now) {
+			t.Fatal("finished job is canceled")
+		}
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		job := model.NewBacktestJob("job", "default", *params, start, end, now)
+		job.Run()
+		if !job.Fail(errors.New("failed"), now) {
+			t.Fatal("Fail() returns false")
+		}
+		if job.ErrorMessage() != "failed" {
+			t.Fatalf("%s != %s", job.ErrorMessage(), "failed")
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		job := model.NewBacktestJob("job", "default", *params, start, end, now)
+		if !job.Cancel(now) {
+			t.Fatal("Cancel() returns false")
+		}
+		if job.Run() {
+			t.Fatal("canceled job runs")
+		}
+	})
+}
+
+func TestBacktestResult(t *testing.T) {
+	closes := []float64{100, 200, 150, 300, 250}
+	candles := make([]model.Candle, 0)
+	for i, c := range closes {
+		candleTime := model.NewCandleTime(time.Date(2021, 1, 1+i, 0, 0, 0, 0, time.UTC))
+		candle := model.NewCandle(config.ProductCode, config.CandleDuration, candleTime, c, c, c, c, 0)
+		candles = append(candles, *candle)
+	}
+
+	events := model.NewSignalEvents(make([]model.SignalEvent, 0))
+	// 100で買って200で売る，150で買って保有し続ける
+	events.AddBuySignal(*model.NewSignalEvent(candles[0].Time().Time(), config.ProductCode, model.OrderSideBuy, 100, 1))
+	events.AddSellSignal(*model.NewSignalEvent(candles[1].Time().Time(), config.ProductCode, model.OrderSideSell, 200, 1))
+	events.AddBuySignal(*model.NewSignalEvent(candles[2].Time().Time(), config.ProductCode, model.OrderSideBuy, 150, 1))
+
+	result := model.NewBacktestResult(candles, events)
+	if result == nil {
+		t.Fatal("NewBacktestResult() returns nil")
+	}
+
+	if result.Profit() != 100 {
+		t.Fatalf("%v != %v", result.Profit(), 100)
+	}
+	if result.TradeCount() != 1 || result.WinRate() != 1 {
+		t.Fatalf("tradeCount: %d, winRate: %v", result.TradeCount(), result.WinRate())
+	}
+
+	expected := []float64{0, 100, 100, 250, 200}
+	for i, point := range result.EquityCurve() {
+		if point.Equity() != expected[i] {
+			t.Fatalf("%v != %v", point.Equity(), expected[i])
+		}
+	}
+
+	if result.MaxDrawdown() != 50 {
+		t.Fatalf("%v != %v", result.MaxDrawdown(), 50)
+	}
+}
@@ -97,3 +97,49 @@ func (candle *Candle) Low() float64 {
 func (candle *Candle) Volume() float64 {
 	return candle.volume
 }
+
+// キャンドルをより長い時間足にまとめる
+// candlesは時刻の昇順で，durationはcandlesの時間足の整数倍であること
+// 先頭の期間が途中から始まっている場合は，欠けたキャンドルになるので捨てる
+func ResampleCandles(candles []Candle, duration time.Duration, origin time.Time) []Candle {
+	if len(candles) == 0 {
+		return nil
+	}
+	baseDuration := candles[0].Duration()
+	if duration < baseDuration || duration%baseDuration != 0 {
+		return nil
+	}
+
+	first := TruncateCandleTime(candles[0].Time().Time(), duration, origin)
+	skipFirst := !candles[0].Time().Equal(first)
+
+	resampled := make([]Candle, 0)
+	for _, candle := range candles {
+		candleTime := TruncateCandleTime(candle.Time().Time(), duration, origin)
+		if skipFirst && candleTime.Equal(first) {
+			continue
+		}
+
+		last := len(resampled) - 1
+		if last >= 0 && resampled[last].Time().Equal(candleTime) {
+			c := &resampled[last]
+			c.close = candle.close
+			if c.high < candle.high {
+				c.high = candle.high
+			}
+			if c.low > candle.low {
+				c.low = candle.low
+			}
+			c.volume += candle.volume
+			continue
+		}
+
+		c := NewCandle(candle.productCode, duration, candleTime, candle.open, candle.close, candle.high, candle.low, candle.volume)
+		if c == nil {
+			continue
+		}
+		resampled = append(resampled, *c)
+	}
+
+	return resampled
+}
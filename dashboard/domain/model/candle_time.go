@@ -47,3 +47,13 @@ func (candleTime CandleTime) TruncateHour(localTime *time.Location, hour int) Ca
 
 	return NewCandleTime(truncateTime)
 }
+
+// originを基準にduration単位で切り捨てた時刻
+// 日足ならoriginを取引時刻（例: 日本時間9:00）にする
+func TruncateCandleTime(timeTime time.Time, duration time.Duration, origin time.Time) CandleTime {
+	elapsed := timeTime.Sub(origin) % duration
+	if elapsed < 0 {
+		elapsed += duration
+	}
+	return NewCandleTime(timeTime.Add(-elapsed))
+}
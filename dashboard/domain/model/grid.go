@@ -0,0 +1,380 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// グリッドの各段の状態
+type GridLevelState string
+
+const (
+	GridLevelStateWaiting GridLevelState = "WAITING" // 注文を出していない
+	GridLevelStateBuying  GridLevelState = "BUYING"  // 買い注文を出している
+	GridLevelStateSelling GridLevelState = "SELLING" // 売り注文を出している
+	GridLevelStateBought  GridLevelState = "BOUGHT"  // 買い注文が約定し，売り注文をまだ出していない
+)
+
+// グリッドの1段
+// buyPriceで買い，1段上のsellPriceで売る
+type GridLevel struct {
+	productCode string
+	index       int
+	buyPrice    float64
+	sellPrice   float64
+	size        float64
+	state       GridLevelState
+	orderID     string
+}
+
+func NewGridLevel(productCode string, index int, buyPrice, sellPrice, size float64, state GridLevelState, orderID string) *GridLevel {
+	if productCode == "" {
+		return nil
+	}
+
+	if index < 0 {
+		return nil
+	}
+
+	if buyPrice <= 0 || sellPrice <= buyPrice {
+		return nil
+	}
+
+	if size <= 0 {
+		return nil
+	}
+
+	switch state {
+	case GridLevelStateWaiting, GridLevelStateBought:
+		if orderID != "" {
+			return nil
+		}
+	case GridLevelStateBuying, GridLevelStateSelling:
+		if orderID == "" {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	return &GridLevel{
+		productCode: productCode,
+		index:       index,
+		buyPrice:    buyPrice,
+		sellPrice:   sellPrice,
+		size:        size,
+		state:       state,
+		orderID:     orderID,
+	}
+}
+
+func (gl *GridLevel) ProductCode() string {
+	return gl.productCode
+}
+
+func (gl *GridLevel) Index() int {
+	return gl.index
+}
+
+func (gl *GridLevel) BuyPrice() float64 {
+	return gl.buyPrice
+}
+
+func (gl *GridLevel) SellPrice() float64 {
+	return gl.sellPrice
+}
+
+func (gl *GridLevel) Size() float64 {
+	return gl.size
+}
+
+func (gl *GridLevel) State() GridLevelState {
+	return gl.state
+}
+
+// 出している注文のID（child_order_acceptance_id）
+func (gl *GridLevel) OrderID() string {
+	return gl.orderID
+}
+
+// 価格がbuyPriceより上にあるときだけ買い注文を出す
+// 下にあるときに出すと，すぐに約定してbuyPriceより高く買うことになる
+func (gl *GridLevel) ShouldPlaceBuy(price float64) bool {
+	return gl.state == GridLevelStateWaiting && price > gl.buyPrice
+}
+
+func (gl *GridLevel) PlaceBuy(orderID string) bool {
+	if gl.state != GridLevelStateWaiting || orderID == "" {
+		return false
+	}
+	gl.state = GridLevelStateBuying
+	gl.orderID = orderID
+	return true
+}
+
+// 買った分を持っているか
+func (gl *GridLevel) Holding() bool {
+	return gl.state == GridLevelStateBought || gl.state == GridLevelStateSelling
+}
+
+// 買い注文の約定後，または売り注文の出し直し
+func (gl *GridLevel) PlaceSell(orderID string) bool {
+	if gl.state == GridLevelStateWaiting || orderID == "" {
+		return false
+	}
+	gl.state = GridLevelStateSelling
+	gl.orderID = orderID
+	return true
+}
+
+// 買い注文が約定したとき，または売り注文が取り消されたとき
+// 売り注文を出す前の状態で，出せなかったときは次に出し直す
+func (gl *GridLevel) Bought() {
+	gl.state = GridLevelStateBought
+	gl.orderID = ""
+}
+
+// 売り注文の約定後，または買い注文が取り消されたとき
+func (gl *GridLevel) Wait() {
+	gl.state = GridLevelStateWaiting
+	gl.orderID = ""
+}
+
+// 価格の上下限の間を等間隔に分けたグリッド
+type Grid struct {
+	productCode string
+	lowerPrice  float64
+	upperPrice  float64
+	levels      int
+	size        float64
+}
+
+// levelsは上下限を含む価格の本数（段の数はlevels-1）
+func NewGrid(productCode string, lowerPrice, upperPrice float64, levels int, size float64) *Grid {
+	if productCode == "" {
+		return nil
+	}
+
+	if lowerPrice <= 0 || upperPrice <= lowerPrice {
+		return nil
+	}
+
+	if levels < 2 {
+		return nil
+	}
+
+	if size <= 0 {
+		return nil
+	}
+
+	return &Grid{
+		productCode: productCode,
+		lowerPrice:  lowerPrice,
+		upperPrice:  upperPrice,
+		levels:      levels,
+		size:        size,
+	}
+}
+
+func (g *Grid) ProductCode() string {
+	return g.productCode
+}
+
+func (g *Grid) LowerPrice() float64 {
+	return g.lowerPrice
+}
+
+func (g *Grid) UpperPrice() float64 {
+	return g.upperPrice
+}
+
+func (g *Grid) Levels() int {
+	return g.levels
+}
+
+func (g *Grid) Size() float64 {
+	return g.size
+}
+
+// 下限から上限までの価格
+func (g *Grid) Prices() []float64 {
+	prices := make([]float64, g.levels)
+	step := (g.upperPrice - g.lowerPrice) / float64(g.levels-1)
+	for i := range prices {
+		prices[i] = g.lowerPrice + step*float64(i)
+	}
+	prices[g.levels-1] = g.upperPrice
+	return prices
+}
+
+// 注文を出していない状態の各段
+func (g *Grid) NewLevels() []GridLevel {
+	prices := g.Prices()
+	levels := make([]GridLevel, 0, g.levels-1)
+	for i := 0; i < g.levels-1; i++ {
+		level := NewGridLevel(g.productCode, i, prices[i], prices[i+1], g.size, GridLevelStateWaiting, "")
+		if level == nil {
+			return nil
+		}
+		levels = append(levels, *level)
+	}
+	return levels
+}
+
+// 保存されている各段が，このグリッドの設定で作られたものか
+func (g *Grid) Matches(levels []GridLevel) bool {
+	expected := g.NewLevels()
+	if len(levels) != len(expected) {
+		return false
+	}
+
+	for i := range levels {
+		if levels[i].index != expected[i].index ||
+			levels[i].size != expected[i].size ||
+			!nearlyEqual(levels[i].buyPrice, expected[i].buyPrice) ||
+			!nearlyEqual(levels[i].sellPrice, expected[i].sellPrice) {
+			return false
+		}
+	}
+	return true
+}
+
+// DBに保存した価格の丸め誤差を許容する
+func nearlyEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(math.Abs(a), math.Abs(b))
+}
+
+// グリッドの約定
+type GridFill struct {
+	time  time.Time
+	level int
+	side  OrderSide
+	price float64
+	size  float64
+}
+
+func NewGridFill(timeTime time.Time, level GridLevel, side OrderSide) *GridFill {
+	price := level.buyPrice
+	if side == OrderSideSell {
+		price = level.sellPrice
+	}
+
+	return &GridFill{
+		time:  timeTime,
+		level: level.index,
+		side:  side,
+		price: price,
+		size:  level.size,
+	}
+}
+
+func (gf *GridFill) Time() time.Time {
+	return gf.time
+}
+
+func (gf *GridFill) Level() int {
+	return gf.level
+}
+
+func (gf *GridFill) Side() OrderSide {
+	return gf.side
+}
+
+func (gf *GridFill) Price() float64 {
+	return gf.price
+}
+
+func (gf *GridFill) Size() float64 {
+	return gf.size
+}
+
+// 約定を売買履歴として扱う（保存と通知）
+func (gf *GridFill) SignalEvent(productCode string) *SignalEvent {
+	return NewSignalEventWithTag(gf.time, productCode, gf.side, gf.price, gf.size, SignalEventTagGrid)
+}
+
+type GridBacktestResult struct {
+	fills     []GridFill
+	profit    float64
+	position  float64
+	lastPrice float64
+}
+
+func (gr *GridBacktestResult) Fills() []GridFill {
+	return gr.fills
+}
+
+// 売りまで約定した分の利益
+func (gr *GridBacktestResult) Profit() float64 {
+	return gr.profit
+}
+
+// 期間の終わりに保有している数量
+func (gr *GridBacktestResult) Position() float64 {
+	return gr.position
+}
+
+// 期間の終わりに保有している分の評価損益を含めた利益
+func (gr *GridBacktestResult) TotalProfit(levels []GridLevel) float64 {
+	total := gr.profit
+	for _, level := range levels {
+		if level.Holding() {
+			total += (gr.lastPrice - level.buyPrice) * level.size
+		}
+	}
+	return total
+}
+
+// キャンドルの高値・安値で指値注文の約定を判定するバックテスト
+// 各キャンドルの終値で，出していない買い注文を出す（実際の取引で価格を見て注文を出すのと同じ）
+// 同じキャンドルの中で買いと売りの両方が約定したかは分からないので，
+// 約定した段の反対注文は次のキャンドルから約定を判定する
+// 戻り値の各段は期間の終わりの状態
+func (g *Grid) Backtest(candles []Candle) (*GridBacktestResult, []GridLevel) {
+	levels := g.NewLevels()
+	result := &GridBacktestResult{
+		fills: make([]GridFill, 0),
+	}
+	if len(candles) == 0 {
+		return result, levels
+	}
+
+	// バックテストでは注文IDを使わないので，固定の値を入れる
+	placeBuys := func(price float64) {
+		for i := range levels {
+			if levels[i].ShouldPlaceBuy(price) {
+				levels[i].PlaceBuy("backtest")
+			}
+		}
+	}
+	placeBuys(candles[0].Open())
+
+	for _, candle := range candles {
+		for i := range levels {
+			level := &levels[i]
+			switch level.state {
+			case GridLevelStateBuying:
+				if candle.Low() <= level.buyPrice {
+					result.fills = append(result.fills, *NewGridFill(candle.Time().Time(), *level, OrderSideBuy))
+					level.PlaceSell("backtest")
+				}
+			case GridLevelStateSelling:
+				if candle.High() >= level.sellPrice {
+					result.fills = append(result.fills, *NewGridFill(candle.Time().Time(), *level, OrderSideSell))
+					result.profit += (level.sellPrice - level.buyPrice) * level.size
+					level.Wait()
+				}
+			}
+		}
+		placeBuys(candle.Close())
+	}
+
+	for _, level := range levels {
+		if level.Holding() {
+			result.position += level.size
+		}
+	}
+	result.lastPrice = candles[len(candles)-1].Close()
+
+	return result, levels
+}
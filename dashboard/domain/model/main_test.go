@@ -0,0 +1,18 @@
+package model_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+)
+
+// テストの取引履歴やキャンドルはPRODUCT_CODEの銘柄で作るので，指定がなければ実行しない
+func TestMain(m *testing.M) {
+	if config.ProductCode == "" {
+		fmt.Println("PRODUCT_CODE is not set (e.g. PRODUCT_CODE=ETH_JPY)")
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
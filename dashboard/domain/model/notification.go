@@ -0,0 +1,109 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// 通知の重要度
+type NotificationLevel string
+
+const (
+	NotificationLevelInfo  NotificationLevel = "info"  // 約定・パラメータの更新など
+	NotificationLevelWarn  NotificationLevel = "warn"  // 取引の見送り・損切りなど
+	NotificationLevelError NotificationLevel = "error" // 取引の失敗
+)
+
+func (nl NotificationLevel) Valid() bool {
+	return nl == NotificationLevelInfo || nl == NotificationLevelWarn || nl == NotificationLevelError
+}
+
+// 取引が無効になっている（見送りでも失敗でもないので通知しない）
+var ErrTradeDisabled = errors.New("trade is not enabled")
+
+// 取引を見送った理由（失敗ではないので，通知の重要度を下げる）
+var (
+	ErrNotEnoughMoney  = errors.New("you don't have enough money")
+	ErrBoardNotRunning = errors.New("board is not running")
+)
+
+// errが取引の見送りを表していれば，その理由を返す
+func TradeSkipReason(err error) (error, bool) {
+	for _, reason := range []error{ErrNotEnoughMoney, ErrBoardNotRunning} {
+		if errors.Is(err, reason) {
+			return reason, true
+		}
+	}
+	return nil, false
+}
+
+type Notification struct {
+	time        time.Time
+	level       NotificationLevel
+	productCode string
+	title       string
+	message     string
+}
+
+func NewNotification(timeTime time.Time, level NotificationLevel, productCode, title, message string) *Notification {
+	if !level.Valid() {
+		return nil
+	}
+
+	if title == "" {
+		return nil
+	}
+
+	timeTime = timeTime.In(time.UTC)
+
+	return &Notification{
+		time:        timeTime,
+		level:       level,
+		productCode: productCode,
+		title:       title,
+		message:     message,
+	}
+}
+
+func (n *Notification) Time() time.Time {
+	return n.time
+}
+
+func (n *Notification) Level() NotificationLevel {
+	return n.level
+}
+
+// 銘柄に関係しない通知では空文字
+func (n *Notification) ProductCode() string {
+	return n.productCode
+}
+
+func (n *Notification) Title() string {
+	return n.title
+}
+
+func (n *Notification) Message() string {
+	return n.message
+}
+
+// 約定の通知
+func NewTradingSuccessNotification(event SignalEvent) *Notification {
+	message := fmt.Sprintf("Price: %f\nSize: %f", event.Price(), event.Size())
+	return NewNotification(event.Time(), NotificationLevelInfo, event.ProductCode(), string(event.Side()), message)
+}
+
+// 取引の失敗の通知
+func NewTradingFailureNotification(timeTime time.Time, productCode string, err error) *Notification {
+	return NewNotification(timeTime, NotificationLevelError, productCode, "エラーが生じました", err.Error())
+}
+
+// 取引所間の価格差の通知
+func NewSpreadNotification(spread Spread) *Notification {
+	message := fmt.Sprintf("%s: %f\n%s: %f\nRate: %+.2f%%",
+		spread.BaseExchange(), spread.BasePrice(),
+		spread.Exchange(), spread.Price(),
+		spread.Rate()*100,
+	)
+	return NewNotification(spread.Time(), NotificationLevelWarn, spread.ProductCode(), "SPREAD", message)
+}
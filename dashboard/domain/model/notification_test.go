@@ -0,0 +1,57 @@
+package model_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+)
+
+func TestNotification(t *testing.T) {
+	timeTime := time.Date(2021, 11, 9, 0, 0, 0, 0, time.UTC)
+
+	if model.NewNotification(timeTime, "debug", "ETH_JPY", "title", "") != nil {
+		t.Fatal("NewNotification() with unknown level returns not nil")
+	}
+	if model.NewNotification(timeTime, model.NotificationLevelInfo, "ETH_JPY", "", "") != nil {
+		t.Fatal("NewNotification() without title returns not nil")
+	}
+	if model.NewNotification(timeTime, model.NotificationLevelWarn, "", "title", "") == nil {
+		t.Fatal("NewNotification() returns nil")
+	}
+}
+
+func TestTradeSkipReason(t *testing.T) {
+	err := fmt.Errorf("[Buy] %w. available: %f, need: %f", model.ErrNotEnoughMoney, 1000.0, 2000.0)
+	if reason, ok := model.TradeSkipReason(err); !ok || reason != model.ErrNotEnoughMoney {
+		t.Fatalf("reason=%v, ok=%v", reason, ok)
+	}
+
+	if _, ok := model.TradeSkipReason(errors.New("order failed")); ok {
+		t.Fatal("TradeSkipReason() returns ok for a failure")
+	}
+
+	if _, ok := model.TradeSkipReason(fmt.Errorf("[DCA] %w", model.ErrTradeDisabled)); ok {
+		t.Fatal("TradeSkipReason() returns ok for disabled trade")
+	}
+}
+
+func TestConvertToNotification(t *testing.T) {
+	timeTime := time.Date(2021, 11, 9, 0, 0, 0, 0, time.UTC)
+
+	event := model.NewSignalEvent(timeTime, "ETH_JPY", model.OrderSideBuy, 500000, 0.01)
+	if n := model.NewTradingSuccessNotification(*event); n.Level() != model.NotificationLevelInfo || n.Title() != "BUY" || n.ProductCode() != "ETH_JPY" {
+		t.Fatalf("notification=%+v", n)
+	}
+
+	if n := model.NewTradingFailureNotification(timeTime, "ETH_JPY", errors.New("order failed")); n.Level() != model.NotificationLevelError || n.Message() != "order failed" {
+		t.Fatalf("notification=%+v", n)
+	}
+
+	spread := model.NewSpread(timeTime, "ETH_JPY", "bitflyer", "coincheck", 500000, 510000)
+	if n := model.NewSpreadNotification(*spread); n.Level() != model.NotificationLevelWarn || !n.Time().Equal(timeTime) {
+		t.Fatalf("notification=%+v", n)
+	}
+}
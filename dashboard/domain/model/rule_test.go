@@ -0,0 +1,244 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+)
+
+func newRuleTestDataFrame(closes []float64) *model.DataFrame {
+	candles := make([]model.Candle, len(closes))
+	currentTime := time.Now()
+	for i, c := range closes {
+		candleTime := model.NewCandleTime(currentTime)
+		candles[i] = *model.NewCandle(config.ProductCode, config.CandleDuration, candleTime, c, c, c+1, c-1, 1)
+		currentTime = currentTime.Add(config.CandleDuration)
+	}
+	return model.NewDataFrame(config.ProductCode, candles, model.NewSignalEvents(make([]model.SignalEvent, 0)))
+}
+
+func TestParseRule(t *testing.T) {
+	valid := []string{
+		"crossover(ema(7), ema(21)) and rsi(14) < 35",
+		"close > bb_upper(20, 2) or not (macd_hist(12, 26, 9) >= 0)",
+		"abs(close - prev(close, 3)) / atr(14) > 1.5",
+		"true",
+		"-close < -1",
+		"CLOSE > SMA(5)",
+	}
+	for _, source := range valid {
+		rule, err := model.ParseRule(source)
+		if err != nil {
+			t.Fatalf("ParseRule(%q): %s", source, err.Error())
+		}
+		if rule.String() != source {
+			t.Fatalf("%q != %q", rule.String(), source)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"close",
+		"close >",
+		"close > 1 and",
+		"(close > 1",
+		"close = 1",
+		"close > sma(0)",
+		"close > sma(2.5)",
+		"close > sma(close)",
+		"close > sma(5, 1)",
+		"close > foo(1)",
+		"(close > 1) + 1 > 0",
+		"close and true",
+		"close > 1 $",
+		"prev(close, 0) > 1",
+	}
+	for _, source := range invalid {
+		if _, err := model.ParseRule(source); err == nil {
+			t.Fatalf("ParseRule(%q) returns no error", source)
+		}
+	}
+}
+
+// TA-Libが受け付けない期間は，評価する前に弾く
+func TestRulePeriodLimits(t *testing.T) {
+	invalid := []string{
+		"macd(1, 1, 1) < macd(1, 1, 1)",
+		"macd_signal(1, 26, 9) > 0",
+		"macd_hist(12, 26, 1) > 0",
+		"macd(26, 12, 9) > 0",
+		"macd(12, 12, 9) > 0",
+		"sma(1) > 0",
+		"ema(1) > 0",
+		"rsi(1) > 0",
+		"bb_upper(1, 2) > 0",
+		"adx(1) > 0",
+		"obv_signal(1) > 0",
+		"donchian_upper(1) > 0",
+		"keltner_lower(1, 2) > 0",
+	}
+	for _, source := range invalid {
+		if _, err := model.ParseRule(source); err == nil {
+			t.Fatalf("ParseRule(%q) returns no error", source)
+		}
+	}
+
+	// 下限ちょうどの期間は，どの足で評価してもpanicしない
+	closes := make([]float64, 40)
+	for i := range closes {
+		closes[i] = float64(100 + i%5)
+	}
+	df := newRuleTestDataFrame(closes)
+	valid := []string{
+		"macd(2, 3, 2) < macd_signal(2, 3, 2)",
+		"macd_hist(2, 3, 2) > 0",
+		"sma(2) > ema(2)",
+		"rsi(2) > 50",
+		"bb_lower(2, 1) < close",
+		"adx(2) > plus_di(2) or minus_di(2) > atr(1)",
+		"stoch_k(1, 1, 1) > stoch_d(1, 1, 1)",
+		"obv_signal(2) > 0",
+		"donchian_upper(2) > keltner_upper(2, 1)",
+	}
+	for _, source := range valid {
+		rule, err := model.ParseRule(source)
+		if err != nil {
+			t.Fatalf("ParseRule(%q): %s", source, err.Error())
+		}
+		ctx := model.NewRuleContext(df)
+		for at := 0; at < len(closes); at++ {
+			rule.Evaluate(ctx, at)
+		}
+		if err := rule.Check(); err != nil {
+			t.Fatalf("Check(%q): %s", source, err.Error())
+		}
+	}
+}
+
+func TestRuleEvaluate(t *testing.T) {
+	closes := []float64{10, 10, 10, 10, 10, 9, 8, 7, 9, 11, 13, 10}
+	df := newRuleTestDataFrame(closes)
+	ctx := model.NewRuleContext(df)
+	if ctx == nil {
+		t.Fatal("NewRuleContext() returns nil")
+	}
+
+	evaluate := func(source string) []int {
+		rule, err := model.ParseRule(source)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		at := make([]int, 0)
+		for i := range closes {
+			if rule.Evaluate(ctx, i) {
+				at = append(at, i)
+			}
+		}
+		return at
+	}
+
+	equal := func(a, b []int) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	t.Run("comparison", func(t *testing.T) {
+		if at := evaluate("close >= 11"); !equal(at, []int{9, 10}) {
+			t.Fatalf("at=%v", at)
+		}
+		if at := evaluate("close - prev(close) == 2"); !equal(at, []int{8, 9, 10}) {
+			t.Fatalf("at=%v", at)
+		}
+	})
+
+	t.Run("insufficient data", func(t *testing.T) {
+		// sma(3)はインデックス2から値が決まる
+		if at := evaluate("sma(3) > 0"); len(at) != len(closes)-2 || at[0] != 2 {
+			t.Fatalf("at=%v", at)
+		}
+		// 片方が偽なら，もう片方が決まらなくても偽
+		if at := evaluate("not (sma(3) > 0 and close > 100)"); len(at) != len(closes) {
+			t.Fatalf("at=%v", at)
+		}
+		// 片方が真なら，もう片方が決まらなくても真
+		if at := evaluate("sma(3) > 0 or close > 0"); len(at) != len(closes) {
+			t.Fatalf("at=%v", at)
+		}
+		if at := evaluate("close / 0 > 0"); len(at) != 0 {
+			t.Fatalf("at=%v", at)
+		}
+	})
+
+	t.Run("crossover", func(t *testing.T) {
+		if at := evaluate("crossover(close, sma(3))"); !equal(at, []int{8}) {
+			t.Fatalf("at=%v", at)
+		}
+		if at := evaluate("crossunder(close, sma(3))"); !equal(at, []int{5, 11}) {
+			t.Fatalf("at=%v", at)
+		}
+	})
+
+	t.Run("indicators", func(t *testing.T) {
+		sources := []string{
+			"ema(3) > 0",
+			"rsi(3) >= 0",
+			"macd(2, 3, 2) != 0 or macd_signal(2, 3, 2) != 0 or macd_hist(2, 3, 2) == 0",
+			"bb_upper(3, 2) >= bb_middle(3, 2) and bb_middle(3, 2) >= bb_lower(3, 2)",
+			"atr(3) > 0",
+			"adx(3) >= 0 and plus_di(3) >= 0 and minus_di(3) >= 0",
+			"stoch_k(3, 2, 2) >= 0 and stoch_d(3, 2, 2) >= 0",
+			"obv() != obv_signal(3) or true",
+			"vwap(3) > 0",
+			"sar(0.02, 0.2) > 0",
+			"donchian_upper(3) > donchian_lower(3)",
+			"keltner_upper(3, 2) > keltner_lower(3, 2)",
+			"ha_high >= ha_low and ha_open > 0 and ha_close > 0",
+			"open > 0 and high > low and volume > 0",
+		}
+		for _, source := range sources {
+			if at := evaluate(source); len(at) == 0 {
+				t.Fatalf("%q is never true", source)
+			}
+		}
+	})
+}
+
+func TestStrategyRule(t *testing.T) {
+	var sr *model.StrategyRule
+
+	sr = model.NewStrategyRule(config.ProductCode, "crossover(close, sma(3))", "crossunder(close, sma(3))")
+	if sr == nil {
+		t.Fatal("NewStrategyRule() returns nil")
+	}
+	if sr.BuyRule().String() != "crossover(close, sma(3))" || sr.SellRule().String() != "crossunder(close, sma(3))" {
+		t.Fatalf("buy=%q, sell=%q", sr.BuyRule().String(), sr.SellRule().String())
+	}
+
+	df := newRuleTestDataFrame([]float64{10, 10, 10, 10, 10, 9, 8, 7, 9, 11, 13, 10})
+	ctx := model.NewRuleContext(df)
+	if buy, sell := sr.Analyze(ctx, 8); !buy || sell {
+		t.Fatalf("buy=%t, sell=%t", buy, sell)
+	}
+	if buy, sell := sr.Analyze(ctx, 11); buy || !sell {
+		t.Fatalf("buy=%t, sell=%t", buy, sell)
+	}
+
+	sr = model.NewStrategyRule(config.ProductCode, "close >", "close < 1")
+	if sr != nil {
+		t.Fatal("NewStrategyRule() returns not nil")
+	}
+
+	sr = model.NewStrategyRule("", "close > 1", "close < 1")
+	if sr != nil {
+		t.Fatal("NewStrategyRule() returns not nil")
+	}
+}
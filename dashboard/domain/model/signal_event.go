@@ -4,15 +4,29 @@ import (
 	"time"
 )
 
+// 売買の種類
+type SignalEventTag string
+
+const (
+	SignalEventTagStrategy SignalEventTag = "STRATEGY" // 売買サインによる取引
+	SignalEventTagDCA      SignalEventTag = "DCA"      // 積立
+	SignalEventTagGrid     SignalEventTag = "GRID"     // グリッド取引
+)
+
 type SignalEvent struct {
 	time        time.Time
 	productCode string
 	side        OrderSide
 	price       float64
 	size        float64
+	tag         SignalEventTag
 }
 
 func NewSignalEvent(timeTime time.Time, productCode string, side OrderSide, price float64, size float64) *SignalEvent {
+	return NewSignalEventWithTag(timeTime, productCode, side, price, size, SignalEventTagStrategy)
+}
+
+func NewSignalEventWithTag(timeTime time.Time, productCode string, side OrderSide, price float64, size float64, tag SignalEventTag) *SignalEvent {
 	if productCode == "" {
 		return nil
 	}
@@ -29,6 +43,18 @@ func NewSignalEvent(timeTime time.Time, productCode string, side OrderSide, pric
 		return nil
 	}
 
+	switch tag {
+	case SignalEventTagStrategy:
+	case SignalEventTagDCA:
+		// 積立は買うだけ
+		if side != OrderSideBuy {
+			return nil
+		}
+	case SignalEventTagGrid:
+	default:
+		return nil
+	}
+
 	timeTime = timeTime.In(time.UTC)
 
 	return &SignalEvent{
@@ -37,6 +63,7 @@ func NewSignalEvent(timeTime time.Time, productCode string, side OrderSide, pric
 		side:        side,
 		price:       price,
 		size:        size,
+		tag:         tag,
 	}
 }
 
@@ -60,29 +87,87 @@ func (s *SignalEvent) Size() float64 {
 	return s.size
 }
 
+func (s *SignalEvent) Tag() SignalEventTag {
+	return s.tag
+}
+
+func (s *SignalEvent) IsDCA() bool {
+	return s.tag == SignalEventTagDCA
+}
+
+// 積立とグリッド取引は，売買サインによる買いと売りの繰り返しに含めない
+func (s *SignalEvent) IsStrategy() bool {
+	return s.tag == SignalEventTagStrategy
+}
+
 type SignalEvents struct {
-	signals []SignalEvent
-	profit  float64
+	signals  []SignalEvent
+	profit   float64
+	maxLots  int
+	position *Position
 }
 
+// 買いと売りを1回ずつ交互に繰り返す
 func NewSignalEvents(signals []SignalEvent) *SignalEvents {
+	return NewSignalEventsWithLots(signals, 1, LotMatchingFIFO)
+}
+
+// 最大maxLots回まで買い増しでき，売りは一部の決済もできる
+func NewSignalEventsWithLots(signals []SignalEvent, maxLots int, matching LotMatching) *SignalEvents {
 	if signals == nil {
 		return nil
 	}
 
+	if maxLots < 1 {
+		return nil
+	}
+
+	position := NewPosition(matching)
+	if position == nil {
+		return nil
+	}
+
+	// 履歴からポジションを復元する
+	for _, signal := range signals {
+		if !signal.IsStrategy() {
+			continue
+		}
+		switch signal.side {
+		case OrderSideBuy:
+			position.Open(signal.time, signal.price, signal.size)
+		case OrderSideSell:
+			position.Close(signal.price, signal.size)
+		}
+	}
+
 	return &SignalEvents{
-		signals: signals,
-		profit:  0,
+		signals:  signals,
+		profit:   0,
+		maxLots:  maxLots,
+		position: position,
 	}
 }
 
+// 売買サインによる最後の取引（積立とグリッド取引は除く）
 func (s *SignalEvents) LastSignal() *SignalEvent {
-	lenSignals := len(s.signals)
-	if lenSignals == 0 {
-		return nil
+	for i := len(s.signals) - 1; i >= 0; i-- {
+		if s.signals[i].IsStrategy() {
+			return &s.signals[i]
+		}
+	}
+
+	return nil
+}
+
+// 最後の積立の取引
+func (s *SignalEvents) LastDCASignal() *SignalEvent {
+	for i := len(s.signals) - 1; i >= 0; i-- {
+		if s.signals[i].IsDCA() {
+			return &s.signals[i]
+		}
 	}
 
-	return &s.signals[lenSignals-1]
+	return nil
 }
 
 func (s *SignalEvents) Signals() []SignalEvent {
@@ -93,30 +178,37 @@ func (s *SignalEvents) Profit() float64 {
 	return s.profit
 }
 
+func (s *SignalEvents) MaxLots() int {
+	return s.maxLots
+}
+
+// 売買サインによる取引で保有しているポジション
+func (s *SignalEvents) Position() *Position {
+	return s.position
+}
+
+// 前回の取引より後で，ロットの数が上限に達していなければ買える
 func (s *SignalEvents) CanBuyAt(timeTime time.Time) bool {
 	lastSignal := s.LastSignal()
-	if lastSignal == nil {
-		return true
+	if lastSignal != nil && !lastSignal.time.Before(timeTime) {
+		return false
 	}
 
-	canBuy := lastSignal.side == OrderSideSell &&
-		lastSignal.time.Before(timeTime)
-	return canBuy
+	return len(s.position.lots) < s.maxLots
 }
 
+// 前回の取引より後で，ポジションを持っていれば売れる
 func (s *SignalEvents) CanSellAt(timeTime time.Time) bool {
 	lastSignal := s.LastSignal()
-	if lastSignal == nil {
+	if lastSignal == nil || !lastSignal.time.Before(timeTime) {
 		return false
 	}
 
-	canSell := lastSignal.side == OrderSideBuy &&
-		lastSignal.time.Before(timeTime)
-	return canSell
+	return len(s.position.lots) > 0
 }
 
 func (s *SignalEvents) AddBuySignal(signal SignalEvent) bool {
-	if signal.side != OrderSideBuy {
+	if signal.side != OrderSideBuy || !signal.IsStrategy() {
 		return false
 	}
 
@@ -125,9 +217,11 @@ func (s *SignalEvents) AddBuySignal(signal SignalEvent) bool {
 	}
 
 	s.signals = append(s.signals, signal)
+	s.position.Open(signal.time, signal.price, signal.size)
 	return true
 }
 
+// 保有している数量より少なければ一部を決済する
 func (s *SignalEvents) AddSellSignal(signal SignalEvent) bool {
 	if signal.side != OrderSideSell {
 		return false
@@ -138,50 +232,29 @@ func (s *SignalEvents) AddSellSignal(signal SignalEvent) bool {
 	}
 
 	s.signals = append(s.signals, signal)
+	s.position.Close(signal.price, signal.size)
 	return true
 }
 
-// 買って売ってを繰り返した履歴データから，利益を推定
+// 履歴データから，決済済みの損益を推定
+// 積立とグリッド取引，決済していないポジションは含めない
 func (s *SignalEvents) EstimateProfit() float64 {
-	total := 0.0
-	beforeSell := 0.0
-	isHolding := false
-	for _, signal := range s.signals {
-		if signal.side == OrderSideBuy {
-			total -= signal.price * signal.size
-			isHolding = true
-		}
-		if signal.side == OrderSideSell {
-			total += signal.price * signal.size
-			beforeSell = total
-			isHolding = false
-		}
-	}
-
-	var profit float64
-	if isHolding {
-		profit = beforeSell
-	} else {
-		profit = total
-	}
-
-	s.profit = profit
-	return profit
+	s.profit = s.position.RealizedProfit()
+	return s.profit
 }
 
 // 損切りすべきか判断する
-// 最近の買い注文の後
+// ポジションの平均取得単価から下落したとき
 func (s *SignalEvents) ShouldCutLoss(currentPrice, stopLimitPercent float64) bool {
 	if s == nil {
 		return false
 	}
 
-	lastSignal := s.LastSignal()
-	if lastSignal == nil ||
-		lastSignal.Side() != OrderSideBuy {
+	averagePrice := s.position.AveragePrice()
+	if averagePrice <= 0 {
 		return false
 	}
 
-	stopLimit := lastSignal.Price() * stopLimitPercent
+	stopLimit := averagePrice * stopLimitPercent
 	return currentPrice < stopLimit
 }
@@ -182,3 +182,168 @@ func TestSignalEvents(t *testing.T) {
 		}
 	})
 }
+
+func TestDCASignalEvent(t *testing.T) {
+	buy := model.NewSignalEvent(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), config.ProductCode, model.OrderSideBuy, 1000, 1)
+	dca := model.NewSignalEventWithTag(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), config.ProductCode, model.OrderSideBuy, 500, 2, model.SignalEventTagDCA)
+	if dca == nil {
+		t.Fatal("NewSignalEventWithTag() returns nil")
+	}
+	if !dca.IsDCA() || buy.IsDCA() || buy.Tag() != model.SignalEventTagStrategy {
+		t.Fatalf("buy=%s, dca=%s", buy.Tag(), dca.Tag())
+	}
+
+	if model.NewSignalEventWithTag(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), config.ProductCode, model.OrderSideSell, 500, 2, model.SignalEventTagDCA) != nil {
+		t.Fatal("NewSignalEventWithTag() returns not nil")
+	}
+	if model.NewSignalEventWithTag(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), config.ProductCode, model.OrderSideBuy, 500, 2, "UNKNOWN") != nil {
+		t.Fatal("NewSignalEventWithTag() returns not nil")
+	}
+
+	signalEvents := model.NewSignalEvents([]model.SignalEvent{*buy, *dca})
+
+	t.Run("LastSignal", func(t *testing.T) {
+		if *signalEvents.LastSignal() != *buy {
+			t.Fatalf("%+v != %+v", *signalEvents.LastSignal(), *buy)
+		}
+		if *signalEvents.LastDCASignal() != *dca {
+			t.Fatalf("%+v != %+v", *signalEvents.LastDCASignal(), *dca)
+		}
+	})
+
+	t.Run("CanBuyAt", func(t *testing.T) {
+		// 積立の後でも，売買サインによる買いの後なので買えない
+		if signalEvents.CanBuyAt(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)) {
+			t.Fatal("CanBuyAt() returns true")
+		}
+		if !signalEvents.CanSellAt(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)) {
+			t.Fatal("CanSellAt() returns false")
+		}
+	})
+
+	t.Run("AddBuySignal", func(t *testing.T) {
+		if signalEvents.AddBuySignal(*dca) {
+			t.Fatal("AddBuySignal() returns true")
+		}
+	})
+
+	t.Run("EstimateProfit", func(t *testing.T) {
+		sell := model.NewSignalEvent(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC), config.ProductCode, model.OrderSideSell, 1500, 1)
+		signalEvents.AddSellSignal(*sell)
+		if profit := signalEvents.EstimateProfit(); profit != 500 {
+			t.Fatalf("profit=%f", profit)
+		}
+	})
+
+	// グリッド取引の売買も，売買サインによる取引のポジションに含めない
+	t.Run("grid", func(t *testing.T) {
+		grid := model.NewSignalEventWithTag(time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC), config.ProductCode, model.OrderSideBuy, 1000, 1, model.SignalEventTagGrid)
+		if grid == nil {
+			t.Fatal("NewSignalEventWithTag() returns nil")
+		}
+		signalEvents := model.NewSignalEvents([]model.SignalEvent{*buy, *grid})
+		if *signalEvents.LastSignal() != *buy || signalEvents.Position().Size() != 1 {
+			t.Fatalf("lastSignal=%+v, size=%f", *signalEvents.LastSignal(), signalEvents.Position().Size())
+		}
+		if signalEvents.AddBuySignal(*grid) {
+			t.Fatal("AddBuySignal() returns true")
+		}
+	})
+}
+
+func TestSignalEventsWithLots(t *testing.T) {
+	if model.NewSignalEventsWithLots([]model.SignalEvent{}, 0, model.LotMatchingFIFO) != nil {
+		t.Fatal("NewSignalEventsWithLots() returns not nil")
+	}
+	if model.NewSignalEventsWithLots([]model.SignalEvent{}, 2, "UNKNOWN") != nil {
+		t.Fatal("NewSignalEventsWithLots() returns not nil")
+	}
+
+	day := func(d int) time.Time {
+		return time.Date(2021, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	// 履歴からポジションを復元する
+	history := []model.SignalEvent{
+		*model.NewSignalEvent(day(1), config.ProductCode, model.OrderSideBuy, 1000, 1),
+		*model.NewSignalEventWithTag(day(2), config.ProductCode, model.OrderSideBuy, 500, 3, model.SignalEventTagDCA),
+	}
+	signalEvents := model.NewSignalEventsWithLots(history, 2, model.LotMatchingFIFO)
+	if signalEvents == nil {
+		t.Fatal("NewSignalEventsWithLots() returns nil")
+	}
+	if signalEvents.Position().Size() != 1 {
+		t.Fatalf("size=%f", signalEvents.Position().Size())
+	}
+
+	t.Run("pyramiding", func(t *testing.T) {
+		buy := model.NewSignalEvent(day(3), config.ProductCode, model.OrderSideBuy, 2000, 1)
+		if !signalEvents.AddBuySignal(*buy) {
+			t.Fatal("AddBuySignal() returns false")
+		}
+		// ロットの数が上限に達している
+		if signalEvents.CanBuyAt(day(4)) {
+			t.Fatal("CanBuyAt() returns true")
+		}
+	})
+
+	t.Run("partial exit", func(t *testing.T) {
+		sell := model.NewSignalEvent(day(4), config.ProductCode, model.OrderSideSell, 3000, 1)
+		if !signalEvents.AddSellSignal(*sell) {
+			t.Fatal("AddSellSignal() returns false")
+		}
+		if signalEvents.EstimateProfit() != 2000 {
+			t.Fatalf("profit=%f", signalEvents.Profit())
+		}
+		if !signalEvents.CanBuyAt(day(5)) || !signalEvents.CanSellAt(day(5)) {
+			t.Fatal("CanBuyAt() or CanSellAt() returns false")
+		}
+	})
+
+	t.Run("ShouldCutLoss", func(t *testing.T) {
+		// 残っているのは2000で買ったロット
+		if signalEvents.ShouldCutLoss(1700, 0.8) {
+			t.Fatal("ShouldCutLoss() returns true")
+		}
+		if !signalEvents.ShouldCutLoss(1700, 0.9) {
+			t.Fatal("ShouldCutLoss() returns false")
+		}
+	})
+
+	t.Run("exit all", func(t *testing.T) {
+		sell := model.NewSignalEvent(day(5), config.ProductCode, model.OrderSideSell, 1000, 1)
+		signalEvents.AddSellSignal(*sell)
+		if signalEvents.CanSellAt(day(6)) {
+			t.Fatal("CanSellAt() returns true")
+		}
+		if signalEvents.EstimateProfit() != 1000 {
+			t.Fatalf("profit=%f", signalEvents.Profit())
+		}
+	})
+
+	t.Run("AVERAGE", func(t *testing.T) {
+		signalEvents := model.NewSignalEventsWithLots([]model.SignalEvent{}, 2, model.LotMatchingAverage)
+		for i, price := range []float64{1000, 2000} {
+			buy := model.NewSignalEvent(day(i+1), config.ProductCode, model.OrderSideBuy, price, 1)
+			if !signalEvents.AddBuySignal(*buy) {
+				t.Fatal("AddBuySignal() returns false")
+			}
+		}
+		sell := model.NewSignalEvent(day(3), config.ProductCode, model.OrderSideSell, 3000, 1)
+		if !signalEvents.AddSellSignal(*sell) {
+			t.Fatal("AddSellSignal() returns false")
+		}
+
+		// 一部を決済したのでロットに空きができる
+		buy := model.NewSignalEvent(day(4), config.ProductCode, model.OrderSideBuy, 1500, 1)
+		if !signalEvents.AddBuySignal(*buy) {
+			t.Fatal("AddBuySignal() returns false")
+		}
+		if signalEvents.Position().Size() != 2 || signalEvents.Position().AveragePrice() != 1500 {
+			t.Fatalf("size=%f, averagePrice=%f", signalEvents.Position().Size(), signalEvents.Position().AveragePrice())
+		}
+		if signalEvents.CanBuyAt(day(5)) {
+			t.Fatal("CanBuyAt() returns true")
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package model
+
+// 売買サインの投票に参加する指標
+type SignalIndicator int
+
+const (
+	SignalIndicatorEMA SignalIndicator = iota
+	SignalIndicatorBBands
+	SignalIndicatorIchimoku
+	SignalIndicatorRSI
+	SignalIndicatorMACD
+	SignalIndicatorATR
+	SignalIndicatorStoch
+	SignalIndicatorADX
+	SignalIndicatorOBV
+	SignalIndicatorVWAP
+	SignalIndicatorSAR
+	SignalIndicatorDonchian
+	SignalIndicatorKeltner
+	SignalIndicatorHeikinAshi
+	signalIndicatorLen
+)
+
+func SignalIndicators() []SignalIndicator {
+	indicators := make([]SignalIndicator, signalIndicatorLen)
+	for i := range indicators {
+		indicators[i] = SignalIndicator(i)
+	}
+	return indicators
+}
+
+// 各指標の売買サインに掛ける重み
+// TradeParamsを比較可能に保つため，配列で持つ
+type SignalWeights struct {
+	weights [signalIndicatorLen]float64
+}
+
+func NewSignalWeights(ema, bbands, ichimoku, rsi, macd, atr, stoch, adx, obv, vwap, sar, donchian, keltner, heikinAshi float64) *SignalWeights {
+	weights := [signalIndicatorLen]float64{
+		ema,
+		bbands,
+		ichimoku,
+		rsi,
+		macd,
+		atr,
+		stoch,
+		adx,
+		obv,
+		vwap,
+		sar,
+		donchian,
+		keltner,
+		heikinAshi,
+	}
+	for _, weight := range weights {
+		if weight < 0 {
+			return nil
+		}
+	}
+
+	return &SignalWeights{
+		weights: weights,
+	}
+}
+
+// 全ての指標の重みが1
+func NewUniformSignalWeights() *SignalWeights {
+	return NewSignalWeights(1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1)
+}
+
+func (sw *SignalWeights) Weight(indicator SignalIndicator) float64 {
+	if indicator < 0 || signalIndicatorLen <= indicator {
+		return 0
+	}
+	return sw.weights[indicator]
+}
+
+// 指定した指標の重みだけを変えたSignalWeightsを返す
+func (sw *SignalWeights) WithWeight(indicator SignalIndicator, weight float64) *SignalWeights {
+	if indicator < 0 || signalIndicatorLen <= indicator {
+		return nil
+	}
+	if weight < 0 {
+		return nil
+	}
+
+	weights := sw.weights
+	weights[indicator] = weight
+	return &SignalWeights{
+		weights: weights,
+	}
+}
+
+func (sw *SignalWeights) EMA() float64 {
+	return sw.weights[SignalIndicatorEMA]
+}
+
+func (sw *SignalWeights) BBands() float64 {
+	return sw.weights[SignalIndicatorBBands]
+}
+
+func (sw *SignalWeights) Ichimoku() float64 {
+	return sw.weights[SignalIndicatorIchimoku]
+}
+
+func (sw *SignalWeights) RSI() float64 {
+	return sw.weights[SignalIndicatorRSI]
+}
+
+func (sw *SignalWeights) MACD() float64 {
+	return sw.weights[SignalIndicatorMACD]
+}
+
+func (sw *SignalWeights) ATR() float64 {
+	return sw.weights[SignalIndicatorATR]
+}
+
+func (sw *SignalWeights) Stoch() float64 {
+	return sw.weights[SignalIndicatorStoch]
+}
+
+func (sw *SignalWeights) ADX() float64 {
+	return sw.weights[SignalIndicatorADX]
+}
+
+func (sw *SignalWeights) OBV() float64 {
+	return sw.weights[SignalIndicatorOBV]
+}
+
+func (sw *SignalWeights) VWAP() float64 {
+	return sw.weights[SignalIndicatorVWAP]
+}
+
+func (sw *SignalWeights) SAR() float64 {
+	return sw.weights[SignalIndicatorSAR]
+}
+
+func (sw *SignalWeights) Donchian() float64 {
+	return sw.weights[SignalIndicatorDonchian]
+}
+
+func (sw *SignalWeights) Keltner() float64 {
+	return sw.weights[SignalIndicatorKeltner]
+}
+
+func (sw *SignalWeights) HeikinAshi() float64 {
+	return sw.weights[SignalIndicatorHeikinAshi]
+}
@@ -0,0 +1,47 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+)
+
+func TestSignalWeights(t *testing.T) {
+	var weights *model.SignalWeights
+
+	weights = model.NewSignalWeights(1, 2, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 0.5)
+	if weights == nil {
+		t.Fatal("NewSignalWeights() returns nil")
+	}
+	if weights.BBands() != 2 || weights.Ichimoku() != 0 || weights.HeikinAshi() != 0.5 {
+		t.Fatalf("bbands=%f, ichimoku=%f, heikinAshi=%f", weights.BBands(), weights.Ichimoku(), weights.HeikinAshi())
+	}
+
+	weights = model.NewSignalWeights(1, -1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1)
+	if weights != nil {
+		t.Fatal("NewSignalWeights() returns not nil")
+	}
+
+	t.Run("with weight", func(t *testing.T) {
+		uniform := model.NewUniformSignalWeights()
+
+		changed := uniform.WithWeight(model.SignalIndicatorMACD, 3)
+		if changed == nil {
+			t.Fatal("WithWeight() returns nil")
+		}
+		if changed.MACD() != 3 || changed.Weight(model.SignalIndicatorMACD) != 3 {
+			t.Fatalf("macd=%f", changed.MACD())
+		}
+		// 元の重みは変わらない
+		if uniform.MACD() != 1 {
+			t.Fatalf("uniform macd=%f", uniform.MACD())
+		}
+		if *uniform == *changed {
+			t.Fatal("WithWeight() should return different weights")
+		}
+
+		if uniform.WithWeight(model.SignalIndicatorMACD, -1) != nil {
+			t.Fatal("WithWeight() returns not nil")
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+// Slackのスラッシュコマンド（/bot <action>）で行う操作
+type SlackCommandAction string
+
+const (
+	SlackCommandActionStatus  SlackCommandAction = "status"
+	SlackCommandActionBalance SlackCommandAction = "balance"
+	SlackCommandActionPause   SlackCommandAction = "pause"
+	SlackCommandActionResume  SlackCommandAction = "resume"
+	SlackCommandActionHelp    SlackCommandAction = "help"
+)
+
+var ErrUnknownSlackCommand = errors.New("unknown command")
+
+// コマンドの引数から操作を読み取る．空なら使い方を返す
+func ParseSlackCommandAction(text string) (SlackCommandAction, error) {
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) == 0 {
+		return SlackCommandActionHelp, nil
+	}
+
+	action := SlackCommandAction(fields[0])
+	switch action {
+	case SlackCommandActionStatus, SlackCommandActionBalance, SlackCommandActionPause, SlackCommandActionResume, SlackCommandActionHelp:
+		return action, nil
+	}
+	return "", ErrUnknownSlackCommand
+}
+
+// 取引を止めたり再開したりする操作か
+func (a SlackCommandAction) Mutates() bool {
+	return a == SlackCommandActionPause || a == SlackCommandActionResume
+}
+
+// SlackのユーザIDに割り当てる権限
+type SlackRole string
+
+const (
+	SlackRoleViewer   SlackRole = "viewer"   // 状態と残高を見るだけ
+	SlackRoleOperator SlackRole = "operator" // 取引の停止・再開もできる
+)
+
+func (r SlackRole) Valid() bool {
+	return r == SlackRoleViewer || r == SlackRoleOperator
+}
+
+func (r SlackRole) Allows(action SlackCommandAction) bool {
+	switch r {
+	case SlackRoleOperator:
+		return true
+	case SlackRoleViewer:
+		return !action.Mutates()
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+)
+
+func TestSlackCommand(t *testing.T) {
+	t.Run("parse action", func(t *testing.T) {
+		cases := map[string]model.SlackCommandAction{
+			"":           model.SlackCommandActionHelp,
+			"status":     model.SlackCommandActionStatus,
+			" Pause ":    model.SlackCommandActionPause,
+			"resume now": model.SlackCommandActionResume,
+		}
+		for text, expected := range cases {
+			action, err := model.ParseSlackCommandAction(text)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if action != expected {
+				t.Fatalf("text=%q, action=%s", text, action)
+			}
+		}
+
+		if _, err := model.ParseSlackCommandAction("sell"); err != model.ErrUnknownSlackCommand {
+			t.Fatalf("err=%v", err)
+		}
+	})
+
+	t.Run("role", func(t *testing.T) {
+		if !model.SlackRoleViewer.Allows(model.SlackCommandActionStatus) || model.SlackRoleViewer.Allows(model.SlackCommandActionPause) {
+			t.Fatal("viewer should only read")
+		}
+		if !model.SlackRoleOperator.Allows(model.SlackCommandActionResume) {
+			t.Fatal("operator should resume")
+		}
+		if model.SlackRole("").Allows(model.SlackCommandActionStatus) || model.SlackRole("admin").Valid() {
+			t.Fatal("unknown role should not be allowed")
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// 同じ時刻の，基準の取引所と他の取引所の価格差
+// 価格は最良気配値の仲値
+type Spread struct {
+	time         time.Time
+	productCode  string
+	baseExchange string
+	exchange     string
+	basePrice    float64
+	price        float64
+}
+
+func NewSpread(timeTime time.Time, productCode, baseExchange, exchange string, basePrice, price float64) *Spread {
+	if productCode == "" {
+		return nil
+	}
+
+	if baseExchange == "" || exchange == "" || baseExchange == exchange {
+		return nil
+	}
+
+	if basePrice <= 0 || price <= 0 {
+		return nil
+	}
+
+	timeTime = timeTime.In(time.UTC)
+
+	return &Spread{
+		time:         timeTime,
+		productCode:  productCode,
+		baseExchange: baseExchange,
+		exchange:     exchange,
+		basePrice:    basePrice,
+		price:        price,
+	}
+}
+
+func (s *Spread) Time() time.Time {
+	return s.time
+}
+
+func (s *Spread) ProductCode() string {
+	return s.productCode
+}
+
+// 基準の取引所（取引している取引所）
+func (s *Spread) BaseExchange() string {
+	return s.baseExchange
+}
+
+// 比べる取引所
+func (s *Spread) Exchange() string {
+	return s.exchange
+}
+
+func (s *Spread) BasePrice() float64 {
+	return s.basePrice
+}
+
+func (s *Spread) Price() float64 {
+	return s.price
+}
+
+// 基準の取引所の価格に対する価格差の割合
+// 比べる取引所のほうが高ければ正
+func (s *Spread) Rate() float64 {
+	return (s.price - s.basePrice) / s.basePrice
+}
+
+// 価格差の割合の絶対値がthreshold以上か
+func (s *Spread) Exceeds(threshold float64) bool {
+	return math.Abs(s.Rate()) >= threshold
+}
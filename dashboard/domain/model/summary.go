@@ -0,0 +1,231 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 定期的に送る運用成績のまとめの期間
+type SummaryPeriod string
+
+const (
+	SummaryPeriodDaily  SummaryPeriod = "daily"
+	SummaryPeriodWeekly SummaryPeriod = "weekly"
+)
+
+func (sp SummaryPeriod) Valid() bool {
+	return sp == SummaryPeriodDaily || sp == SummaryPeriodWeekly
+}
+
+func (sp SummaryPeriod) Duration() time.Duration {
+	switch sp {
+	case SummaryPeriodWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// 最新の足で指標が出している売買サイン（どちらも出ていなければ空文字）
+type IndicatorState struct {
+	name string
+	side OrderSide
+}
+
+func NewIndicatorState(name string, side OrderSide) *IndicatorState {
+	if name == "" {
+		return nil
+	}
+
+	if side != "" && side != OrderSideBuy && side != OrderSideSell {
+		return nil
+	}
+
+	return &IndicatorState{
+		name: name,
+		side: side,
+	}
+}
+
+func (is *IndicatorState) Name() string {
+	return is.name
+}
+
+func (is *IndicatorState) Side() OrderSide {
+	return is.side
+}
+
+// 表示用（サインが出ていなければ"-"）
+func (is *IndicatorState) Signal() string {
+	if is.side == "" {
+		return "-"
+	}
+	return string(is.side)
+}
+
+// 前回のまとめから今回までの運用成績
+type Summary struct {
+	time        time.Time
+	period      SummaryPeriod
+	since       time.Time // 前回のまとめの時刻（なければ1期間前）
+	portfolio   Portfolio
+	trades      []SignalEvent
+	indicators  []IndicatorState
+	paramsLines []string // 今回の売買パラメータ
+	changes     []string // 前回から変わった売買パラメータ
+}
+
+func NewSummary(timeTime time.Time, period SummaryPeriod, since time.Time, portfolio Portfolio, trades []SignalEvent, indicators []IndicatorState, paramsLines, changes []string) *Summary {
+	if !period.Valid() {
+		return nil
+	}
+
+	if since.After(timeTime) {
+		return nil
+	}
+
+	timeTime = timeTime.In(time.UTC)
+	since = since.In(time.UTC)
+
+	return &Summary{
+		time:        timeTime,
+		period:      period,
+		since:       since,
+		portfolio:   portfolio,
+		trades:      trades,
+		indicators:  indicators,
+		paramsLines: paramsLines,
+		changes:     changes,
+	}
+}
+
+func (s *Summary) Time() time.Time {
+	return s.time
+}
+
+func (s *Summary) Period() SummaryPeriod {
+	return s.period
+}
+
+func (s *Summary) Since() time.Time {
+	return s.since
+}
+
+func (s *Summary) Portfolio() Portfolio {
+	return s.portfolio
+}
+
+func (s *Summary) ProductCode() string {
+	return s.portfolio.ProductCode()
+}
+
+func (s *Summary) Trades() []SignalEvent {
+	return s.trades
+}
+
+func (s *Summary) Indicators() []IndicatorState {
+	return s.indicators
+}
+
+func (s *Summary) ParamsLines() []string {
+	return s.paramsLines
+}
+
+func (s *Summary) Changes() []string {
+	return s.changes
+}
+
+func (s *Summary) ParamsChanged() bool {
+	return len(s.changes) > 0
+}
+
+func (s *Summary) Title() string {
+	if s.period == SummaryPeriodWeekly {
+		return "週次レポート"
+	}
+	return "日次レポート"
+}
+
+// 次のまとめで差分を取るための記録
+func (s *Summary) Report() *SummaryReport {
+	return NewSummaryReport(s.time, s.ProductCode(), s.period, s.paramsLines)
+}
+
+// まとめを送った時刻と，そのときの売買パラメータ
+type SummaryReport struct {
+	time        time.Time
+	productCode string
+	period      SummaryPeriod
+	paramsLines []string
+}
+
+func NewSummaryReport(timeTime time.Time, productCode string, period SummaryPeriod, paramsLines []string) *SummaryReport {
+	if productCode == "" {
+		return nil
+	}
+
+	if !period.Valid() {
+		return nil
+	}
+
+	timeTime = timeTime.In(time.UTC)
+
+	return &SummaryReport{
+		time:        timeTime,
+		productCode: productCode,
+		period:      period,
+		paramsLines: paramsLines,
+	}
+}
+
+func (sr *SummaryReport) Time() time.Time {
+	return sr.time
+}
+
+func (sr *SummaryReport) ProductCode() string {
+	return sr.productCode
+}
+
+func (sr *SummaryReport) Period() SummaryPeriod {
+	return sr.period
+}
+
+func (sr *SummaryReport) ParamsLines() []string {
+	return sr.paramsLines
+}
+
+// Slack以外の通知先に送るときの文面
+func NewSummaryNotification(summary Summary) *Notification {
+	portfolio := summary.Portfolio()
+
+	lines := []string{
+		fmt.Sprintf("Price: %f", portfolio.CurrentPrice()),
+		fmt.Sprintf("Position: %f", portfolio.Position()),
+		fmt.Sprintf("Equity: %.0f", portfolio.Equity()),
+		fmt.Sprintf("PnL: %+.0f (realized %+.0f, unrealized %+.0f)", portfolio.TotalPnL(), portfolio.RealizedPnL(), portfolio.UnrealizedPnL()),
+		fmt.Sprintf("Trades: %d", len(summary.Trades())),
+	}
+	for _, trade := range summary.Trades() {
+		lines = append(lines, fmt.Sprintf("  %s %s %f @ %f", trade.Time().Format("01-02 15:04"), trade.Side(), trade.Size(), trade.Price()))
+	}
+
+	indicators := make([]string, 0, len(summary.Indicators()))
+	for _, indicator := range summary.Indicators() {
+		indicators = append(indicators, indicator.Name()+": "+indicator.Signal())
+	}
+	if len(indicators) > 0 {
+		lines = append(lines, "Indicators: "+strings.Join(indicators, ", "))
+	}
+
+	if summary.ParamsChanged() {
+		lines = append(lines, "Params changed:")
+		for _, change := range summary.Changes() {
+			lines = append(lines, "  "+change)
+		}
+	} else {
+		lines = append(lines, "Params: unchanged")
+	}
+
+	return NewNotification(summary.Time(), NotificationLevelInfo, summary.ProductCode(), summary.Title(), strings.Join(lines, "\n"))
+}
@@ -0,0 +1,162 @@
+package model_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+)
+
+func newTaxReportSignals() []model.SignalEvent {
+	table := []struct {
+		time  time.Time
+		side  model.OrderSide
+		price float64
+		size  float64
+	}{
+		{
+			time:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			side:  model.OrderSideBuy,
+			price: 1000,
+			size:  1,
+		},
+		{
+			time:  time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+			side:  model.OrderSideSell,
+			price: 3000,
+			size:  1,
+		},
+		{
+			time:  time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+			side:  model.OrderSideBuy,
+			price: 2000,
+			size:  1,
+		},
+		// 日本時間では2021年1月1日
+		{
+			time:  time.Date(2020, 12, 31, 15, 0, 0, 0, time.UTC),
+			side:  model.OrderSideBuy,
+			price: 4000,
+			size:  2,
+		},
+		{
+			time:  time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			side:  model.OrderSideSell,
+			price: 5000,
+			size:  1,
+		},
+	}
+
+	signals := make([]model.SignalEvent, 0)
+	for _, s := range table {
+		signal := model.NewSignalEvent(s.time, config.ProductCode, s.side, s.price, s.size)
+		signals = append(signals, *signal)
+	}
+	return signals
+}
+
+func TestNewTaxReports(t *testing.T) {
+	signals := newTaxReportSignals()
+
+	t.Run("invalid args", func(t *testing.T) {
+		if model.NewTaxReports("", signals, 0, model.CostMethodMovingAverage, config.LocalTime) != nil {
+			t.Fatal("NewTaxReports() returns not nil")
+		}
+		if model.NewTaxReports(config.ProductCode, signals, 0, model.CostMethod("fifo"), config.LocalTime) != nil {
+			t.Fatal("NewTaxReports() returns not nil")
+		}
+		if model.NewTaxReports(config.ProductCode, signals, 0, model.CostMethodMovingAverage, nil) != nil {
+			t.Fatal("NewTaxReports() returns not nil")
+		}
+	})
+
+	table := []struct {
+		method      model.CostMethod
+		gains       []float64
+		endPosition []float64
+		endCost     []float64
+	}{
+		{
+			method:      model.CostMethodMovingAverage,
+			gains:       []float64{2000, 5000 - 10000.0/3},
+			endPosition: []float64{1, 2},
+			endCost:     []float64{2000, 20000.0 / 3},
+		},
+		{
+			method:      model.CostMethodTotalAverage,
+			gains:       []float64{1500, 5000 - 9500.0/3},
+			endPosition: []float64{1, 2},
+			endCost:     []float64{1500, 19000.0 / 3},
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(string(tt.method), func(t *testing.T) {
+			reports := model.NewTaxReports(config.ProductCode, signals, 0, tt.method, config.LocalTime)
+			if reports == nil {
+				t.Fatal("NewTaxReports() returns nil")
+			}
+			if len(reports) != 2 {
+				t.Fatalf("%d != %d", len(reports), 2)
+			}
+			for i, report := range reports {
+				if report.Year() != 2020+i {
+					t.Fatalf("%d != %d", report.Year(), 2020+i)
+				}
+				if math.Abs(report.Gain()-tt.gains[i]) > 1e-6 {
+					t.Fatalf("%v != %v", report.Gain(), tt.gains[i])
+				}
+				if math.Abs(report.EndPosition()-tt.endPosition[i]) > 1e-9 {
+					t.Fatalf("%v != %v", report.EndPosition(), tt.endPosition[i])
+				}
+				if math.Abs(report.EndCostAmount()-tt.endCost[i]) > 1e-6 {
+					t.Fatalf("%v != %v", report.EndCostAmount(), tt.endCost[i])
+				}
+			}
+		})
+	}
+
+	// 保有数量を超えた分の売却は含めない
+	t.Run("oversell", func(t *testing.T) {
+		oversell := []model.SignalEvent{
+			*model.NewSignalEvent(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), config.ProductCode, model.OrderSideBuy, 1000, 1),
+			*model.NewSignalEvent(time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), config.ProductCode, model.OrderSideSell, 3000, 2),
+		}
+		for _, method := range []model.CostMethod{model.CostMethodMovingAverage, model.CostMethodTotalAverage} {
+			reports := model.NewTaxReports(config.ProductCode, oversell, 0.001, method, config.LocalTime)
+			if len(reports) != 1 {
+				t.Fatalf("%s: %d != %d", method, len(reports), 1)
+			}
+			report := reports[0]
+			if report.SellSize() != 1 || report.SellAmount() != 3000 {
+				t.Fatalf("%s: sellSize=%v, sellAmount=%v", method, report.SellSize(), report.SellAmount())
+			}
+			// 3000 - (1000 + 1) - 3
+			if math.Abs(report.Gain()-1996) > 1e-9 {
+				t.Fatalf("%s: %v != %v", method, report.Gain(), 1996)
+			}
+			if math.Abs(report.Commission()-4) > 1e-9 {
+				t.Fatalf("%s: %v != %v", method, report.Commission(), 4)
+			}
+			if report.EndPosition() != 0 || math.Abs(report.EndCostAmount()) > 1e-9 {
+				t.Fatalf("%s: endPosition=%v, endCost=%v", method, report.EndPosition(), report.EndCostAmount())
+			}
+		}
+	})
+
+	t.Run("with commission", func(t *testing.T) {
+		reports := model.NewTaxReports(config.ProductCode, signals[:2], 0.001, model.CostMethodMovingAverage, config.LocalTime)
+		if len(reports) != 1 {
+			t.Fatalf("%d != %d", len(reports), 1)
+		}
+		// 3000 - (1000 + 1) - 3
+		if math.Abs(reports[0].Gain()-1996) > 1e-9 {
+			t.Fatalf("%v != %v", reports[0].Gain(), 1996)
+		}
+		if math.Abs(reports[0].Commission()-4) > 1e-9 {
+			t.Fatalf("%v != %v", reports[0].Commission(), 4)
+		}
+	})
+}
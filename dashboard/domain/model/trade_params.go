@@ -1,40 +1,85 @@
 package model
 
 type TradeParams struct {
-	tradeEnable      bool
-	productCode      string
-	size             float64
-	smaEnable        bool
-	smaPeriod1       int
-	smaPeriod2       int
-	smaPeriod3       int
-	emaEnable        bool
-	emaPeriod1       int
-	emaPeriod2       int
-	emaPeriod3       int
-	bbandsEnable     bool
-	bbandsN          int
-	bbandsK          float64
-	ichimokuEnable   bool
-	rsiEnable        bool
-	rsiPeriod        int
-	rsiBuyThread     float64
-	rsiSellThread    float64
-	macdEnable       bool
-	macdFastPeriod   int
-	macdSlowPeriod   int
-	macdSignalPeriod int
-	stopLimitPercent float64
+	tradeEnable           bool
+	productCode           string
+	size                  float64
+	smaEnable             bool
+	smaPeriod1            int
+	smaPeriod2            int
+	smaPeriod3            int
+	emaEnable             bool
+	emaPeriod1            int
+	emaPeriod2            int
+	emaPeriod3            int
+	bbandsEnable          bool
+	bbandsN               int
+	bbandsK               float64
+	ichimokuEnable        bool
+	ichimokuTenkanPeriod  int
+	ichimokuKijunPeriod   int
+	ichimokuSenkouBPeriod int
+	rsiEnable             bool
+	rsiPeriod             int
+	rsiBuyThread          float64
+	rsiSellThread         float64
+	macdEnable            bool
+	macdFastPeriod        int
+	macdSlowPeriod        int
+	macdSignalPeriod      int
+	atrEnable             bool
+	atrPeriod             int
+	atrMultiplier         float64
+	stochEnable           bool
+	stochFastKPeriod      int
+	stochSlowKPeriod      int
+	stochSlowDPeriod      int
+	stochBuyThread        float64
+	stochSellThread       float64
+	adxEnable             bool
+	adxPeriod             int
+	adxThread             float64
+	obvEnable             bool
+	obvPeriod             int
+	vwapEnable            bool
+	vwapPeriod            int
+	sarEnable             bool
+	sarAcceleration       float64
+	sarMaximum            float64
+	donchianEnable        bool
+	donchianPeriod        int
+	keltnerEnable         bool
+	keltnerPeriod         int
+	keltnerMultiplier     float64
+	heikinAshiEnable      bool
+	heikinAshiPeriod      int
+	signalWeights         SignalWeights
+	buyVoteThreshold      float64
+	sellVoteThreshold     float64
+	stopLimitPercent      float64
+	maxLots               int
+	lotMatching           LotMatching
 }
 
 func NewTradeParams(tradeEnable bool, productCode string, size float64,
 	smaEnable bool, smaPeriod1, smaPeriod2, smaPeriod3 int,
 	emaEnable bool, emaPeriod1, emaPeriod2, emaPeriod3 int,
 	bbandsEnable bool, bbandsN int, bbandsK float64,
-	ichimokuEnable bool,
+	ichimokuEnable bool, ichimokuTenkanPeriod, ichimokuKijunPeriod, ichimokuSenkouBPeriod int,
 	rsiEnable bool, rsiPeriod int, rsiBuyThread, rsiSellThread float64,
 	macdEnable bool, macdFastPeriod, macdSlowPeriod, macdSignalPeriod int,
-	stopLimitPercent float64) *TradeParams {
+	atrEnable bool, atrPeriod int, atrMultiplier float64,
+	stochEnable bool, stochFastKPeriod, stochSlowKPeriod, stochSlowDPeriod int, stochBuyThread, stochSellThread float64,
+	adxEnable bool, adxPeriod int, adxThread float64,
+	obvEnable bool, obvPeriod int,
+	vwapEnable bool, vwapPeriod int,
+	sarEnable bool, sarAcceleration, sarMaximum float64,
+	donchianEnable bool, donchianPeriod int,
+	keltnerEnable bool, keltnerPeriod int, keltnerMultiplier float64,
+	heikinAshiEnable bool, heikinAshiPeriod int,
+	signalWeights SignalWeights, buyVoteThreshold, sellVoteThreshold float64,
+	stopLimitPercent float64,
+	maxLots int, lotMatching LotMatching) *TradeParams {
 	if productCode == "" {
 		return nil
 	}
@@ -63,6 +108,13 @@ func NewTradeParams(tradeEnable bool, productCode string, size float64,
 		return nil
 	}
 
+	if ichimokuEnable &&
+		(ichimokuTenkanPeriod <= 0 ||
+			ichimokuKijunPeriod <= 0 ||
+			ichimokuSenkouBPeriod <= 0) {
+		return nil
+	}
+
 	if rsiEnable &&
 		(rsiPeriod <= 0 ||
 			rsiBuyThread < 0 || 100 < rsiBuyThread ||
@@ -77,35 +129,132 @@ func NewTradeParams(tradeEnable bool, productCode string, size float64,
 		return nil
 	}
 
+	if atrEnable &&
+		(atrPeriod <= 0 ||
+			atrMultiplier <= 0) {
+		return nil
+	}
+
+	if stochEnable &&
+		(stochFastKPeriod <= 0 ||
+			stochSlowKPeriod <= 0 ||
+			stochSlowDPeriod <= 0 ||
+			stochBuyThread < 0 || 100 < stochBuyThread ||
+			stochSellThread < 0 || 100 < stochSellThread) {
+		return nil
+	}
+
+	if adxEnable &&
+		(adxPeriod <= 0 ||
+			adxThread < 0 || 100 < adxThread) {
+		return nil
+	}
+
+	if obvEnable && obvPeriod <= 0 {
+		return nil
+	}
+
+	if vwapEnable && vwapPeriod <= 0 {
+		return nil
+	}
+
+	if sarEnable &&
+		(sarAcceleration <= 0 ||
+			sarMaximum < sarAcceleration) {
+		return nil
+	}
+
+	if donchianEnable && donchianPeriod <= 0 {
+		return nil
+	}
+
+	if keltnerEnable &&
+		(keltnerPeriod <= 0 ||
+			keltnerMultiplier <= 0) {
+		return nil
+	}
+
+	if heikinAshiEnable && heikinAshiPeriod <= 0 {
+		return nil
+	}
+
+	for _, indicator := range SignalIndicators() {
+		if signalWeights.Weight(indicator) < 0 {
+			return nil
+		}
+	}
+
+	if buyVoteThreshold <= 0 || sellVoteThreshold <= 0 {
+		return nil
+	}
+
 	if stopLimitPercent < 0 || 100 < stopLimitPercent {
 		return nil
 	}
 
+	if maxLots < 1 || !lotMatching.Valid() {
+		return nil
+	}
+
 	return &TradeParams{
-		tradeEnable:      tradeEnable,
-		productCode:      productCode,
-		size:             size,
-		smaEnable:        smaEnable,
-		smaPeriod1:       smaPeriod1,
-		smaPeriod2:       smaPeriod2,
-		smaPeriod3:       smaPeriod3,
-		emaEnable:        emaEnable,
-		emaPeriod1:       emaPeriod1,
-		emaPeriod2:       emaPeriod2,
-		emaPeriod3:       emaPeriod3,
-		bbandsEnable:     bbandsEnable,
-		bbandsN:          bbandsN,
-		bbandsK:          bbandsK,
-		ichimokuEnable:   ichimokuEnable,
-		rsiEnable:        rsiEnable,
-		rsiPeriod:        rsiPeriod,
-		rsiBuyThread:     rsiBuyThread,
-		rsiSellThread:    rsiSellThread,
-		macdEnable:       macdEnable,
-		macdFastPeriod:   macdFastPeriod,
-		macdSlowPeriod:   macdSlowPeriod,
-		macdSignalPeriod: macdSignalPeriod,
-		stopLimitPercent: stopLimitPercent,
+		tradeEnable:           tradeEnable,
+		productCode:           productCode,
+		size:                  size,
+		smaEnable:             smaEnable,
+		smaPeriod1:            smaPeriod1,
+		smaPeriod2:            smaPeriod2,
+		smaPeriod3:            smaPeriod3,
+		emaEnable:             emaEnable,
+		emaPeriod1:            emaPeriod1,
+		emaPeriod2:            emaPeriod2,
+		emaPeriod3:            emaPeriod3,
+		bbandsEnable:          bbandsEnable,
+		bbandsN:               bbandsN,
+		bbandsK:               bbandsK,
+		ichimokuEnable:        ichimokuEnable,
+		ichimokuTenkanPeriod:  ichimokuTenkanPeriod,
+		ichimokuKijunPeriod:   ichimokuKijunPeriod,
+		ichimokuSenkouBPeriod: ichimokuSenkouBPeriod,
+		rsiEnable:             rsiEnable,
+		rsiPeriod:             rsiPeriod,
+		rsiBuyThread:          rsiBuyThread,
+		rsiSellThread:         rsiSellThread,
+		macdEnable:            macdEnable,
+		macdFastPeriod:        macdFastPeriod,
+		macdSlowPeriod:        macdSlowPeriod,
+		macdSignalPeriod:      macdSignalPeriod,
+		atrEnable:             atrEnable,
+		atrPeriod:             atrPeriod,
+		atrMultiplier:         atrMultiplier,
+		stochEnable:           stochEnable,
+		stochFastKPeriod:      stochFastKPeriod,
+		stochSlowKPeriod:      stochSlowKPeriod,
+		stochSlowDPeriod:      stochSlowDPeriod,
+		stochBuyThread:        stochBuyThread,
+		stochSellThread:       stochSellThread,
+		adxEnable:             adxEnable,
+		adxPeriod:             adxPeriod,
+		adxThread:             adxThread,
+		obvEnable:             obvEnable,
+		obvPeriod:             obvPeriod,
+		vwapEnable:            vwapEnable,
+		vwapPeriod:            vwapPeriod,
+		sarEnable:             sarEnable,
+		sarAcceleration:       sarAcceleration,
+		sarMaximum:            sarMaximum,
+		donchianEnable:        donchianEnable,
+		donchianPeriod:        donchianPeriod,
+		keltnerEnable:         keltnerEnable,
+		keltnerPeriod:         keltnerPeriod,
+		keltnerMultiplier:     keltnerMultiplier,
+		heikinAshiEnable:      heikinAshiEnable,
+		heikinAshiPeriod:      heikinAshiPeriod,
+		signalWeights:         signalWeights,
+		buyVoteThreshold:      buyVoteThreshold,
+		sellVoteThreshold:     sellVoteThreshold,
+		stopLimitPercent:      stopLimitPercent,
+		maxLots:               maxLots,
+		lotMatching:           lotMatching,
 	}
 }
 
@@ -113,6 +262,13 @@ func (tp *TradeParams) TradeEnable() bool {
 	return tp.tradeEnable
 }
 
+// 取引するかどうかだけを変えたパラメータ
+func (tp *TradeParams) WithTradeEnable(tradeEnable bool) *TradeParams {
+	params := *tp
+	params.tradeEnable = tradeEnable
+	return &params
+}
+
 func (tp *TradeParams) ProductCode() string {
 	return tp.productCode
 }
@@ -169,6 +325,21 @@ func (tp *TradeParams) IchimokuEnable() bool {
 	return tp.ichimokuEnable
 }
 
+// 転換線の期間
+func (tp *TradeParams) IchimokuTenkanPeriod() int {
+	return tp.ichimokuTenkanPeriod
+}
+
+// 基準線の期間（先行スパン・遅行スパンのずらし幅にも使う）
+func (tp *TradeParams) IchimokuKijunPeriod() int {
+	return tp.ichimokuKijunPeriod
+}
+
+// 先行スパン2の期間
+func (tp *TradeParams) IchimokuSenkouBPeriod() int {
+	return tp.ichimokuSenkouBPeriod
+}
+
 func (tp *TradeParams) RSIEnable() bool {
 	return tp.rsiEnable
 }
@@ -201,10 +372,138 @@ func (tp *TradeParams) MACDSignalPeriod() int {
 	return tp.macdSignalPeriod
 }
 
+func (tp *TradeParams) ATREnable() bool {
+	return tp.atrEnable
+}
+
+func (tp *TradeParams) ATRPeriod() int {
+	return tp.atrPeriod
+}
+
+// 前の足の終値からATRの何倍動いたらブレイクアウトとみなすか
+func (tp *TradeParams) ATRMultiplier() float64 {
+	return tp.atrMultiplier
+}
+
+func (tp *TradeParams) StochEnable() bool {
+	return tp.stochEnable
+}
+
+func (tp *TradeParams) StochFastKPeriod() int {
+	return tp.stochFastKPeriod
+}
+
+func (tp *TradeParams) StochSlowKPeriod() int {
+	return tp.stochSlowKPeriod
+}
+
+func (tp *TradeParams) StochSlowDPeriod() int {
+	return tp.stochSlowDPeriod
+}
+
+func (tp *TradeParams) StochBuyThread() float64 {
+	return tp.stochBuyThread
+}
+
+func (tp *TradeParams) StochSellThread() float64 {
+	return tp.stochSellThread
+}
+
+func (tp *TradeParams) ADXEnable() bool {
+	return tp.adxEnable
+}
+
+func (tp *TradeParams) ADXPeriod() int {
+	return tp.adxPeriod
+}
+
+// トレンドが出ているとみなすADXの下限
+func (tp *TradeParams) ADXThread() float64 {
+	return tp.adxThread
+}
+
+func (tp *TradeParams) OBVEnable() bool {
+	return tp.obvEnable
+}
+
+func (tp *TradeParams) OBVPeriod() int {
+	return tp.obvPeriod
+}
+
+func (tp *TradeParams) VWAPEnable() bool {
+	return tp.vwapEnable
+}
+
+func (tp *TradeParams) VWAPPeriod() int {
+	return tp.vwapPeriod
+}
+
+func (tp *TradeParams) SAREnable() bool {
+	return tp.sarEnable
+}
+
+func (tp *TradeParams) SARAcceleration() float64 {
+	return tp.sarAcceleration
+}
+
+func (tp *TradeParams) SARMaximum() float64 {
+	return tp.sarMaximum
+}
+
+func (tp *TradeParams) DonchianEnable() bool {
+	return tp.donchianEnable
+}
+
+func (tp *TradeParams) DonchianPeriod() int {
+	return tp.donchianPeriod
+}
+
+func (tp *TradeParams) KeltnerEnable() bool {
+	return tp.keltnerEnable
+}
+
+func (tp *TradeParams) KeltnerPeriod() int {
+	return tp.keltnerPeriod
+}
+
+func (tp *TradeParams) KeltnerMultiplier() float64 {
+	return tp.keltnerMultiplier
+}
+
+func (tp *TradeParams) HeikinAshiEnable() bool {
+	return tp.heikinAshiEnable
+}
+
+func (tp *TradeParams) HeikinAshiPeriod() int {
+	return tp.heikinAshiPeriod
+}
+
+func (tp *TradeParams) SignalWeights() SignalWeights {
+	return tp.signalWeights
+}
+
+func (tp *TradeParams) BuyVoteThreshold() float64 {
+	return tp.buyVoteThreshold
+}
+
+func (tp *TradeParams) SellVoteThreshold() float64 {
+	return tp.sellVoteThreshold
+}
+
 func (tp *TradeParams) StopLimitPercent() float64 {
 	return tp.stopLimitPercent
 }
 
+// 同時に保有できるロットの数（1なら買いと売りを交互に繰り返す）
+func (tp *TradeParams) MaxLots() int {
+	return tp.maxLots
+}
+
+// 売りで決済するロットの選び方
+func (tp *TradeParams) LotMatching() LotMatching {
+	return tp.lotMatching
+}
+
 func (tp *TradeParams) EnableSMA(enable bool) {
 	tp.smaEnable = enable
 }
@@ -229,6 +528,46 @@ func (tp *TradeParams) EnableMACD(enable bool) {
 	tp.macdEnable = enable
 }
 
+func (tp *TradeParams) EnableATR(enable bool) {
+	tp.atrEnable = enable
+}
+
+func (tp *TradeParams) EnableStoch(enable bool) {
+	tp.stochEnable = enable
+}
+
+func (tp *TradeParams) EnableADX(enable bool) {
+	tp.adxEnable = enable
+}
+
+func (tp *TradeParams) EnableOBV(enable bool) {
+	tp.obvEnable = enable
+}
+
+func (tp *TradeParams) EnableVWAP(enable bool) {
+	tp.vwapEnable = enable
+}
+
+func (tp *TradeParams) EnableSAR(enable bool) {
+	tp.sarEnable = enable
+}
+
+func (tp *TradeParams) EnableDonchian(enable bool) {
+	tp.donchianEnable = enable
+}
+
+func (tp *TradeParams) EnableKeltner(enable bool) {
+	tp.keltnerEnable = enable
+}
+
+func (tp *TradeParams) EnableHeikinAshi(enable bool) {
+	tp.heikinAshiEnable = enable
+}
+
+func (tp *TradeParams) SetSignalWeights(weights SignalWeights) {
+	tp.signalWeights = weights
+}
+
 func NewBasicTradeParams(productCode string, size float64) *TradeParams {
 	return NewTradeParams(
 		true,
@@ -246,6 +585,9 @@ func NewBasicTradeParams(productCode string, size float64) *TradeParams {
 		20,
 		2,
 		true,
+		9,
+		26,
+		52,
 		true,
 		14,
 		30,
@@ -254,6 +596,37 @@ func NewBasicTradeParams(productCode string, size float64) *TradeParams {
 		12,
 		26,
 		9,
+		false,
+		14,
+		2,
+		false,
+		14,
+		3,
+		3,
+		20,
+		80,
+		false,
+		14,
+		25,
+		false,
+		20,
+		false,
+		20,
+		false,
+		0.02,
+		0.2,
+		false,
+		20,
+		false,
+		20,
+		2,
+		false,
+		3,
+		*NewUniformSignalWeights(),
+		2,
+		2,
 		0.95,
+		1,
+		LotMatchingFIFO,
 	)
 }
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+)
+
+type AlertRuleRepository interface {
+	// IDが0なら追加し，そうでなければ条件を更新する．追加・更新したルールのIDを返す
+	Save(rule model.AlertRule) (int64, error)
+	FindAll(productCode string) ([]model.AlertRule, error)
+	Delete(id int64) error
+	// 評価した結果（条件が成り立っていたか，最後に通知した時刻）だけを更新する
+	UpdateState(rule model.AlertRule) error
+}
+
+type PricePointRepository interface {
+	Save(point model.PricePoint) error
+	// 時刻timeTime以前で最も新しい価格．なければnilを返す
+	FindLatestBefore(productCode string, timeTime time.Time) (*model.PricePoint, error)
+	DeleteBefore(productCode string, timeTime time.Time) error
+}
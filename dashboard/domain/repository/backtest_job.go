@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+)
+
+type BacktestJobRepository interface {
+	Save(job model.BacktestJob) error
+	Find(id string) (*model.BacktestJob, error)
+	FindAll(productCode string, limit int64) ([]model.BacktestJob, error)
+	// 待機中・実行中のジョブを古い順に返す
+	FindUnfinished() ([]model.BacktestJob, error)
+}
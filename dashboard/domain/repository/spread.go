@@ -0,0 +1,9 @@
+package repository
+
+import "github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+
+type SpreadRepository interface {
+	Save(spread model.Spread) error
+	// 全ての取引所の価格差を，新しいものからlimit件まで時刻の昇順で返す
+	FindAll(productCode string, limit int64) ([]model.Spread, error)
+}
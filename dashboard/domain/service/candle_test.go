@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
@@ -57,6 +58,7 @@ func TestCandleServicePerDay(t *testing.T) {
 
 		high += 1000
 		low -= 1000
+		volume := candle.Volume()
 		newCandle = model.NewCandle(
 			candle.ProductCode(),
 			candle.Duration(),
@@ -65,12 +67,16 @@ func TestCandleServicePerDay(t *testing.T) {
 			candle.Close(),
 			high,
 			low,
-			candle.Volume(),
+			1.5,
 		)
 		candle = candleService.Update(candle, newCandle)
 		if candle == nil {
 			t.Fatal("Update() returns nil")
 		}
+		// 出来高は足し合わされる
+		if candle.Volume() != volume+1.5 {
+			t.Fatalf("%v != %v", candle.Volume(), volume+1.5)
+		}
 		if candle.High() != high {
 			t.Fatalf("candle.High() != %f", high)
 		}
@@ -118,7 +124,7 @@ func TestCandleServicePerDay(t *testing.T) {
 	})
 
 	t.Run("find all candle", func(t *testing.T) {
-		candles, err := candleService.FindAll(config.ProductCode, 10)
+		candles, err := candleService.FindAll(config.ProductCode, candleService.Duration(), 10)
 		if err != nil {
 			t.Fatal(err.Error())
 		}
@@ -126,4 +132,25 @@ func TestCandleServicePerDay(t *testing.T) {
 			t.Fatal("len(candles) > 10")
 		}
 	})
+
+	t.Run("find all weekly candle", func(t *testing.T) {
+		duration := 7 * candleService.Duration()
+		candles, err := candleService.FindAll(config.ProductCode, duration, 4)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(candles) > 4 {
+			t.Fatal("len(candles) > 4")
+		}
+		for _, candle := range candles {
+			if candle.Duration() != duration {
+				t.Fatalf("%s != %s", candle.Duration(), duration)
+			}
+		}
+
+		_, err = candleService.FindAll(config.ProductCode, 36*time.Hour, 4)
+		if err == nil {
+			t.Fatal("FindAll() returns no error for unsupported duration")
+		}
+	})
 }
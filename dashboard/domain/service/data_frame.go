@@ -1,20 +1,39 @@
 package service
 
 import (
+	"time"
+
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/repository"
 )
 
 type DataFrameService interface {
 	BacktestEMA(df *model.DataFrame, fastPeriod, slowPeriod int, size float64) *model.SignalEvents
 	BacktestBBands(df *model.DataFrame, n int, k float64, size float64) *model.SignalEvents
-	BacktestIchimoku(df *model.DataFrame, size float64) *model.SignalEvents
+	BacktestIchimoku(df *model.DataFrame, tenkanPeriod, kijunPeriod, senkouBPeriod int, size float64) *model.SignalEvents
 	BacktestRSI(df *model.DataFrame, period int, buyThread, sellThread float64, size float64) *model.SignalEvents
 	BacktestMACD(df *model.DataFrame, fastPeriod, slowPeriod, signalPeriod int, size float64) *model.SignalEvents
+	BacktestATR(df *model.DataFrame, period int, multiplier float64, size float64) *model.SignalEvents
+	BacktestStochastic(df *model.DataFrame, fastKPeriod, slowKPeriod, slowDPeriod int, buyThread, sellThread float64, size float64) *model.SignalEvents
+	BacktestADX(df *model.DataFrame, period int, thread float64, size float64) *model.SignalEvents
+	BacktestOBV(df *model.DataFrame, period int, size float64) *model.SignalEvents
+	BacktestVWAP(df *model.DataFrame, period int, size float64) *model.SignalEvents
+	BacktestParabolicSAR(df *model.DataFrame, acceleration, maximum float64, size float64) *model.SignalEvents
+	BacktestDonchian(df *model.DataFrame, period int, size float64) *model.SignalEvents
+	BacktestKeltner(df *model.DataFrame, period int, multiplier float64, size float64) *model.SignalEvents
+	BacktestHeikinAshi(df *model.DataFrame, period int, size float64) *model.SignalEvents
 
 	Backtest(df *model.DataFrame, tp *model.TradeParams)
 	Analyze(df *model.DataFrame, at int, params *model.TradeParams) (bool, bool)
 }
 
+// 上位の時間足も使って売買サインを判定するDataFrameService
+// DataFrameを作る側は，Timeframesの時間足のDataFrameをAddTimeframeで追加してから渡す
+type MultiTimeframeDataFrameService interface {
+	DataFrameService
+	Timeframes() []time.Duration
+}
+
 type dataFrameService struct {
 	indicatorService IndicatorService
 }
@@ -83,8 +102,8 @@ func (ds *dataFrameService) BacktestBBands(df *model.DataFrame, n int, k float64
 	return signalEvents
 }
 
-func (ds *dataFrameService) BacktestIchimoku(df *model.DataFrame, size float64) *model.SignalEvents {
-	ichimoku := model.NewIchimokuCloud(df.Closes())
+func (ds *dataFrameService) BacktestIchimoku(df *model.DataFrame, tenkanPeriod, kijunPeriod, senkouBPeriod int, size float64) *model.SignalEvents {
+	ichimoku := model.NewIchimokuCloud(df.Highs(), df.Lows(), df.Closes(), tenkanPeriod, kijunPeriod, senkouBPeriod)
 	if ichimoku == nil {
 		return nil
 	}
@@ -164,28 +183,174 @@ func (ds *dataFrameService) BacktestMACD(df *model.DataFrame, fastPeriod, slowPe
 	return signalEvents
 }
 
+// 1つの指標の売買サインだけでバックテストする
+func backtestBySignal(df *model.DataFrame, size float64, buySignal, sellSignal func(at int) bool) *model.SignalEvents {
+	signals := make([]model.SignalEvent, 0)
+	signalEvents := model.NewSignalEvents(signals)
+	for i, candle := range df.Candles() {
+		if buySignal(i) {
+			signal := model.NewSignalEvent(candle.Time().Time(), df.ProductCode(), model.OrderSideBuy, candle.Close(), size)
+			if signal != nil {
+				signalEvents.AddBuySignal(*signal)
+			}
+		}
+
+		if sellSignal(i) {
+			signal := model.NewSignalEvent(candle.Time().Time(), df.ProductCode(), model.OrderSideSell, candle.Close(), size)
+			if signal != nil {
+				signalEvents.AddSellSignal(*signal)
+			}
+		}
+	}
+
+	return signalEvents
+}
+
+func (ds *dataFrameService) BacktestATR(df *model.DataFrame, period int, multiplier float64, size float64) *model.SignalEvents {
+	atr := model.NewATR(df.Highs(), df.Lows(), df.Closes(), period)
+	if atr == nil || multiplier <= 0 {
+		return nil
+	}
+
+	return backtestBySignal(df, size,
+		func(at int) bool { return ds.indicatorService.BuySignalOfATR(atr, multiplier, df.Candles(), at) },
+		func(at int) bool { return ds.indicatorService.SellSignalOfATR(atr, multiplier, df.Candles(), at) },
+	)
+}
+
+func (ds *dataFrameService) BacktestStochastic(df *model.DataFrame, fastKPeriod, slowKPeriod, slowDPeriod int, buyThread, sellThread float64, size float64) *model.SignalEvents {
+	stoch := model.NewStochastic(df.Highs(), df.Lows(), df.Closes(), fastKPeriod, slowKPeriod, slowDPeriod)
+	if stoch == nil {
+		return nil
+	}
+
+	return backtestBySignal(df, size,
+		func(at int) bool { return ds.indicatorService.BuySignalOfStochastic(stoch, buyThread, at) },
+		func(at int) bool { return ds.indicatorService.SellSignalOfStochastic(stoch, sellThread, at) },
+	)
+}
+
+func (ds *dataFrameService) BacktestADX(df *model.DataFrame, period int, thread float64, size float64) *model.SignalEvents {
+	adx := model.NewADX(df.Highs(), df.Lows(), df.Closes(), period)
+	if adx == nil {
+		return nil
+	}
+
+	return backtestBySignal(df, size,
+		func(at int) bool { return ds.indicatorService.BuySignalOfADX(adx, thread, at) },
+		func(at int) bool { return ds.indicatorService.SellSignalOfADX(adx, thread, at) },
+	)
+}
+
+func (ds *dataFrameService) BacktestOBV(df *model.DataFrame, period int, size float64) *model.SignalEvents {
+	obv := model.NewOBV(df.Closes(), df.Volumes(), period)
+	if obv == nil {
+		return nil
+	}
+
+	return backtestBySignal(df, size,
+		func(at int) bool { return ds.indicatorService.BuySignalOfOBV(obv, at) },
+		func(at int) bool { return ds.indicatorService.SellSignalOfOBV(obv, at) },
+	)
+}
+
+func (ds *dataFrameService) BacktestVWAP(df *model.DataFrame, period int, size float64) *model.SignalEvents {
+	vwap := model.NewVWAP(df.Highs(), df.Lows(), df.Closes(), df.Volumes(), period)
+	if vwap == nil {
+		return nil
+	}
+
+	return backtestBySignal(df, size,
+		func(at int) bool { return ds.indicatorService.BuySignalOfVWAP(vwap, df.Candles(), at) },
+		func(at int) bool { return ds.indicatorService.SellSignalOfVWAP(vwap, df.Candles(), at) },
+	)
+}
+
+func (ds *dataFrameService) BacktestParabolicSAR(df *model.DataFrame, acceleration, maximum float64, size float64) *model.SignalEvents {
+	sar := model.NewParabolicSAR(df.Highs(), df.Lows(), acceleration, maximum)
+	if sar == nil {
+		return nil
+	}
+
+	return backtestBySignal(df, size,
+		func(at int) bool { return ds.indicatorService.BuySignalOfParabolicSAR(sar, df.Candles(), at) },
+		func(at int) bool { return ds.indicatorService.SellSignalOfParabolicSAR(sar, df.Candles(), at) },
+	)
+}
+
+func (ds *dataFrameService) BacktestDonchian(df *model.DataFrame, period int, size float64) *model.SignalEvents {
+	donchian := model.NewDonchianChannel(df.Highs(), df.Lows(), period)
+	if donchian == nil {
+		return nil
+	}
+
+	return backtestBySignal(df, size,
+		func(at int) bool { return ds.indicatorService.BuySignalOfDonchian(donchian, df.Candles(), at) },
+		func(at int) bool { return ds.indicatorService.SellSignalOfDonchian(donchian, df.Candles(), at) },
+	)
+}
+
+func (ds *dataFrameService) BacktestKeltner(df *model.DataFrame, period int, multiplier float64, size float64) *model.SignalEvents {
+	keltner := model.NewKeltnerChannel(df.Highs(), df.Lows(), df.Closes(), period, multiplier)
+	if keltner == nil {
+		return nil
+	}
+
+	return backtestBySignal(df, size,
+		func(at int) bool { return ds.indicatorService.BuySignalOfKeltner(keltner, df.Candles(), at) },
+		func(at int) bool { return ds.indicatorService.SellSignalOfKeltner(keltner, df.Candles(), at) },
+	)
+}
+
+func (ds *dataFrameService) BacktestHeikinAshi(df *model.DataFrame, period int, size float64) *model.SignalEvents {
+	averageCandle := model.NewAverageCandle(df.Candles())
+	if averageCandle == nil {
+		return nil
+	}
+
+	return backtestBySignal(df, size,
+		func(at int) bool { return ds.indicatorService.BuySignalOfHeikinAshi(averageCandle, period, at) },
+		func(at int) bool { return ds.indicatorService.SellSignalOfHeikinAshi(averageCandle, period, at) },
+	)
+}
+
 func (ds *dataFrameService) Backtest(df *model.DataFrame, params *model.TradeParams) {
 	if df == nil || params == nil {
 		return
 	}
 
+	backtestByAnalyze(df, params, func(at int) (bool, bool) {
+		return ds.Analyze(df, at, params)
+	})
+}
+
+// 各時点で売買サインを判定してバックテストし，結果をDataFrameに追加する
+func backtestByAnalyze(df *model.DataFrame, params *model.TradeParams, analyze func(at int) (bool, bool)) {
 	signals := make([]model.SignalEvent, 0)
-	signalEvents := model.NewSignalEvents(signals)
+	signalEvents := model.NewSignalEventsWithLots(signals, params.MaxLots(), params.LotMatching())
 	for i, candle := range df.Candles() {
-		buy, sell := ds.Analyze(df, i, params)
+		buy, sell := analyze(i)
+
+		// 損切りでは全てのロットを，売りサインでは1ロット分を売る
+		// 本番の取引と同じく，売りを先に判断し，売ったときは買わない
+		sellSize := params.Size()
+		if signalEvents.ShouldCutLoss(candle.Close(), params.StopLimitPercent()) {
+			sell = true
+			sellSize = signalEvents.Position().Size()
+		}
 
-		if buy {
-			signal := model.NewSignalEvent(candle.Time().Time(), df.ProductCode(), model.OrderSideBuy, candle.Close(), params.Size())
+		if sell {
+			signal := model.NewSignalEvent(candle.Time().Time(), df.ProductCode(), model.OrderSideSell, candle.Close(), sellSize)
 			if signal != nil {
-				signalEvents.AddBuySignal(*signal)
+				signalEvents.AddSellSignal(*signal)
 			}
+			continue
 		}
 
-		if sell ||
-			signalEvents.ShouldCutLoss(candle.Close(), params.StopLimitPercent()) {
-			signal := model.NewSignalEvent(candle.Time().Time(), df.ProductCode(), model.OrderSideSell, candle.Close(), params.Size())
+		if buy {
+			signal := model.NewSignalEvent(candle.Time().Time(), df.ProductCode(), model.OrderSideBuy, candle.Close(), params.Size())
 			if signal != nil {
-				signalEvents.AddSellSignal(*signal)
+				signalEvents.AddBuySignal(*signal)
 			}
 		}
 	}
@@ -198,95 +363,197 @@ func (ds *dataFrameService) Backtest(df *model.DataFrame, params *model.TradePar
 // 各指標の時点"at"で分析する
 // buyPoint, sellPointを返す
 func (ds *dataFrameService) Analyze(df *model.DataFrame, at int, params *model.TradeParams) (bool, bool) {
-	buyPoint, sellPoint := 0, 0
-
 	if at <= 0 {
 		return false, false
 	}
 
+	buyPoint, sellPoint := voteSignals(ds.indicatorService, df, at, params, *model.NewUniformSignalWeights())
+
+	return buyPoint > 1, sellPoint > 1
+}
+
+// 有効な指標ごとに，時点"at"で売買サインが出ていれば重みを加算する
+// 買いと売りそれぞれの得点を返す
+func voteSignals(is IndicatorService, df *model.DataFrame, at int, params *model.TradeParams, weights model.SignalWeights) (float64, float64) {
+	buyPoint, sellPoint := 0.0, 0.0
+	for _, signal := range indicatorSignals(is, df, at, params, weights) {
+		if signal.buy {
+			buyPoint += signal.weight
+		}
+		if signal.sell {
+			sellPoint += signal.weight
+		}
+	}
+	return buyPoint, sellPoint
+}
+
+// 時点"at"で指標が出している売買サイン
+type indicatorSignal struct {
+	name   string
+	weight float64
+	buy    bool
+	sell   bool
+}
+
+// 有効な指標ごとに，時点"at"の売買サインを調べる
+// DataFrameに追加されていない指標は無視する
+func indicatorSignals(is IndicatorService, df *model.DataFrame, at int, params *model.TradeParams, weights model.SignalWeights) []indicatorSignal {
+	signals := make([]indicatorSignal, 0)
+
 	if params.EMAEnable() &&
 		len(df.EMAs()) >= 2 {
 		emaFast := df.EMAs()[0]
 		emaSlow := df.EMAs()[1]
-		if ds.indicatorService.BuySignalOfEMA(&emaFast, &emaSlow, at) {
-			buyPoint++
-		}
-		if ds.indicatorService.SellSignalOfEMA(&emaFast, &emaSlow, at) {
-			sellPoint++
-		}
+		signals = append(signals, indicatorSignal{
+			name:   "EMA",
+			weight: weights.EMA(),
+			buy:    is.BuySignalOfEMA(&emaFast, &emaSlow, at),
+			sell:   is.SellSignalOfEMA(&emaFast, &emaSlow, at),
+		})
 	}
 
-	if params.BBandsEnable() {
+	if params.BBandsEnable() && df.BBands() != nil {
 		bbands := df.BBands()
-		if ds.indicatorService.BuySignalOfBBands(bbands, df.Candles(), at) {
-			buyPoint++
-		}
-		if ds.indicatorService.SellSignalOfBBands(bbands, df.Candles(), at) {
-			sellPoint++
-		}
+		signals = append(signals, indicatorSignal{
+			name:   "BBands",
+			weight: weights.BBands(),
+			buy:    is.BuySignalOfBBands(bbands, df.Candles(), at),
+			sell:   is.SellSignalOfBBands(bbands, df.Candles(), at),
+		})
 	}
 
-	if params.IchimokuEnable() {
+	if params.IchimokuEnable() && df.IchimokuCloud() != nil {
 		ichomoku := df.IchimokuCloud()
-		if ds.indicatorService.BuySignalOfIchimoku(ichomoku, df.Candles(), at) {
-			buyPoint++
-		}
-		if ds.indicatorService.SellSignalOfIchimoku(ichomoku, df.Candles(), at) {
-			sellPoint++
-		}
+		signals = append(signals, indicatorSignal{
+			name:   "Ichimoku",
+			weight: weights.Ichimoku(),
+			buy:    is.BuySignalOfIchimoku(ichomoku, df.Candles(), at),
+			sell:   is.SellSignalOfIchimoku(ichomoku, df.Candles(), at),
+		})
 	}
 
-	if params.RSIEnable() {
+	if params.RSIEnable() && df.RSI() != nil {
 		rsi := df.RSI()
-		if ds.indicatorService.BuySignalOfRSI(rsi, params.RSIBuyThread(), at) {
-			buyPoint++
-		}
-		if ds.indicatorService.SellSignalOfRSI(rsi, params.RSISellThread(), at) {
-			sellPoint++
-		}
+		signals = append(signals, indicatorSignal{
+			name:   "RSI",
+			weight: weights.RSI(),
+			buy:    is.BuySignalOfRSI(rsi, params.RSIBuyThread(), at),
+			sell:   is.SellSignalOfRSI(rsi, params.RSISellThread(), at),
+		})
 	}
 
-	if params.MACDEnable() {
+	if params.MACDEnable() && df.MACD() != nil {
 		macd := df.MACD()
-		if ds.indicatorService.BuySignalOfMACD(macd, at) {
-			buyPoint++
-		}
-		if ds.indicatorService.SellSignalOfMACD(macd, at) {
-			sellPoint++
-		}
+		signals = append(signals, indicatorSignal{
+			name:   "MACD",
+			weight: weights.MACD(),
+			buy:    is.BuySignalOfMACD(macd, at),
+			sell:   is.SellSignalOfMACD(macd, at),
+		})
 	}
 
-	return buyPoint > 1, sellPoint > 1
-}
+	if params.ATREnable() && df.ATR() != nil {
+		atr := df.ATR()
+		signals = append(signals, indicatorSignal{
+			name:   "ATR",
+			weight: weights.ATR(),
+			buy:    is.BuySignalOfATR(atr, params.ATRMultiplier(), df.Candles(), at),
+			sell:   is.SellSignalOfATR(atr, params.ATRMultiplier(), df.Candles(), at),
+		})
+	}
 
-// MACDとRSIを組み合わせて売買サインを出す
-type mrBaseDataFrameService struct {
-	indicatorService IndicatorService
-}
+	if params.StochEnable() && df.Stochastic() != nil {
+		stoch := df.Stochastic()
+		signals = append(signals, indicatorSignal{
+			name:   "Stoch",
+			weight: weights.Stoch(),
+			buy:    is.BuySignalOfStochastic(stoch, params.StochBuyThread(), at),
+			sell:   is.SellSignalOfStochastic(stoch, params.StochSellThread(), at),
+		})
+	}
 
-func NewMRBaseDataFrameService(is IndicatorService) DataFrameService {
-	return &mrBaseDataFrameService{
-		indicatorService: is,
+	if params.ADXEnable() && df.ADX() != nil {
+		adx := df.ADX()
+		signals = append(signals, indicatorSignal{
+			name:   "ADX",
+			weight: weights.ADX(),
+			buy:    is.BuySignalOfADX(adx, params.ADXThread(), at),
+			sell:   is.SellSignalOfADX(adx, params.ADXThread(), at),
+		})
 	}
-}
 
-func (ds *mrBaseDataFrameService) BacktestEMA(df *model.DataFrame, fastPeriod, slowPeriod int, size float64) *model.SignalEvents {
-	return NewDataFrameService(ds.indicatorService).BacktestEMA(df, fastPeriod, slowPeriod, size)
-}
-func (ds *mrBaseDataFrameService) BacktestBBands(df *model.DataFrame, n int, k float64, size float64) *model.SignalEvents {
-	return NewDataFrameService(ds.indicatorService).BacktestBBands(df, n, k, size)
-}
+	if params.OBVEnable() && df.OBV() != nil {
+		obv := df.OBV()
+		signals = append(signals, indicatorSignal{
+			name:   "OBV",
+			weight: weights.OBV(),
+			buy:    is.BuySignalOfOBV(obv, at),
+			sell:   is.SellSignalOfOBV(obv, at),
+		})
+	}
+
+	if params.VWAPEnable() && df.VWAP() != nil {
+		vwap := df.VWAP()
+		signals = append(signals, indicatorSignal{
+			name:   "VWAP",
+			weight: weights.VWAP(),
+			buy:    is.BuySignalOfVWAP(vwap, df.Candles(), at),
+			sell:   is.SellSignalOfVWAP(vwap, df.Candles(), at),
+		})
+	}
+
+	if params.SAREnable() && df.ParabolicSAR() != nil {
+		sar := df.ParabolicSAR()
+		signals = append(signals, indicatorSignal{
+			name:   "SAR",
+			weight: weights.SAR(),
+			buy:    is.BuySignalOfParabolicSAR(sar, df.Candles(), at),
+			sell:   is.SellSignalOfParabolicSAR(sar, df.Candles(), at),
+		})
+	}
+
+	if params.DonchianEnable() && df.DonchianChannel() != nil {
+		donchian := df.DonchianChannel()
+		signals = append(signals, indicatorSignal{
+			name:   "Donchian",
+			weight: weights.Donchian(),
+			buy:    is.BuySignalOfDonchian(donchian, df.Candles(), at),
+			sell:   is.SellSignalOfDonchian(donchian, df.Candles(), at),
+		})
+	}
+
+	if params.KeltnerEnable() && df.KeltnerChannel() != nil {
+		keltner := df.KeltnerChannel()
+		signals = append(signals, indicatorSignal{
+			name:   "Keltner",
+			weight: weights.Keltner(),
+			buy:    is.BuySignalOfKeltner(keltner, df.Candles(), at),
+			sell:   is.SellSignalOfKeltner(keltner, df.Candles(), at),
+		})
+	}
+
+	if params.HeikinAshiEnable() && df.AverageCandle() != nil {
+		averageCandle := df.AverageCandle()
+		signals = append(signals, indicatorSignal{
+			name:   "HeikinAshi",
+			weight: weights.HeikinAshi(),
+			buy:    is.BuySignalOfHeikinAshi(averageCandle, params.HeikinAshiPeriod(), at),
+			sell:   is.SellSignalOfHeikinAshi(averageCandle, params.HeikinAshiPeriod(), at),
+		})
+	}
 
-func (ds *mrBaseDataFrameService) BacktestIchimoku(df *model.DataFrame, size float64) *model.SignalEvents {
-	return NewDataFrameService(ds.indicatorService).BacktestIchimoku(df, size)
+	return signals
 }
 
-func (ds *mrBaseDataFrameService) BacktestRSI(df *model.DataFrame, period int, buyThread, sellThread float64, size float64) *model.SignalEvents {
-	return NewDataFrameService(ds.indicatorService).BacktestRSI(df, period, buyThread, sellThread, size)
+// MACDとRSIを組み合わせて売買サインを出す
+type mrBaseDataFrameService struct {
+	dataFrameService
 }
 
-func (ds *mrBaseDataFrameService) BacktestMACD(df *model.DataFrame, fastPeriod, slowPeriod, signalPeriod int, size float64) *model.SignalEvents {
-	return NewDataFrameService(ds.indicatorService).BacktestMACD(df, fastPeriod, slowPeriod, signalPeriod, size)
+func NewMRBaseDataFrameService(is IndicatorService) DataFrameService {
+	return &mrBaseDataFrameService{
+		dataFrameService: dataFrameService{indicatorService: is},
+	}
 }
 
 func (ds *mrBaseDataFrameService) Backtest(df *model.DataFrame, params *model.TradeParams) {
@@ -294,30 +561,9 @@ func (ds *mrBaseDataFrameService) Backtest(df *model.DataFrame, params *model.Tr
 		return
 	}
 
-	signals := make([]model.SignalEvent, 0)
-	signalEvents := model.NewSignalEvents(signals)
-	for i, candle := range df.Candles() {
-		buy, sell := ds.Analyze(df, i, params)
-
-		if buy {
-			signal := model.NewSignalEvent(candle.Time().Time(), df.ProductCode(), model.OrderSideBuy, candle.Close(), params.Size())
-			if signal != nil {
-				signalEvents.AddBuySignal(*signal)
-			}
-		}
-
-		if sell ||
-			signalEvents.ShouldCutLoss(candle.Close(), params.StopLimitPercent()) {
-			signal := model.NewSignalEvent(candle.Time().Time(), df.ProductCode(), model.OrderSideSell, candle.Close(), params.Size())
-			if signal != nil {
-				signalEvents.AddSellSignal(*signal)
-			}
-		}
-	}
-
-	signalEvents.EstimateProfit()
-
-	df.AddBacktestEvents(signalEvents)
+	backtestByAnalyze(df, params, func(at int) (bool, bool) {
+		return ds.Analyze(df, at, params)
+	})
 }
 
 func (ds *mrBaseDataFrameService) Analyze(df *model.DataFrame, at int, params *model.TradeParams) (bool, bool) {
@@ -325,11 +571,11 @@ func (ds *mrBaseDataFrameService) Analyze(df *model.DataFrame, at int, params *m
 		return false, false
 	}
 
-	if !params.MACDEnable() {
+	if !params.MACDEnable() || df.MACD() == nil {
 		return false, false
 	}
 
-	if !params.RSIEnable() {
+	if !params.RSIEnable() || df.RSI() == nil {
 		return false, false
 	}
 
@@ -354,3 +600,165 @@ func (ds *mrBaseDataFrameService) Analyze(df *model.DataFrame, at int, params *m
 	// MACDのサインには素直に従う
 	return macdBuySignal, macdSellSignal
 }
+
+// 有効な指標の売買サインを重み付きで投票し，
+// 得点がTradeParamsの閾値以上になったら売買サインを出す
+type weightedDataFrameService struct {
+	dataFrameService
+}
+
+func NewWeightedDataFrameService(is IndicatorService) DataFrameService {
+	return &weightedDataFrameService{
+		dataFrameService: dataFrameService{indicatorService: is},
+	}
+}
+
+// 売買サインの判定にTradeParamsの重みを使うかどうか
+func usesSignalWeights(ds DataFrameService) bool {
+	switch ds := ds.(type) {
+	case *weightedDataFrameService:
+		return true
+	case *trendFilterDataFrameService:
+		return usesSignalWeights(ds.DataFrameService)
+	default:
+		return false
+	}
+}
+
+func (ds *weightedDataFrameService) Backtest(df *model.DataFrame, params *model.TradeParams) {
+	if df == nil || params == nil {
+		return
+	}
+
+	backtestByAnalyze(df, params, func(at int) (bool, bool) {
+		return ds.Analyze(df, at, params)
+	})
+}
+
+func (ds *weightedDataFrameService) Analyze(df *model.DataFrame, at int, params *model.TradeParams) (bool, bool) {
+	if at <= 0 {
+		return false, false
+	}
+
+	buyPoint, sellPoint := voteSignals(ds.indicatorService, df, at, params, params.SignalWeights())
+
+	buy := buyPoint >= params.BuyVoteThreshold()
+	sell := sellPoint >= params.SellVoteThreshold()
+
+	// 両方の閾値を超えた場合は得点の高い方に従う
+	if buy && sell {
+		return buyPoint > sellPoint, sellPoint > buyPoint
+	}
+
+	return buy, sell
+}
+
+// 銘柄ごとに保存された売買ルールの式で売買サインを判定する
+// ルールが保存されていない銘柄では売買サインを出さない
+type ruleDataFrameService struct {
+	dataFrameService
+	strategyRuleRepository repository.StrategyRuleRepository
+}
+
+func NewRuleDataFrameService(is IndicatorService, sr repository.StrategyRuleRepository) DataFrameService {
+	return &ruleDataFrameService{
+		dataFrameService:       dataFrameService{indicatorService: is},
+		strategyRuleRepository: sr,
+	}
+}
+
+func (ds *ruleDataFrameService) Backtest(df *model.DataFrame, params *model.TradeParams) {
+	if df == nil || params == nil {
+		return
+	}
+
+	rule, err := ds.strategyRuleRepository.Find(df.ProductCode())
+	if err != nil || rule == nil {
+		return
+	}
+	// 指標の計算結果を全期間で使い回す
+	ctx := model.NewRuleContext(df)
+
+	backtestByAnalyze(df, params, func(at int) (bool, bool) {
+		return rule.Analyze(ctx, at)
+	})
+}
+
+func (ds *ruleDataFrameService) Analyze(df *model.DataFrame, at int, params *model.TradeParams) (bool, bool) {
+	if df == nil {
+		return false, false
+	}
+
+	rule, err := ds.strategyRuleRepository.Find(df.ProductCode())
+	if err != nil || rule == nil {
+		return false, false
+	}
+
+	return rule.Analyze(model.NewRuleContext(df), at)
+}
+
+// 上位の時間足で上昇トレンド（終値がEMAより上）のときだけ買いサインを通す
+// 売りサインと損切りはそのまま通す
+type trendFilterDataFrameService struct {
+	DataFrameService
+	duration  time.Duration
+	emaPeriod int
+}
+
+func NewTrendFilterDataFrameService(ds DataFrameService, duration time.Duration, emaPeriod int) MultiTimeframeDataFrameService {
+	if ds == nil || duration <= 0 || emaPeriod <= 0 {
+		return nil
+	}
+
+	return &trendFilterDataFrameService{
+		DataFrameService: ds,
+		duration:         duration,
+		emaPeriod:        emaPeriod,
+	}
+}
+
+func (ds *trendFilterDataFrameService) Timeframes() []time.Duration {
+	return []time.Duration{ds.duration}
+}
+
+func (ds *trendFilterDataFrameService) Backtest(df *model.DataFrame, params *model.TradeParams) {
+	if df == nil || params == nil {
+		return
+	}
+
+	backtestByAnalyze(df, params, func(at int) (bool, bool) {
+		return ds.Analyze(df, at, params)
+	})
+}
+
+func (ds *trendFilterDataFrameService) Analyze(df *model.DataFrame, at int, params *model.TradeParams) (bool, bool) {
+	buy, sell := ds.DataFrameService.Analyze(df, at, params)
+	if buy && !ds.isUptrend(df, at) {
+		buy = false
+	}
+	return buy, sell
+}
+
+// 上位の時間足が足りずにトレンドを確認できない場合はfalse
+func (ds *trendFilterDataFrameService) isUptrend(df *model.DataFrame, at int) bool {
+	if df == nil {
+		return false
+	}
+	higher := df.Timeframe(ds.duration)
+	if higher == nil {
+		return false
+	}
+
+	i := df.TimeframeIndex(ds.duration, at)
+	if i < ds.emaPeriod {
+		return false
+	}
+
+	// 確定したキャンドルだけでEMAを計算する
+	closes := higher.Closes()[:i+1]
+	ema := model.NewEMA(closes, ds.emaPeriod)
+	if ema == nil {
+		return false
+	}
+	return closes[i] > ema.Values()[i]
+}
@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
@@ -11,6 +12,9 @@ import (
 
 func TestIndicatorService(t *testing.T) {
 	candleRepository := persistence.NewCandleMockRepository(config.CandleTableName, config.TimeFormat, config.ProductCode, config.CandleDuration)
+	if candleRepository == nil {
+		t.Fatal("NewCandleMockRepository() returns nil")
+	}
 	candles, err := candleRepository.FindAll(config.ProductCode, config.CandleDuration, -1)
 	if err != nil {
 		t.Fatal(err.Error())
@@ -44,7 +48,7 @@ func TestIndicatorService(t *testing.T) {
 	})
 
 	t.Run("Ichimoku Cloud", func(t *testing.T) {
-		ichimoku := model.NewIchimokuCloud(inReal)
+		ichimoku := model.NewIchimokuCloud(df.Highs(), df.Lows(), inReal, 9, 26, 52)
 
 		buy := indicatorService.BuySignalOfIchimoku(ichimoku, candles, lenCandle-1)
 		t.Logf("BuySignalOfIchimoku: %t", buy)
@@ -72,4 +76,171 @@ func TestIndicatorService(t *testing.T) {
 		sell := indicatorService.SellSignalOfMACD(macd, lenCandle-1)
 		t.Logf("SellSignalOfMACD: %t", sell)
 	})
+
+	t.Run("ATR", func(t *testing.T) {
+		atr := model.NewATR(df.Highs(), df.Lows(), inReal, 14)
+
+		buy := indicatorService.BuySignalOfATR(atr, 2, candles, lenCandle-1)
+		t.Logf("BuySignalOfATR: %t", buy)
+
+		sell := indicatorService.SellSignalOfATR(atr, 2, candles, lenCandle-1)
+		t.Logf("SellSignalOfATR: %t", sell)
+	})
+
+	t.Run("Stochastic", func(t *testing.T) {
+		stoch := model.NewStochastic(df.Highs(), df.Lows(), inReal, 14, 3, 3)
+
+		buy := indicatorService.BuySignalOfStochastic(stoch, 20, lenCandle-1)
+		t.Logf("BuySignalOfStochastic: %t", buy)
+
+		sell := indicatorService.SellSignalOfStochastic(stoch, 80, lenCandle-1)
+		t.Logf("SellSignalOfStochastic: %t", sell)
+	})
+
+	t.Run("ADX", func(t *testing.T) {
+		adx := model.NewADX(df.Highs(), df.Lows(), inReal, 14)
+
+		buy := indicatorService.BuySignalOfADX(adx, 25, lenCandle-1)
+		t.Logf("BuySignalOfADX: %t", buy)
+
+		sell := indicatorService.SellSignalOfADX(adx, 25, lenCandle-1)
+		t.Logf("SellSignalOfADX: %t", sell)
+	})
+
+	t.Run("OBV", func(t *testing.T) {
+		obv := model.NewOBV(inReal, df.Volumes(), 20)
+
+		buy := indicatorService.BuySignalOfOBV(obv, lenCandle-1)
+		t.Logf("BuySignalOfOBV: %t", buy)
+
+		sell := indicatorService.SellSignalOfOBV(obv, lenCandle-1)
+		t.Logf("SellSignalOfOBV: %t", sell)
+	})
+
+	t.Run("VWAP", func(t *testing.T) {
+		vwap := model.NewVWAP(df.Highs(), df.Lows(), inReal, df.Volumes(), 20)
+
+		buy := indicatorService.BuySignalOfVWAP(vwap, candles, lenCandle-1)
+		t.Logf("BuySignalOfVWAP: %t", buy)
+
+		sell := indicatorService.SellSignalOfVWAP(vwap, candles, lenCandle-1)
+		t.Logf("SellSignalOfVWAP: %t", sell)
+	})
+
+	t.Run("Parabolic SAR", func(t *testing.T) {
+		sar := model.NewParabolicSAR(df.Highs(), df.Lows(), 0.02, 0.2)
+
+		buy := indicatorService.BuySignalOfParabolicSAR(sar, candles, lenCandle-1)
+		t.Logf("BuySignalOfParabolicSAR: %t", buy)
+
+		sell := indicatorService.SellSignalOfParabolicSAR(sar, candles, lenCandle-1)
+		t.Logf("SellSignalOfParabolicSAR: %t", sell)
+	})
+
+	t.Run("Donchian Channel", func(t *testing.T) {
+		donchian := model.NewDonchianChannel(df.Highs(), df.Lows(), 20)
+
+		buy := indicatorService.BuySignalOfDonchian(donchian, candles, lenCandle-1)
+		t.Logf("BuySignalOfDonchian: %t", buy)
+
+		sell := indicatorService.SellSignalOfDonchian(donchian, candles, lenCandle-1)
+		t.Logf("SellSignalOfDonchian: %t", sell)
+	})
+
+	t.Run("Keltner Channel", func(t *testing.T) {
+		keltner := model.NewKeltnerChannel(df.Highs(), df.Lows(), inReal, 20, 2)
+
+		buy := indicatorService.BuySignalOfKeltner(keltner, candles, lenCandle-1)
+		t.Logf("BuySignalOfKeltner: %t", buy)
+
+		sell := indicatorService.SellSignalOfKeltner(keltner, candles, lenCandle-1)
+		t.Logf("SellSignalOfKeltner: %t", sell)
+	})
+
+	t.Run("Heikin-Ashi", func(t *testing.T) {
+		averageCandle := model.NewAverageCandle(candles)
+
+		buy := indicatorService.BuySignalOfHeikinAshi(averageCandle, 3, lenCandle-1)
+		t.Logf("BuySignalOfHeikinAshi: %t", buy)
+
+		sell := indicatorService.SellSignalOfHeikinAshi(averageCandle, 3, lenCandle-1)
+		t.Logf("SellSignalOfHeikinAshi: %t", sell)
+	})
+}
+
+func TestIndicatorServiceBreakout(t *testing.T) {
+	// 横ばいの後に急騰・急落するキャンドル
+	closes := []float64{100, 101, 100, 101, 100, 101, 100, 101, 110, 111, 90}
+	candles := candlesByCloses(closes)
+	df := model.NewDataFrame(config.ProductCode, candles, nil)
+
+	indicatorService := service.NewIndicatorService()
+
+	t.Run("Donchian Channel", func(t *testing.T) {
+		donchian := model.NewDonchianChannel(df.Highs(), df.Lows(), 5)
+
+		if !indicatorService.BuySignalOfDonchian(donchian, candles, 8) {
+			t.Fatal("BuySignalOfDonchian should be true at 8")
+		}
+		// 既にブレイクアウトした後は買わない
+		if indicatorService.BuySignalOfDonchian(donchian, candles, 9) {
+			t.Fatal("BuySignalOfDonchian should be false at 9")
+		}
+		if !indicatorService.SellSignalOfDonchian(donchian, candles, 10) {
+			t.Fatal("SellSignalOfDonchian should be true at 10")
+		}
+	})
+
+	t.Run("ATR", func(t *testing.T) {
+		atr := model.NewATR(df.Highs(), df.Lows(), df.Closes(), 5)
+
+		if !indicatorService.BuySignalOfATR(atr, 2, candles, 8) {
+			t.Fatal("BuySignalOfATR should be true at 8")
+		}
+		if indicatorService.SellSignalOfATR(atr, 2, candles, 8) {
+			t.Fatal("SellSignalOfATR should be false at 8")
+		}
+		if !indicatorService.SellSignalOfATR(atr, 2, candles, 10) {
+			t.Fatal("SellSignalOfATR should be true at 10")
+		}
+	})
+}
+
+func TestIndicatorServiceHeikinAshi(t *testing.T) {
+	// 横ばいの後に上昇・下降するキャンドル
+	closes := []float64{100, 100, 100, 100, 101, 102, 103, 104, 103, 102, 101, 100}
+	candles := candlesByCloses(closes)
+	averageCandle := model.NewAverageCandle(candles)
+
+	indicatorService := service.NewIndicatorService()
+
+	// 陽線が3本連続した時点で買い
+	if indicatorService.BuySignalOfHeikinAshi(averageCandle, 3, 5) {
+		t.Fatal("BuySignalOfHeikinAshi should be false at 5")
+	}
+	if !indicatorService.BuySignalOfHeikinAshi(averageCandle, 3, 6) {
+		t.Fatal("BuySignalOfHeikinAshi should be true at 6")
+	}
+	// 既に上昇トレンドに入った後は買わない
+	if indicatorService.BuySignalOfHeikinAshi(averageCandle, 3, 7) {
+		t.Fatal("BuySignalOfHeikinAshi should be false at 7")
+	}
+	// 陰線が3本連続した時点で売り
+	if indicatorService.SellSignalOfHeikinAshi(averageCandle, 3, 9) {
+		t.Fatal("SellSignalOfHeikinAshi should be false at 9")
+	}
+	if !indicatorService.SellSignalOfHeikinAshi(averageCandle, 3, 10) {
+		t.Fatal("SellSignalOfHeikinAshi should be true at 10")
+	}
+}
+
+func candlesByCloses(closes []float64) []model.Candle {
+	candles := make([]model.Candle, len(closes))
+	currentTime := time.Now()
+	for i := range candles {
+		candleTime := model.NewCandleTime(currentTime)
+		candles[i] = *model.NewCandle(config.ProductCode, config.CandleDuration, candleTime, closes[i], closes[i], closes[i], closes[i], 1)
+		currentTime = currentTime.Add(config.CandleDuration)
+	}
+	return candles
 }
@@ -1,22 +1,56 @@
 package service
 
 import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/repository"
 )
 
 type NotificationService interface {
 	NotifyOfTradingSuccess(event model.SignalEvent) error
+	// 取引を見送っただけのとき（お金が足りない，板が動いていないなど）は重要度をwarnに下げる
 	NotifyOfTradingFailed(productCode string, err error) error
+	// 取引所間の価格差が閾値を超えたことを通知する
+	NotifyOfSpread(spread model.Spread) error
+	// 最適化で売買パラメータが変わったことを通知する
+	NotifyOfParamsOptimized(before, after model.TradeParams) error
+	// 損切りや証拠金維持率による決済を通知する
+	NotifyOfRiskGuard(productCode, reason string) error
+	// 定期的な運用成績のまとめを通知する
+	NotifyOfSummary(summary model.Summary) error
+	// 価格や指標のアラートを通知する（間隔はルールごとのcooldownで空ける）
+	NotifyOfAlert(alert model.Alert) error
+	Notify(notification model.Notification) error
 }
 
 type notificationService struct {
 	notificationRepository repository.NotificationRepository
+	// 同じ内容の失敗・見送り・価格差は，この間隔を空けてから通知する（0なら毎回通知する）
+	dedupInterval time.Duration
+	mu            sync.Mutex
+	sent          map[string]*sentNotification
+}
+
+type sentNotification struct {
+	time time.Time
+	// 通知しなかった回数
+	suppressed int
 }
 
 func NewNotificationService(nr repository.NotificationRepository) NotificationService {
+	return NewNotificationServiceWithDedup(nr, 0)
+}
+
+// プロセス内で重複を判定するので，再起動すると次の通知は送られる
+func NewNotificationServiceWithDedup(nr repository.NotificationRepository, dedupInterval time.Duration) NotificationService {
 	return &notificationService{
 		notificationRepository: nr,
+		dedupInterval:          dedupInterval,
+		sent:                   make(map[string]*sentNotification),
 	}
 }
 
@@ -25,5 +59,155 @@ func (ns *notificationService) NotifyOfTradingSuccess(event model.SignalEvent) e
 }
 
 func (ns *notificationService) NotifyOfTradingFailed(productCode string, err error) error {
-	return ns.notificationRepository.NotifyOfTradingFailure(productCode, err)
+	now := time.Now().UTC()
+
+	// 見送りの理由ごとにまとめる（金額などが変わっても同じ通知とみなす）
+	if reason, ok := model.TradeSkipReason(err); ok {
+		notification := model.NewNotification(now, model.NotificationLevelWarn, productCode, "取引を見送りました", err.Error())
+		return ns.notifyOnce(fmt.Sprintf("skip:%s:%s", productCode, reason.Error()), *notification)
+	}
+
+	key := fmt.Sprintf("failure:%s:%s", productCode, err.Error())
+	ok, suppressed := ns.dedup(key, now)
+	if !ok {
+		return nil
+	}
+	if suppressed > 0 {
+		err = fmt.Errorf("%w\n%s", err, suppressedMessage(suppressed))
+	}
+
+	if err := ns.notificationRepository.NotifyOfTradingFailure(productCode, err); err != nil {
+		ns.forget(key)
+		return err
+	}
+	return nil
+}
+
+func (ns *notificationService) NotifyOfSpread(spread model.Spread) error {
+	key := fmt.Sprintf("spread:%s:%s", spread.ProductCode(), spread.Exchange())
+	if ok, _ := ns.dedup(key, spread.Time()); !ok {
+		return nil
+	}
+
+	if err := ns.notificationRepository.NotifyOfSpread(spread); err != nil {
+		ns.forget(key)
+		return err
+	}
+	return nil
+}
+
+func (ns *notificationService) NotifyOfParamsOptimized(before, after model.TradeParams) error {
+	lines := changedLines(tradeParamsLines(&before), tradeParamsLines(&after))
+
+	notification := model.NewNotification(time.Now().UTC(), model.NotificationLevelInfo, after.ProductCode(), "売買パラメータを更新しました", strings.Join(lines, "\n"))
+	return ns.notificationRepository.Notify(*notification)
+}
+
+func (ns *notificationService) NotifyOfRiskGuard(productCode, reason string) error {
+	notification := model.NewNotification(time.Now().UTC(), model.NotificationLevelWarn, productCode, "リスク管理のために決済しました", reason)
+	return ns.notificationRepository.Notify(*notification)
+}
+
+func (ns *notificationService) NotifyOfSummary(summary model.Summary) error {
+	return ns.notificationRepository.NotifyOfSummary(summary)
+}
+
+func (ns *notificationService) NotifyOfAlert(alert model.Alert) error {
+	return ns.notificationRepository.Notify(*model.NewAlertNotification(alert))
+}
+
+func (ns *notificationService) Notify(notification model.Notification) error {
+	return ns.notificationRepository.Notify(notification)
+}
+
+// keyの通知が間隔を空けずに続いていれば送らない
+func (ns *notificationService) notifyOnce(key string, notification model.Notification) error {
+	ok, suppressed := ns.dedup(key, notification.Time())
+	if !ok {
+		return nil
+	}
+	if suppressed > 0 {
+		message := strings.TrimSpace(notification.Message() + "\n" + suppressedMessage(suppressed))
+		notification = *model.NewNotification(notification.Time(), notification.Level(), notification.ProductCode(), notification.Title(), message)
+	}
+
+	if err := ns.notificationRepository.Notify(notification); err != nil {
+		ns.forget(key)
+		return err
+	}
+	return nil
+}
+
+// 送るならtrueと，前回送ってから送らなかった回数を返す
+func (ns *notificationService) dedup(key string, timeTime time.Time) (bool, int) {
+	if ns.dedupInterval <= 0 {
+		return true, 0
+	}
+
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	last, ok := ns.sent[key]
+	if ok && timeTime.Sub(last.time) < ns.dedupInterval {
+		last.suppressed++
+		return false, 0
+	}
+
+	suppressed := 0
+	if ok {
+		suppressed = last.suppressed
+	}
+	ns.sent[key] = &sentNotification{time: timeTime}
+	return true, suppressed
+}
+
+// 送れなかった通知は，次の機会に送る
+func (ns *notificationService) forget(key string) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	delete(ns.sent, key)
+}
+
+func suppressedMessage(suppressed int) string {
+	return fmt.Sprintf("（前回の通知から同じ内容を%d回省略しました）", suppressed)
+}
+
+// 最適化で変わる指標のパラメータ
+func tradeParamsLines(params *model.TradeParams) []string {
+	weights := params.SignalWeights()
+	return []string{
+		fmt.Sprintf("EMA: %d, %d", params.EMAPeriod1(), params.EMAPeriod2()),
+		fmt.Sprintf("BBands: %d, %.2f", params.BBandsN(), params.BBandsK()),
+		fmt.Sprintf("RSI: %d, %.1f, %.1f", params.RSIPeriod(), params.RSIBuyThread(), params.RSISellThread()),
+		fmt.Sprintf("MACD: %d, %d, %d", params.MACDFastPeriod(), params.MACDSlowPeriod(), params.MACDSignalPeriod()),
+		fmt.Sprintf("ATR: %d, %.2f", params.ATRPeriod(), params.ATRMultiplier()),
+		fmt.Sprintf("Stoch: %d, %d, %d, %.1f, %.1f", params.StochFastKPeriod(), params.StochSlowKPeriod(), params.StochSlowDPeriod(), params.StochBuyThread(), params.StochSellThread()),
+		fmt.Sprintf("ADX: %d, %.1f", params.ADXPeriod(), params.ADXThread()),
+		fmt.Sprintf("OBV: %d", params.OBVPeriod()),
+		fmt.Sprintf("VWAP: %d", params.VWAPPeriod()),
+		fmt.Sprintf("SAR: %.3f, %.3f", params.SARAcceleration(), params.SARMaximum()),
+		fmt.Sprintf("Donchian: %d", params.DonchianPeriod()),
+		fmt.Sprintf("Keltner: %d, %.2f", params.KeltnerPeriod(), params.KeltnerMultiplier()),
+		fmt.Sprintf("HeikinAshi: %d", params.HeikinAshiPeriod()),
+		fmt.Sprintf("SignalWeights: %g, %g, %g, %g, %g, %g, %g, %g, %g, %g, %g, %g, %g, %g",
+			weights.EMA(), weights.BBands(), weights.Ichimoku(), weights.RSI(), weights.MACD(), weights.ATR(), weights.Stoch(),
+			weights.ADX(), weights.OBV(), weights.VWAP(), weights.SAR(), weights.Donchian(), weights.Keltner(), weights.HeikinAshi()),
+	}
+}
+
+// 変わった行だけ"前 → 後"の形で並べる
+// 行数が違えば（表示する指標が増えたなど），増えた行は"-"から変わったものとする
+func changedLines(before, after []string) []string {
+	lines := make([]string, 0)
+	for i := range after {
+		old := "-"
+		if i < len(before) {
+			old = before[i]
+		}
+		if old != after[i] {
+			lines = append(lines, fmt.Sprintf("%s → %s", old, after[i]))
+		}
+	}
+	return lines
 }
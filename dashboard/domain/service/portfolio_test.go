@@ -0,0 +1,114 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/repository"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+)
+
+func TestPortfolioService(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	balanceRepository := bitflyer.NewBitFlyerBalanceMockRepository()
+	tickerRepository := bitflyer.NewBitflyerTickerMockRepository()
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	equitySnapshotRepository := persistence.NewEquitySnapshotRepository(tx, config.TimeFormat)
+	portfolioService := service.NewPortfolioService(balanceRepository, tickerRepository, signalEventRepository, equitySnapshotRepository, config.CommissionRate)
+
+	snapshotTime := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("get portfolio", func(t *testing.T) {
+		event := model.NewSignalEvent(snapshotTime, config.ProductCode, model.OrderSideBuy, 100000, 0.01)
+		err := signalEventRepository.Save(*event)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		portfolio, err := portfolioService.Get(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if portfolio.Equity() <= 0 {
+			t.Fatal("portfolio.Equity() <= 0")
+		}
+	})
+
+	// 取引対象でない通貨の価格を取得できなくても，その通貨を除いて計算する
+	t.Run("skip currency without price", func(t *testing.T) {
+		expected, err := portfolioService.Get(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		balanceRepository := &extraBalanceRepository{
+			BalanceRepository: balanceRepository,
+			extra:             *model.NewBalance("BTC", 1, 1),
+		}
+		tickerRepository := &failingTickerRepository{
+			TickerRepository: tickerRepository,
+			productCode:      "BTC_JPY",
+		}
+		portfolioService := service.NewPortfolioService(balanceRepository, tickerRepository, signalEventRepository, equitySnapshotRepository, config.CommissionRate)
+
+		portfolio, err := portfolioService.Get(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if portfolio.Equity() != expected.Equity() {
+			t.Fatalf("%f != %f", portfolio.Equity(), expected.Equity())
+		}
+	})
+
+	t.Run("save snapshot", func(t *testing.T) {
+		err := portfolioService.SaveSnapshot(config.ProductCode, snapshotTime)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		snapshots, err := portfolioService.FindSnapshots(config.ProductCode, 1)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(snapshots) != 1 {
+			t.Fatal("len(snapshots) != 1")
+		}
+		if !snapshots[0].Time().Equal(snapshotTime) {
+			t.Fatalf("%v != %v", snapshots[0].Time(), snapshotTime)
+		}
+	})
+}
+
+// 残高に通貨を1つ加える
+type extraBalanceRepository struct {
+	repository.BalanceRepository
+	extra model.Balance
+}
+
+func (er *extraBalanceRepository) FetchAll() ([]model.Balance, error) {
+	balances, err := er.BalanceRepository.FetchAll()
+	if err != nil {
+		return nil, err
+	}
+	return append(balances, er.extra), nil
+}
+
+// 指定した銘柄の価格だけ取得に失敗する
+type failingTickerRepository struct {
+	repository.TickerRepository
+	productCode string
+}
+
+func (fr *failingTickerRepository) Fetch(productCode string) (*model.Ticker, error) {
+	if productCode == fr.productCode {
+		return nil, errors.New("ticker is not available: " + productCode)
+	}
+	return fr.TickerRepository.Fetch(productCode)
+}
@@ -11,9 +11,19 @@ type TradeParamsService interface {
 
 	OptimizeEMA(df *model.DataFrame, fastPeriod, slowPeriod int, size float64) (float64, int, int, bool)
 	OptimizeBBands(df *model.DataFrame, n int, k float64, size float64) (float64, int, float64, bool)
-	OptimizeIchimoku(df *model.DataFrame, size float64) (float64, bool)
+	OptimizeIchimoku(df *model.DataFrame, tenkanPeriod, kijunPeriod, senkouBPeriod int, size float64) (float64, bool)
 	OptimizeRSI(df *model.DataFrame, period int, buyThread, sellThread float64, size float64) (float64, int, float64, float64, bool)
 	OptimizeMACD(df *model.DataFrame, fastPeriod, slowPeriod, signalPeriod int, size float64) (float64, int, int, int, bool)
+	OptimizeATR(df *model.DataFrame, period int, multiplier float64, size float64) (float64, int, float64, bool)
+	OptimizeStochastic(df *model.DataFrame, fastKPeriod, slowKPeriod, slowDPeriod int, buyThread, sellThread float64, size float64) (float64, int, int, int, float64, float64, bool)
+	OptimizeADX(df *model.DataFrame, period int, thread float64, size float64) (float64, int, float64, bool)
+	OptimizeOBV(df *model.DataFrame, period int, size float64) (float64, int, bool)
+	OptimizeVWAP(df *model.DataFrame, period int, size float64) (float64, int, bool)
+	OptimizeParabolicSAR(df *model.DataFrame, acceleration, maximum float64, size float64) (float64, float64, float64, bool)
+	OptimizeDonchian(df *model.DataFrame, period int, size float64) (float64, int, bool)
+	OptimizeKeltner(df *model.DataFrame, period int, multiplier float64, size float64) (float64, int, float64, bool)
+	OptimizeHeikinAshi(df *model.DataFrame, period int, size float64) (float64, int, bool)
+	OptimizeSignalWeights(df *model.DataFrame, params *model.TradeParams) (float64, model.SignalWeights, bool)
 
 	OptimizeAll(df *model.DataFrame, params *model.TradeParams) (*model.TradeParams, bool)
 }
@@ -90,8 +100,8 @@ func (ts *tradeParamsService) OptimizeBBands(df *model.DataFrame, n int, k float
 	return performance, bestN, bestK, changed
 }
 
-func (ts *tradeParamsService) OptimizeIchimoku(df *model.DataFrame, size float64) (float64, bool) {
-	signalEvents := ts.dataFrameService.BacktestIchimoku(df, size)
+func (ts *tradeParamsService) OptimizeIchimoku(df *model.DataFrame, tenkanPeriod, kijunPeriod, senkouBPeriod int, size float64) (float64, bool) {
+	signalEvents := ts.dataFrameService.BacktestIchimoku(df, tenkanPeriod, kijunPeriod, senkouBPeriod, size)
 	if signalEvents == nil {
 		return 0, false
 	}
@@ -161,11 +171,298 @@ func (ts *tradeParamsService) OptimizeMACD(df *model.DataFrame, fastPeriod, slow
 	return performance, bestFastPeriod, bestSlowPeriod, bestSignalPeriod, changed
 }
 
+func (ts *tradeParamsService) OptimizeATR(df *model.DataFrame, period int, multiplier float64, size float64) (float64, int, float64, bool) {
+	performance := float64(0)
+	bestPeriod := period
+	bestMultiplier := multiplier
+
+	for period := 10; period <= 20; period++ {
+		for multiplier := 1.0; multiplier <= 3.0; multiplier += 0.5 {
+			signalEvents := ts.dataFrameService.BacktestATR(df, period, multiplier, size)
+			if signalEvents == nil {
+				continue
+			}
+			profit := signalEvents.EstimateProfit()
+			if performance < profit {
+				performance = profit
+				bestPeriod = period
+				bestMultiplier = multiplier
+			}
+		}
+	}
+
+	changed := period != bestPeriod ||
+		multiplier != bestMultiplier
+
+	return performance, bestPeriod, bestMultiplier, changed
+}
+
+func (ts *tradeParamsService) OptimizeStochastic(df *model.DataFrame, fastKPeriod, slowKPeriod, slowDPeriod int, buyThread, sellThread float64, size float64) (float64, int, int, int, float64, float64, bool) {
+	performance := float64(0)
+	bestFastKPeriod := fastKPeriod
+	bestSlowKPeriod := slowKPeriod
+	bestSlowDPeriod := slowDPeriod
+	bestBuyThread, bestSellThread := buyThread, sellThread
+
+	for fastKPeriod := 9; fastKPeriod <= 14; fastKPeriod++ {
+		for slowKPeriod := 3; slowKPeriod <= 3; slowKPeriod++ {
+			for slowDPeriod := 3; slowDPeriod <= 3; slowDPeriod++ {
+				for buyThread := float64(15); buyThread <= 25; buyThread += 5 {
+					for sellThread := float64(75); sellThread <= 85; sellThread += 5 {
+						signalEvents := ts.dataFrameService.BacktestStochastic(df, fastKPeriod, slowKPeriod, slowDPeriod, buyThread, sellThread, size)
+						if signalEvents == nil {
+							continue
+						}
+						profit := signalEvents.EstimateProfit()
+						if performance < profit {
+							performance = profit
+							bestFastKPeriod = fastKPeriod
+							bestSlowKPeriod = slowKPeriod
+							bestSlowDPeriod = slowDPeriod
+							bestBuyThread = buyThread
+							bestSellThread = sellThread
+						}
+					}
+				}
+			}
+		}
+	}
+
+	changed := fastKPeriod != bestFastKPeriod ||
+		slowKPeriod != bestSlowKPeriod ||
+		slowDPeriod != bestSlowDPeriod ||
+		buyThread != bestBuyThread ||
+		sellThread != bestSellThread
+
+	return performance, bestFastKPeriod, bestSlowKPeriod, bestSlowDPeriod, bestBuyThread, bestSellThread, changed
+}
+
+func (ts *tradeParamsService) OptimizeADX(df *model.DataFrame, period int, thread float64, size float64) (float64, int, float64, bool) {
+	performance := float64(0)
+	bestPeriod := period
+	bestThread := thread
+
+	for period := 10; period <= 20; period++ {
+		for thread := float64(20); thread <= 30; thread += 5 {
+			signalEvents := ts.dataFrameService.BacktestADX(df, period, thread, size)
+			if signalEvents == nil {
+				continue
+			}
+			profit := signalEvents.EstimateProfit()
+			if performance < profit {
+				performance = profit
+				bestPeriod = period
+				bestThread = thread
+			}
+		}
+	}
+
+	changed := period != bestPeriod ||
+		thread != bestThread
+
+	return performance, bestPeriod, bestThread, changed
+}
+
+func (ts *tradeParamsService) OptimizeOBV(df *model.DataFrame, period int, size float64) (float64, int, bool) {
+	performance := float64(0)
+	bestPeriod := period
+
+	for period := 10; period <= 30; period += 5 {
+		signalEvents := ts.dataFrameService.BacktestOBV(df, period, size)
+		if signalEvents == nil {
+			continue
+		}
+		profit := signalEvents.EstimateProfit()
+		if performance < profit {
+			performance = profit
+			bestPeriod = period
+		}
+	}
+
+	changed := period != bestPeriod
+
+	return performance, bestPeriod, changed
+}
+
+func (ts *tradeParamsService) OptimizeVWAP(df *model.DataFrame, period int, size float64) (float64, int, bool) {
+	performance := float64(0)
+	bestPeriod := period
+
+	for period := 10; period <= 30; period += 5 {
+		signalEvents := ts.dataFrameService.BacktestVWAP(df, period, size)
+		if signalEvents == nil {
+			continue
+		}
+		profit := signalEvents.EstimateProfit()
+		if performance < profit {
+			performance = profit
+			bestPeriod = period
+		}
+	}
+
+	changed := period != bestPeriod
+
+	return performance, bestPeriod, changed
+}
+
+func (ts *tradeParamsService) OptimizeParabolicSAR(df *model.DataFrame, acceleration, maximum float64, size float64) (float64, float64, float64, bool) {
+	performance := float64(0)
+	bestAcceleration := acceleration
+	bestMaximum := maximum
+
+	// 加速因子は0.01刻み
+	for a := 1; a <= 3; a++ {
+		for maximum := 0.2; maximum <= 0.2; maximum += 0.1 {
+			acceleration := float64(a) / 100
+			signalEvents := ts.dataFrameService.BacktestParabolicSAR(df, acceleration, maximum, size)
+			if signalEvents == nil {
+				continue
+			}
+			profit := signalEvents.EstimateProfit()
+			if performance < profit {
+				performance = profit
+				bestAcceleration = acceleration
+				bestMaximum = maximum
+			}
+		}
+	}
+
+	changed := acceleration != bestAcceleration ||
+		maximum != bestMaximum
+
+	return performance, bestAcceleration, bestMaximum, changed
+}
+
+func (ts *tradeParamsService) OptimizeDonchian(df *model.DataFrame, period int, size float64) (float64, int, bool) {
+	performance := float64(0)
+	bestPeriod := period
+
+	for period := 10; period <= 30; period += 5 {
+		signalEvents := ts.dataFrameService.BacktestDonchian(df, period, size)
+		if signalEvents == nil {
+			continue
+		}
+		profit := signalEvents.EstimateProfit()
+		if performance < profit {
+			performance = profit
+			bestPeriod = period
+		}
+	}
+
+	changed := period != bestPeriod
+
+	return performance, bestPeriod, changed
+}
+
+func (ts *tradeParamsService) OptimizeKeltner(df *model.DataFrame, period int, multiplier float64, size float64) (float64, int, float64, bool) {
+	performance := float64(0)
+	bestPeriod := period
+	bestMultiplier := multiplier
+
+	for period := 15; period <= 25; period += 5 {
+		for multiplier := 1.5; multiplier <= 2.5; multiplier += 0.5 {
+			signalEvents := ts.dataFrameService.BacktestKeltner(df, period, multiplier, size)
+			if signalEvents == nil {
+				continue
+			}
+			profit := signalEvents.EstimateProfit()
+			if performance < profit {
+				performance = profit
+				bestPeriod = period
+				bestMultiplier = multiplier
+			}
+		}
+	}
+
+	changed := period != bestPeriod ||
+		multiplier != bestMultiplier
+
+	return performance, bestPeriod, bestMultiplier, changed
+}
+
+func (ts *tradeParamsService) OptimizeHeikinAshi(df *model.DataFrame, period int, size float64) (float64, int, bool) {
+	performance := float64(0)
+	bestPeriod := period
+
+	for period := 2; period <= 5; period++ {
+		signalEvents := ts.dataFrameService.BacktestHeikinAshi(df, period, size)
+		if signalEvents == nil {
+			continue
+		}
+		profit := signalEvents.EstimateProfit()
+		if performance < profit {
+			performance = profit
+			bestPeriod = period
+		}
+	}
+
+	changed := period != bestPeriod
+
+	return performance, bestPeriod, changed
+}
+
+// 指標ごとに重みを変えてバックテストし，利益が最大になる重みを順に決める
+// 今の重みより利益が増えなければ変えない
+// dfには有効な指標が追加済みであること
+func (ts *tradeParamsService) OptimizeSignalWeights(df *model.DataFrame, params *model.TradeParams) (float64, model.SignalWeights, bool) {
+	weights := params.SignalWeights()
+	bestWeights := weights
+
+	// バックテストで上書きされる結果を最後に戻す
+	backtestEvents := df.BacktestEvents()
+	defer df.AddBacktestEvents(backtestEvents)
+
+	candidate := *params
+	ts.dataFrameService.Backtest(df, &candidate)
+	performance := float64(0)
+	if signalEvents := df.BacktestEvents(); signalEvents != nil {
+		performance = signalEvents.Profit()
+	}
+
+	for _, indicator := range model.SignalIndicators() {
+		for weight := 0; weight <= 20; weight += 5 {
+			newWeights := bestWeights.WithWeight(indicator, float64(weight)/10)
+			if newWeights == nil {
+				continue
+			}
+			candidate.SetSignalWeights(*newWeights)
+			ts.dataFrameService.Backtest(df, &candidate)
+			signalEvents := df.BacktestEvents()
+			if signalEvents == nil {
+				continue
+			}
+			profit := signalEvents.Profit()
+			if performance < profit {
+				performance = profit
+				bestWeights = *newWeights
+			}
+		}
+	}
+
+	changed := weights != bestWeights
+
+	return performance, bestWeights, changed
+}
+
 func (ts *tradeParamsService) OptimizeAll(df *model.DataFrame, params *model.TradeParams) (*model.TradeParams, bool) {
 	_, emaPeriod1, emaPeriod2, emaChanged := ts.OptimizeEMA(df, params.EMAPeriod1(), params.EMAPeriod2(), params.Size())
 	_, bbandsN, bbandsK, bbandsChanged := ts.OptimizeBBands(df, params.BBandsN(), params.BBandsK(), params.Size())
 	_, rsiPeriod, rsiBuyThread, rsiSellThread, rsiChanged := ts.OptimizeRSI(df, params.RSIPeriod(), params.RSIBuyThread(), params.RSISellThread(), params.Size())
 	_, macdFastPeriod, macdSlowPeriod, macdSignalPeriod, macdChanged := ts.OptimizeMACD(df, params.MACDFastPeriod(), params.MACDSlowPeriod(), params.MACDSignalPeriod(), params.Size())
+	_, atrPeriod, atrMultiplier, atrChanged := ts.OptimizeATR(df, params.ATRPeriod(), params.ATRMultiplier(), params.Size())
+	_, stochFastKPeriod, stochSlowKPeriod, stochSlowDPeriod, stochBuyThread, stochSellThread, stochChanged := ts.OptimizeStochastic(df, params.StochFastKPeriod(), params.StochSlowKPeriod(), params.StochSlowDPeriod(), params.StochBuyThread(), params.StochSellThread(), params.Size())
+	_, adxPeriod, adxThread, adxChanged := ts.OptimizeADX(df, params.ADXPeriod(), params.ADXThread(), params.Size())
+	_, obvPeriod, obvChanged := ts.OptimizeOBV(df, params.OBVPeriod(), params.Size())
+	_, vwapPeriod, vwapChanged := ts.OptimizeVWAP(df, params.VWAPPeriod(), params.Size())
+	_, sarAcceleration, sarMaximum, sarChanged := ts.OptimizeParabolicSAR(df, params.SARAcceleration(), params.SARMaximum(), params.Size())
+	_, donchianPeriod, donchianChanged := ts.OptimizeDonchian(df, params.DonchianPeriod(), params.Size())
+	_, keltnerPeriod, keltnerMultiplier, keltnerChanged := ts.OptimizeKeltner(df, params.KeltnerPeriod(), params.KeltnerMultiplier(), params.Size())
+	_, heikinAshiPeriod, heikinAshiChanged := ts.OptimizeHeikinAshi(df, params.HeikinAshiPeriod(), params.Size())
+	// 重みは重み付きの投票で判定するときだけ使う
+	signalWeights, signalWeightsChanged := params.SignalWeights(), false
+	if usesSignalWeights(ts.dataFrameService) {
+		_, signalWeights, signalWeightsChanged = ts.OptimizeSignalWeights(df, params)
+	}
 
 	newParams := model.NewTradeParams(
 		params.TradeEnable(),
@@ -183,6 +480,9 @@ func (ts *tradeParamsService) OptimizeAll(df *model.DataFrame, params *model.Tra
 		bbandsN,
 		bbandsK,
 		params.IchimokuEnable(),
+		params.IchimokuTenkanPeriod(),
+		params.IchimokuKijunPeriod(),
+		params.IchimokuSenkouBPeriod(),
 		params.RSIEnable(),
 		rsiPeriod,
 		rsiBuyThread,
@@ -191,13 +491,54 @@ func (ts *tradeParamsService) OptimizeAll(df *model.DataFrame, params *model.Tra
 		macdFastPeriod,
 		macdSlowPeriod,
 		macdSignalPeriod,
+		params.ATREnable(),
+		atrPeriod,
+		atrMultiplier,
+		params.StochEnable(),
+		stochFastKPeriod,
+		stochSlowKPeriod,
+		stochSlowDPeriod,
+		stochBuyThread,
+		stochSellThread,
+		params.ADXEnable(),
+		adxPeriod,
+		adxThread,
+		params.OBVEnable(),
+		obvPeriod,
+		params.VWAPEnable(),
+		vwapPeriod,
+		params.SAREnable(),
+		sarAcceleration,
+		sarMaximum,
+		params.DonchianEnable(),
+		donchianPeriod,
+		params.KeltnerEnable(),
+		keltnerPeriod,
+		keltnerMultiplier,
+		params.HeikinAshiEnable(),
+		heikinAshiPeriod,
+		signalWeights,
+		params.BuyVoteThreshold(),
+		params.SellVoteThreshold(),
 		params.StopLimitPercent(),
+		params.MaxLots(),
+		params.LotMatching(),
 	)
 
 	changed := emaChanged ||
 		bbandsChanged ||
 		rsiChanged ||
-		macdChanged
+		macdChanged ||
+		atrChanged ||
+		stochChanged ||
+		adxChanged ||
+		obvChanged ||
+		vwapChanged ||
+		sarChanged ||
+		donchianChanged ||
+		keltnerChanged ||
+		heikinAshiChanged ||
+		signalWeightsChanged
 
 	return newParams, changed
 }
@@ -14,6 +14,9 @@ func TestTradeParamsService(t *testing.T) {
 	defer tx.Rollback()
 
 	candleRepository := persistence.NewCandleMockRepository(config.CandleTableName, config.TimeFormat, config.ProductCode, config.CandleDuration)
+	if candleRepository == nil {
+		t.Fatal("NewCandleMockRepository() returns nil")
+	}
 	candles, err := candleRepository.FindAll(config.ProductCode, config.CandleDuration, -1)
 	if err != nil {
 		t.Fatal(err.Error())
@@ -70,7 +73,7 @@ func TestTradeParamsService(t *testing.T) {
 	})
 
 	t.Run("optimize ichimoku cloud", func(t *testing.T) {
-		performance, changed := tradeParamsService.OptimizeIchimoku(df, params.Size())
+		performance, changed := tradeParamsService.OptimizeIchimoku(df, params.IchimokuTenkanPeriod(), params.IchimokuKijunPeriod(), params.IchimokuSenkouBPeriod(), params.Size())
 		t.Logf("performance=%f", performance)
 		if changed {
 			t.Fatal("params is changed(?)")
@@ -101,8 +104,155 @@ func TestTradeParamsService(t *testing.T) {
 		}
 	})
 
+	t.Run("optimize atr", func(t *testing.T) {
+		performance, period, multiplier, changed := tradeParamsService.OptimizeATR(df, params.ATRPeriod(), params.ATRMultiplier(), params.Size())
+		t.Logf("performance=%f, period=%d, multiplier=%f", performance, period, multiplier)
+		if changed &&
+			(period == params.ATRPeriod() && multiplier == params.ATRMultiplier()) {
+			t.Fatal("params is not changed")
+		} else if !changed &&
+			(period != params.ATRPeriod() || multiplier != params.ATRMultiplier()) {
+			t.Fatal("params is changed")
+		}
+	})
+
+	t.Run("optimize stochastic", func(t *testing.T) {
+		performance, fastKPeriod, slowKPeriod, slowDPeriod, buyThread, sellThread, changed := tradeParamsService.OptimizeStochastic(df, params.StochFastKPeriod(), params.StochSlowKPeriod(), params.StochSlowDPeriod(), params.StochBuyThread(), params.StochSellThread(), params.Size())
+		t.Logf("performance=%f, fastKPeriod=%d, slowKPeriod=%d, slowDPeriod=%d, buyThread=%f, sellThread=%f", performance, fastKPeriod, slowKPeriod, slowDPeriod, buyThread, sellThread)
+		if changed &&
+			(fastKPeriod == params.StochFastKPeriod() && slowKPeriod == params.StochSlowKPeriod() && slowDPeriod == params.StochSlowDPeriod() && buyThread == params.StochBuyThread() && sellThread == params.StochSellThread()) {
+			t.Fatal("params is not changed")
+		} else if !changed &&
+			(fastKPeriod != params.StochFastKPeriod() || slowKPeriod != params.StochSlowKPeriod() || slowDPeriod != params.StochSlowDPeriod() || buyThread != params.StochBuyThread() || sellThread != params.StochSellThread()) {
+			t.Fatal("params is changed")
+		}
+	})
+
+	t.Run("optimize adx", func(t *testing.T) {
+		performance, period, thread, changed := tradeParamsService.OptimizeADX(df, params.ADXPeriod(), params.ADXThread(), params.Size())
+		t.Logf("performance=%f, period=%d, thread=%f", performance, period, thread)
+		if changed &&
+			(period == params.ADXPeriod() && thread == params.ADXThread()) {
+			t.Fatal("params is not changed")
+		} else if !changed &&
+			(period != params.ADXPeriod() || thread != params.ADXThread()) {
+			t.Fatal("params is changed")
+		}
+	})
+
+	t.Run("optimize obv", func(t *testing.T) {
+		performance, period, changed := tradeParamsService.OptimizeOBV(df, params.OBVPeriod(), params.Size())
+		t.Logf("performance=%f, period=%d", performance, period)
+		if changed &&
+			(period == params.OBVPeriod()) {
+			t.Fatal("params is not changed")
+		} else if !changed &&
+			(period != params.OBVPeriod()) {
+			t.Fatal("params is changed")
+		}
+	})
+
+	t.Run("optimize vwap", func(t *testing.T) {
+		performance, period, changed := tradeParamsService.OptimizeVWAP(df, params.VWAPPeriod(), params.Size())
+		t.Logf("performance=%f, period=%d", performance, period)
+		if changed &&
+			(period == params.VWAPPeriod()) {
+			t.Fatal("params is not changed")
+		} else if !changed &&
+			(period != params.VWAPPeriod()) {
+			t.Fatal("params is changed")
+		}
+	})
+
+	t.Run("optimize parabolic sar", func(t *testing.T) {
+		performance, acceleration, maximum, changed := tradeParamsService.OptimizeParabolicSAR(df, params.SARAcceleration(), params.SARMaximum(), params.Size())
+		t.Logf("performance=%f, acceleration=%f, maximum=%f", performance, acceleration, maximum)
+		if changed &&
+			(acceleration == params.SARAcceleration() && maximum == params.SARMaximum()) {
+			t.Fatal("params is not changed")
+		} else if !changed &&
+			(acceleration != params.SARAcceleration() || maximum != params.SARMaximum()) {
+			t.Fatal("params is changed")
+		}
+	})
+
+	t.Run("optimize donchian channel", func(t *testing.T) {
+		performance, period, changed := tradeParamsService.OptimizeDonchian(df, params.DonchianPeriod(), params.Size())
+		t.Logf("performance=%f, period=%d", performance, period)
+		if changed &&
+			(period == params.DonchianPeriod()) {
+			t.Fatal("params is not changed")
+		} else if !changed &&
+			(period != params.DonchianPeriod()) {
+			t.Fatal("params is changed")
+		}
+	})
+
+	t.Run("optimize keltner channel", func(t *testing.T) {
+		performance, period, multiplier, changed := tradeParamsService.OptimizeKeltner(df, params.KeltnerPeriod(), params.KeltnerMultiplier(), params.Size())
+		t.Logf("performance=%f, period=%d, multiplier=%f", performance, period, multiplier)
+		if changed &&
+			(period == params.KeltnerPeriod() && multiplier == params.KeltnerMultiplier()) {
+			t.Fatal("params is not changed")
+		} else if !changed &&
+			(period != params.KeltnerPeriod() || multiplier != params.KeltnerMultiplier()) {
+			t.Fatal("params is changed")
+		}
+	})
+
+	t.Run("optimize heikin-ashi", func(t *testing.T) {
+		performance, period, changed := tradeParamsService.OptimizeHeikinAshi(df, params.HeikinAshiPeriod(), params.Size())
+		t.Logf("performance=%f, period=%d", performance, period)
+		if changed &&
+			(period == params.HeikinAshiPeriod()) {
+			t.Fatal("params is not changed")
+		} else if !changed &&
+			(period != params.HeikinAshiPeriod()) {
+			t.Fatal("params is changed")
+		}
+	})
+
+	t.Run("optimize signal weights", func(t *testing.T) {
+		// 重みを使わない戦略では，どの重みでも利益が変わらないので今の重みのまま
+		performance, weights, changed := tradeParamsService.OptimizeSignalWeights(df, params)
+		t.Logf("performance=%f, weights=%+v", performance, weights)
+		if changed || weights != params.SignalWeights() {
+			t.Fatalf("weights are changed: %+v", weights)
+		}
+
+		// 重み付きの投票では，最適化した重みで今の重み以上の利益が出る
+		df.AddEMA(params.EMAPeriod1())
+		df.AddEMA(params.EMAPeriod2())
+		df.AddBBands(params.BBandsN(), params.BBandsK())
+		df.AddRSI(params.RSIPeriod())
+		weightedDataFrameService := service.NewWeightedDataFrameService(indicatorService)
+		weightedTradeParamsService := service.NewTradeParamsService(tradeParamsRepository, weightedDataFrameService)
+		current := *params
+		weightedDataFrameService.Backtest(df, &current)
+		currentProfit := df.BacktestEvents().Profit()
+
+		performance, weights, changed = weightedTradeParamsService.OptimizeSignalWeights(df, params)
+		t.Logf("performance=%f, weights=%+v", performance, weights)
+		optimized := *params
+		optimized.SetSignalWeights(weights)
+		weightedDataFrameService.Backtest(df, &optimized)
+		if profit := df.BacktestEvents().Profit(); profit != performance {
+			t.Fatalf("%f != %f", profit, performance)
+		}
+		if changed && performance <= currentProfit {
+			t.Fatalf("weights are changed without improvement: %f <= %f", performance, currentProfit)
+		}
+		if !changed && (weights != params.SignalWeights() || performance != currentProfit) {
+			t.Fatalf("weights=%+v, performance=%f, currentProfit=%f", weights, performance, currentProfit)
+		}
+	})
+
 	t.Run("optimize all", func(t *testing.T) {
 		optimizedParams, changed := tradeParamsService.OptimizeAll(df, params)
+		// 重みを使わない戦略なので，重みは最適化しない
+		if optimizedParams.SignalWeights() != params.SignalWeights() {
+			t.Fatalf("weights are changed: %+v", optimizedParams.SignalWeights())
+		}
 
 		if optimizedParams.EMAEnable() {
 			ok1 := df.AddEMA(optimizedParams.EMAPeriod1())
@@ -114,7 +264,7 @@ func TestTradeParamsService(t *testing.T) {
 			optimizedParams.EnableBBands(ok)
 		}
 		if optimizedParams.IchimokuEnable() {
-			ok := df.AddIchimoku()
+			ok := df.AddIchimoku(optimizedParams.IchimokuTenkanPeriod(), optimizedParams.IchimokuKijunPeriod(), optimizedParams.IchimokuSenkouBPeriod())
 			optimizedParams.EnableIchimoku(ok)
 		}
 		if optimizedParams.MACDEnable() {
@@ -144,6 +294,9 @@ func TestTradeParamsServiceMRBase(t *testing.T) {
 	defer tx.Rollback()
 
 	candleRepository := persistence.NewCandleMockRepository(config.CandleTableName, config.TimeFormat, config.ProductCode, config.CandleDuration)
+	if candleRepository == nil {
+		t.Fatal("NewCandleMockRepository() returns nil")
+	}
 	candles, err := candleRepository.FindAll(config.ProductCode, config.CandleDuration, -1)
 	if err != nil {
 		t.Fatal(err.Error())
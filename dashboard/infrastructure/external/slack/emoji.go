@@ -3,6 +3,10 @@ package slack
 type Emoji string
 
 const (
-	EmojiCoin      Emoji = ":coin:"
-	EmojiDizzyFace Emoji = ":dizzy_face:"
+	EmojiCoin                  Emoji = ":coin:"
+	EmojiDizzyFace             Emoji = ":dizzy_face:"
+	EmojiScales                Emoji = ":scales:"
+	EmojiWarning               Emoji = ":warning:"
+	EmojiInformationSource     Emoji = ":information_source:"
+	EmojiChartWithUpwardsTrend Emoji = ":chart_with_upwards_trend:"
 )
@@ -29,4 +29,18 @@ func TestSlackNotificationRepository(t *testing.T) {
 			t.Skip(err)
 		}
 	})
+	t.Run("notify of spread", func(t *testing.T) {
+		spread := model.NewSpread(time.Now(), config.ProductCode, "bitflyer", "coincheck", 500000, 510000)
+		err := notificationRepository.NotifyOfSpread(*spread)
+		if err != nil {
+			t.Skip(err)
+		}
+	})
+	t.Run("notify", func(t *testing.T) {
+		notification := model.NewNotification(time.Now(), model.NotificationLevelWarn, config.ProductCode, "test of Notify", "message")
+		err := notificationRepository.Notify(*notification)
+		if err != nil {
+			t.Skip(err)
+		}
+	})
 }
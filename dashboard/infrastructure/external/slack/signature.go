@@ -0,0 +1,48 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// リクエストの時刻がこれより古ければ，再送攻撃とみなして受け付けない
+const signatureMaxAge = 5 * time.Minute
+
+var ErrInvalidSignature = errors.New("invalid slack signature")
+
+// Slackから送られたリクエストかどうかを，Signing Secretによる署名で確かめる
+// https://api.slack.com/authentication/verifying-requests-from-slack
+func VerifySignature(signingSecret string, header http.Header, body []byte, now time.Time) error {
+	if signingSecret == "" {
+		return ErrInvalidSignature
+	}
+
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if math.Abs(now.Sub(time.Unix(sec, 0)).Seconds()) > signatureMaxAge.Seconds() {
+		return ErrInvalidSignature
+	}
+
+	expected := Signature(signingSecret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature"))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// "v0:<timestamp>:<body>"のHMAC-SHA256
+func Signature(signingSecret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,65 @@
+package slack_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/external/slack"
+)
+
+func TestVerifySignature(t *testing.T) {
+	secret := "8f742231b10e8888abcd99yyyzzz85a5"
+	body := []byte("token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J&command=%2Fbot&text=status")
+	now := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newHeader := func(timestamp time.Time, signature string) http.Header {
+		header := http.Header{}
+		ts := strconv.FormatInt(timestamp.Unix(), 10)
+		if signature == "" {
+			signature = slack.Signature(secret, ts, body)
+		}
+		header.Set("X-Slack-Request-Timestamp", ts)
+		header.Set("X-Slack-Signature", signature)
+		return header
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		if err := slack.VerifySignature(secret, newHeader(now.Add(-time.Minute), ""), body, now); err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+
+	t.Run("known signature", func(t *testing.T) {
+		// Slackのドキュメントの例
+		docBody := []byte("token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J&team_domain=testteamnow&channel_id=G8PSS9T3V&channel_name=foobar&user_id=U2CERLKJA&user_name=roadrunner&command=%2Fwebhook-collect&text=&response_url=https%3A%2F%2Fhooks.slack.com%2Fcommands%2FT1DC2JH3J%2F397700885554%2F96rGlfmibIGlgcZRskXaIFfN&trigger_id=398738663015.47445629121.803a0bc887a14d10d2c447fce8b6703c")
+		if signature := slack.Signature(secret, "1531420618", docBody); signature != "v0=a2114d57b48eac39b9ad189dd8316235a7b4a8d21a10bd27519666489c69b503" {
+			t.Fatalf("signature=%s", signature)
+		}
+	})
+
+	t.Run("wrong signature", func(t *testing.T) {
+		if err := slack.VerifySignature(secret, newHeader(now, "v0=0000"), body, now); err != slack.ErrInvalidSignature {
+			t.Fatalf("err=%v", err)
+		}
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		if err := slack.VerifySignature(secret, newHeader(now, ""), []byte("text=pause"), now); err != slack.ErrInvalidSignature {
+			t.Fatalf("err=%v", err)
+		}
+	})
+
+	t.Run("too old", func(t *testing.T) {
+		if err := slack.VerifySignature(secret, newHeader(now.Add(-10*time.Minute), ""), body, now); err != slack.ErrInvalidSignature {
+			t.Fatalf("err=%v", err)
+		}
+	})
+
+	t.Run("no secret", func(t *testing.T) {
+		if err := slack.VerifySignature("", newHeader(now, ""), body, now); err != slack.ErrInvalidSignature {
+			t.Fatalf("err=%v", err)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/slack-go/slack"
+)
+
+// Block Kitの1ブロックに入れられるテキストの上限
+const maxBlockTextLength = 3000
+
+// 運用成績のまとめをBlock Kitで組み立てる
+func buildSummaryBlocks(summary model.Summary, timeLocation *time.Location) []slack.Block {
+	portfolio := summary.Portfolio()
+	timeFormat := "2006-01-02 15:04"
+
+	header := slack.NewHeaderBlock(
+		slack.NewTextBlockObject(slack.PlainTextType, fmt.Sprintf("%s %s（%s）", EmojiChartWithUpwardsTrend, summary.Title(), summary.ProductCode()), true, false),
+	)
+
+	period := slack.NewContextBlock("",
+		slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("%s 〜 %s",
+			summary.Since().In(timeLocation).Format(timeFormat),
+			summary.Time().In(timeLocation).Format(timeFormat),
+		), false, false),
+	)
+
+	fields := []*slack.TextBlockObject{
+		markdownField("Price", fmt.Sprintf("%f", portfolio.CurrentPrice())),
+		markdownField("Position", fmt.Sprintf("%f", portfolio.Position())),
+		markdownField("Equity", fmt.Sprintf("%.0f", portfolio.Equity())),
+		markdownField("PnL", fmt.Sprintf("%+.0f", portfolio.TotalPnL())),
+		markdownField("Realized", fmt.Sprintf("%+.0f", portfolio.RealizedPnL())),
+		markdownField("Unrealized", fmt.Sprintf("%+.0f", portfolio.UnrealizedPnL())),
+	}
+	overview := slack.NewSectionBlock(nil, fields, nil)
+
+	trades := []string{fmt.Sprintf("*Trades*: %d", len(summary.Trades()))}
+	for _, trade := range summary.Trades() {
+		trades = append(trades, fmt.Sprintf("• %s %s %f @ %f", trade.Time().In(timeLocation).Format(timeFormat), trade.Side(), trade.Size(), trade.Price()))
+	}
+
+	indicators := make([]string, 0, len(summary.Indicators()))
+	for _, indicator := range summary.Indicators() {
+		indicators = append(indicators, fmt.Sprintf("%s `%s`", indicator.Name(), indicator.Signal()))
+	}
+	if len(indicators) == 0 {
+		indicators = append(indicators, "-")
+	}
+
+	params := []string{"*Params*: unchanged"}
+	if summary.ParamsChanged() {
+		params = []string{"*Params*: changed"}
+		for _, change := range summary.Changes() {
+			params = append(params, "• "+change)
+		}
+	}
+
+	return []slack.Block{
+		header,
+		period,
+		overview,
+		slack.NewDividerBlock(),
+		markdownSection(strings.Join(trades, "\n")),
+		markdownSection("*Indicators*: " + strings.Join(indicators, ", ")),
+		markdownSection(strings.Join(params, "\n")),
+	}
+}
+
+func markdownField(name, value string) *slack.TextBlockObject {
+	return slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*%s*\n%s", name, value), false, false)
+}
+
+func markdownSection(text string) *slack.SectionBlock {
+	runes := []rune(text)
+	if len(runes) > maxBlockTextLength {
+		text = string(runes[:maxBlockTextLength-1]) + "…"
+	}
+	return slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil)
+}
@@ -0,0 +1,62 @@
+package slack_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/external/slack"
+)
+
+func TestSlackNotifyOfSummary(t *testing.T) {
+	var text string
+	var blocks []map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat.postMessage" {
+			t.Errorf("path=%s", r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Error(err.Error())
+		}
+		text = r.FormValue("text")
+		if err := json.Unmarshal([]byte(r.FormValue("blocks")), &blocks); err != nil {
+			t.Error(err.Error())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok": true, "channel": "C0123", "ts": "1"}`))
+	}))
+	defer ts.Close()
+
+	client := slack.NewClientWithAPIURL("token", "C0123", ts.URL+"/")
+	notificationRepository := slack.NewSlackNotificationRepository(client, time.UTC)
+
+	timeTime := time.Date(2021, 11, 9, 0, 0, 0, 0, time.UTC)
+	trades := []model.SignalEvent{
+		*model.NewSignalEvent(timeTime.Add(-time.Hour), "ETH_JPY", model.OrderSideBuy, 500000, 0.01),
+	}
+	portfolio := model.NewPortfolio("ETH_JPY", trades, 510000, 0, 100000)
+	indicators := []model.IndicatorState{*model.NewIndicatorState("RSI", model.OrderSideSell)}
+	summary := model.NewSummary(timeTime, model.SummaryPeriodWeekly, timeTime.AddDate(0, 0, -7), *portfolio, trades, indicators, nil, nil)
+
+	if err := notificationRepository.NotifyOfSummary(*summary); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if !strings.Contains(text, "週次レポート") {
+		t.Fatalf("text=%s", text)
+	}
+	types := make([]string, 0)
+	for _, block := range blocks {
+		types = append(types, block["type"].(string))
+	}
+	if strings.Join(types, ",") != "header,context,section,divider,section,section,section" {
+		t.Fatalf("types=%v", types)
+	}
+	if b, _ := json.Marshal(blocks); !strings.Contains(string(b), "RSI `SELL`") || !strings.Contains(string(b), "*Params*: unchanged") {
+		t.Fatalf("blocks=%s", b)
+	}
+}
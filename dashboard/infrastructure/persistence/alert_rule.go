@@ -0,0 +1,155 @@
+package persistence
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/repository"
+)
+
+type alertRuleRepository struct {
+	db         DB
+	timeFormat string
+}
+
+func NewAlertRuleRepository(db DB, timeFormat string) repository.AlertRuleRepository {
+	return &alertRuleRepository{
+		db:         db,
+		timeFormat: timeFormat,
+	}
+}
+
+// cooldownは分単位で保存する
+// 条件を変えたら，成り立っていたかどうかは評価し直す
+func (ar *alertRuleRepository) Save(rule model.AlertRule) (int64, error) {
+	if rule.ID() == 0 {
+		cmd := `
+            INSERT INTO alert_rules
+                (product_code, kind, threshold, hours, expression, cooldown_minutes, enable)
+            VALUES
+                (?, ?, ?, ?, ?, ?, ?)
+            `
+		result, err := ar.db.Exec(cmd,
+			rule.ProductCode(),
+			string(rule.Kind()),
+			rule.Threshold(),
+			rule.Hours(),
+			rule.Expression(),
+			int64(rule.Cooldown()/time.Minute),
+			rule.Enable(),
+		)
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+
+	cmd := `
+        UPDATE
+            alert_rules
+        SET
+            product_code = ?, kind = ?, threshold = ?, hours = ?, expression = ?, cooldown_minutes = ?, enable = ?, active = 0
+        WHERE
+            id = ?
+        `
+	_, err := ar.db.Exec(cmd,
+		rule.ProductCode(),
+		string(rule.Kind()),
+		rule.Threshold(),
+		rule.Hours(),
+		rule.Expression(),
+		int64(rule.Cooldown()/time.Minute),
+		rule.Enable(),
+		rule.ID(),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return rule.ID(), nil
+}
+
+func (ar *alertRuleRepository) FindAll(productCode string) ([]model.AlertRule, error) {
+	cmd := `
+        SELECT
+            id, kind, threshold, hours, expression, cooldown_minutes, enable, active, notified_at
+        FROM
+            alert_rules
+        WHERE
+            product_code = ?
+        ORDER BY
+            id ASC
+        `
+	rows, err := ar.db.Query(cmd, productCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]model.AlertRule, 0)
+	for rows.Next() {
+		var id, cooldownMinutes int64
+		var kind, expression string
+		var threshold float64
+		var hours int
+		var enable, active bool
+		var notifiedAtStr sql.NullString
+		err := rows.Scan(&id, &kind, &threshold, &hours, &expression, &cooldownMinutes, &enable, &active, &notifiedAtStr)
+		if err != nil {
+			return nil, err
+		}
+
+		// for sqlite: convert string to time.Time
+		var notifiedAtTime time.Time
+		if notifiedAtStr.Valid {
+			notifiedAtTime, err = time.Parse(ar.timeFormat, notifiedAtStr.String)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rule := model.NewAlertRule(id, productCode, model.AlertKind(kind), threshold, hours, expression, time.Duration(cooldownMinutes)*time.Minute, enable, active, notifiedAtTime)
+		if rule == nil {
+			return nil, errors.New(fmt.Sprint("invalid alert_rule:", id, productCode, kind, threshold, hours, expression, cooldownMinutes))
+		}
+
+		rules = append(rules, *rule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (ar *alertRuleRepository) Delete(id int64) error {
+	cmd := `
+        DELETE FROM
+            alert_rules
+        WHERE
+            id = ?
+        `
+	_, err := ar.db.Exec(cmd, id)
+	return err
+}
+
+func (ar *alertRuleRepository) UpdateState(rule model.AlertRule) error {
+	var notifiedAt interface{}
+	if !rule.NotifiedAt().IsZero() {
+		notifiedAt = rule.NotifiedAt().Format(ar.timeFormat)
+	}
+
+	cmd := `
+        UPDATE
+            alert_rules
+        SET
+            active = ?, notified_at = ?
+        WHERE
+            id = ?
+        `
+	_, err := ar.db.Exec(cmd, rule.Active(), notifiedAt, rule.ID())
+	return err
+}
@@ -0,0 +1,90 @@
+package persistence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+)
+
+func TestAlertRule(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	alertRuleRepository := persistence.NewAlertRuleRepository(tx, config.TimeFormat)
+
+	var id int64
+
+	t.Run("save alert rule", func(t *testing.T) {
+		rule := model.NewAlertRule(0, config.ProductCode, model.AlertKindIndicator, 0, 0, "rsi(14) < 25", time.Hour, true, false, time.Time{})
+		var err error
+		id, err = alertRuleRepository.Save(*rule)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if id <= 0 {
+			t.Fatalf("id=%d", id)
+		}
+	})
+
+	t.Run("update state", func(t *testing.T) {
+		notifiedAt := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+		rule := model.NewAlertRule(id, config.ProductCode, model.AlertKindIndicator, 0, 0, "rsi(14) < 25", time.Hour, true, true, notifiedAt)
+		if err := alertRuleRepository.UpdateState(*rule); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		rules, err := alertRuleRepository.FindAll(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		found := false
+		for _, r := range rules {
+			if r.ID() != id {
+				continue
+			}
+			found = true
+			if !r.Active() || !r.NotifiedAt().Equal(notifiedAt) || r.Cooldown() != time.Hour || r.Expression() != rule.Expression() {
+				t.Fatalf("rule=%+v", r)
+			}
+		}
+		if !found {
+			t.Fatalf("rule %d is not found", id)
+		}
+	})
+
+	t.Run("update condition", func(t *testing.T) {
+		rule := model.NewAlertRule(id, config.ProductCode, model.AlertKindPriceAbove, 5000000, 0, "", 30*time.Minute, true, false, time.Time{})
+		if _, err := alertRuleRepository.Save(*rule); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		rules, err := alertRuleRepository.FindAll(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		for _, r := range rules {
+			if r.ID() == id && (r.Kind() != model.AlertKindPriceAbove || r.Active()) {
+				t.Fatalf("rule=%+v", r)
+			}
+		}
+	})
+
+	t.Run("delete alert rule", func(t *testing.T) {
+		if err := alertRuleRepository.Delete(id); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		rules, err := alertRuleRepository.FindAll(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		for _, r := range rules {
+			if r.ID() == id {
+				t.Fatalf("rule %d is not deleted", id)
+			}
+		}
+	})
+}
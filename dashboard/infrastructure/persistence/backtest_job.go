@@ -0,0 +1,555 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/repository"
+)
+
+type backtestJobRepository struct {
+	db         DB
+	timeFormat string
+}
+
+func NewBacktestJobRepository(db DB, timeFormat string) repository.BacktestJobRepository {
+	return &backtestJobRepository{
+		db:         db,
+		timeFormat: timeFormat,
+	}
+}
+
+// パラメータと結果はJSONで保存する
+type backtestParams struct {
+	TradeEnable           bool                   `json:"tradeEnable"`
+	ProductCode           string                 `json:"productCode"`
+	Size                  float64                `json:"size"`
+	SMAEnable             bool                   `json:"smaEnable"`
+	SMAPeriod1            int                    `json:"smaPeriod1"`
+	SMAPeriod2            int                    `json:"smaPeriod2"`
+	SMAPeriod3            int                    `json:"smaPeriod3"`
+	EMAEnable             bool                   `json:"emaEnable"`
+	EMAPeriod1            int                    `json:"emaPeriod1"`
+	EMAPeriod2            int                    `json:"emaPeriod2"`
+	EMAPeriod3            int                    `json:"emaPeriod3"`
+	BBandsEnable          bool                   `json:"bbandsEnable"`
+	BBandsN               int                    `json:"bbandsN"`
+	BBandsK               float64                `json:"bbandsK"`
+	IchimokuEnable        bool                   `json:"ichimokuEnable"`
+	IchimokuTenkanPeriod  int                    `json:"ichimokuTenkanPeriod"`
+	IchimokuKijunPeriod   int                    `json:"ichimokuKijunPeriod"`
+	IchimokuSenkouBPeriod int                    `json:"ichimokuSenkouBPeriod"`
+	RSIEnable             bool                   `json:"rsiEnable"`
+	RSIPeriod             int                    `json:"rsiPeriod"`
+	RSIBuyThread          float64                `json:"rsiBuyThread"`
+	RSISellThread         float64                `json:"rsiSellThread"`
+	MACDEnable            bool                   `json:"macdEnable"`
+	MACDFastPeriod        int                    `json:"macdFastPeriod"`
+	MACDSlowPeriod        int                    `json:"macdSlowPeriod"`
+	MACDSignalPeriod      int                    `json:"macdSignalPeriod"`
+	ATREnable             bool                   `json:"atr"`
+	ATRPeriod             int                    `json:"atrPeriod"`
+	ATRMultiplier         float64                `json:"atrMultiplier"`
+	StochEnable           bool                   `json:"stoch"`
+	StochFastKPeriod      int                    `json:"stochFastKPeriod"`
+	StochSlowKPeriod      int                    `json:"stochSlowKPeriod"`
+	StochSlowDPeriod      int                    `json:"stochSlowDPeriod"`
+	StochBuyThread        float64                `json:"stochBuyThread"`
+	StochSellThread       float64                `json:"stochSellThread"`
+	ADXEnable             bool                   `json:"adx"`
+	ADXPeriod             int                    `json:"adxPeriod"`
+	ADXThread             float64                `json:"adxThread"`
+	OBVEnable             bool                   `json:"obv"`
+	OBVPeriod             int                    `json:"obvPeriod"`
+	VWAPEnable            bool                   `json:"vwap"`
+	VWAPPeriod            int                    `json:"vwapPeriod"`
+	SAREnable             bool                   `json:"sar"`
+	SARAcceleration       float64                `json:"sarAcceleration"`
+	SARMaximum            float64                `json:"sarMaximum"`
+	DonchianEnable        bool                   `json:"donchian"`
+	DonchianPeriod        int                    `json:"donchianPeriod"`
+	KeltnerEnable         bool                   `json:"keltner"`
+	KeltnerPeriod         int                    `json:"keltnerPeriod"`
+	KeltnerMultiplier     float64                `json:"keltnerMultiplier"`
+	HeikinAshiEnable      bool                   `json:"heikinAshi"`
+	HeikinAshiPeriod      int                    `json:"heikinAshiPeriod"`
+	SignalWeights         *backtestSignalWeights `json:"signalWeights,omitempty"`
+	BuyVoteThreshold      float64                `json:"buyVoteThreshold"`
+	SellVoteThreshold     float64                `json:"sellVoteThreshold"`
+	StopLimitPercent      float64                `json:"stopLimitPercent"`
+	MaxLots               int                    `json:"maxLots"`
+	LotMatching           model.LotMatching      `json:"lotMatching"`
+}
+
+func newBacktestParams(params model.TradeParams) backtestParams {
+	return backtestParams{
+		TradeEnable:           params.TradeEnable(),
+		ProductCode:           params.ProductCode(),
+		Size:                  params.Size(),
+		SMAEnable:             params.SMAEnable(),
+		SMAPeriod1:            params.SMAPeriod1(),
+		SMAPeriod2:            params.SMAPeriod2(),
+		SMAPeriod3:            params.SMAPeriod3(),
+		EMAEnable:             params.EMAEnable(),
+		EMAPeriod1:            params.EMAPeriod1(),
+		EMAPeriod2:            params.EMAPeriod2(),
+		EMAPeriod3:            params.EMAPeriod3(),
+		BBandsEnable:          params.BBandsEnable(),
+		BBandsN:               params.BBandsN(),
+		BBandsK:               params.BBandsK(),
+		IchimokuEnable:        params.IchimokuEnable(),
+		IchimokuTenkanPeriod:  params.IchimokuTenkanPeriod(),
+		IchimokuKijunPeriod:   params.IchimokuKijunPeriod(),
+		IchimokuSenkouBPeriod: params.IchimokuSenkouBPeriod(),
+		RSIEnable:             params.RSIEnable(),
+		RSIPeriod:             params.RSIPeriod(),
+		RSIBuyThread:          params.RSIBuyThread(),
+		RSISellThread:         params.RSISellThread(),
+		MACDEnable:            params.MACDEnable(),
+		MACDFastPeriod:        params.MACDFastPeriod(),
+		MACDSlowPeriod:        params.MACDSlowPeriod(),
+		MACDSignalPeriod:      params.MACDSignalPeriod(),
+		ATREnable:             params.ATREnable(),
+		ATRPeriod:             params.ATRPeriod(),
+		ATRMultiplier:         params.ATRMultiplier(),
+		StochEnable:           params.StochEnable(),
+		StochFastKPeriod:      params.StochFastKPeriod(),
+		StochSlowKPeriod:      params.StochSlowKPeriod(),
+		StochSlowDPeriod:      params.StochSlowDPeriod(),
+		StochBuyThread:        params.StochBuyThread(),
+		StochSellThread:       params.StochSellThread(),
+		ADXEnable:             params.ADXEnable(),
+		ADXPeriod:             params.ADXPeriod(),
+		ADXThread:             params.ADXThread(),
+		OBVEnable:             params.OBVEnable(),
+		OBVPeriod:             params.OBVPeriod(),
+		VWAPEnable:            params.VWAPEnable(),
+		VWAPPeriod:            params.VWAPPeriod(),
+		SAREnable:             params.SAREnable(),
+		SARAcceleration:       params.SARAcceleration(),
+		SARMaximum:            params.SARMaximum(),
+		DonchianEnable:        params.DonchianEnable(),
+		DonchianPeriod:        params.DonchianPeriod(),
+		KeltnerEnable:         params.KeltnerEnable(),
+		KeltnerPeriod:         params.KeltnerPeriod(),
+		KeltnerMultiplier:     params.KeltnerMultiplier(),
+		HeikinAshiEnable:      params.HeikinAshiEnable(),
+		HeikinAshiPeriod:      params.HeikinAshiPeriod(),
+		SignalWeights:         newBacktestSignalWeights(params.SignalWeights()),
+		BuyVoteThreshold:      params.BuyVoteThreshold(),
+		SellVoteThreshold:     params.SellVoteThreshold(),
+		StopLimitPercent:      params.StopLimitPercent(),
+		MaxLots:               params.MaxLots(),
+		LotMatching:           params.LotMatching(),
+	}
+}
+
+func (p backtestParams) toDomainModelTradeParams() *model.TradeParams {
+	// 重み付き投票の導入前に保存されたジョブは，全ての重みを1，閾値を2とみなす
+	signalWeights := model.NewUniformSignalWeights()
+	if p.SignalWeights != nil {
+		signalWeights = p.SignalWeights.toDomainModelSignalWeights()
+	}
+	if signalWeights == nil {
+		return nil
+	}
+	buyVoteThreshold, sellVoteThreshold := p.BuyVoteThreshold, p.SellVoteThreshold
+	if buyVoteThreshold == 0 {
+		buyVoteThreshold = 2
+	}
+	if sellVoteThreshold == 0 {
+		sellVoteThreshold = 2
+	}
+	// ロットの導入前に保存されたジョブは，1ロットずつ売買したものとみなす
+	maxLots, lotMatching := p.MaxLots, p.LotMatching
+	if maxLots == 0 {
+		maxLots = 1
+	}
+	if lotMatching == "" {
+		lotMatching = model.LotMatchingFIFO
+	}
+
+	return model.NewTradeParams(
+		p.TradeEnable,
+		p.ProductCode,
+		p.Size,
+		p.SMAEnable,
+		p.SMAPeriod1,
+		p.SMAPeriod2,
+		p.SMAPeriod3,
+		p.EMAEnable,
+		p.EMAPeriod1,
+		p.EMAPeriod2,
+		p.EMAPeriod3,
+		p.BBandsEnable,
+		p.BBandsN,
+		p.BBandsK,
+		p.IchimokuEnable,
+		p.IchimokuTenkanPeriod,
+		p.IchimokuKijunPeriod,
+		p.IchimokuSenkouBPeriod,
+		p.RSIEnable,
+		p.RSIPeriod,
+		p.RSIBuyThread,
+		p.RSISellThread,
+		p.MACDEnable,
+		p.MACDFastPeriod,
+		p.MACDSlowPeriod,
+		p.MACDSignalPeriod,
+		p.ATREnable,
+		p.ATRPeriod,
+		p.ATRMultiplier,
+		p.StochEnable,
+		p.StochFastKPeriod,
+		p.StochSlowKPeriod,
+		p.StochSlowDPeriod,
+		p.StochBuyThread,
+		p.StochSellThread,
+		p.ADXEnable,
+		p.ADXPeriod,
+		p.ADXThread,
+		p.OBVEnable,
+		p.OBVPeriod,
+		p.VWAPEnable,
+		p.VWAPPeriod,
+		p.SAREnable,
+		p.SARAcceleration,
+		p.SARMaximum,
+		p.DonchianEnable,
+		p.DonchianPeriod,
+		p.KeltnerEnable,
+		p.KeltnerPeriod,
+		p.KeltnerMultiplier,
+		p.HeikinAshiEnable,
+		p.HeikinAshiPeriod,
+		*signalWeights,
+		buyVoteThreshold,
+		sellVoteThreshold,
+		p.StopLimitPercent,
+		maxLots,
+		lotMatching,
+	)
+}
+
+type backtestSignalWeights struct {
+	EMA        float64 `json:"ema"`
+	BBands     float64 `json:"bbands"`
+	Ichimoku   float64 `json:"ichimoku"`
+	RSI        float64 `json:"rsi"`
+	MACD       float64 `json:"macd"`
+	ATR        float64 `json:"atr"`
+	Stoch      float64 `json:"stoch"`
+	ADX        float64 `json:"adx"`
+	OBV        float64 `json:"obv"`
+	VWAP       float64 `json:"vwap"`
+	SAR        float64 `json:"sar"`
+	Donchian   float64 `json:"donchian"`
+	Keltner    float64 `json:"keltner"`
+	HeikinAshi float64 `json:"heikinAshi"`
+}
+
+func newBacktestSignalWeights(weights model.SignalWeights) *backtestSignalWeights {
+	return &backtestSignalWeights{
+		EMA:        weights.EMA(),
+		BBands:     weights.BBands(),
+		Ichimoku:   weights.Ichimoku(),
+		RSI:        weights.RSI(),
+		MACD:       weights.MACD(),
+		ATR:        weights.ATR(),
+		Stoch:      weights.Stoch(),
+		ADX:        weights.ADX(),
+		OBV:        weights.OBV(),
+		VWAP:       weights.VWAP(),
+		SAR:        weights.SAR(),
+		Donchian:   weights.Donchian(),
+		Keltner:    weights.Keltner(),
+		HeikinAshi: weights.HeikinAshi(),
+	}
+}
+
+func (w backtestSignalWeights) toDomainModelSignalWeights() *model.SignalWeights {
+	return model.NewSignalWeights(
+		w.EMA,
+		w.BBands,
+		w.Ichimoku,
+		w.RSI,
+		w.MACD,
+		w.ATR,
+		w.Stoch,
+		w.ADX,
+		w.OBV,
+		w.VWAP,
+		w.SAR,
+		w.Donchian,
+		w.Keltner,
+		w.HeikinAshi,
+	)
+}
+
+type backtestSignal struct {
+	Time  time.Time       `json:"time"`
+	Side  model.OrderSide `json:"side"`
+	Price float64         `json:"price"`
+	Size  float64         `json:"size"`
+}
+
+type backtestEquityPoint struct {
+	Time   time.Time `json:"time"`
+	Equity float64   `json:"equity"`
+}
+
+type backtestResult struct {
+	Signals     []backtestSignal      `json:"signals"`
+	Profit      float64               `json:"profit"`
+	TradeCount  int                   `json:"tradeCount"`
+	WinCount    int                   `json:"winCount"`
+	MaxDrawdown float64               `json:"maxDrawdown"`
+	EquityCurve []backtestEquityPoint `json:"equityCurve"`
+}
+
+func newBacktestResult(result model.BacktestResult) backtestResult {
+	signals := make([]backtestSignal, 0)
+	for _, signal := range result.Signals() {
+		signals = append(signals, backtestSignal{
+			Time:  signal.Time(),
+			Side:  signal.Side(),
+			Price: signal.Price(),
+			Size:  signal.Size(),
+		})
+	}
+
+	equityCurve := make([]backtestEquityPoint, 0)
+	for _, point := range result.EquityCurve() {
+		equityCurve = append(equityCurve, backtestEquityPoint{
+			Time:   point.Time(),
+			Equity: point.Equity(),
+		})
+	}
+
+	return backtestResult{
+		Signals:     signals,
+		Profit:      result.Profit(),
+		TradeCount:  result.TradeCount(),
+		WinCount:    result.WinCount(),
+		MaxDrawdown: result.MaxDrawdown(),
+		EquityCurve: equityCurve,
+	}
+}
+
+func (r backtestResult) toDomainModelBacktestResult(productCode string) *model.BacktestResult {
+	signals := make([]model.SignalEvent, 0)
+	for _, s := range r.Signals {
+		signal := model.NewSignalEvent(s.Time, productCode, s.Side, s.Price, s.Size)
+		if signal == nil {
+			return nil
+		}
+		signals = append(signals, *signal)
+	}
+
+	equityCurve := make([]model.EquityPoint, 0)
+	for _, p := range r.EquityCurve {
+		equityCurve = append(equityCurve, model.NewEquityPoint(p.Time, p.Equity))
+	}
+
+	return model.RestoreBacktestResult(signals, r.Profit, r.TradeCount, r.WinCount, r.MaxDrawdown, equityCurve)
+}
+
+func (br *backtestJobRepository) Save(job model.BacktestJob) error {
+	params, err := json.Marshal(newBacktestParams(job.Params()))
+	if err != nil {
+		return err
+	}
+
+	var result []byte
+	if job.Result() != nil {
+		result, err = json.Marshal(newBacktestResult(*job.Result()))
+		if err != nil {
+			return err
+		}
+	}
+
+	var finishedAt string
+	if !job.FinishedAt().IsZero() {
+		finishedAt = job.FinishedAt().Format(br.timeFormat)
+	}
+
+	cmd := `
+        INSERT INTO backtest_jobs
+            (id, product_code, strategy, params, start_time, end_time, status, error_message, created_at, finished_at, result)
+        VALUES
+            (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+        ON CONFLICT(id) DO UPDATE SET
+            status = excluded.status,
+            error_message = excluded.error_message,
+            finished_at = excluded.finished_at,
+            result = excluded.result
+        `
+	_, err = br.db.Exec(cmd,
+		job.ID(),
+		job.ProductCode(),
+		job.Strategy(),
+		string(params),
+		job.Start().Format(br.timeFormat),
+		job.End().Format(br.timeFormat),
+		job.Status(),
+		job.ErrorMessage(),
+		job.CreatedAt().Format(br.timeFormat),
+		finishedAt,
+		string(result),
+	)
+	return err
+}
+
+type backtestJobScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (br *backtestJobRepository) scan(row backtestJobScanner) (*model.BacktestJob, error) {
+	var id, productCode, strategy, paramsStr string
+	var startStr, endStr string
+	var status model.BacktestJobStatus
+	var errorMessage, createdAtStr, finishedAtStr, resultStr string
+	err := row.Scan(&id, &productCode, &strategy, &paramsStr, &startStr, &endStr, &status, &errorMessage, &createdAtStr, &finishedAtStr, &resultStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// for sqlite: convert string to time.Time
+	start, err := time.Parse(br.timeFormat, startStr)
+	if err != nil {
+		return nil, err
+	}
+	end, err := time.Parse(br.timeFormat, endStr)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, err := time.Parse(br.timeFormat, createdAtStr)
+	if err != nil {
+		return nil, err
+	}
+	var finishedAt time.Time
+	if finishedAtStr != "" {
+		finishedAt, err = time.Parse(br.timeFormat, finishedAtStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var p backtestParams
+	if err := json.Unmarshal([]byte(paramsStr), &p); err != nil {
+		return nil, err
+	}
+	params := p.toDomainModelTradeParams()
+	if params == nil {
+		return nil, errors.New(fmt.Sprint("invalid backtest_job params:", id, paramsStr))
+	}
+
+	var result *model.BacktestResult
+	if resultStr != "" {
+		var r backtestResult
+		if err := json.Unmarshal([]byte(resultStr), &r); err != nil {
+			return nil, err
+		}
+		result = r.toDomainModelBacktestResult(productCode)
+		if result == nil {
+			return nil, errors.New(fmt.Sprint("invalid backtest_job result:", id))
+		}
+	}
+
+	job := model.RestoreBacktestJob(id, strategy, *params, start, end, status, errorMessage, createdAt, finishedAt, result)
+	if job == nil {
+		return nil, errors.New(fmt.Sprint("invalid backtest_job:", id, strategy, start, end, status))
+	}
+
+	return job, nil
+}
+
+func (br *backtestJobRepository) Find(id string) (*model.BacktestJob, error) {
+	cmd := `
+        SELECT
+            id, product_code, strategy, params, start_time, end_time, status, error_message, created_at, finished_at, result
+        FROM
+            backtest_jobs
+        WHERE
+            id = ?
+        `
+	row := br.db.QueryRow(cmd, id)
+
+	job, err := br.scan(row)
+	// 発見できなかったらそのままnilを返す
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return job, err
+}
+
+func (br *backtestJobRepository) FindAll(productCode string, limit int64) ([]model.BacktestJob, error) {
+	cmd := `
+        SELECT
+            id, product_code, strategy, params, start_time, end_time, status, error_message, created_at, finished_at, result
+        FROM
+            backtest_jobs
+        WHERE
+            product_code = ?
+        ORDER BY
+            created_at DESC
+        LIMIT ?
+        `
+	rows, err := br.db.Query(cmd, productCode, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := make([]model.BacktestJob, 0)
+	for rows.Next() {
+		job, err := br.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (br *backtestJobRepository) FindUnfinished() ([]model.BacktestJob, error) {
+	cmd := `
+        SELECT
+            id, product_code, strategy, params, start_time, end_time, status, error_message, created_at, finished_at, result
+        FROM
+            backtest_jobs
+        WHERE
+            status IN (?, ?)
+        ORDER BY
+            created_at ASC
+        `
+	rows, err := br.db.Query(cmd, model.BacktestJobStatusQueued, model.BacktestJobStatusRunning)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := make([]model.BacktestJob, 0)
+	for rows.Next() {
+		job, err := br.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
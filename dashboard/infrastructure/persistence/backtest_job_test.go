@@ -0,0 +1,119 @@
+package persistence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+)
+
+func TestBacktestJob(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	backtestJobRepository := persistence.NewBacktestJobRepository(tx, config.TimeFormat)
+
+	params := model.NewBasicTradeParams(config.ProductCode, 0.01)
+	start := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2100, 1, 5, 0, 0, 0, 0, time.UTC)
+	job := model.NewBacktestJob("test-job", "default", *params, start, end, start)
+
+	t.Run("save queued job", func(t *testing.T) {
+		err := backtestJobRepository.Save(*job)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		found, err := backtestJobRepository.Find(job.ID())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if found == nil {
+			t.Fatal("Find() returns nil")
+		}
+		if found.Status() != model.BacktestJobStatusQueued {
+			t.Fatalf("%s != %s", found.Status(), model.BacktestJobStatusQueued)
+		}
+		if found.Params() != *params {
+			t.Fatalf("%+v != %+v", found.Params(), *params)
+		}
+	})
+
+	t.Run("save finished job", func(t *testing.T) {
+		candle := model.NewCandle(config.ProductCode, config.CandleDuration, model.NewCandleTime(start), 100, 100, 100, 100, 0)
+		events := model.NewSignalEvents(make([]model.SignalEvent, 0))
+		events.AddBuySignal(*model.NewSignalEvent(start, config.ProductCode, model.OrderSideBuy, 100, 0.01))
+		result := model.NewBacktestResult([]model.Candle{*candle}, events)
+
+		job.Run()
+		job.Complete(result, end)
+		err := backtestJobRepository.Save(*job)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		found, err := backtestJobRepository.Find(job.ID())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if found.Status() != model.BacktestJobStatusDone {
+			t.Fatalf("%s != %s", found.Status(), model.BacktestJobStatusDone)
+		}
+		if found.Result() == nil {
+			t.Fatal("Result() returns nil")
+		}
+		if len(found.Result().Signals()) != 1 {
+			t.Fatalf("%d != %d", len(found.Result().Signals()), 1)
+		}
+		if len(found.Result().EquityCurve()) != 1 {
+			t.Fatalf("%d != %d", len(found.Result().EquityCurve()), 1)
+		}
+	})
+
+	t.Run("find not exist job", func(t *testing.T) {
+		found, err := backtestJobRepository.Find("not-exist")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if found != nil {
+			t.Fatal("Find() returns not nil")
+		}
+	})
+
+	t.Run("find all jobs", func(t *testing.T) {
+		jobs, err := backtestJobRepository.FindAll(config.ProductCode, 10)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(jobs) < 1 {
+			t.Fatal("len(jobs) < 1")
+		}
+	})
+
+	t.Run("find unfinished jobs", func(t *testing.T) {
+		queued := model.NewBacktestJob("test-queued-job", "default", *params, start, end, start)
+		err := backtestJobRepository.Save(*queued)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		jobs, err := backtestJobRepository.FindUnfinished()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		found := false
+		for _, j := range jobs {
+			if j.Status().IsFinished() {
+				t.Fatalf("finished job is found: %s", j.ID())
+			}
+			if j.ID() == queued.ID() {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatal("queued job is not found")
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"os"
+	"sort"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/repository"
+)
+
+// CSVまたはParquetファイルから読み込んだキャンドルを扱う
+// ファイルには1つのproductCode, durationのキャンドルだけが入っている前提
+// Saveはメモリ上のデータを更新するだけで，ファイルには書き戻さない
+type candleFileRepository struct {
+	productCode string
+	duration    time.Duration
+	candles     []model.Candle
+}
+
+func NewCandleFileRepository(filePath, timeFormat, productCode string, duration time.Duration) repository.CandleRepository {
+	format, err := FileFormatFromPath(filePath)
+	if err != nil {
+		return nil
+	}
+
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return nil
+	}
+	defer fp.Close()
+
+	candles, err := ReadCandles(fp, format, productCode, duration, timeFormat)
+	if err != nil {
+		return nil
+	}
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Time().Time().Before(candles[j].Time().Time())
+	})
+
+	return &candleFileRepository{
+		productCode: productCode,
+		duration:    duration,
+		candles:     candles,
+	}
+}
+
+func (cr *candleFileRepository) Save(candle model.Candle) error {
+	for i := range cr.candles {
+		if cr.candles[i].Time().Equal(candle.Time()) {
+			cr.candles[i] = candle
+			return nil
+		}
+	}
+
+	cr.candles = append(cr.candles, candle)
+	sort.Slice(cr.candles, func(i, j int) bool {
+		timeBefore := cr.candles[i].Time().Time()
+		timeAfter := cr.candles[j].Time().Time()
+		return timeBefore.Before(timeAfter)
+	})
+
+	return nil
+}
+
+func (cr *candleFileRepository) FindByCandleTime(productCode string, duration time.Duration, timeTime model.CandleTime) (*model.Candle, error) {
+	for _, candle := range cr.candles {
+		if candle.Time().Equal(timeTime) {
+			return &candle, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (cr *candleFileRepository) FindAll(productCode string, duration time.Duration, limit int64) ([]model.Candle, error) {
+	if limit < 0 {
+		return cr.candles, nil
+	}
+
+	if lenCandles := int64(len(cr.candles)); lenCandles > limit {
+		return cr.candles[lenCandles-limit:], nil
+	}
+	return cr.candles, nil
+}
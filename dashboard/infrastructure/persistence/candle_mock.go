@@ -2,17 +2,15 @@ package persistence
 
 import (
 	"context"
-	"encoding/csv"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
-	"sort"
-	"strconv"
+	"path/filepath"
+	"runtime"
 	"time"
 
 	"cloud.google.com/go/storage"
-	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/repository"
 	"google.golang.org/api/option"
 )
@@ -20,35 +18,46 @@ import (
 // モックデータ
 // というよりは，本番DBからエクスポートされた価格データファイルを取得する
 // candleTableNameも固定するのでproductCodeとdurationも固定することにする
-type candleMockRepository struct {
-	candleTableName string
-	timeFormat      string
-	productCode     string
-	duration        time.Duration
-	candles         []model.Candle
-}
-
+// CANDLE_FILEにCSVまたはParquetファイルのパスを指定すると，そのファイルを使う
+// 指定しなければ，GCS_BUCKETを指定したときはGCSから取得し，どちらもなければtestdataのファイルを使う
+// 読み込めなかったときは理由を出力してnilを返す
 func NewCandleMockRepository(candleTableName, timeFormat, productCode string, duration time.Duration) repository.CandleRepository {
 	if candleTableName == "" {
 		return nil
 	}
 
-	cr := &candleMockRepository{
-		candleTableName: candleTableName,
-		timeFormat:      timeFormat,
-		productCode:     productCode,
-		duration:        duration,
+	if productCode == "" {
+		fmt.Println("[CandleMock] PRODUCT_CODE is not set")
+		return nil
 	}
 
-	candles, err := cr.fetchAll(productCode, duration)
-	if err != nil {
+	filePath := CANDLE_FILE
+	if filePath == "" {
+		if GCS_BUCKET != "" {
+			filePath = fetchMockData(candleTableName)
+		} else {
+			filePath = testDataFile(candleTableName)
+		}
+	}
+	if !exists(filePath) {
+		fmt.Printf("[CandleMock] candle file %s is not found (set CANDLE_FILE)\n", filePath)
 		return nil
 	}
-	cr.candles = candles
 
+	cr := NewCandleFileRepository(filePath, timeFormat, productCode, duration)
+	if cr == nil {
+		fmt.Printf("[CandleMock] failed to read candles of %s from %s\n", productCode, filePath)
+	}
 	return cr
 }
 
+// リポジトリに含めたテスト用のファイル
+// テストは各パッケージのディレクトリで実行されるので，このファイルの位置から探す
+func testDataFile(candleTableName string) string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "testdata", candleTableName+".csv")
+}
+
 const (
 	dataDir = "/mock_data"
 	saKey   = "/sa_key"
@@ -57,109 +66,23 @@ const (
 var (
 	MYSQL_DATABASE = os.Getenv("MYSQL_DATABASE")
 	GCS_BUCKET     = os.Getenv("GCS_BUCKET")
+	CANDLE_FILE    = os.Getenv("CANDLE_FILE")
 )
 
-func (cr *candleMockRepository) Save(candle model.Candle) error {
-	for i, candle := range cr.candles {
-		if candle.Time().Equal(candle.Time()) {
-			cr.candles[i] = candle
-			return nil
-		}
-	}
-
-	cr.candles = append(cr.candles, candle)
-	sort.Slice(cr.candles, func(i, j int) bool {
-		timeBefore := cr.candles[i].Time().Time()
-		timeAfter := cr.candles[j].Time().Time()
-		return timeBefore.Before(timeAfter)
-	})
-
-	return nil
-}
-
-func (cr *candleMockRepository) FindByCandleTime(productCode string, duration time.Duration, timeTime model.CandleTime) (*model.Candle, error) {
-	for _, candle := range cr.candles {
-		if candle.Time().Equal(timeTime) {
-			return &candle, nil
-		}
-	}
-
-	return nil, nil
-}
-
-func (cr *candleMockRepository) FindAll(productCode string, duration time.Duration, limit int64) ([]model.Candle, error) {
-	if limit < 0 {
-		return cr.candles, nil
-	}
-
-	if lenCandles := int64(len(cr.candles)); lenCandles > limit {
-		return cr.candles[lenCandles-limit:], nil
-	}
-	return cr.candles, nil
-}
-
-func (cr *candleMockRepository) fetchAll(productCode string, duration time.Duration) ([]model.Candle, error) {
+// エクスポートされたファイルがなければGCSからダウンロードして，そのパスを返す
+func fetchMockData(candleTableName string) string {
 	dirPath := path.Join(dataDir, GCS_BUCKET)
 	if !exists(dirPath) {
 		os.MkdirAll(dirPath, 0777)
 	}
-	objectName := fmt.Sprintf("%s.%s.csv", MYSQL_DATABASE, cr.candleTableName)
+	objectName := fmt.Sprintf("%s.%s.csv", MYSQL_DATABASE, candleTableName)
 	filePath := path.Join(dirPath, objectName)
 	if !exists(filePath) {
-		downloadGCSObject(GCS_BUCKET, objectName, filePath)
-	}
-
-	fp, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer fp.Close()
-
-	reader := csv.NewReader(fp)
-	candles := make([]model.Candle, 0)
-	for {
-		// time, open, close, high, low, volume
-		line, err := reader.Read()
-		if err != nil {
-			break
-		}
-
-		time, err := time.Parse(cr.timeFormat, line[0])
-		if err != nil {
-			return nil, err
-		}
-
-		open, err := strconv.ParseFloat(line[1], 64)
-		if err != nil {
-			return nil, err
-		}
-
-		close, err := strconv.ParseFloat(line[2], 64)
-		if err != nil {
-			return nil, err
+		if err := downloadGCSObject(GCS_BUCKET, objectName, filePath); err != nil {
+			fmt.Println("[CandleMock]", err)
 		}
-
-		high, err := strconv.ParseFloat(line[3], 64)
-		if err != nil {
-			return nil, err
-		}
-
-		low, err := strconv.ParseFloat(line[4], 64)
-		if err != nil {
-			return nil, err
-		}
-
-		volume, err := strconv.ParseFloat(line[5], 64)
-		if err != nil {
-			return nil, err
-		}
-
-		candleTime := model.NewCandleTime(time)
-		candle := model.NewCandle(productCode, duration, candleTime, open, close, high, low, volume)
-		candles = append(candles, *candle)
 	}
-
-	return candles, nil
+	return filePath
 }
 
 func exists(fileName string) bool {
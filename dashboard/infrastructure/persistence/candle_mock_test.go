@@ -9,6 +9,9 @@ import (
 
 func TestCandleMock(t *testing.T) {
 	cr := persistence.NewCandleMockRepository(config.CandleTableName, config.TimeFormat, config.ProductCode, config.CandleDuration)
+	if cr == nil {
+		t.Fatal("NewCandleMockRepository() returns nil")
+	}
 
 	t.Run("find all candle", func(t *testing.T) {
 		candles, err := cr.FindAll(config.ProductCode, config.CandleDuration, 10)
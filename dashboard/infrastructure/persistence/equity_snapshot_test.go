@@ -0,0 +1,52 @@
+package persistence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+)
+
+func TestEquitySnapshot(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	equitySnapshotRepository := persistence.NewEquitySnapshotRepository(tx, config.TimeFormat)
+
+	// 日時は2100年1月1日以降かつ昇順
+	snapshots := []model.EquitySnapshot{
+		*model.NewEquitySnapshot(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), config.ProductCode, 10000, 0, 0, 0, 1000),
+		*model.NewEquitySnapshot(time.Date(2100, 1, 2, 0, 0, 0, 0, time.UTC), config.ProductCode, 12000, 500, 1500, 1, 2500),
+	}
+
+	t.Run("save equity_snapshot", func(t *testing.T) {
+		for _, snapshot := range snapshots {
+			err := equitySnapshotRepository.Save(snapshot)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+	})
+
+	t.Run("overwrite equity_snapshot", func(t *testing.T) {
+		err := equitySnapshotRepository.Save(snapshots[1])
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+
+	t.Run("find all equity_snapshot", func(t *testing.T) {
+		ss, err := equitySnapshotRepository.FindAll(config.ProductCode, int64(len(snapshots)))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(ss) != len(snapshots) {
+			t.Fatalf("%d != %d", len(ss), len(snapshots))
+		}
+		if ss[len(ss)-1] != snapshots[len(snapshots)-1] {
+			t.Fatalf("%+v != %+v", ss[len(ss)-1], snapshots[len(snapshots)-1])
+		}
+	})
+}
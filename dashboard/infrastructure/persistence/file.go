@@ -0,0 +1,291 @@
+package persistence
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence/parquet"
+)
+
+// エクスポート・インポートするファイルの形式
+type FileFormat string
+
+const (
+	FileFormatCSV     = FileFormat("csv")
+	FileFormatParquet = FileFormat("parquet")
+)
+
+// 拡張子からファイル形式を判定する
+func FileFormatFromPath(filePath string) (FileFormat, error) {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".csv":
+		return FileFormatCSV, nil
+	case ".parquet":
+		return FileFormatParquet, nil
+	}
+	return "", errors.New(fmt.Sprint("unknown file format:", filePath))
+}
+
+// CSVはCloud SQLのエクスポートと同じく，ヘッダなしで
+// time, open, close, high, low, volume の順に並べる
+func WriteCandles(w io.Writer, format FileFormat, candles []model.Candle, timeFormat string) error {
+	switch format {
+	case FileFormatCSV:
+		writer := csv.NewWriter(w)
+		for _, candle := range candles {
+			err := writer.Write([]string{
+				candle.Time().Format(timeFormat),
+				formatFloat(candle.Open()),
+				formatFloat(candle.Close()),
+				formatFloat(candle.High()),
+				formatFloat(candle.Low()),
+				formatFloat(candle.Volume()),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	case FileFormatParquet:
+		columns := []parquet.Column{
+			{Name: "time", Type: parquet.TypeTimestamp},
+			{Name: "open", Type: parquet.TypeDouble},
+			{Name: "close", Type: parquet.TypeDouble},
+			{Name: "high", Type: parquet.TypeDouble},
+			{Name: "low", Type: parquet.TypeDouble},
+			{Name: "volume", Type: parquet.TypeDouble},
+		}
+		for _, candle := range candles {
+			columns[0].Values = append(columns[0].Values, candle.Time().Time())
+			columns[1].Values = append(columns[1].Values, candle.Open())
+			columns[2].Values = append(columns[2].Values, candle.Close())
+			columns[3].Values = append(columns[3].Values, candle.High())
+			columns[4].Values = append(columns[4].Values, candle.Low())
+			columns[5].Values = append(columns[5].Values, candle.Volume())
+		}
+		return parquet.Write(w, columns)
+	}
+	return errors.New(fmt.Sprint("unknown file format:", format))
+}
+
+func ReadCandles(r io.Reader, format FileFormat, productCode string, duration time.Duration, timeFormat string) ([]model.Candle, error) {
+	var rows [][]interface{}
+	var err error
+	switch format {
+	case FileFormatCSV:
+		rows, err = readCSVRows(r, timeFormat, 6, 0)
+	case FileFormatParquet:
+		rows, err = readParquetRows(r, "time", "open", "close", "high", "low", "volume")
+	default:
+		err = errors.New(fmt.Sprint("unknown file format:", format))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]model.Candle, 0, len(rows))
+	for _, row := range rows {
+		timeTime, ok1 := row[0].(time.Time)
+		open, ok2 := toFloat(row[1])
+		close, ok3 := toFloat(row[2])
+		high, ok4 := toFloat(row[3])
+		low, ok5 := toFloat(row[4])
+		volume, ok6 := toFloat(row[5])
+		if !(ok1 && ok2 && ok3 && ok4 && ok5 && ok6) {
+			return nil, errors.New(fmt.Sprint("invalid candle row:", row))
+		}
+
+		candle := model.NewCandle(productCode, duration, model.NewCandleTime(timeTime), open, close, high, low, volume)
+		if candle == nil {
+			return nil, errors.New(fmt.Sprint("invalid candle:", row))
+		}
+		candles = append(candles, *candle)
+	}
+
+	return candles, nil
+}
+
+// CSVはsignal_eventsテーブルと同じく，ヘッダなしで
+// time, product_code, side, price, size, tag の順に並べる
+func WriteSignalEvents(w io.Writer, format FileFormat, signalEvents []model.SignalEvent, timeFormat string) error {
+	switch format {
+	case FileFormatCSV:
+		writer := csv.NewWriter(w)
+		for _, signal := range signalEvents {
+			err := writer.Write([]string{
+				signal.Time().Format(timeFormat),
+				signal.ProductCode(),
+				string(signal.Side()),
+				formatFloat(signal.Price()),
+				formatFloat(signal.Size()),
+				string(signal.Tag()),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	case FileFormatParquet:
+		columns := []parquet.Column{
+			{Name: "time", Type: parquet.TypeTimestamp},
+			{Name: "product_code", Type: parquet.TypeString},
+			{Name: "side", Type: parquet.TypeString},
+			{Name: "price", Type: parquet.TypeDouble},
+			{Name: "size", Type: parquet.TypeDouble},
+			{Name: "tag", Type: parquet.TypeString},
+		}
+		for _, signal := range signalEvents {
+			columns[0].Values = append(columns[0].Values, signal.Time())
+			columns[1].Values = append(columns[1].Values, signal.ProductCode())
+			columns[2].Values = append(columns[2].Values, string(signal.Side()))
+			columns[3].Values = append(columns[3].Values, signal.Price())
+			columns[4].Values = append(columns[4].Values, signal.Size())
+			columns[5].Values = append(columns[5].Values, string(signal.Tag()))
+		}
+		return parquet.Write(w, columns)
+	}
+	return errors.New(fmt.Sprint("unknown file format:", format))
+}
+
+func ReadSignalEvents(r io.Reader, format FileFormat, timeFormat string) ([]model.SignalEvent, error) {
+	var rows [][]interface{}
+	var err error
+	switch format {
+	case FileFormatCSV:
+		// product_code, side, tagは文字列のまま
+		rows, err = readCSVRows(r, timeFormat, 6, 1, 2, 5)
+	case FileFormatParquet:
+		rows, err = readParquetRows(r, "time", "product_code", "side", "price", "size", "tag")
+	default:
+		err = errors.New(fmt.Sprint("unknown file format:", format))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signalEvents := make([]model.SignalEvent, 0, len(rows))
+	for _, row := range rows {
+		timeTime, ok1 := row[0].(time.Time)
+		productCode, ok2 := row[1].(string)
+		side, ok3 := row[2].(string)
+		price, ok4 := toFloat(row[3])
+		size, ok5 := toFloat(row[4])
+		tag, ok6 := row[5].(string)
+		if !(ok1 && ok2 && ok3 && ok4 && ok5 && ok6) {
+			return nil, errors.New(fmt.Sprint("invalid signal_event row:", row))
+		}
+
+		signal := model.NewSignalEventWithTag(timeTime, productCode, model.OrderSide(side), price, size, model.SignalEventTag(tag))
+		if signal == nil {
+			return nil, errors.New(fmt.Sprint("invalid signal_event:", row))
+		}
+		signalEvents = append(signalEvents, *signal)
+	}
+
+	return signalEvents, nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch f := v.(type) {
+	case float64:
+		return f, true
+	case int64:
+		return float64(f), true
+	}
+	return 0, false
+}
+
+// 1列目を時刻，stringColumnsに指定した列を文字列，それ以外を数値として読む
+func readCSVRows(r io.Reader, timeFormat string, numColumns int, stringColumns ...int) ([][]interface{}, error) {
+	isString := make(map[int]bool)
+	for _, i := range stringColumns {
+		isString[i] = true
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = numColumns
+	rows := make([][]interface{}, 0)
+	for {
+		line, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		timeTime, err := time.Parse(timeFormat, line[0])
+		if err != nil {
+			return nil, err
+		}
+
+		row := []interface{}{timeTime}
+		for i := 1; i < numColumns; i++ {
+			if isString[i] {
+				row = append(row, line[i])
+				continue
+			}
+			f, err := strconv.ParseFloat(line[i], 64)
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, f)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// 指定した列を行ごとに並べ替えて返す
+func readParquetRows(r io.Reader, names ...string) ([][]interface{}, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := parquet.Read(data)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]parquet.Column)
+	for _, column := range columns {
+		byName[column.Name] = column
+	}
+
+	selected := make([]parquet.Column, 0, len(names))
+	for _, name := range names {
+		column, ok := byName[name]
+		if !ok {
+			return nil, errors.New(fmt.Sprint("column not found:", name))
+		}
+		selected = append(selected, column)
+	}
+
+	numRows := len(selected[0].Values)
+	rows := make([][]interface{}, 0, numRows)
+	for i := 0; i < numRows; i++ {
+		row := make([]interface{}, 0, len(selected))
+		for _, column := range selected {
+			row = append(row, column.Values[i])
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,103 @@
+package persistence_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+)
+
+func TestFileFormatFromPath(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected persistence.FileFormat
+		isErr    bool
+	}{
+		{"candles.csv", persistence.FileFormatCSV, false},
+		{"/tmp/candles.PARQUET", persistence.FileFormatParquet, false},
+		{"candles.json", "", true},
+	}
+
+	for _, c := range cases {
+		format, err := persistence.FileFormatFromPath(c.path)
+		if (err != nil) != c.isErr {
+			t.Fatalf("%s: %v", c.path, err)
+		}
+		if format != c.expected {
+			t.Fatalf("%s != %s", format, c.expected)
+		}
+	}
+}
+
+func TestCandleFile(t *testing.T) {
+	start := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+	candles := make([]model.Candle, 0)
+	for i := 0; i < 20; i++ {
+		candleTime := model.NewCandleTime(start.AddDate(0, 0, i))
+		price := 500000.5 + float64(i)
+		candles = append(candles, *model.NewCandle(config.ProductCode, config.CandleDuration, candleTime, price, price+1, price+2, price-1, 123.456))
+	}
+
+	for _, format := range []persistence.FileFormat{persistence.FileFormatCSV, persistence.FileFormatParquet} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			err := persistence.WriteCandles(&buf, format, candles, config.TimeFormat)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			read, err := persistence.ReadCandles(&buf, format, config.ProductCode, config.CandleDuration, config.TimeFormat)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if len(read) != len(candles) {
+				t.Fatalf("%d != %d", len(read), len(candles))
+			}
+			for i := range candles {
+				if read[i] != candles[i] {
+					t.Fatalf("%v != %v", read[i], candles[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSignalEventFile(t *testing.T) {
+	start := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+	signalEvents := []model.SignalEvent{
+		*model.NewSignalEvent(start, config.ProductCode, model.OrderSideBuy, 500000, 0.01),
+		*model.NewSignalEvent(start.AddDate(0, 0, 3), config.ProductCode, model.OrderSideSell, 520000.5, 0.01),
+		*model.NewSignalEventWithTag(start.AddDate(0, 0, 4), config.ProductCode, model.OrderSideBuy, 510000, 0.02, model.SignalEventTagDCA),
+	}
+
+	for _, format := range []persistence.FileFormat{persistence.FileFormatCSV, persistence.FileFormatParquet} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			err := persistence.WriteSignalEvents(&buf, format, signalEvents, config.TimeFormat)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			read, err := persistence.ReadSignalEvents(&buf, format, config.TimeFormat)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if len(read) != len(signalEvents) {
+				t.Fatalf("%d != %d", len(read), len(signalEvents))
+			}
+			for i := range signalEvents {
+				if !read[i].Time().Equal(signalEvents[i].Time()) ||
+					read[i].ProductCode() != signalEvents[i].ProductCode() ||
+					read[i].Side() != signalEvents[i].Side() ||
+					read[i].Price() != signalEvents[i].Price() ||
+					read[i].Size() != signalEvents[i].Size() ||
+					read[i].Tag() != signalEvents[i].Tag() {
+					t.Fatalf("%v != %v", read[i], signalEvents[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence/parquet"
+)
+
+func testColumns() []parquet.Column {
+	now := time.Date(2021, 11, 9, 0, 0, 0, 0, time.UTC)
+	// リストの要素数が15以上になる場合も確認する
+	columns := []parquet.Column{
+		{Name: "time", Type: parquet.TypeTimestamp},
+		{Name: "product_code", Type: parquet.TypeString},
+		{Name: "price", Type: parquet.TypeDouble},
+		{Name: "id", Type: parquet.TypeInt64},
+	}
+	for i := 0; i < 20; i++ {
+		columns[0].Values = append(columns[0].Values, now.Add(time.Duration(i)*time.Hour))
+		columns[1].Values = append(columns[1].Values, "ETH_JPY")
+		columns[2].Values = append(columns[2].Values, 500000.5+float64(i))
+		columns[3].Values = append(columns[3].Values, int64(i))
+	}
+	return columns
+}
+
+func TestParquet(t *testing.T) {
+	columns := testColumns()
+
+	var buf bytes.Buffer
+	if err := parquet.Write(&buf, columns); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	read, err := parquet.Read(buf.Bytes())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	assertColumns(t, read, columns)
+
+	t.Run("invalid file", func(t *testing.T) {
+		if _, err := parquet.Read([]byte("time,open,close")); err == nil {
+			t.Fatal("Read() returns no error")
+		}
+	})
+}
+
+// testdata/reference.parquetは，testColumnsと同じ値を別の実装
+// （github.com/parquet-go/parquet-go v0.32.0）で，PLAINエンコーディング・無圧縮・データページv1を指定して書いたもの
+func TestParquetReference(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/reference.parquet")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	read, err := parquet.Read(data)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	assertColumns(t, read, testColumns())
+}
+
+// testdata/written.parquetは，Writeで書いてgithub.com/parquet-go/parquet-go v0.32.0で読めることを確認したもの
+// 書き出す内容が変わったら，もう一度別の実装で読めることを確かめてから更新する
+func TestParquetWritten(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/written.parquet")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	var buf bytes.Buffer
+	if err := parquet.Write(&buf, testColumns()); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("written file differs from testdata/written.parquet")
+	}
+}
+
+func assertColumns(t *testing.T, read, columns []parquet.Column) {
+	t.Helper()
+
+	if len(read) != len(columns) {
+		t.Fatalf("%d != %d", len(read), len(columns))
+	}
+	for i := range columns {
+		if read[i].Name != columns[i].Name || read[i].Type != columns[i].Type {
+			t.Fatalf("%v != %v", read[i], columns[i])
+		}
+		if len(read[i].Values) != len(columns[i].Values) {
+			t.Fatalf("%d != %d", len(read[i].Values), len(columns[i].Values))
+		}
+		for j := range columns[i].Values {
+			if tt, ok := columns[i].Values[j].(time.Time); ok {
+				if !tt.Equal(read[i].Values[j].(time.Time)) {
+					t.Fatalf("%v != %v", read[i].Values[j], tt)
+				}
+				continue
+			}
+			if read[i].Values[j] != columns[i].Values[j] {
+				t.Fatalf("%v != %v", read[i].Values[j], columns[i].Values[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package persistence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+)
+
+func TestSpread(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	spreadRepository := persistence.NewSpreadRepository(tx, config.TimeFormat)
+
+	// 日時は2100年1月1日以降かつ昇順
+	spreads := []model.Spread{
+		*model.NewSpread(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), config.ProductCode, "bitflyer", "coincheck", 500000, 505000),
+		*model.NewSpread(time.Date(2100, 1, 1, 0, 1, 0, 0, time.UTC), config.ProductCode, "bitflyer", "coincheck", 500000, 495000),
+	}
+
+	t.Run("save spread", func(t *testing.T) {
+		for _, spread := range spreads {
+			err := spreadRepository.Save(spread)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+	})
+
+	t.Run("overwrite spread", func(t *testing.T) {
+		err := spreadRepository.Save(spreads[1])
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+
+	t.Run("find all spread", func(t *testing.T) {
+		ss, err := spreadRepository.FindAll(config.ProductCode, int64(len(spreads)))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(ss) != len(spreads) {
+			t.Fatalf("%d != %d", len(ss), len(spreads))
+		}
+		if ss[len(ss)-1] != spreads[len(spreads)-1] {
+			t.Fatalf("%+v != %+v", ss[len(ss)-1], spreads[len(spreads)-1])
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package persistence
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/repository"
+)
+
+type strategyRuleRepository struct {
+	db DB
+}
+
+func NewStrategyRuleRepository(db DB) repository.StrategyRuleRepository {
+	return &strategyRuleRepository{
+		db: db,
+	}
+}
+
+func (sr *strategyRuleRepository) Save(rule model.StrategyRule) error {
+	cmd := `
+        INSERT INTO strategy_rules
+            (product_code, buy_rule, sell_rule)
+        VALUES
+            (?, ?, ?)
+        ON CONFLICT(product_code) DO UPDATE SET
+            buy_rule = excluded.buy_rule,
+            sell_rule = excluded.sell_rule,
+            updated_at = CURRENT_TIMESTAMP
+        `
+	_, err := sr.db.Exec(cmd, rule.ProductCode(), rule.BuyRule().String(), rule.SellRule().String())
+	return err
+}
+
+func (sr *strategyRuleRepository) Find(productCode string) (*model.StrategyRule, error) {
+	cmd := `
+        SELECT
+            buy_rule, sell_rule
+        FROM
+            strategy_rules
+        WHERE
+            product_code = ?
+        `
+	row := sr.db.QueryRow(cmd, productCode)
+
+	var buyRule, sellRule string
+	err := row.Scan(&buyRule, &sellRule)
+	// 発見できなかったらそのままnilを返す
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rule := model.NewStrategyRule(productCode, buyRule, sellRule)
+	if rule == nil {
+		return nil, errors.New(fmt.Sprint("invalid strategy_rule:", productCode, buyRule, sellRule))
+	}
+	return rule, nil
+}
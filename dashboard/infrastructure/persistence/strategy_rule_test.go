@@ -0,0 +1,55 @@
+package persistence_test
+
+import (
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+)
+
+func TestStrategyRule(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	strategyRuleRepository := persistence.NewStrategyRuleRepository(tx)
+
+	rules := []model.StrategyRule{
+		*model.NewStrategyRule(config.ProductCode, "crossover(ema(7), ema(21)) and rsi(14) < 35", "crossunder(ema(7), ema(21))"),
+		*model.NewStrategyRule(config.ProductCode, "close < bb_lower(20, 2)", "close > bb_upper(20, 2)"),
+	}
+
+	t.Run("save strategy_rule", func(t *testing.T) {
+		for _, rule := range rules {
+			err := strategyRuleRepository.Save(rule)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+	})
+
+	t.Run("find strategy_rule", func(t *testing.T) {
+		rule, err := strategyRuleRepository.Find(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if rule == nil {
+			t.Fatal("strategy_rule is not found")
+		}
+
+		expected := rules[len(rules)-1]
+		if rule.BuyRule().String() != expected.BuyRule().String() || rule.SellRule().String() != expected.SellRule().String() {
+			t.Fatalf("buy=%q, sell=%q", rule.BuyRule().String(), rule.SellRule().String())
+		}
+	})
+
+	t.Run("find not existing strategy_rule", func(t *testing.T) {
+		rule, err := strategyRuleRepository.Find("NOT_EXISTING")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if rule != nil {
+			t.Fatal("strategy_rule is found")
+		}
+	})
+}
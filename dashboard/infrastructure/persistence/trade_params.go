@@ -36,6 +36,9 @@ func (tr *tradeParamsRepository) Save(tp model.TradeParams) error {
             bbands_n,
             bbands_k,
             ichimoku_enable,
+            ichimoku_tenkan_period,
+            ichimoku_kijun_period,
+            ichimoku_senkou_b_period,
             rsi_enable,
             rsi_period,
             rsi_buy_thread,
@@ -44,9 +47,100 @@ func (tr *tradeParamsRepository) Save(tp model.TradeParams) error {
             macd_fast_period,
             macd_slow_period,
             macd_signal_period,
-            stop_limit_percent
+            atr_enable,
+            atr_period,
+            atr_multiplier,
+            stoch_enable,
+            stoch_fast_k_period,
+            stoch_slow_k_period,
+            stoch_slow_d_period,
+            stoch_buy_thread,
+            stoch_sell_thread,
+            adx_enable,
+            adx_period,
+            adx_thread,
+            obv_enable,
+            obv_period,
+            vwap_enable,
+            vwap_period,
+            sar_enable,
+            sar_acceleration,
+            sar_maximum,
+            donchian_enable,
+            donchian_period,
+            keltner_enable,
+            keltner_period,
+            keltner_multiplier,
+            heikin_ashi_enable,
+            heikin_ashi_period,
+            ema_weight,
+            bbands_weight,
+            ichimoku_weight,
+            rsi_weight,
+            macd_weight,
+            atr_weight,
+            stoch_weight,
+            adx_weight,
+            obv_weight,
+            vwap_weight,
+            sar_weight,
+            donchian_weight,
+            keltner_weight,
+            heikin_ashi_weight,
+            buy_vote_threshold,
+            sell_vote_threshold,
+            stop_limit_percent,
+            max_lots,
+            lot_matching
         )
         VALUES (
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
+            ?,
             ?,
             ?,
             ?,
@@ -75,6 +169,7 @@ func (tr *tradeParamsRepository) Save(tp model.TradeParams) error {
         `,
 	)
 
+	signalWeights := tp.SignalWeights()
 	_, err := tr.db.Exec(cmd,
 		tp.TradeEnable(),
 		tp.ProductCode(),
@@ -91,6 +186,9 @@ func (tr *tradeParamsRepository) Save(tp model.TradeParams) error {
 		tp.BBandsN(),
 		tp.BBandsK(),
 		tp.IchimokuEnable(),
+		tp.IchimokuTenkanPeriod(),
+		tp.IchimokuKijunPeriod(),
+		tp.IchimokuSenkouBPeriod(),
 		tp.RSIEnable(),
 		tp.RSIPeriod(),
 		tp.RSIBuyThread(),
@@ -99,7 +197,51 @@ func (tr *tradeParamsRepository) Save(tp model.TradeParams) error {
 		tp.MACDFastPeriod(),
 		tp.MACDSlowPeriod(),
 		tp.MACDSignalPeriod(),
+		tp.ATREnable(),
+		tp.ATRPeriod(),
+		tp.ATRMultiplier(),
+		tp.StochEnable(),
+		tp.StochFastKPeriod(),
+		tp.StochSlowKPeriod(),
+		tp.StochSlowDPeriod(),
+		tp.StochBuyThread(),
+		tp.StochSellThread(),
+		tp.ADXEnable(),
+		tp.ADXPeriod(),
+		tp.ADXThread(),
+		tp.OBVEnable(),
+		tp.OBVPeriod(),
+		tp.VWAPEnable(),
+		tp.VWAPPeriod(),
+		tp.SAREnable(),
+		tp.SARAcceleration(),
+		tp.SARMaximum(),
+		tp.DonchianEnable(),
+		tp.DonchianPeriod(),
+		tp.KeltnerEnable(),
+		tp.KeltnerPeriod(),
+		tp.KeltnerMultiplier(),
+		tp.HeikinAshiEnable(),
+		tp.HeikinAshiPeriod(),
+		signalWeights.EMA(),
+		signalWeights.BBands(),
+		signalWeights.Ichimoku(),
+		signalWeights.RSI(),
+		signalWeights.MACD(),
+		signalWeights.ATR(),
+		signalWeights.Stoch(),
+		signalWeights.ADX(),
+		signalWeights.OBV(),
+		signalWeights.VWAP(),
+		signalWeights.SAR(),
+		signalWeights.Donchian(),
+		signalWeights.Keltner(),
+		signalWeights.HeikinAshi(),
+		tp.BuyVoteThreshold(),
+		tp.SellVoteThreshold(),
 		tp.StopLimitPercent(),
+		tp.MaxLots(),
+		string(tp.LotMatching()),
 	)
 	return err
 }
@@ -123,6 +265,9 @@ func (tr *tradeParamsRepository) Find(productCode string) (*model.TradeParams, e
                 tp.bbands_n,
                 tp.bbands_k,
                 tp.ichimoku_enable,
+                tp.ichimoku_tenkan_period,
+                tp.ichimoku_kijun_period,
+                tp.ichimoku_senkou_b_period,
                 tp.rsi_enable,
                 tp.rsi_period,
                 tp.rsi_buy_thread,
@@ -131,7 +276,51 @@ func (tr *tradeParamsRepository) Find(productCode string) (*model.TradeParams, e
                 tp.macd_fast_period,
                 tp.macd_slow_period,
                 tp.macd_signal_period,
-                tp.stop_limit_percent
+                tp.atr_enable,
+                tp.atr_period,
+                tp.atr_multiplier,
+                tp.stoch_enable,
+                tp.stoch_fast_k_period,
+                tp.stoch_slow_k_period,
+                tp.stoch_slow_d_period,
+                tp.stoch_buy_thread,
+                tp.stoch_sell_thread,
+                tp.adx_enable,
+                tp.adx_period,
+                tp.adx_thread,
+                tp.obv_enable,
+                tp.obv_period,
+                tp.vwap_enable,
+                tp.vwap_period,
+                tp.sar_enable,
+                tp.sar_acceleration,
+                tp.sar_maximum,
+                tp.donchian_enable,
+                tp.donchian_period,
+                tp.keltner_enable,
+                tp.keltner_period,
+                tp.keltner_multiplier,
+                tp.heikin_ashi_enable,
+                tp.heikin_ashi_period,
+                tp.ema_weight,
+                tp.bbands_weight,
+                tp.ichimoku_weight,
+                tp.rsi_weight,
+                tp.macd_weight,
+                tp.atr_weight,
+                tp.stoch_weight,
+                tp.adx_weight,
+                tp.obv_weight,
+                tp.vwap_weight,
+                tp.sar_weight,
+                tp.donchian_weight,
+                tp.keltner_weight,
+                tp.heikin_ashi_weight,
+                tp.buy_vote_threshold,
+                tp.sell_vote_threshold,
+                tp.stop_limit_percent,
+                tp.max_lots,
+                tp.lot_matching
             FROM
                 trade_params AS tp
             WHERE
@@ -157,12 +346,40 @@ func (tr *tradeParamsRepository) Find(productCode string) (*model.TradeParams, e
 	var bbandsN int
 	var bbandsK float64
 	var ichimokuEnable bool
+	var ichimokuTenkanPeriod, ichimokuKijunPeriod, ichimokuSenkouBPeriod int
 	var rsiEnable bool
 	var rsiPeriod int
 	var rsiBuyThread, rsiSellThread float64
 	var macdEnable bool
 	var macdFastPeriod, macdSlowPeriod, macdSignalPeriod int
+	var atrEnable bool
+	var atrPeriod int
+	var atrMultiplier float64
+	var stochEnable bool
+	var stochFastKPeriod, stochSlowKPeriod, stochSlowDPeriod int
+	var stochBuyThread, stochSellThread float64
+	var adxEnable bool
+	var adxPeriod int
+	var adxThread float64
+	var obvEnable bool
+	var obvPeriod int
+	var vwapEnable bool
+	var vwapPeriod int
+	var sarEnable bool
+	var sarAcceleration, sarMaximum float64
+	var donchianEnable bool
+	var donchianPeriod int
+	var keltnerEnable bool
+	var keltnerPeriod int
+	var keltnerMultiplier float64
+	var heikinAshiEnable bool
+	var heikinAshiPeriod int
+	var emaWeight, bbandsWeight, ichimokuWeight, rsiWeight, macdWeight, atrWeight, stochWeight float64
+	var adxWeight, obvWeight, vwapWeight, sarWeight, donchianWeight, keltnerWeight, heikinAshiWeight float64
+	var buyVoteThreshold, sellVoteThreshold float64
 	var stopLimitPercent float64
+	var maxLots int
+	var lotMatching string
 	err := row.Scan(
 		&tradeEnable,
 		&size,
@@ -178,6 +395,9 @@ func (tr *tradeParamsRepository) Find(productCode string) (*model.TradeParams, e
 		&bbandsN,
 		&bbandsK,
 		&ichimokuEnable,
+		&ichimokuTenkanPeriod,
+		&ichimokuKijunPeriod,
+		&ichimokuSenkouBPeriod,
 		&rsiEnable,
 		&rsiPeriod,
 		&rsiBuyThread,
@@ -186,12 +406,91 @@ func (tr *tradeParamsRepository) Find(productCode string) (*model.TradeParams, e
 		&macdFastPeriod,
 		&macdSlowPeriod,
 		&macdSignalPeriod,
+		&atrEnable,
+		&atrPeriod,
+		&atrMultiplier,
+		&stochEnable,
+		&stochFastKPeriod,
+		&stochSlowKPeriod,
+		&stochSlowDPeriod,
+		&stochBuyThread,
+		&stochSellThread,
+		&adxEnable,
+		&adxPeriod,
+		&adxThread,
+		&obvEnable,
+		&obvPeriod,
+		&vwapEnable,
+		&vwapPeriod,
+		&sarEnable,
+		&sarAcceleration,
+		&sarMaximum,
+		&donchianEnable,
+		&donchianPeriod,
+		&keltnerEnable,
+		&keltnerPeriod,
+		&keltnerMultiplier,
+		&heikinAshiEnable,
+		&heikinAshiPeriod,
+		&emaWeight,
+		&bbandsWeight,
+		&ichimokuWeight,
+		&rsiWeight,
+		&macdWeight,
+		&atrWeight,
+		&stochWeight,
+		&adxWeight,
+		&obvWeight,
+		&vwapWeight,
+		&sarWeight,
+		&donchianWeight,
+		&keltnerWeight,
+		&heikinAshiWeight,
+		&buyVoteThreshold,
+		&sellVoteThreshold,
 		&stopLimitPercent,
+		&maxLots,
+		&lotMatching,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	signalWeights := model.NewSignalWeights(
+		emaWeight,
+		bbandsWeight,
+		ichimokuWeight,
+		rsiWeight,
+		macdWeight,
+		atrWeight,
+		stochWeight,
+		adxWeight,
+		obvWeight,
+		vwapWeight,
+		sarWeight,
+		donchianWeight,
+		keltnerWeight,
+		heikinAshiWeight,
+	)
+	if signalWeights == nil {
+		return nil, errors.New(fmt.Sprint("invalid signal weights:",
+			emaWeight,
+			bbandsWeight,
+			ichimokuWeight,
+			rsiWeight,
+			macdWeight,
+			atrWeight,
+			stochWeight,
+			adxWeight,
+			obvWeight,
+			vwapWeight,
+			sarWeight,
+			donchianWeight,
+			keltnerWeight,
+			heikinAshiWeight,
+		))
+	}
+
 	tradeParams := model.NewTradeParams(
 		tradeEnable,
 		productCode,
@@ -208,6 +507,9 @@ func (tr *tradeParamsRepository) Find(productCode string) (*model.TradeParams, e
 		bbandsN,
 		bbandsK,
 		ichimokuEnable,
+		ichimokuTenkanPeriod,
+		ichimokuKijunPeriod,
+		ichimokuSenkouBPeriod,
 		rsiEnable,
 		rsiPeriod,
 		rsiBuyThread,
@@ -216,7 +518,38 @@ func (tr *tradeParamsRepository) Find(productCode string) (*model.TradeParams, e
 		macdFastPeriod,
 		macdSlowPeriod,
 		macdSignalPeriod,
+		atrEnable,
+		atrPeriod,
+		atrMultiplier,
+		stochEnable,
+		stochFastKPeriod,
+		stochSlowKPeriod,
+		stochSlowDPeriod,
+		stochBuyThread,
+		stochSellThread,
+		adxEnable,
+		adxPeriod,
+		adxThread,
+		obvEnable,
+		obvPeriod,
+		vwapEnable,
+		vwapPeriod,
+		sarEnable,
+		sarAcceleration,
+		sarMaximum,
+		donchianEnable,
+		donchianPeriod,
+		keltnerEnable,
+		keltnerPeriod,
+		keltnerMultiplier,
+		heikinAshiEnable,
+		heikinAshiPeriod,
+		*signalWeights,
+		buyVoteThreshold,
+		sellVoteThreshold,
 		stopLimitPercent,
+		maxLots,
+		model.LotMatching(lotMatching),
 	)
 	if tradeParams == nil {
 		return nil, errors.New(fmt.Sprint("invalid trade_params:",
@@ -234,6 +567,9 @@ func (tr *tradeParamsRepository) Find(productCode string) (*model.TradeParams, e
 			bbandsN,
 			bbandsK,
 			ichimokuEnable,
+			ichimokuTenkanPeriod,
+			ichimokuKijunPeriod,
+			ichimokuSenkouBPeriod,
 			rsiEnable,
 			rsiPeriod,
 			rsiBuyThread,
@@ -242,7 +578,38 @@ func (tr *tradeParamsRepository) Find(productCode string) (*model.TradeParams, e
 			macdFastPeriod,
 			macdSlowPeriod,
 			macdSignalPeriod,
+			atrEnable,
+			atrPeriod,
+			atrMultiplier,
+			stochEnable,
+			stochFastKPeriod,
+			stochSlowKPeriod,
+			stochSlowDPeriod,
+			stochBuyThread,
+			stochSellThread,
+			adxEnable,
+			adxPeriod,
+			adxThread,
+			obvEnable,
+			obvPeriod,
+			vwapEnable,
+			vwapPeriod,
+			sarEnable,
+			sarAcceleration,
+			sarMaximum,
+			donchianEnable,
+			donchianPeriod,
+			keltnerEnable,
+			keltnerPeriod,
+			keltnerMultiplier,
+			heikinAshiEnable,
+			heikinAshiPeriod,
+			*signalWeights,
+			buyVoteThreshold,
+			sellVoteThreshold,
 			stopLimitPercent,
+			maxLots,
+			lotMatching,
 		))
 	}
 	return tradeParams, nil
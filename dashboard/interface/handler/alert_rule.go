@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler/dto"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+type AlertRuleHandler interface {
+	HandlerFunc() http.HandlerFunc
+}
+
+type alertRuleHandler struct {
+	alertRuleUsecase usecase.AlertRuleUsecase
+}
+
+func NewAlertRuleHandler(au usecase.AlertRuleUsecase) AlertRuleHandler {
+	return &alertRuleHandler{
+		alertRuleUsecase: au,
+	}
+}
+
+func (ah *alertRuleHandler) HandlerFunc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			ah.Get(w, r)
+		case http.MethodPost:
+			ah.Post(w, r)
+		case http.MethodDelete:
+			ah.Delete(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func (ah *alertRuleHandler) Get(w http.ResponseWriter, r *http.Request) {
+	productCode := r.URL.Query().Get("productCode")
+
+	rules, err := ah.alertRuleUsecase.GetAll(productCode)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.ConvertAlertRules(rules))
+}
+
+// idが0なら追加し，そうでなければ更新する
+func (ah *alertRuleHandler) Post(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var ruleDto dto.AlertRule
+	if err := json.Unmarshal(body, &ruleDto); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 式の誤りはそのまま返して画面に表示する
+	rule, err := dtoToAlertRule(ruleDto)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id, err := ah.alertRuleUsecase.Save(*rule)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ruleDto.ID = id
+	writeJSON(w, http.StatusOK, ruleDto)
+}
+
+func (ah *alertRuleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	err = ah.alertRuleUsecase.Delete(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Success"))
+}
+
+func dtoToAlertRule(dto dto.AlertRule) (*model.AlertRule, error) {
+	kind := model.AlertKind(dto.Kind)
+	if !kind.Valid() {
+		return nil, errors.New("invalid kind: " + dto.Kind)
+	}
+	if kind == model.AlertKindIndicator {
+		if _, err := model.ParseRule(dto.Expression); err != nil {
+			return nil, err
+		}
+	}
+
+	rule := model.NewAlertRule(dto.ID, dto.ProductCode, kind, dto.Threshold, dto.Hours, dto.Expression, time.Duration(dto.CooldownMinutes)*time.Minute, dto.Enable, false, time.Time{})
+	if rule == nil {
+		return nil, errors.New("invalid alert rule")
+	}
+	// 保存したあとticker取得のたびに失敗しないよう，評価できる式かを先に確かめる
+	if err := rule.Check(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
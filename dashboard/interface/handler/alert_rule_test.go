@@ -0,0 +1,116 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler/dto"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestAlertRule(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	alertRuleRepository := persistence.NewAlertRuleRepository(tx, config.TimeFormat)
+
+	alertRuleUsecase := usecase.NewAlertRuleUsecase(alertRuleRepository)
+
+	alertRuleHandler := handler.NewAlertRuleHandler(alertRuleUsecase)
+
+	ts := httptest.NewServer(alertRuleHandler.HandlerFunc())
+	defer ts.Close()
+
+	do := func(method, url string, ruleDto *dto.AlertRule) *http.Response {
+		var reqBody []byte
+		if ruleDto != nil {
+			var err error
+			reqBody, err = json.Marshal(ruleDto)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+
+		req, err := http.NewRequest(method, url, bytes.NewBuffer(reqBody))
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		client := http.Client{}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		return resp
+	}
+
+	var id int64
+
+	t.Run("post alert_rule", func(t *testing.T) {
+		resp := do("POST", ts.URL, &dto.AlertRule{
+			ProductCode:     config.ProductCode,
+			Kind:            "indicator",
+			Expression:      "rsi(14) < 25",
+			CooldownMinutes: 60,
+			Enable:          true,
+		})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+
+		respBody, _ := ioutil.ReadAll(resp.Body)
+
+		var ruleDto dto.AlertRule
+		if err := json.Unmarshal(respBody, &ruleDto); err != nil {
+			t.Fatal(err.Error())
+		}
+		if ruleDto.ID <= 0 {
+			t.Fatalf("id=%d", ruleDto.ID)
+		}
+		id = ruleDto.ID
+	})
+
+	t.Run("post invalid alert_rule", func(t *testing.T) {
+		resp := do("POST", ts.URL, &dto.AlertRule{
+			ProductCode: config.ProductCode,
+			Kind:        "indicator",
+			Expression:  "rsi(14) <",
+		})
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatal("resp.StatusCode != http.StatusBadRequest")
+		}
+	})
+
+	t.Run("get alert_rules", func(t *testing.T) {
+		resp := do("GET", ts.URL+"?productCode="+config.ProductCode, nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+
+		respBody, _ := ioutil.ReadAll(resp.Body)
+
+		var rules []dto.AlertRule
+		if err := json.Unmarshal(respBody, &rules); err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(rules) == 0 || rules[len(rules)-1].Expression != "rsi(14) < 25" {
+			t.Fatalf("rules=%+v", rules)
+		}
+	})
+
+	t.Run("delete alert_rule", func(t *testing.T) {
+		resp := do("DELETE", fmt.Sprintf("%s?id=%d", ts.URL, id), nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+	})
+}
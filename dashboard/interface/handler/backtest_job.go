@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler/dto"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+type BacktestJobHandler interface {
+	HandlerFunc(productCode string) http.HandlerFunc
+	JobHandlerFunc() http.HandlerFunc
+	ListHandlerFunc(productCode string) http.HandlerFunc
+	GetHandlerFunc() http.HandlerFunc
+}
+
+type backtestJobHandler struct {
+	backtestJobUsecase usecase.BacktestJobUsecase
+}
+
+func NewBacktestJobHandler(bu usecase.BacktestJobUsecase) BacktestJobHandler {
+	return &backtestJobHandler{
+		backtestJobUsecase: bu,
+	}
+}
+
+// GET: ジョブの一覧, POST: ジョブの登録
+func (bh *backtestJobHandler) HandlerFunc(productCode string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			bh.List(w, r, productCode)
+		case http.MethodPost:
+			bh.Submit(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// GET: ジョブの結果, DELETE: ジョブのキャンセル
+func (bh *backtestJobHandler) JobHandlerFunc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			bh.Get(w, r)
+		case http.MethodDelete:
+			bh.Cancel(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// 公開用（GETのみ）: ジョブの一覧
+func (bh *backtestJobHandler) ListHandlerFunc(productCode string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		bh.List(w, r, productCode)
+	}
+}
+
+// 公開用（GETのみ）: ジョブの結果
+func (bh *backtestJobHandler) GetHandlerFunc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		bh.Get(w, r)
+	}
+}
+
+func (bh *backtestJobHandler) List(w http.ResponseWriter, r *http.Request, productCode string) {
+	// [0, 100]の範囲に限定
+	limit := getQueryUintDefault(r, "limit", 20)
+	if limit > 100 {
+		limit = 100
+	}
+
+	jobs, err := bh.backtestJobUsecase.List(productCode, int64(limit))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resDto := make([]dto.BacktestJob, 0)
+	for i := range jobs {
+		dto := dto.ConvertBacktestJob(&jobs[i], false)
+		if dto != nil {
+			resDto = append(resDto, *dto)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resDto)
+}
+
+func (bh *backtestJobHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req dto.BacktestJobRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	params, err := dtoToTradeParams(req.Params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	job, err := bh.backtestJobUsecase.Submit(req.Strategy, params, req.Start, req.End)
+	switch err {
+	case nil:
+	case usecase.ErrBacktestQueueFull:
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, dto.ConvertBacktestJob(job, false))
+}
+
+func (bh *backtestJobHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+
+	job, err := bh.backtestJobUsecase.Get(id)
+	if err == usecase.ErrBacktestJobNotFound {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.ConvertBacktestJob(job, true))
+}
+
+func (bh *backtestJobHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+
+	err := bh.backtestJobUsecase.Cancel(id)
+	switch err {
+	case nil:
+	case usecase.ErrBacktestJobNotFound:
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case usecase.ErrBacktestJobFinished:
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Success"))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	js, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(js)
+}
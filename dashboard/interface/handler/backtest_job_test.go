@@ -0,0 +1,172 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler/dto"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestBacktestJob(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	candleRepository := persistence.NewCandleRepository(tx, config.CandleTableName, config.TimeFormat)
+	backtestJobRepository := persistence.NewBacktestJobRepository(tx, config.TimeFormat)
+
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	indicatorService := service.NewIndicatorService()
+	strategies := map[string]service.DataFrameService{
+		"mr_base": service.NewMRBaseDataFrameService(indicatorService),
+	}
+
+	backtestJobUsecase := usecase.NewBacktestJobUsecase(candleService, strategies, backtestJobRepository, 8)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	backtestJobUsecase.Start(ctx, 1)
+
+	backtestJobHandler := handler.NewBacktestJobHandler(backtestJobUsecase)
+
+	ts := httptest.NewServer(backtestJobHandler.HandlerFunc(config.ProductCode))
+	defer ts.Close()
+	jobTs := httptest.NewServer(backtestJobHandler.JobHandlerFunc())
+	defer jobTs.Close()
+
+	var jobID string
+
+	t.Run("submit job", func(t *testing.T) {
+		params := dto.ConvertTradeParams(model.NewBasicTradeParams(config.ProductCode, 0.01))
+		reqDto := dto.BacktestJobRequest{
+			Strategy: "mr_base",
+			Params:   *params,
+			Start:    time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:      time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		body, _ := json.Marshal(reqDto)
+
+		resp, err := http.Post(ts.URL, "application/json", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusAccepted {
+			t.Fatal("resp.StatusCode != http.StatusAccepted")
+		}
+
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		var job dto.BacktestJob
+		err = json.Unmarshal(respBody, &job)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		jobID = job.ID
+	})
+
+	t.Run("get job", func(t *testing.T) {
+		var job dto.BacktestJob
+		for i := 0; i < 100; i++ {
+			resp, err := http.Get(jobTs.URL + "?id=" + jobID)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Fatal("resp.StatusCode != http.StatusOK")
+			}
+			respBody, _ := ioutil.ReadAll(resp.Body)
+			err = json.Unmarshal(respBody, &job)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if job.Status == string(model.BacktestJobStatusDone) {
+				break
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		if job.Result == nil {
+			t.Fatal("job.Result == nil")
+		}
+	})
+
+	t.Run("list jobs", func(t *testing.T) {
+		resp, err := http.Get(ts.URL)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		var jobs []dto.BacktestJob
+		err = json.Unmarshal(respBody, &jobs)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(jobs) < 1 {
+			t.Fatal("len(jobs) < 1")
+		}
+	})
+
+	t.Run("cancel finished job", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, jobTs.URL+"?id="+jobID, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusConflict {
+			t.Fatal("resp.StatusCode != http.StatusConflict")
+		}
+	})
+
+	t.Run("public handlers are read only", func(t *testing.T) {
+		listTs := httptest.NewServer(backtestJobHandler.ListHandlerFunc(config.ProductCode))
+		defer listTs.Close()
+		getTs := httptest.NewServer(backtestJobHandler.GetHandlerFunc())
+		defer getTs.Close()
+
+		resp, err := http.Post(listTs.URL, "application/json", bytes.NewBufferString("{}"))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusMethodNotAllowed {
+			t.Fatal("resp.StatusCode != http.StatusMethodNotAllowed")
+		}
+
+		req, _ := http.NewRequest(http.MethodDelete, getTs.URL+"?id="+jobID, nil)
+		resp, err = http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusMethodNotAllowed {
+			t.Fatal("resp.StatusCode != http.StatusMethodNotAllowed")
+		}
+
+		resp, err = http.Get(getTs.URL + "?id=" + jobID)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+	})
+
+	t.Run("get not exist job", func(t *testing.T) {
+		resp, err := http.Get(jobTs.URL + "?id=not-exist")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatal("resp.StatusCode != http.StatusNotFound")
+		}
+	})
+}
@@ -88,6 +88,12 @@ func reqUrlToTradeParams(r *http.Request, productCode string) *model.TradeParams
 
 	ichimoku := r.URL.Query().Get("ichimoku")
 	ichimokuEnable := ichimoku == "true"
+	var ichimokuTenkanPeriod, ichimokuKijunPeriod, ichimokuSenkouBPeriod int
+	if ichimokuEnable {
+		ichimokuTenkanPeriod = getQueryUintDefault(r, "ichimokuTenkanPeriod", 9)
+		ichimokuKijunPeriod = getQueryUintDefault(r, "ichimokuKijunPeriod", 26)
+		ichimokuSenkouBPeriod = getQueryUintDefault(r, "ichimokuSenkouBPeriod", 52)
+	}
 
 	rsi := r.URL.Query().Get("rsi")
 	rsiEnable := rsi == "true"
@@ -108,8 +114,110 @@ func reqUrlToTradeParams(r *http.Request, productCode string) *model.TradeParams
 		macdSignalPeriod = getQueryUintDefault(r, "macdPeriod3", 9)
 	}
 
+	atr := r.URL.Query().Get("atr")
+	atrEnable := atr == "true"
+	var atrPeriod int
+	var atrMultiplier float64
+	if atrEnable {
+		atrPeriod = getQueryUintDefault(r, "atrPeriod", 14)
+		atrMultiplier = getQueryFloatDefault(r, "atrMultiplier", 2)
+	}
+
+	stoch := r.URL.Query().Get("stoch")
+	stochEnable := stoch == "true"
+	var stochFastKPeriod, stochSlowKPeriod, stochSlowDPeriod int
+	var stochBuyThread, stochSellThread float64
+	if stochEnable {
+		stochFastKPeriod = getQueryUintDefault(r, "stochFastKPeriod", 14)
+		stochSlowKPeriod = getQueryUintDefault(r, "stochSlowKPeriod", 3)
+		stochSlowDPeriod = getQueryUintDefault(r, "stochSlowDPeriod", 3)
+		stochBuyThread = getQueryFloatDefault(r, "stochBuyThread", 20)
+		stochSellThread = getQueryFloatDefault(r, "stochSellThread", 80)
+	}
+
+	adx := r.URL.Query().Get("adx")
+	adxEnable := adx == "true"
+	var adxPeriod int
+	var adxThread float64
+	if adxEnable {
+		adxPeriod = getQueryUintDefault(r, "adxPeriod", 14)
+		adxThread = getQueryFloatDefault(r, "adxThread", 25)
+	}
+
+	obv := r.URL.Query().Get("obv")
+	obvEnable := obv == "true"
+	var obvPeriod int
+	if obvEnable {
+		obvPeriod = getQueryUintDefault(r, "obvPeriod", 20)
+	}
+
+	vwap := r.URL.Query().Get("vwap")
+	vwapEnable := vwap == "true"
+	var vwapPeriod int
+	if vwapEnable {
+		vwapPeriod = getQueryUintDefault(r, "vwapPeriod", 20)
+	}
+
+	sar := r.URL.Query().Get("sar")
+	sarEnable := sar == "true"
+	var sarAcceleration, sarMaximum float64
+	if sarEnable {
+		sarAcceleration = getQueryFloatDefault(r, "sarAcceleration", 0.02)
+		sarMaximum = getQueryFloatDefault(r, "sarMaximum", 0.2)
+	}
+
+	donchian := r.URL.Query().Get("donchian")
+	donchianEnable := donchian == "true"
+	var donchianPeriod int
+	if donchianEnable {
+		donchianPeriod = getQueryUintDefault(r, "donchianPeriod", 20)
+	}
+
+	keltner := r.URL.Query().Get("keltner")
+	keltnerEnable := keltner == "true"
+	var keltnerPeriod int
+	var keltnerMultiplier float64
+	if keltnerEnable {
+		keltnerPeriod = getQueryUintDefault(r, "keltnerPeriod", 20)
+		keltnerMultiplier = getQueryFloatDefault(r, "keltnerMultiplier", 2)
+	}
+
+	heikinAshi := r.URL.Query().Get("heikinAshi")
+	heikinAshiEnable := heikinAshi == "true"
+	var heikinAshiPeriod int
+	if heikinAshiEnable {
+		heikinAshiPeriod = getQueryUintDefault(r, "heikinAshiPeriod", 3)
+	}
+
+	// 重み付き投票の重みと閾値
+	signalWeights := model.NewSignalWeights(
+		getQueryFloatDefault(r, "emaWeight", 1),
+		getQueryFloatDefault(r, "bbandsWeight", 1),
+		getQueryFloatDefault(r, "ichimokuWeight", 1),
+		getQueryFloatDefault(r, "rsiWeight", 1),
+		getQueryFloatDefault(r, "macdWeight", 1),
+		getQueryFloatDefault(r, "atrWeight", 1),
+		getQueryFloatDefault(r, "stochWeight", 1),
+		getQueryFloatDefault(r, "adxWeight", 1),
+		getQueryFloatDefault(r, "obvWeight", 1),
+		getQueryFloatDefault(r, "vwapWeight", 1),
+		getQueryFloatDefault(r, "sarWeight", 1),
+		getQueryFloatDefault(r, "donchianWeight", 1),
+		getQueryFloatDefault(r, "keltnerWeight", 1),
+		getQueryFloatDefault(r, "heikinAshiWeight", 1),
+	)
+	buyVoteThreshold := getQueryFloatDefault(r, "buyVoteThreshold", 2)
+	sellVoteThreshold := getQueryFloatDefault(r, "sellVoteThreshold", 2)
+
 	stopLimitPercent := getQueryFloatDefault(r, "stopLimitPercent", 0.75)
 
+	// 買い増しできるロットの数と，売りで決済するロットの選び方
+	maxLots := getQueryUintDefault(r, "maxLots", 1)
+	lotMatching := model.LotMatching(r.URL.Query().Get("lotMatching"))
+	if lotMatching == "" {
+		lotMatching = model.LotMatchingFIFO
+	}
+
 	params := model.NewTradeParams(
 		false,
 		productCode,
@@ -126,6 +234,9 @@ func reqUrlToTradeParams(r *http.Request, productCode string) *model.TradeParams
 		bbandsN,
 		bbandsK,
 		ichimokuEnable,
+		ichimokuTenkanPeriod,
+		ichimokuKijunPeriod,
+		ichimokuSenkouBPeriod,
 		rsiEnable,
 		rsiPeriod,
 		rsiBuyThread,
@@ -134,7 +245,38 @@ func reqUrlToTradeParams(r *http.Request, productCode string) *model.TradeParams
 		macdFastPeriod,
 		macdSlowPeriod,
 		macdSignalPeriod,
+		atrEnable,
+		atrPeriod,
+		atrMultiplier,
+		stochEnable,
+		stochFastKPeriod,
+		stochSlowKPeriod,
+		stochSlowDPeriod,
+		stochBuyThread,
+		stochSellThread,
+		adxEnable,
+		adxPeriod,
+		adxThread,
+		obvEnable,
+		obvPeriod,
+		vwapEnable,
+		vwapPeriod,
+		sarEnable,
+		sarAcceleration,
+		sarMaximum,
+		donchianEnable,
+		donchianPeriod,
+		keltnerEnable,
+		keltnerPeriod,
+		keltnerMultiplier,
+		heikinAshiEnable,
+		heikinAshiPeriod,
+		*signalWeights,
+		buyVoteThreshold,
+		sellVoteThreshold,
 		stopLimitPercent,
+		maxLots,
+		lotMatching,
 	)
 
 	return params
@@ -21,6 +21,9 @@ func TestDataFrameHandler(t *testing.T) {
 	defer tx.Rollback()
 
 	candleRepository := persistence.NewCandleMockRepository(config.CandleTableName, config.TimeFormat, config.ProductCode, config.CandleDuration)
+	if candleRepository == nil {
+		t.Fatal("NewCandleMockRepository() returns nil")
+	}
 	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
 
 	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
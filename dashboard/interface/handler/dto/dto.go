@@ -7,16 +7,25 @@ import (
 )
 
 type DataFrame struct {
-	ProductCode    string         `json:"productCode"`
-	Candles        []Candle       `json:"candles"`
-	Events         *SignalEvents  `json:"events"`
-	SMAs           []SMA          `json:"smas,omitempty"`
-	EMAs           []EMA          `json:"emas,omitempty"`
-	BBands         *BBands        `json:"bbands,omitempty"`
-	IchimokuCloud  *IchimokuCloud `json:"ichimoku,omitempty"`
-	RSI            *RSI           `json:"rsi,omitempty"`
-	MACD           *MACD          `json:"macd,omitempty"`
-	BacktestEvents *SignalEvents  `json:"backtestEvents,omitempty"`
+	ProductCode     string           `json:"productCode"`
+	Candles         []Candle         `json:"candles"`
+	Events          *SignalEvents    `json:"events"`
+	SMAs            []SMA            `json:"smas,omitempty"`
+	EMAs            []EMA            `json:"emas,omitempty"`
+	BBands          *BBands          `json:"bbands,omitempty"`
+	IchimokuCloud   *IchimokuCloud   `json:"ichimoku,omitempty"`
+	RSI             *RSI             `json:"rsi,omitempty"`
+	MACD            *MACD            `json:"macd,omitempty"`
+	ATR             *ATR             `json:"atr,omitempty"`
+	Stochastic      *Stochastic      `json:"stochastic,omitempty"`
+	ADX             *ADX             `json:"adx,omitempty"`
+	OBV             *OBV             `json:"obv,omitempty"`
+	VWAP            *VWAP            `json:"vwap,omitempty"`
+	ParabolicSAR    *ParabolicSAR    `json:"parabolicSar,omitempty"`
+	DonchianChannel *DonchianChannel `json:"donchian,omitempty"`
+	KeltnerChannel  *KeltnerChannel  `json:"keltner,omitempty"`
+	HeikinAshi      *HeikinAshi      `json:"heikinAshi,omitempty"`
+	BacktestEvents  *SignalEvents    `json:"backtestEvents,omitempty"`
 }
 
 func ConvertDataFrame(df *model.DataFrame) DataFrame {
@@ -45,19 +54,46 @@ func ConvertDataFrame(df *model.DataFrame) DataFrame {
 
 	macd := ConvertMACD(df.MACD())
 
+	atr := ConvertATR(df.ATR())
+
+	stochastic := ConvertStochastic(df.Stochastic())
+
+	adx := ConvertADX(df.ADX())
+
+	obv := ConvertOBV(df.OBV())
+
+	vwap := ConvertVWAP(df.VWAP())
+
+	sar := ConvertParabolicSAR(df.ParabolicSAR())
+
+	donchian := ConvertDonchianChannel(df.DonchianChannel())
+
+	keltner := ConvertKeltnerChannel(df.KeltnerChannel())
+
+	heikinAshi := ConvertHeikinAshi(df.AverageCandle())
+
 	backTestEvents := ConvertSignalEvents(df.BacktestEvents())
 
 	dto := DataFrame{
-		ProductCode:    df.ProductCode(),
-		Candles:        candles,
-		Events:         events,
-		SMAs:           smas,
-		EMAs:           emas,
-		BBands:         bbands,
-		IchimokuCloud:  ichimoku,
-		RSI:            rsi,
-		MACD:           macd,
-		BacktestEvents: backTestEvents,
+		ProductCode:     df.ProductCode(),
+		Candles:         candles,
+		Events:          events,
+		SMAs:            smas,
+		EMAs:            emas,
+		BBands:          bbands,
+		IchimokuCloud:   ichimoku,
+		RSI:             rsi,
+		MACD:            macd,
+		ATR:             atr,
+		Stochastic:      stochastic,
+		ADX:             adx,
+		OBV:             obv,
+		VWAP:            vwap,
+		ParabolicSAR:    sar,
+		DonchianChannel: donchian,
+		KeltnerChannel:  keltner,
+		HeikinAshi:      heikinAshi,
+		BacktestEvents:  backTestEvents,
 	}
 
 	return dto
@@ -108,11 +144,12 @@ func ConvertSignalEvents(s *model.SignalEvents) *SignalEvents {
 }
 
 type SignalEvent struct {
-	Time        time.Time       `json:"time"`
-	ProductCode string          `json:"productCode"`
-	Side        model.OrderSide `json:"side"`
-	Price       float64         `json:"price"`
-	Size        float64         `json:"size"`
+	Time        time.Time            `json:"time"`
+	ProductCode string               `json:"productCode"`
+	Side        model.OrderSide      `json:"side"`
+	Price       float64              `json:"price"`
+	Size        float64              `json:"size"`
+	Tag         model.SignalEventTag `json:"tag,omitempty"`
 }
 
 func ConvertSignalEvent(s model.SignalEvent) SignalEvent {
@@ -122,6 +159,7 @@ func ConvertSignalEvent(s model.SignalEvent) SignalEvent {
 		Side:        s.Side(),
 		Price:       s.Price(),
 		Size:        s.Size(),
+		Tag:         s.Tag(),
 	}
 }
 
@@ -172,11 +210,15 @@ func ConvertBBands(bbands *model.BBands) *BBands {
 }
 
 type IchimokuCloud struct {
-	Tenkan  []float64 `json:"tenkan,omitempty"`
-	Kijun   []float64 `json:"kijun,omitempty"`
-	SenkouA []float64 `json:"senkoua,omitempty"`
-	SenkouB []float64 `json:"senkoub,omitempty"`
-	Chikou  []float64 `json:"chikou,omitempty"`
+	TenkanPeriod  int       `json:"tenkanPeriod,omitempty"`
+	KijunPeriod   int       `json:"kijunPeriod,omitempty"`
+	SenkouBPeriod int       `json:"senkouBPeriod,omitempty"`
+	Displacement  int       `json:"displacement,omitempty"`
+	Tenkan        []float64 `json:"tenkan,omitempty"`
+	Kijun         []float64 `json:"kijun,omitempty"`
+	SenkouA       []float64 `json:"senkoua,omitempty"`
+	SenkouB       []float64 `json:"senkoub,omitempty"`
+	Chikou        []float64 `json:"chikou,omitempty"`
 }
 
 func ConvertIchimokuCloud(ic *model.IchimokuCloud) *IchimokuCloud {
@@ -185,11 +227,15 @@ func ConvertIchimokuCloud(ic *model.IchimokuCloud) *IchimokuCloud {
 	}
 
 	return &IchimokuCloud{
-		Tenkan:  ic.Tenkan(),
-		Kijun:   ic.Kijun(),
-		SenkouA: ic.SenkouA(),
-		SenkouB: ic.SenkouB(),
-		Chikou:  ic.Chikou(),
+		TenkanPeriod:  ic.TenkanPeriod(),
+		KijunPeriod:   ic.KijunPeriod(),
+		SenkouBPeriod: ic.SenkouBPeriod(),
+		Displacement:  ic.Displacement(),
+		Tenkan:        ic.Tenkan(),
+		Kijun:         ic.Kijun(),
+		SenkouA:       ic.SenkouA(),
+		SenkouB:       ic.SenkouB(),
+		Chikou:        ic.Chikou(),
 	}
 }
 
@@ -233,31 +279,237 @@ func ConvertMACD(macd *model.MACD) *MACD {
 	}
 }
 
+type ATR struct {
+	Period int       `json:"period,omitempty"`
+	Values []float64 `json:"values,omitempty"`
+}
+
+func ConvertATR(atr *model.ATR) *ATR {
+	if atr == nil {
+		return nil
+	}
+
+	return &ATR{
+		Period: atr.Period(),
+		Values: atr.Values(),
+	}
+}
+
+type Stochastic struct {
+	FastKPeriod int       `json:"fastKPeriod,omitempty"`
+	SlowKPeriod int       `json:"slowKPeriod,omitempty"`
+	SlowDPeriod int       `json:"slowDPeriod,omitempty"`
+	SlowK       []float64 `json:"slowK,omitempty"`
+	SlowD       []float64 `json:"slowD,omitempty"`
+}
+
+func ConvertStochastic(stoch *model.Stochastic) *Stochastic {
+	if stoch == nil {
+		return nil
+	}
+
+	return &Stochastic{
+		FastKPeriod: stoch.FastKPeriod(),
+		SlowKPeriod: stoch.SlowKPeriod(),
+		SlowDPeriod: stoch.SlowDPeriod(),
+		SlowK:       stoch.SlowK(),
+		SlowD:       stoch.SlowD(),
+	}
+}
+
+type ADX struct {
+	Period  int       `json:"period,omitempty"`
+	ADX     []float64 `json:"adx,omitempty"`
+	PlusDI  []float64 `json:"plusDI,omitempty"`
+	MinusDI []float64 `json:"minusDI,omitempty"`
+}
+
+func ConvertADX(adx *model.ADX) *ADX {
+	if adx == nil {
+		return nil
+	}
+
+	return &ADX{
+		Period:  adx.Period(),
+		ADX:     adx.ADX(),
+		PlusDI:  adx.PlusDI(),
+		MinusDI: adx.MinusDI(),
+	}
+}
+
+type OBV struct {
+	Period int       `json:"period,omitempty"`
+	Values []float64 `json:"values,omitempty"`
+	Signal []float64 `json:"signal,omitempty"`
+}
+
+func ConvertOBV(obv *model.OBV) *OBV {
+	if obv == nil {
+		return nil
+	}
+
+	return &OBV{
+		Period: obv.Period(),
+		Values: obv.Values(),
+		Signal: obv.Signal(),
+	}
+}
+
+type VWAP struct {
+	Period int       `json:"period,omitempty"`
+	Values []float64 `json:"values,omitempty"`
+}
+
+func ConvertVWAP(vwap *model.VWAP) *VWAP {
+	if vwap == nil {
+		return nil
+	}
+
+	return &VWAP{
+		Period: vwap.Period(),
+		Values: vwap.Values(),
+	}
+}
+
+type ParabolicSAR struct {
+	Acceleration float64   `json:"acceleration,omitempty"`
+	Maximum      float64   `json:"maximum,omitempty"`
+	Values       []float64 `json:"values,omitempty"`
+}
+
+func ConvertParabolicSAR(sar *model.ParabolicSAR) *ParabolicSAR {
+	if sar == nil {
+		return nil
+	}
+
+	return &ParabolicSAR{
+		Acceleration: sar.Acceleration(),
+		Maximum:      sar.Maximum(),
+		Values:       sar.Values(),
+	}
+}
+
+type DonchianChannel struct {
+	Period int       `json:"period,omitempty"`
+	Up     []float64 `json:"up,omitempty"`
+	Mid    []float64 `json:"mid,omitempty"`
+	Down   []float64 `json:"down,omitempty"`
+}
+
+func ConvertDonchianChannel(dc *model.DonchianChannel) *DonchianChannel {
+	if dc == nil {
+		return nil
+	}
+
+	return &DonchianChannel{
+		Period: dc.Period(),
+		Up:     dc.Up(),
+		Mid:    dc.Mid(),
+		Down:   dc.Down(),
+	}
+}
+
+type KeltnerChannel struct {
+	Period     int       `json:"period,omitempty"`
+	Multiplier float64   `json:"multiplier,omitempty"`
+	Up         []float64 `json:"up,omitempty"`
+	Mid        []float64 `json:"mid,omitempty"`
+	Down       []float64 `json:"down,omitempty"`
+}
+
+func ConvertKeltnerChannel(kc *model.KeltnerChannel) *KeltnerChannel {
+	if kc == nil {
+		return nil
+	}
+
+	return &KeltnerChannel{
+		Period:     kc.Period(),
+		Multiplier: kc.Multiplier(),
+		Up:         kc.Up(),
+		Mid:        kc.Mid(),
+		Down:       kc.Down(),
+	}
+}
+
+type HeikinAshi struct {
+	Opens  []float64 `json:"opens,omitempty"`
+	Closes []float64 `json:"closes,omitempty"`
+	Highs  []float64 `json:"highs,omitempty"`
+	Lows   []float64 `json:"lows,omitempty"`
+}
+
+func ConvertHeikinAshi(ac *model.AverageCandle) *HeikinAshi {
+	if ac == nil {
+		return nil
+	}
+
+	return &HeikinAshi{
+		Opens:  ac.Opens(),
+		Closes: ac.Closes(),
+		Highs:  ac.Highs(),
+		Lows:   ac.Lows(),
+	}
+}
+
 type TradeParams struct {
-	TradeEnable      bool    `json:"trade"`
-	ProductCode      string  `json:"productCode"`
-	Size             float64 `json:"size"`
-	SMAEnable        bool    `json:"sma"`
-	SMAPeriod1       int     `json:"smaPeriod1"`
-	SMAPeriod2       int     `json:"smaPeriod2"`
-	SMAPeriod3       int     `json:"smaPeriod3"`
-	EMAEnable        bool    `json:"ema"`
-	EMAPeriod1       int     `json:"emaPeriod1"`
-	EMAPeriod2       int     `json:"emaPeriod2"`
-	EMAPeriod3       int     `json:"emaPeriod3"`
-	BBandsEnable     bool    `json:"bbands"`
-	BBandsN          int     `json:"bbandsN"`
-	BBandsK          float64 `json:"bbandsK"`
-	IchimokuEnable   bool    `json:"ichimoku"`
-	RSIEnable        bool    `json:"rsi"`
-	RSIPeriod        int     `json:"rsiPeriod"`
-	RSIBuyThread     float64 `json:"rsiBuyThread"`
-	RSISellThread    float64 `json:"rsiSellThread"`
-	MACDEnable       bool    `json:"macd"`
-	MACDFastPeriod   int     `json:"macdFastPeriod"`
-	MACDSlowPeriod   int     `json:"macdSlowPeriod"`
-	MACDSignalPeriod int     `json:"macdSignalPeriod"`
-	StopLimitPercent float64 `json:"stopLimitPercent"`
+	TradeEnable           bool              `json:"trade"`
+	ProductCode           string            `json:"productCode"`
+	Size                  float64           `json:"size"`
+	SMAEnable             bool              `json:"sma"`
+	SMAPeriod1            int               `json:"smaPeriod1"`
+	SMAPeriod2            int               `json:"smaPeriod2"`
+	SMAPeriod3            int               `json:"smaPeriod3"`
+	EMAEnable             bool              `json:"ema"`
+	EMAPeriod1            int               `json:"emaPeriod1"`
+	EMAPeriod2            int               `json:"emaPeriod2"`
+	EMAPeriod3            int               `json:"emaPeriod3"`
+	BBandsEnable          bool              `json:"bbands"`
+	BBandsN               int               `json:"bbandsN"`
+	BBandsK               float64           `json:"bbandsK"`
+	IchimokuEnable        bool              `json:"ichimoku"`
+	IchimokuTenkanPeriod  int               `json:"ichimokuTenkanPeriod"`
+	IchimokuKijunPeriod   int               `json:"ichimokuKijunPeriod"`
+	IchimokuSenkouBPeriod int               `json:"ichimokuSenkouBPeriod"`
+	RSIEnable             bool              `json:"rsi"`
+	RSIPeriod             int               `json:"rsiPeriod"`
+	RSIBuyThread          float64           `json:"rsiBuyThread"`
+	RSISellThread         float64           `json:"rsiSellThread"`
+	MACDEnable            bool              `json:"macd"`
+	MACDFastPeriod        int               `json:"macdFastPeriod"`
+	MACDSlowPeriod        int               `json:"macdSlowPeriod"`
+	MACDSignalPeriod      int               `json:"macdSignalPeriod"`
+	ATREnable             bool              `json:"atr"`
+	ATRPeriod             int               `json:"atrPeriod"`
+	ATRMultiplier         float64           `json:"atrMultiplier"`
+	StochEnable           bool              `json:"stoch"`
+	StochFastKPeriod      int               `json:"stochFastKPeriod"`
+	StochSlowKPeriod      int               `json:"stochSlowKPeriod"`
+	StochSlowDPeriod      int               `json:"stochSlowDPeriod"`
+	StochBuyThread        float64           `json:"stochBuyThread"`
+	StochSellThread       float64           `json:"stochSellThread"`
+	ADXEnable             bool              `json:"adx"`
+	ADXPeriod             int               `json:"adxPeriod"`
+	ADXThread             float64           `json:"adxThread"`
+	OBVEnable             bool              `json:"obv"`
+	OBVPeriod             int               `json:"obvPeriod"`
+	VWAPEnable            bool              `json:"vwap"`
+	VWAPPeriod            int               `json:"vwapPeriod"`
+	SAREnable             bool              `json:"sar"`
+	SARAcceleration       float64           `json:"sarAcceleration"`
+	SARMaximum            float64           `json:"sarMaximum"`
+	DonchianEnable        bool              `json:"donchian"`
+	DonchianPeriod        int               `json:"donchianPeriod"`
+	KeltnerEnable         bool              `json:"keltner"`
+	KeltnerPeriod         int               `json:"keltnerPeriod"`
+	KeltnerMultiplier     float64           `json:"keltnerMultiplier"`
+	HeikinAshiEnable      bool              `json:"heikinAshi"`
+	HeikinAshiPeriod      int               `json:"heikinAshiPeriod"`
+	SignalWeights         SignalWeights     `json:"signalWeights"`
+	BuyVoteThreshold      float64           `json:"buyVoteThreshold"`
+	SellVoteThreshold     float64           `json:"sellVoteThreshold"`
+	StopLimitPercent      float64           `json:"stopLimitPercent"`
+	MaxLots               int               `json:"maxLots"`
+	LotMatching           model.LotMatching `json:"lotMatching"`
 }
 
 func ConvertTradeParams(params *model.TradeParams) *TradeParams {
@@ -266,31 +518,163 @@ func ConvertTradeParams(params *model.TradeParams) *TradeParams {
 	}
 
 	return &TradeParams{
-		TradeEnable:      params.TradeEnable(),
-		ProductCode:      params.ProductCode(),
-		Size:             params.Size(),
-		SMAEnable:        params.SMAEnable(),
-		SMAPeriod1:       params.SMAPeriod1(),
-		SMAPeriod2:       params.SMAPeriod2(),
-		SMAPeriod3:       params.SMAPeriod3(),
-		EMAEnable:        params.EMAEnable(),
-		EMAPeriod1:       params.EMAPeriod1(),
-		EMAPeriod2:       params.EMAPeriod2(),
-		EMAPeriod3:       params.EMAPeriod3(),
-		BBandsEnable:     params.BBandsEnable(),
-		BBandsN:          params.BBandsN(),
-		BBandsK:          params.BBandsK(),
-		IchimokuEnable:   params.IchimokuEnable(),
-		RSIEnable:        params.RSIEnable(),
-		RSIPeriod:        params.RSIPeriod(),
-		RSIBuyThread:     params.RSIBuyThread(),
-		RSISellThread:    params.RSISellThread(),
-		MACDEnable:       params.MACDEnable(),
-		MACDFastPeriod:   params.MACDFastPeriod(),
-		MACDSlowPeriod:   params.MACDSlowPeriod(),
-		MACDSignalPeriod: params.MACDSignalPeriod(),
-		StopLimitPercent: params.StopLimitPercent(),
+		TradeEnable:           params.TradeEnable(),
+		ProductCode:           params.ProductCode(),
+		Size:                  params.Size(),
+		SMAEnable:             params.SMAEnable(),
+		SMAPeriod1:            params.SMAPeriod1(),
+		SMAPeriod2:            params.SMAPeriod2(),
+		SMAPeriod3:            params.SMAPeriod3(),
+		EMAEnable:             params.EMAEnable(),
+		EMAPeriod1:            params.EMAPeriod1(),
+		EMAPeriod2:            params.EMAPeriod2(),
+		EMAPeriod3:            params.EMAPeriod3(),
+		BBandsEnable:          params.BBandsEnable(),
+		BBandsN:               params.BBandsN(),
+		BBandsK:               params.BBandsK(),
+		IchimokuEnable:        params.IchimokuEnable(),
+		IchimokuTenkanPeriod:  params.IchimokuTenkanPeriod(),
+		IchimokuKijunPeriod:   params.IchimokuKijunPeriod(),
+		IchimokuSenkouBPeriod: params.IchimokuSenkouBPeriod(),
+		RSIEnable:             params.RSIEnable(),
+		RSIPeriod:             params.RSIPeriod(),
+		RSIBuyThread:          params.RSIBuyThread(),
+		RSISellThread:         params.RSISellThread(),
+		MACDEnable:            params.MACDEnable(),
+		MACDFastPeriod:        params.MACDFastPeriod(),
+		MACDSlowPeriod:        params.MACDSlowPeriod(),
+		MACDSignalPeriod:      params.MACDSignalPeriod(),
+		ATREnable:             params.ATREnable(),
+		ATRPeriod:             params.ATRPeriod(),
+		ATRMultiplier:         params.ATRMultiplier(),
+		StochEnable:           params.StochEnable(),
+		StochFastKPeriod:      params.StochFastKPeriod(),
+		StochSlowKPeriod:      params.StochSlowKPeriod(),
+		StochSlowDPeriod:      params.StochSlowDPeriod(),
+		StochBuyThread:        params.StochBuyThread(),
+		StochSellThread:       params.StochSellThread(),
+		ADXEnable:             params.ADXEnable(),
+		ADXPeriod:             params.ADXPeriod(),
+		ADXThread:             params.ADXThread(),
+		OBVEnable:             params.OBVEnable(),
+		OBVPeriod:             params.OBVPeriod(),
+		VWAPEnable:            params.VWAPEnable(),
+		VWAPPeriod:            params.VWAPPeriod(),
+		SAREnable:             params.SAREnable(),
+		SARAcceleration:       params.SARAcceleration(),
+		SARMaximum:            params.SARMaximum(),
+		DonchianEnable:        params.DonchianEnable(),
+		DonchianPeriod:        params.DonchianPeriod(),
+		KeltnerEnable:         params.KeltnerEnable(),
+		KeltnerPeriod:         params.KeltnerPeriod(),
+		KeltnerMultiplier:     params.KeltnerMultiplier(),
+		HeikinAshiEnable:      params.HeikinAshiEnable(),
+		HeikinAshiPeriod:      params.HeikinAshiPeriod(),
+		SignalWeights:         ConvertSignalWeights(params.SignalWeights()),
+		BuyVoteThreshold:      params.BuyVoteThreshold(),
+		SellVoteThreshold:     params.SellVoteThreshold(),
+		StopLimitPercent:      params.StopLimitPercent(),
+		MaxLots:               params.MaxLots(),
+		LotMatching:           params.LotMatching(),
+	}
+}
+
+type SignalWeights struct {
+	EMA        float64 `json:"ema"`
+	BBands     float64 `json:"bbands"`
+	Ichimoku   float64 `json:"ichimoku"`
+	RSI        float64 `json:"rsi"`
+	MACD       float64 `json:"macd"`
+	ATR        float64 `json:"atr"`
+	Stoch      float64 `json:"stoch"`
+	ADX        float64 `json:"adx"`
+	OBV        float64 `json:"obv"`
+	VWAP       float64 `json:"vwap"`
+	SAR        float64 `json:"sar"`
+	Donchian   float64 `json:"donchian"`
+	Keltner    float64 `json:"keltner"`
+	HeikinAshi float64 `json:"heikinAshi"`
+}
+
+func ConvertSignalWeights(weights model.SignalWeights) SignalWeights {
+	return SignalWeights{
+		EMA:        weights.EMA(),
+		BBands:     weights.BBands(),
+		Ichimoku:   weights.Ichimoku(),
+		RSI:        weights.RSI(),
+		MACD:       weights.MACD(),
+		ATR:        weights.ATR(),
+		Stoch:      weights.Stoch(),
+		ADX:        weights.ADX(),
+		OBV:        weights.OBV(),
+		VWAP:       weights.VWAP(),
+		SAR:        weights.SAR(),
+		Donchian:   weights.Donchian(),
+		Keltner:    weights.Keltner(),
+		HeikinAshi: weights.HeikinAshi(),
+	}
+}
+
+type StrategyRule struct {
+	ProductCode string `json:"productCode"`
+	BuyRule     string `json:"buyRule"`
+	SellRule    string `json:"sellRule"`
+}
+
+func ConvertStrategyRule(rule *model.StrategyRule) *StrategyRule {
+	if rule == nil {
+		return nil
 	}
+
+	return &StrategyRule{
+		ProductCode: rule.ProductCode(),
+		BuyRule:     rule.BuyRule().String(),
+		SellRule:    rule.SellRule().String(),
+	}
+}
+
+type AlertRule struct {
+	ID              int64      `json:"id"`
+	ProductCode     string     `json:"productCode"`
+	Kind            string     `json:"kind"`
+	Threshold       float64    `json:"threshold"`
+	Hours           int        `json:"hours"`
+	Expression      string     `json:"expression"`
+	CooldownMinutes int64      `json:"cooldownMinutes"`
+	Enable          bool       `json:"enable"`
+	Active          bool       `json:"active"`
+	NotifiedAt      *time.Time `json:"notifiedAt,omitempty"`
+}
+
+func ConvertAlertRules(rules []model.AlertRule) []AlertRule {
+	dto := make([]AlertRule, len(rules))
+	for i, rule := range rules {
+		// 通知したことがなければ省く
+		var notifiedAt *time.Time
+		if !rule.NotifiedAt().IsZero() {
+			t := rule.NotifiedAt()
+			notifiedAt = &t
+		}
+		dto[i] = AlertRule{
+			ID:              rule.ID(),
+			ProductCode:     rule.ProductCode(),
+			Kind:            string(rule.Kind()),
+			Threshold:       rule.Threshold(),
+			Hours:           rule.Hours(),
+			Expression:      rule.Expression(),
+			CooldownMinutes: int64(rule.Cooldown() / time.Minute),
+			Enable:          rule.Enable(),
+			Active:          rule.Active(),
+			NotifiedAt:      notifiedAt,
+		}
+	}
+	return dto
+}
+
+// スラッシュコマンドへの返信．ephemeralならコマンドを送ったユーザにだけ表示される
+type SlackCommandResponse struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
 }
 
 type Balance struct {
@@ -310,3 +694,225 @@ func ConvertBalance(balance *model.Balance) *Balance {
 		Available:    balance.Available(),
 	}
 }
+
+type Portfolio struct {
+	ProductCode   string  `json:"productCode"`
+	Position      float64 `json:"position"`
+	AveragePrice  float64 `json:"averagePrice"`
+	CurrentPrice  float64 `json:"currentPrice"`
+	RealizedPnL   float64 `json:"realizedPnl"`
+	UnrealizedPnL float64 `json:"unrealizedPnl"`
+	TotalPnL      float64 `json:"totalPnl"`
+	Commission    float64 `json:"commission"`
+	Equity        float64 `json:"equity"`
+}
+
+func ConvertPortfolio(portfolio *model.Portfolio) *Portfolio {
+	if portfolio == nil {
+		return nil
+	}
+
+	return &Portfolio{
+		ProductCode:   portfolio.ProductCode(),
+		Position:      portfolio.Position(),
+		AveragePrice:  portfolio.AveragePrice(),
+		CurrentPrice:  portfolio.CurrentPrice(),
+		RealizedPnL:   portfolio.RealizedPnL(),
+		UnrealizedPnL: portfolio.UnrealizedPnL(),
+		TotalPnL:      portfolio.TotalPnL(),
+		Commission:    portfolio.Commission(),
+		Equity:        portfolio.Equity(),
+	}
+}
+
+type EquitySnapshot struct {
+	Time          time.Time `json:"time"`
+	ProductCode   string    `json:"productCode"`
+	Equity        float64   `json:"equity"`
+	RealizedPnL   float64   `json:"realizedPnl"`
+	UnrealizedPnL float64   `json:"unrealizedPnl"`
+	Position      float64   `json:"position"`
+	Price         float64   `json:"price"`
+}
+
+func ConvertEquitySnapshot(snapshot model.EquitySnapshot) EquitySnapshot {
+	return EquitySnapshot{
+		Time:          snapshot.Time(),
+		ProductCode:   snapshot.ProductCode(),
+		Equity:        snapshot.Equity(),
+		RealizedPnL:   snapshot.RealizedPnL(),
+		UnrealizedPnL: snapshot.UnrealizedPnL(),
+		Position:      snapshot.Position(),
+		Price:         snapshot.Price(),
+	}
+}
+
+type Spread struct {
+	Time         time.Time `json:"time"`
+	ProductCode  string    `json:"productCode"`
+	BaseExchange string    `json:"baseExchange"`
+	Exchange     string    `json:"exchange"`
+	BasePrice    float64   `json:"basePrice"`
+	Price        float64   `json:"price"`
+	Rate         float64   `json:"rate"`
+}
+
+func ConvertSpread(spread model.Spread) Spread {
+	return Spread{
+		Time:         spread.Time(),
+		ProductCode:  spread.ProductCode(),
+		BaseExchange: spread.BaseExchange(),
+		Exchange:     spread.Exchange(),
+		BasePrice:    spread.BasePrice(),
+		Price:        spread.Price(),
+		Rate:         spread.Rate(),
+	}
+}
+
+type BacktestJobRequest struct {
+	Strategy string      `json:"strategy"`
+	Params   TradeParams `json:"params"`
+	Start    time.Time   `json:"start"`
+	End      time.Time   `json:"end"`
+}
+
+type BacktestJob struct {
+	ID          string          `json:"id"`
+	ProductCode string          `json:"productCode"`
+	Strategy    string          `json:"strategy"`
+	Params      *TradeParams    `json:"params"`
+	Start       time.Time       `json:"start"`
+	End         time.Time       `json:"end"`
+	Status      string          `json:"status"`
+	Error       string          `json:"error,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	FinishedAt  *time.Time      `json:"finishedAt,omitempty"`
+	Result      *BacktestResult `json:"result,omitempty"`
+}
+
+// 一覧表示ではwithDetail=falseにして，売買履歴と資産推移を省く
+func ConvertBacktestJob(job *model.BacktestJob, withDetail bool) *BacktestJob {
+	if job == nil {
+		return nil
+	}
+
+	params := job.Params()
+
+	var finishedAt *time.Time
+	if !job.FinishedAt().IsZero() {
+		t := job.FinishedAt()
+		finishedAt = &t
+	}
+
+	return &BacktestJob{
+		ID:          job.ID(),
+		ProductCode: job.ProductCode(),
+		Strategy:    job.Strategy(),
+		Params:      ConvertTradeParams(&params),
+		Start:       job.Start(),
+		End:         job.End(),
+		Status:      string(job.Status()),
+		Error:       job.ErrorMessage(),
+		CreatedAt:   job.CreatedAt(),
+		FinishedAt:  finishedAt,
+		Result:      ConvertBacktestResult(job.Result(), withDetail),
+	}
+}
+
+type BacktestResult struct {
+	Signals     []SignalEvent `json:"signals,omitempty"`
+	Profit      float64       `json:"profit"`
+	TradeCount  int           `json:"tradeCount"`
+	WinCount    int           `json:"winCount"`
+	WinRate     float64       `json:"winRate"`
+	MaxDrawdown float64       `json:"maxDrawdown"`
+	EquityCurve []EquityPoint `json:"equityCurve,omitempty"`
+}
+
+func ConvertBacktestResult(result *model.BacktestResult, withDetail bool) *BacktestResult {
+	if result == nil {
+		return nil
+	}
+
+	dto := &BacktestResult{
+		Profit:      result.Profit(),
+		TradeCount:  result.TradeCount(),
+		WinCount:    result.WinCount(),
+		WinRate:     result.WinRate(),
+		MaxDrawdown: result.MaxDrawdown(),
+	}
+
+	if withDetail {
+		dto.Signals = make([]SignalEvent, 0)
+		for _, s := range result.Signals() {
+			dto.Signals = append(dto.Signals, ConvertSignalEvent(s))
+		}
+		dto.EquityCurve = make([]EquityPoint, 0)
+		for _, p := range result.EquityCurve() {
+			dto.EquityCurve = append(dto.EquityCurve, ConvertEquityPoint(p))
+		}
+	}
+
+	return dto
+}
+
+type EquityPoint struct {
+	Time   time.Time `json:"time"`
+	Equity float64   `json:"equity"`
+}
+
+func ConvertEquityPoint(p model.EquityPoint) EquityPoint {
+	return EquityPoint{
+		Time:   p.Time(),
+		Equity: p.Equity(),
+	}
+}
+
+type GridBacktest struct {
+	ProductCode string     `json:"productCode"`
+	LowerPrice  float64    `json:"lowerPrice"`
+	UpperPrice  float64    `json:"upperPrice"`
+	Levels      int        `json:"levels"`
+	Size        float64    `json:"size"`
+	Fills       []GridFill `json:"fills"`
+	Profit      float64    `json:"profit"`
+	TotalProfit float64    `json:"totalProfit"`
+	Position    float64    `json:"position"`
+}
+
+type GridFill struct {
+	Time  time.Time       `json:"time"`
+	Level int             `json:"level"`
+	Side  model.OrderSide `json:"side"`
+	Price float64         `json:"price"`
+	Size  float64         `json:"size"`
+}
+
+func ConvertGridBacktest(grid *model.Grid, result *model.GridBacktestResult, levels []model.GridLevel) *GridBacktest {
+	if grid == nil || result == nil {
+		return nil
+	}
+
+	fills := make([]GridFill, len(result.Fills()))
+	for i, fill := range result.Fills() {
+		fills[i] = GridFill{
+			Time:  fill.Time(),
+			Level: fill.Level(),
+			Side:  fill.Side(),
+			Price: fill.Price(),
+			Size:  fill.Size(),
+		}
+	}
+
+	return &GridBacktest{
+		ProductCode: grid.ProductCode(),
+		LowerPrice:  grid.LowerPrice(),
+		UpperPrice:  grid.UpperPrice(),
+		Levels:      grid.Levels(),
+		Size:        grid.Size(),
+		Fills:       fills,
+		Profit:      result.Profit(),
+		TotalProfit: result.TotalProfit(levels),
+		Position:    result.Position(),
+	}
+}
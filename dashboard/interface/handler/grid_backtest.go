@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler/dto"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+type GridBacktestHandler interface {
+	Get(productCode string) http.HandlerFunc
+}
+
+type gridBacktestHandler struct {
+	gridBacktestUsecase usecase.GridBacktestUsecase
+}
+
+func NewGridBacktestHandler(gu usecase.GridBacktestUsecase) GridBacktestHandler {
+	return &gridBacktestHandler{
+		gridBacktestUsecase: gu,
+	}
+}
+
+func (gh *gridBacktestHandler) Get(productCode string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		grid := model.NewGrid(
+			productCode,
+			getQueryFloatDefault(r, "lowerPrice", 0),
+			getQueryFloatDefault(r, "upperPrice", 0),
+			getQueryUintDefault(r, "levels", 11),
+			getQueryFloatDefault(r, "size", 0.01),
+		)
+		if grid == nil {
+			http.Error(w, "invalid grid", http.StatusBadRequest)
+			return
+		}
+
+		// [0, 1000]の範囲に限定
+		candleLimit := getQueryUintDefault(r, "limit", 365)
+		if candleLimit > 1000 {
+			candleLimit = 1000
+		}
+
+		result, levels, err := gh.gridBacktestUsecase.Backtest(*grid, int64(candleLimit))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		js, err := json.Marshal(dto.ConvertGridBacktest(grid, result, levels))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(js)
+	}
+}
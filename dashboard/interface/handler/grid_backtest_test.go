@@ -0,0 +1,85 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler/dto"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestGridBacktestHandler(t *testing.T) {
+	candleRepository := persistence.NewCandleMockRepository(config.CandleTableName, config.TimeFormat, config.ProductCode, config.CandleDuration)
+	if candleRepository == nil {
+		t.Fatal("NewCandleMockRepository() returns nil")
+	}
+
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+
+	gridBacktestUsecase := usecase.NewGridBacktestUsecase(candleService)
+
+	gridBacktestHandler := handler.NewGridBacktestHandler(gridBacktestUsecase)
+
+	ts := httptest.NewServer(gridBacktestHandler.Get(config.ProductCode))
+	defer ts.Close()
+
+	get := func(params map[string]string) *http.Response {
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		query := req.URL.Query()
+		for k, v := range params {
+			query.Add(k, v)
+		}
+		req.URL.RawQuery = query.Encode()
+
+		client := http.Client{}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		return resp
+	}
+
+	t.Run("get", func(t *testing.T) {
+		resp := get(map[string]string{
+			"lowerPrice": "200000",
+			"upperPrice": "600000",
+			"levels":     "21",
+			"size":       "0.01",
+			"limit":      "365",
+		})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		var result dto.GridBacktest
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			t.Fatal(err.Error())
+		}
+		if result.Levels != 21 {
+			t.Fatalf("result.Levels=%d", result.Levels)
+		}
+	})
+
+	t.Run("invalid grid", func(t *testing.T) {
+		resp := get(map[string]string{
+			"lowerPrice": "600000",
+			"upperPrice": "200000",
+		})
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatal("resp.StatusCode != http.StatusBadRequest")
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler/dto"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+type PortfolioHandler interface {
+	Get(productCode string) http.HandlerFunc
+	GetEquity(productCode string) http.HandlerFunc
+}
+
+type portfolioHandler struct {
+	portfolioUsecase usecase.PortfolioUsecase
+}
+
+func NewPortfolioHandler(pu usecase.PortfolioUsecase) PortfolioHandler {
+	return &portfolioHandler{
+		portfolioUsecase: pu,
+	}
+}
+
+func (ph *portfolioHandler) Get(productCode string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		portfolio, err := ph.portfolioUsecase.Get(productCode)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		js, err := json.Marshal(dto.ConvertPortfolio(portfolio))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(js)
+	}
+}
+
+// チャート描画用の資産推移
+func (ph *portfolioHandler) GetEquity(productCode string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// [0, 1000]の範囲に限定
+		limit := getQueryUintDefault(r, "limit", 365)
+		if limit > 1000 {
+			limit = 1000
+		}
+
+		snapshots, err := ph.portfolioUsecase.FindSnapshots(productCode, int64(limit))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		resDto := make([]dto.EquitySnapshot, 0)
+		for _, snapshot := range snapshots {
+			resDto = append(resDto, dto.ConvertEquitySnapshot(snapshot))
+		}
+
+		js, err := json.Marshal(resDto)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(js)
+	}
+}
@@ -0,0 +1,86 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler/dto"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestPortfolio(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	balanceRepository := bitflyer.NewBitFlyerBalanceMockRepository()
+	tickerRepository := bitflyer.NewBitflyerTickerMockRepository()
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	equitySnapshotRepository := persistence.NewEquitySnapshotRepository(tx, config.TimeFormat)
+
+	portfolioService := service.NewPortfolioService(balanceRepository, tickerRepository, signalEventRepository, equitySnapshotRepository, config.CommissionRate)
+
+	portfolioUsecase := usecase.NewPortfolioUsecase(portfolioService)
+
+	portfolioHandler := handler.NewPortfolioHandler(portfolioUsecase)
+
+	t.Run("get portfolio", func(t *testing.T) {
+		ts := httptest.NewServer(portfolioHandler.Get(config.ProductCode))
+		defer ts.Close()
+
+		resp, err := http.Get(ts.URL)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+
+		respBody, _ := ioutil.ReadAll(resp.Body)
+
+		var portfolio dto.Portfolio
+		err = json.Unmarshal(respBody, &portfolio)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+
+	t.Run("get equity", func(t *testing.T) {
+		snapshot := model.NewEquitySnapshot(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), config.ProductCode, 10000, 0, 0, 0, 1000)
+		err := equitySnapshotRepository.Save(*snapshot)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		ts := httptest.NewServer(portfolioHandler.GetEquity(config.ProductCode))
+		defer ts.Close()
+
+		resp, err := http.Get(ts.URL + "?limit=1")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+
+		respBody, _ := ioutil.ReadAll(resp.Body)
+
+		var snapshots []dto.EquitySnapshot
+		err = json.Unmarshal(respBody, &snapshots)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(snapshots) != 1 {
+			t.Fatal("len(snapshots) != 1")
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/external/slack"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler/dto"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+type SlackCommandHandler interface {
+	Handle(productCode string) http.HandlerFunc
+}
+
+type slackCommandHandler struct {
+	slackCommandUsecase usecase.SlackCommandUsecase
+	signingSecret       string
+}
+
+func NewSlackCommandHandler(su usecase.SlackCommandUsecase, signingSecret string) SlackCommandHandler {
+	return &slackCommandHandler{
+		slackCommandUsecase: su,
+		signingSecret:       signingSecret,
+	}
+}
+
+// Slackはステータスが200でないと返信を表示しないので，コマンドの失敗は200で本文に書いて返す
+func (sh *slackCommandHandler) Handle(productCode string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// 署名の検証にはパースする前の本文を使う
+		defer r.Body.Close()
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := slack.VerifySignature(sh.signingSecret, r.Header, body, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		text, err := sh.slackCommandUsecase.Execute(productCode, form.Get("user_id"), form.Get("text"))
+		switch err {
+		case nil:
+		case usecase.ErrSlackCommandForbidden:
+			text = "このコマンドを実行する権限がありません"
+		case model.ErrUnknownSlackCommand:
+			text = fmt.Sprintf("%sは使えません．/bot helpで使い方を確認してください", form.Get("text"))
+		default:
+			fmt.Println(err.Error())
+			text = "エラーが生じました: " + err.Error()
+		}
+
+		writeJSON(w, http.StatusOK, dto.SlackCommandResponse{
+			ResponseType: "ephemeral",
+			Text:         text,
+		})
+	}
+}
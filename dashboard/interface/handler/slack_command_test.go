@@ -0,0 +1,110 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/external/slack"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler/dto"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestSlackCommandHandler(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	balanceRepository := bitflyer.NewBitFlyerBalanceMockRepository()
+	tickerRepository := bitflyer.NewBitflyerTickerMockRepository()
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	equitySnapshotRepository := persistence.NewEquitySnapshotRepository(tx, config.TimeFormat)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+
+	portfolioService := service.NewPortfolioService(balanceRepository, tickerRepository, signalEventRepository, equitySnapshotRepository, config.CommissionRate)
+
+	slackCommandUsecase := usecase.NewSlackCommandUsecase(portfolioService, balanceRepository, tradeParamsRepository, map[string]model.SlackRole{
+		"UVIEWER": model.SlackRoleViewer,
+	})
+
+	secret := "signing-secret"
+	slackCommandHandler := handler.NewSlackCommandHandler(slackCommandUsecase, secret)
+
+	ts := httptest.NewServer(slackCommandHandler.Handle(config.ProductCode))
+	defer ts.Close()
+
+	post := func(userID, text string, signed bool) *http.Response {
+		body := url.Values{
+			"command": {"/bot"},
+			"user_id": {userID},
+			"text":    {text},
+		}.Encode()
+
+		req, err := http.NewRequest("POST", ts.URL, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+		signature := "v0=invalid"
+		if signed {
+			signature = slack.Signature(secret, timestamp, []byte(body))
+		}
+		req.Header.Set("X-Slack-Signature", signature)
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		return resp
+	}
+
+	readText := func(resp *http.Response) string {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+
+		var respDto dto.SlackCommandResponse
+		if err := json.Unmarshal(respBody, &respDto); err != nil {
+			t.Fatal(err.Error())
+		}
+		return respDto.Text
+	}
+
+	t.Run("help", func(t *testing.T) {
+		resp := post("UVIEWER", "help", true)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+		if text := readText(resp); !strings.Contains(text, "/bot pause") {
+			t.Fatalf("text=%q", text)
+		}
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		resp := post("UVIEWER", "pause", true)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+		if text := readText(resp); !strings.Contains(text, "権限") {
+			t.Fatalf("text=%q", text)
+		}
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		resp := post("UVIEWER", "status", false)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatal("resp.StatusCode != http.StatusUnauthorized")
+		}
+	})
+}
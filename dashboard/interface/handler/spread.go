@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler/dto"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+type SpreadHandler interface {
+	Get(productCode string) http.HandlerFunc
+}
+
+type spreadHandler struct {
+	spreadUsecase usecase.SpreadUsecase
+}
+
+func NewSpreadHandler(su usecase.SpreadUsecase) SpreadHandler {
+	return &spreadHandler{
+		spreadUsecase: su,
+	}
+}
+
+// チャート描画用の取引所間の価格差（全ての取引所の分をまとめてlimit件）
+func (sh *spreadHandler) Get(productCode string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// [0, 5000]の範囲に限定
+		limit := getQueryUintDefault(r, "limit", 1440)
+		if limit > 5000 {
+			limit = 5000
+		}
+
+		spreads, err := sh.spreadUsecase.FindAll(productCode, int64(limit))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		resDto := make([]dto.Spread, 0)
+		for _, spread := range spreads {
+			resDto = append(resDto, dto.ConvertSpread(spread))
+		}
+
+		js, err := json.Marshal(resDto)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(js)
+	}
+}
@@ -0,0 +1,58 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler/dto"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestSpread(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	spreadRepository := persistence.NewSpreadRepository(tx, config.TimeFormat)
+
+	spreadUsecase := usecase.NewSpreadUsecase(spreadRepository)
+
+	spreadHandler := handler.NewSpreadHandler(spreadUsecase)
+
+	t.Run("get spread", func(t *testing.T) {
+		spread := model.NewSpread(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), config.ProductCode, "bitflyer", "coincheck", 500000, 505000)
+		err := spreadRepository.Save(*spread)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		ts := httptest.NewServer(spreadHandler.Get(config.ProductCode))
+		defer ts.Close()
+
+		resp, err := http.Get(ts.URL + "?limit=1")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+
+		respBody, _ := ioutil.ReadAll(resp.Body)
+
+		var spreads []dto.Spread
+		err = json.Unmarshal(respBody, &spreads)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(spreads) != 1 || spreads[0].Rate != 0.01 {
+			t.Fatalf("spreads=%+v", spreads)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler/dto"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+type StrategyRuleHandler interface {
+	HandlerFunc() http.HandlerFunc
+}
+
+type strategyRuleHandler struct {
+	strategyRuleUsecase usecase.StrategyRuleUsecase
+}
+
+func NewStrategyRuleHandler(su usecase.StrategyRuleUsecase) StrategyRuleHandler {
+	return &strategyRuleHandler{
+		strategyRuleUsecase: su,
+	}
+}
+
+func (sh *strategyRuleHandler) HandlerFunc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			sh.Get(w, r)
+		case http.MethodPost:
+			sh.Post(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func (sh *strategyRuleHandler) Get(w http.ResponseWriter, r *http.Request) {
+	productCode := r.URL.Query().Get("productCode")
+
+	rule, err := sh.strategyRuleUsecase.Get(productCode)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	dto := dto.ConvertStrategyRule(rule)
+
+	js, err := json.Marshal(dto)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(js)
+}
+
+func (sh *strategyRuleHandler) Post(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var dto dto.StrategyRule
+	if err := json.Unmarshal(body, &dto); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 式の誤りはそのまま返して画面に表示する
+	rule, err := dtoToStrategyRule(dto)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = sh.strategyRuleUsecase.Save(*rule)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Success"))
+}
+
+func dtoToStrategyRule(dto dto.StrategyRule) (*model.StrategyRule, error) {
+	if _, err := model.ParseRule(dto.BuyRule); err != nil {
+		return nil, fmt.Errorf("buy rule: %s", err.Error())
+	}
+	if _, err := model.ParseRule(dto.SellRule); err != nil {
+		return nil, fmt.Errorf("sell rule: %s", err.Error())
+	}
+
+	rule := model.NewStrategyRule(dto.ProductCode, dto.BuyRule, dto.SellRule)
+	if rule == nil {
+		return nil, errors.New("invalid strategy rule")
+	}
+	return rule, nil
+}
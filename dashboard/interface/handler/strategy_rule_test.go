@@ -0,0 +1,114 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler/dto"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestStrategyRule(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	strategyRuleRepository := persistence.NewStrategyRuleRepository(tx)
+
+	strategyRuleUsecase := usecase.NewStrategyRuleUsecase(strategyRuleRepository)
+
+	strategyRuleHandler := handler.NewStrategyRuleHandler(strategyRuleUsecase)
+
+	// save dammy strategy_rule
+	rule := model.NewStrategyRule(config.ProductCode, "crossover(ema(7), ema(21))", "crossunder(ema(7), ema(21))")
+	err := strategyRuleUsecase.Save(*rule)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	post := func(ruleDto dto.StrategyRule) *http.Response {
+		ts := httptest.NewServer(strategyRuleHandler.HandlerFunc())
+		defer ts.Close()
+
+		reqBody, err := json.Marshal(ruleDto)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		req, err := http.NewRequest("POST", ts.URL, bytes.NewBuffer(reqBody))
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		client := http.Client{}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		return resp
+	}
+
+	t.Run("get strategy_rule", func(t *testing.T) {
+		ts := httptest.NewServer(strategyRuleHandler.HandlerFunc())
+		defer ts.Close()
+
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		query := req.URL.Query()
+		query.Add("productCode", config.ProductCode)
+		req.URL.RawQuery = query.Encode()
+
+		client := http.Client{}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+
+		respBody, _ := ioutil.ReadAll(resp.Body)
+
+		var ruleDto dto.StrategyRule
+		err = json.Unmarshal(respBody, &ruleDto)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if ruleDto.BuyRule != "crossover(ema(7), ema(21))" {
+			t.Fatalf("buyRule=%q", ruleDto.BuyRule)
+		}
+	})
+
+	t.Run("post strategy_rule", func(t *testing.T) {
+		resp := post(dto.StrategyRule{
+			ProductCode: config.ProductCode,
+			BuyRule:     "rsi(14) < 30",
+			SellRule:    "rsi(14) > 70",
+		})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+	})
+
+	t.Run("post invalid strategy_rule", func(t *testing.T) {
+		resp := post(dto.StrategyRule{
+			ProductCode: config.ProductCode,
+			BuyRule:     "rsi(14) <",
+			SellRule:    "rsi(14) > 70",
+		})
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatal("resp.StatusCode != http.StatusBadRequest")
+		}
+	})
+}
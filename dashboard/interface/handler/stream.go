@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler/dto"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+type StreamHandler interface {
+	Stream(heartbeat time.Duration) http.HandlerFunc
+}
+
+type streamHandler struct {
+	streamUsecase usecase.StreamUsecase
+}
+
+func NewStreamHandler(su usecase.StreamUsecase) StreamHandler {
+	return &streamHandler{
+		streamUsecase: su,
+	}
+}
+
+// Server-Sent Eventsで更新を配信する
+// 接続を維持するため，heartbeatごとにコメント行を送る
+func (sh *streamHandler) Stream(heartbeat time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		events, unsubscribe := sh.streamUsecase.Subscribe()
+		defer unsubscribe()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ticker.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+				flusher.Flush()
+			case event, ok := <-events:
+				if !ok {
+					// バッファが溢れて切断された
+					return
+				}
+				js, err := json.Marshal(convertStreamEvent(event))
+				if err != nil {
+					fmt.Println("[StreamHandler]", err)
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, js)
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+func convertStreamEvent(event usecase.StreamEvent) interface{} {
+	switch event.Type {
+	case usecase.StreamEventTypeCandle:
+		return dto.ConvertCandle(*event.Candle)
+	case usecase.StreamEventTypeSignal:
+		return dto.ConvertSignalEvent(*event.SignalEvent)
+	case usecase.StreamEventTypeTradeParams:
+		// 公開しているストリームなので，パラメータの中身は送らない
+		return struct{}{}
+	}
+	return nil
+}
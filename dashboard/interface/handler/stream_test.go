@@ -0,0 +1,76 @@
+package handler_test
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestStream(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	candleRepository := persistence.NewCandleRepository(tx, config.CandleTableName, config.TimeFormat)
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	signalEventService := service.NewSignalEventService(signalEventRepository)
+
+	streamUsecase := usecase.NewStreamUsecase(candleService, signalEventService, nil, 16)
+
+	streamHandler := handler.NewStreamHandler(streamUsecase)
+
+	ts := httptest.NewServer(streamHandler.Stream(10 * time.Millisecond))
+	defer ts.Close()
+
+	err := streamUsecase.Poll(config.ProductCode)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	t.Run("stream signal event", func(t *testing.T) {
+		resp, err := http.Get(ts.URL)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		defer resp.Body.Close()
+		if resp.Header.Get("Content-Type") != "text/event-stream" {
+			t.Fatal("Content-Type != text/event-stream")
+		}
+
+		signal := model.NewSignalEvent(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), config.ProductCode, model.OrderSideBuy, 1000, 0.01)
+		signalEventService.Save(*signal)
+		err = streamUsecase.Poll(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		heartbeat, signalEvent := false, false
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() && !(heartbeat && signalEvent) {
+			line := scanner.Text()
+			if strings.HasPrefix(line, ": heartbeat") {
+				heartbeat = true
+			}
+			if line == "event: "+string(usecase.StreamEventTypeSignal) {
+				signalEvent = true
+			}
+		}
+		if !heartbeat {
+			t.Fatal("heartbeat is not received")
+		}
+		if !signalEvent {
+			t.Fatal("signal event is not received")
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+type TaxReportHandler interface {
+	Get(productCode string) http.HandlerFunc
+}
+
+type taxReportHandler struct {
+	taxReportUsecase usecase.TaxReportUsecase
+}
+
+func NewTaxReportHandler(tu usecase.TaxReportUsecase) TaxReportHandler {
+	return &taxReportHandler{
+		taxReportUsecase: tu,
+	}
+}
+
+// 年ごとの売買損益をCSVでダウンロードさせる
+func (th *taxReportHandler) Get(productCode string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		method := model.CostMethod(r.URL.Query().Get("method"))
+		if method == "" {
+			method = model.CostMethodMovingAverage
+		}
+		if !method.IsValid() {
+			http.Error(w, "invalid method", http.StatusBadRequest)
+			return
+		}
+
+		// 失敗したときにエラーを返せるよう，一度バッファに書き出す
+		var buf bytes.Buffer
+		err := th.taxReportUsecase.WriteCSV(&buf, productCode, method)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		filename := fmt.Sprintf("tax_report_%s_%s.csv", productCode, method)
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		w.Write(buf.Bytes())
+	}
+}
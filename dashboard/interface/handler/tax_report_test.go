@@ -0,0 +1,59 @@
+package handler_test
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestTaxReport(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	signalEventService := service.NewSignalEventService(signalEventRepository)
+
+	taxReportUsecase := usecase.NewTaxReportUsecase(signalEventService, config.CommissionRate, config.LocalTime)
+
+	taxReportHandler := handler.NewTaxReportHandler(taxReportUsecase)
+
+	ts := httptest.NewServer(taxReportHandler.Get(config.ProductCode))
+	defer ts.Close()
+
+	t.Run("get tax report", func(t *testing.T) {
+		resp, err := http.Get(ts.URL + "?method=total_average")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+
+		records, err := csv.NewReader(resp.Body).ReadAll()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(records) < 1 {
+			t.Fatal("len(records) < 1")
+		}
+	})
+
+	t.Run("invalid method", func(t *testing.T) {
+		resp, err := http.Get(ts.URL + "?method=fifo")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatal("resp.StatusCode != http.StatusBadRequest")
+		}
+	})
+}
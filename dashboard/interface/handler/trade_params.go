@@ -88,6 +88,30 @@ func reqJsonToTradeParams(r *http.Request) (*model.TradeParams, error) {
 		return nil, err
 	}
 
+	return dtoToTradeParams(dto)
+}
+
+func dtoToTradeParams(dto dto.TradeParams) (*model.TradeParams, error) {
+	signalWeights := model.NewSignalWeights(
+		dto.SignalWeights.EMA,
+		dto.SignalWeights.BBands,
+		dto.SignalWeights.Ichimoku,
+		dto.SignalWeights.RSI,
+		dto.SignalWeights.MACD,
+		dto.SignalWeights.ATR,
+		dto.SignalWeights.Stoch,
+		dto.SignalWeights.ADX,
+		dto.SignalWeights.OBV,
+		dto.SignalWeights.VWAP,
+		dto.SignalWeights.SAR,
+		dto.SignalWeights.Donchian,
+		dto.SignalWeights.Keltner,
+		dto.SignalWeights.HeikinAshi,
+	)
+	if signalWeights == nil {
+		return nil, errors.New("invalid signal weights")
+	}
+
 	params := model.NewTradeParams(
 		dto.TradeEnable,
 		dto.ProductCode,
@@ -104,6 +128,9 @@ func reqJsonToTradeParams(r *http.Request) (*model.TradeParams, error) {
 		dto.BBandsN,
 		dto.BBandsK,
 		dto.IchimokuEnable,
+		dto.IchimokuTenkanPeriod,
+		dto.IchimokuKijunPeriod,
+		dto.IchimokuSenkouBPeriod,
 		dto.RSIEnable,
 		dto.RSIPeriod,
 		dto.RSIBuyThread,
@@ -112,7 +139,38 @@ func reqJsonToTradeParams(r *http.Request) (*model.TradeParams, error) {
 		dto.MACDFastPeriod,
 		dto.MACDSlowPeriod,
 		dto.MACDSignalPeriod,
+		dto.ATREnable,
+		dto.ATRPeriod,
+		dto.ATRMultiplier,
+		dto.StochEnable,
+		dto.StochFastKPeriod,
+		dto.StochSlowKPeriod,
+		dto.StochSlowDPeriod,
+		dto.StochBuyThread,
+		dto.StochSellThread,
+		dto.ADXEnable,
+		dto.ADXPeriod,
+		dto.ADXThread,
+		dto.OBVEnable,
+		dto.OBVPeriod,
+		dto.VWAPEnable,
+		dto.VWAPPeriod,
+		dto.SAREnable,
+		dto.SARAcceleration,
+		dto.SARMaximum,
+		dto.DonchianEnable,
+		dto.DonchianPeriod,
+		dto.KeltnerEnable,
+		dto.KeltnerPeriod,
+		dto.KeltnerMultiplier,
+		dto.HeikinAshiEnable,
+		dto.HeikinAshiPeriod,
+		*signalWeights,
+		dto.BuyVoteThreshold,
+		dto.SellVoteThreshold,
 		dto.StopLimitPercent,
+		dto.MaxLots,
+		dto.LotMatching,
 	)
 
 	if params == nil {
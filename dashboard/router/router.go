@@ -1,13 +1,17 @@
 package router
 
 import (
+	"context"
 	"fmt"
 	"html/template"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/external/bitflyer"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/interface/handler"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
@@ -19,11 +23,17 @@ func Run() {
 	// sessionRepository := persistence.NewSessionRepository(config.DB)
 	candleRepository := persistence.NewCandleRepository(config.DB, config.CandleTableName, config.TimeFormat)
 	signalEventRepository := persistence.NewSignalEventRepository(config.DB, config.TimeFormat)
-	// tradeParamsRepository := persistence.NewTradeParamsRepository(config.DB)
+	backtestJobRepository := persistence.NewBacktestJobRepository(config.DB, config.TimeFormat)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(config.DB)
+	strategyRuleRepository := persistence.NewStrategyRuleRepository(config.DB)
+	// alertRuleRepository := persistence.NewAlertRuleRepository(config.DB, config.TimeFormat)
+	spreadRepository := persistence.NewSpreadRepository(config.DB, config.TimeFormat)
+	// equitySnapshotRepository := persistence.NewEquitySnapshotRepository(config.DB, config.TimeFormat)
 	// cookie := persistence.NewCookie("cryptobot", "/", 60*30, config.SecureCookie)
 	// repository (bitflyer)
 	// bitflyerClient := bitflyer.NewClient(config.APIKey, config.APISecret)
 	// balanceRepository := bitflyer.NewBitFlyerBalanceRepository(bitflyerClient)
+	// tickerRepository := bitflyer.NewBitflyerTickerRepository(bitflyerClient)
 
 	// service
 	// authService := service.NewAuthService(userRepository, sessionRepository)
@@ -31,23 +41,80 @@ func Run() {
 	signalEventService := service.NewSignalEventService(signalEventRepository)
 	indicatorService := service.NewIndicatorService()
 	dataFrameService := service.NewMRBaseDataFrameService(indicatorService)
+	// portfolioService := service.NewPortfolioService(balanceRepository, tickerRepository, signalEventRepository, equitySnapshotRepository, config.CommissionRate)
 
 	// usecase
 	dataFrameUsecase := usecase.NewDataFrameUsecase(candleService, signalEventService, dataFrameService)
+	// taxReportUsecase := usecase.NewTaxReportUsecase(signalEventService, config.CommissionRate, config.LocalTime)
+	streamUsecase := usecase.NewStreamUsecase(candleService, signalEventService, tradeParamsRepository, 16)
+	backtestJobUsecase := usecase.NewBacktestJobUsecase(candleService, map[string]service.DataFrameService{
+		"default":  service.NewDataFrameService(indicatorService),
+		"mr_base":  dataFrameService,
+		"weighted": service.NewWeightedDataFrameService(indicatorService),
+		"rule":     service.NewRuleDataFrameService(indicatorService, strategyRuleRepository),
+		// 週足のトレンドで買いサインを確認する
+		"mr_base_weekly_trend": service.NewTrendFilterDataFrameService(dataFrameService, 7*24*time.Hour, 10),
+	}, backtestJobRepository, 32)
+	gridBacktestUsecase := usecase.NewGridBacktestUsecase(candleService)
+	spreadUsecase := usecase.NewSpreadUsecase(spreadRepository)
 	// tradeParamsUsecase := usecase.NewTradeParamsUsecase(tradeParamsRepository)
+	// strategyRuleUsecase := usecase.NewStrategyRuleUsecase(strategyRuleRepository)
+	// alertRuleUsecase := usecase.NewAlertRuleUsecase(alertRuleRepository)
 	// balanceUsecase := usecase.NewBalanceUsecase(balanceRepository)
+	// portfolioUsecase := usecase.NewPortfolioUsecase(portfolioService)
 
 	// handler
 	// authHandler := handler.NewAuthHandler(cookie, authService)
 	dataFrameHandler := handler.NewDataFrameHandler(dataFrameUsecase)
+	// taxReportHandler := handler.NewTaxReportHandler(taxReportUsecase)
+	streamHandler := handler.NewStreamHandler(streamUsecase)
+	backtestJobHandler := handler.NewBacktestJobHandler(backtestJobUsecase)
+	gridBacktestHandler := handler.NewGridBacktestHandler(gridBacktestUsecase)
+	spreadHandler := handler.NewSpreadHandler(spreadUsecase)
 	// tradeParamsHandler := handler.NewTradeParamsHandler(tradeParamsUsecase)
+	// strategyRuleHandler := handler.NewStrategyRuleHandler(strategyRuleUsecase)
+	// alertRuleHandler := handler.NewAlertRuleHandler(alertRuleUsecase)
 	// balanceHandler := handler.NewBalanceHandler(balanceUsecase)
+	// portfolioHandler := handler.NewPortfolioHandler(portfolioUsecase)
 
 	// http.HandleFunc("/api/login", authHandler.Login())
 	// http.HandleFunc("/api/logout", authHandler.Logout())
 	http.HandleFunc("/api/candle", dataFrameHandler.Get(config.ProductCode))
+	http.HandleFunc("/api/stream", streamHandler.Stream(15*time.Second))
+	http.HandleFunc("/api/backtest", backtestJobHandler.ListHandlerFunc(config.ProductCode))
+	http.HandleFunc("/api/backtest/job", backtestJobHandler.GetHandlerFunc())
+	http.HandleFunc("/api/backtest/grid", gridBacktestHandler.Get(config.ProductCode))
+	http.HandleFunc("/api/spread", spreadHandler.Get(config.ProductCode))
+	// http.HandleFunc("/admin/api/backtest", AuthGuardHandlerFunc(backtestJobHandler.HandlerFunc(config.ProductCode), authHandler))
+	// http.HandleFunc("/admin/api/backtest/job", AuthGuardHandlerFunc(backtestJobHandler.JobHandlerFunc(), authHandler))
 	// http.HandleFunc("/admin/api/trade-params", AuthGuardHandlerFunc(tradeParamsHandler.HandlerFunc(), authHandler))
+	// http.HandleFunc("/admin/api/strategy-rule", AuthGuardHandlerFunc(strategyRuleHandler.HandlerFunc(), authHandler))
+	// http.HandleFunc("/admin/api/alert-rule", AuthGuardHandlerFunc(alertRuleHandler.HandlerFunc(), authHandler))
+	// http.HandleFunc("/admin/api/tax-report", AuthGuardHandlerFunc(taxReportHandler.Get(config.ProductCode), authHandler))
 	// http.HandleFunc("/admin/api/balance", AuthGuardHandlerFunc(balanceHandler.Get(), authHandler))
+	// http.HandleFunc("/admin/api/portfolio", AuthGuardHandlerFunc(portfolioHandler.Get(config.ProductCode), authHandler))
+	// http.HandleFunc("/admin/api/portfolio/equity", AuthGuardHandlerFunc(portfolioHandler.GetEquity(config.ProductCode), authHandler))
+
+	// Slackのスラッシュコマンドは，Signing Secretが設定されているときだけ受け付ける
+	if config.SlackSigningSecret != "" {
+		bitflyerClient := bitflyer.NewClient(config.APIKey, config.APISecret)
+		balanceRepository := bitflyer.NewBitFlyerBalanceRepository(bitflyerClient)
+		tickerRepository := bitflyer.NewBitflyerTickerRepository(bitflyerClient)
+		equitySnapshotRepository := persistence.NewEquitySnapshotRepository(config.DB, config.TimeFormat)
+		portfolioService := service.NewPortfolioService(balanceRepository, tickerRepository, signalEventRepository, equitySnapshotRepository, config.CommissionRate)
+
+		slackRoles := make(map[string]model.SlackRole)
+		for _, userID := range config.SlackCommandViewers {
+			slackRoles[userID] = model.SlackRoleViewer
+		}
+		for _, userID := range config.SlackCommandOperators {
+			slackRoles[userID] = model.SlackRoleOperator
+		}
+
+		slackCommandUsecase := usecase.NewSlackCommandUsecase(portfolioService, balanceRepository, tradeParamsRepository, slackRoles)
+		slackCommandHandler := handler.NewSlackCommandHandler(slackCommandUsecase, config.SlackSigningSecret)
+		http.HandleFunc("/slack/command", slackCommandHandler.Handle(config.ProductCode))
+	}
 
 	http.HandleFunc("/", PageHandlerFunc("view/index.html"))
 	http.HandleFunc("/login", PageHandlerFunc("view/login.html"))
@@ -55,6 +122,11 @@ func Run() {
 	http.Handle("/view/admin.html", http.RedirectHandler("/admin", http.StatusFound))
 	http.Handle("/view/", http.StripPrefix("/view/", http.FileServer(http.Dir("view/"))))
 
+	// DBをポーリングしてストリームに流す
+	go streamUsecase.Run(context.Background(), config.ProductCode, 10*time.Second)
+	// バックテストは同時に2件まで実行する
+	backtestJobUsecase.Start(context.Background(), 2)
+
 	// Determine port for HTTP service.
 	port := os.Getenv("PORT")
 	if port == "" {
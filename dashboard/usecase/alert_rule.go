@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/repository"
+)
+
+type AlertRuleUsecase interface {
+	GetAll(productCode string) ([]model.AlertRule, error)
+	Save(rule model.AlertRule) (int64, error)
+	Delete(id int64) error
+}
+
+type alertRuleUsecase struct {
+	alertRuleRepository repository.AlertRuleRepository
+}
+
+func NewAlertRuleUsecase(ar repository.AlertRuleRepository) AlertRuleUsecase {
+	return &alertRuleUsecase{
+		alertRuleRepository: ar,
+	}
+}
+
+func (au *alertRuleUsecase) GetAll(productCode string) ([]model.AlertRule, error) {
+	return au.alertRuleRepository.FindAll(productCode)
+}
+
+func (au *alertRuleUsecase) Save(rule model.AlertRule) (int64, error) {
+	return au.alertRuleRepository.Save(rule)
+}
+
+func (au *alertRuleUsecase) Delete(id int64) error {
+	return au.alertRuleRepository.Delete(id)
+}
@@ -0,0 +1,48 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestAlertRule(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	alertRuleRepository := persistence.NewAlertRuleRepository(tx, config.TimeFormat)
+
+	alertRuleUsecase := usecase.NewAlertRuleUsecase(alertRuleRepository)
+
+	var id int64
+
+	t.Run("save alert_rule", func(t *testing.T) {
+		rule := model.NewAlertRule(0, config.ProductCode, model.AlertKindPriceBelow, 100000, 0, "", time.Hour, true, false, time.Time{})
+		var err error
+		id, err = alertRuleUsecase.Save(*rule)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+
+	t.Run("get alert_rules", func(t *testing.T) {
+		rules, err := alertRuleUsecase.GetAll(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(rules) == 0 || rules[len(rules)-1].ID() != id {
+			t.Fatalf("rules=%+v", rules)
+		}
+	})
+
+	t.Run("delete alert_rule", func(t *testing.T) {
+		err := alertRuleUsecase.Delete(id)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+}
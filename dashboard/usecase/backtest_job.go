@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/repository"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+)
+
+var (
+	ErrBacktestJobNotFound  = errors.New("backtest job not found")
+	ErrBacktestQueueFull    = errors.New("backtest queue is full")
+	ErrUnknownStrategy      = errors.New("unknown strategy")
+	ErrBacktestJobFinished  = errors.New("backtest job is already finished")
+	ErrNoCandlesInTimeRange = errors.New("no candles in the time range")
+	ErrBacktestInterrupted  = errors.New("backtest job was interrupted by restart")
+)
+
+// バックテストで読み込むキャンドルの上限
+const backtestCandleLimit = 10000
+
+type BacktestJobUsecase interface {
+	Submit(strategy string, params *model.TradeParams, start, end time.Time) (*model.BacktestJob, error)
+	Get(id string) (*model.BacktestJob, error)
+	List(productCode string, limit int64) ([]model.BacktestJob, error)
+	Cancel(id string) error
+	Start(ctx context.Context, workers int)
+}
+
+type backtestJobUsecase struct {
+	candleService         service.CandleService
+	strategies            map[string]service.DataFrameService
+	backtestJobRepository repository.BacktestJobRepository
+	queue                 chan string
+
+	mu sync.Mutex
+	// キューに入っているジョブ
+	pending map[string]bool
+	// 実行中のジョブを止めるための関数
+	cancels map[string]context.CancelFunc
+}
+
+// strategiesには戦略名ごとにバックテストに使うDataFrameServiceを渡す
+// queueSizeを超えて待機中のジョブは受け付けない
+func NewBacktestJobUsecase(cs service.CandleService, strategies map[string]service.DataFrameService, br repository.BacktestJobRepository, queueSize int) BacktestJobUsecase {
+	return &backtestJobUsecase{
+		candleService:         cs,
+		strategies:            strategies,
+		backtestJobRepository: br,
+		queue:                 make(chan string, queueSize),
+		pending:               make(map[string]bool),
+		cancels:               make(map[string]context.CancelFunc),
+	}
+}
+
+func newBacktestJobID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (bu *backtestJobUsecase) Submit(strategy string, params *model.TradeParams, start, end time.Time) (*model.BacktestJob, error) {
+	if _, ok := bu.strategies[strategy]; !ok {
+		return nil, ErrUnknownStrategy
+	}
+
+	if params == nil {
+		return nil, errors.New("invalid parameter")
+	}
+
+	id, err := newBacktestJobID()
+	if err != nil {
+		return nil, err
+	}
+
+	job := model.NewBacktestJob(id, strategy, *params, start, end, time.Now())
+	if job == nil {
+		return nil, errors.New("invalid backtest job")
+	}
+
+	err = bu.backtestJobRepository.Save(*job)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bu.enqueue(job.ID()) {
+		job.Fail(ErrBacktestQueueFull, time.Now())
+		if err := bu.backtestJobRepository.Save(*job); err != nil {
+			fmt.Println("[BacktestJobUsecase]", err)
+		}
+		return nil, ErrBacktestQueueFull
+	}
+
+	return job, nil
+}
+
+func (bu *backtestJobUsecase) Get(id string) (*model.BacktestJob, error) {
+	job, err := bu.backtestJobRepository.Find(id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrBacktestJobNotFound
+	}
+	return job, nil
+}
+
+func (bu *backtestJobUsecase) List(productCode string, limit int64) ([]model.BacktestJob, error) {
+	return bu.backtestJobRepository.FindAll(productCode, limit)
+}
+
+func (bu *backtestJobUsecase) Cancel(id string) error {
+	job, err := bu.Get(id)
+	if err != nil {
+		return err
+	}
+
+	if !job.Cancel(time.Now()) {
+		return ErrBacktestJobFinished
+	}
+
+	// 実行中なら止める
+	bu.mu.Lock()
+	if cancel, ok := bu.cancels[id]; ok {
+		cancel()
+	}
+	bu.mu.Unlock()
+
+	return bu.backtestJobRepository.Save(*job)
+}
+
+// キューが一杯ならfalseを返す
+func (bu *backtestJobUsecase) enqueue(id string) bool {
+	bu.mu.Lock()
+	defer bu.mu.Unlock()
+
+	if bu.pending[id] {
+		return true
+	}
+	select {
+	case bu.queue <- id:
+		bu.pending[id] = true
+		return true
+	default:
+		return false
+	}
+}
+
+// workers個のゴルーチンでジョブを処理する
+// 前回の起動時に終わらなかったジョブは，待機中ならキューに入れ直し，実行中なら失敗にする
+func (bu *backtestJobUsecase) Start(ctx context.Context, workers int) {
+	if err := bu.recoverJobs(); err != nil {
+		fmt.Println("[BacktestJobUsecase]", err)
+	}
+
+	for i := 0; i < workers; i++ {
+		go bu.work(ctx)
+	}
+}
+
+func (bu *backtestJobUsecase) recoverJobs() error {
+	jobs, err := bu.backtestJobRepository.FindUnfinished()
+	if err != nil {
+		return err
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		switch job.Status() {
+		case model.BacktestJobStatusQueued:
+			if bu.enqueue(job.ID()) {
+				continue
+			}
+			job.Fail(ErrBacktestQueueFull, time.Now())
+		default:
+			job.Fail(ErrBacktestInterrupted, time.Now())
+		}
+		if err := bu.backtestJobRepository.Save(*job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bu *backtestJobUsecase) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case id := <-bu.queue:
+			bu.mu.Lock()
+			delete(bu.pending, id)
+			bu.mu.Unlock()
+			if err := bu.process(ctx, id); err != nil {
+				fmt.Println("[BacktestJobUsecase]", id, err)
+			}
+		}
+	}
+}
+
+func (bu *backtestJobUsecase) process(ctx context.Context, id string) error {
+	job, err := bu.Get(id)
+	if err != nil {
+		return err
+	}
+
+	// 待機中にキャンセルされていたら何もしない
+	if !job.Run() {
+		return nil
+	}
+	if err := bu.backtestJobRepository.Save(*job); err != nil {
+		return err
+	}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	bu.mu.Lock()
+	bu.cancels[id] = cancel
+	bu.mu.Unlock()
+	defer func() {
+		bu.mu.Lock()
+		delete(bu.cancels, id)
+		bu.mu.Unlock()
+		cancel()
+	}()
+
+	result, runErr := bu.run(jobCtx, job)
+
+	// 実行中にキャンセルされた場合は結果を捨てる
+	if jobCtx.Err() != nil {
+		return nil
+	}
+	latest, err := bu.Get(id)
+	if err == nil && latest.Status() == model.BacktestJobStatusCanceled {
+		return nil
+	}
+
+	if runErr != nil {
+		job.Fail(runErr, time.Now())
+	} else {
+		job.Complete(result, time.Now())
+	}
+	return bu.backtestJobRepository.Save(*job)
+}
+
+func (bu *backtestJobUsecase) run(ctx context.Context, job *model.BacktestJob) (*model.BacktestResult, error) {
+	dataFrameService, ok := bu.strategies[job.Strategy()]
+	if !ok {
+		return nil, ErrUnknownStrategy
+	}
+
+	candles, err := bu.candleService.FindAll(job.ProductCode(), bu.candleService.Duration(), backtestCandleLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	// 期間内のキャンドルに絞る
+	inRange := make([]model.Candle, 0)
+	for _, candle := range candles {
+		candleTime := candle.Time().Time()
+		if candleTime.Before(job.Start()) || candleTime.After(job.End()) {
+			continue
+		}
+		inRange = append(inRange, candle)
+	}
+	if len(inRange) == 0 {
+		return nil, ErrNoCandlesInTimeRange
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	params := job.Params()
+	df := model.NewDataFrame(job.ProductCode(), inRange, model.NewSignalEvents(make([]model.SignalEvent, 0)))
+	addIndicators(df, &params)
+	if err := bu.addTimeframes(df, dataFrameService); err != nil {
+		return nil, err
+	}
+	dataFrameService.Backtest(df, &params)
+
+	result := model.NewBacktestResult(df.Candles(), df.BacktestEvents())
+	if result == nil {
+		return nil, errors.New("failed to backtest")
+	}
+	return result, nil
+}
+
+// 上位の時間足が必要な戦略なら，その時間足のDataFrameを追加する
+// 上位の時間足は期間で絞らない（期間の始めからトレンドを判定できるように）
+// 未確定の足はDataFrame.TimeframeIndexで除かれる
+func (bu *backtestJobUsecase) addTimeframes(df *model.DataFrame, ds service.DataFrameService) error {
+	mtf, ok := ds.(service.MultiTimeframeDataFrameService)
+	if !ok {
+		return nil
+	}
+
+	for _, duration := range mtf.Timeframes() {
+		candles, err := bu.candleService.FindAll(df.ProductCode(), duration, backtestCandleLimit)
+		if err != nil {
+			return err
+		}
+		df.AddTimeframe(model.NewDataFrame(df.ProductCode(), candles, nil))
+	}
+	return nil
+}
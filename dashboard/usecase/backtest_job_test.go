@@ -0,0 +1,175 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+// ジョブが終わるまで待つ
+func waitBacktestJob(t *testing.T, bu usecase.BacktestJobUsecase, id string) *model.BacktestJob {
+	for i := 0; i < 100; i++ {
+		job, err := bu.Get(id)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if job.Status().IsFinished() {
+			return job
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatal("backtest job does not finish")
+	return nil
+}
+
+func TestBacktestJob(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	candleRepository := persistence.NewCandleRepository(tx, config.CandleTableName, config.TimeFormat)
+	backtestJobRepository := persistence.NewBacktestJobRepository(tx, config.TimeFormat)
+
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	indicatorService := service.NewIndicatorService()
+	strategies := map[string]service.DataFrameService{
+		"default": service.NewDataFrameService(indicatorService),
+		"mr_base": service.NewMRBaseDataFrameService(indicatorService),
+		"mr_base_weekly_trend": service.NewTrendFilterDataFrameService(
+			service.NewMRBaseDataFrameService(indicatorService), 7*candleService.Duration(), 10),
+	}
+
+	backtestJobUsecase := usecase.NewBacktestJobUsecase(candleService, strategies, backtestJobRepository, 2)
+
+	params := model.NewBasicTradeParams(config.ProductCode, 0.01)
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		_, err := backtestJobUsecase.Submit("unknown", params, start, end)
+		if err != usecase.ErrUnknownStrategy {
+			t.Fatalf("%v != %v", err, usecase.ErrUnknownStrategy)
+		}
+	})
+
+	t.Run("cancel queued job", func(t *testing.T) {
+		job, err := backtestJobUsecase.Submit("default", params, start, end)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		err = backtestJobUsecase.Cancel(job.ID())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		err = backtestJobUsecase.Cancel(job.ID())
+		if err != usecase.ErrBacktestJobFinished {
+			t.Fatalf("%v != %v", err, usecase.ErrBacktestJobFinished)
+		}
+	})
+
+	var queuedJobID string
+	t.Run("queue is full", func(t *testing.T) {
+		// キャンセル済みのジョブが1つ残っている
+		job, err := backtestJobUsecase.Submit("default", params, start, end)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		queuedJobID = job.ID()
+		_, err = backtestJobUsecase.Submit("default", params, start, end)
+		if err != usecase.ErrBacktestQueueFull {
+			t.Fatalf("%v != %v", err, usecase.ErrBacktestQueueFull)
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	backtestJobUsecase.Start(ctx, 1)
+
+	t.Run("run queued job", func(t *testing.T) {
+		job := waitBacktestJob(t, backtestJobUsecase, queuedJobID)
+		if job.Status() != model.BacktestJobStatusDone {
+			t.Fatalf("%s != %s: %s", job.Status(), model.BacktestJobStatusDone, job.ErrorMessage())
+		}
+	})
+
+	t.Run("run job", func(t *testing.T) {
+		job, err := backtestJobUsecase.Submit("mr_base", params, start, end)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		job = waitBacktestJob(t, backtestJobUsecase, job.ID())
+		if job.Status() != model.BacktestJobStatusDone {
+			t.Fatalf("%s != %s: %s", job.Status(), model.BacktestJobStatusDone, job.ErrorMessage())
+		}
+		if job.Result() == nil {
+			t.Fatal("Result() returns nil")
+		}
+		if len(job.Result().EquityCurve()) == 0 {
+			t.Fatal("len(EquityCurve()) == 0")
+		}
+	})
+
+	t.Run("run multi timeframe job", func(t *testing.T) {
+		job, err := backtestJobUsecase.Submit("mr_base_weekly_trend", params, start, end)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		job = waitBacktestJob(t, backtestJobUsecase, job.ID())
+		if job.Status() != model.BacktestJobStatusDone {
+			t.Fatalf("%s != %s: %s", job.Status(), model.BacktestJobStatusDone, job.ErrorMessage())
+		}
+	})
+
+	t.Run("no candles in range", func(t *testing.T) {
+		job, err := backtestJobUsecase.Submit("default", params, end, end.AddDate(1, 0, 0))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		job = waitBacktestJob(t, backtestJobUsecase, job.ID())
+		if job.Status() != model.BacktestJobStatusFailed {
+			t.Fatalf("%s != %s", job.Status(), model.BacktestJobStatusFailed)
+		}
+	})
+
+	t.Run("list jobs", func(t *testing.T) {
+		jobs, err := backtestJobUsecase.List(config.ProductCode, 10)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(jobs) < 4 {
+			t.Fatalf("%d < %d", len(jobs), 4)
+		}
+	})
+
+	t.Run("restart", func(t *testing.T) {
+		// 前回の起動時に待機中・実行中のまま残ったジョブ
+		queued := model.NewBacktestJob("restart-queued-job", "default", *params, start, end, time.Now())
+		running := model.NewBacktestJob("restart-running-job", "default", *params, start, end, time.Now())
+		running.Run()
+		for _, job := range []*model.BacktestJob{queued, running} {
+			if err := backtestJobRepository.Save(*job); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+
+		restarted := usecase.NewBacktestJobUsecase(candleService, strategies, backtestJobRepository, 2)
+		restarted.Start(ctx, 1)
+
+		job := waitBacktestJob(t, restarted, queued.ID())
+		if job.Status() != model.BacktestJobStatusDone {
+			t.Fatalf("%s != %s: %s", job.Status(), model.BacktestJobStatusDone, job.ErrorMessage())
+		}
+		job = waitBacktestJob(t, restarted, running.ID())
+		if job.Status() != model.BacktestJobStatusFailed {
+			t.Fatalf("%s != %s", job.Status(), model.BacktestJobStatusFailed)
+		}
+	})
+}
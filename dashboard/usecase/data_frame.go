@@ -24,7 +24,7 @@ func NewDataFrameUsecase(cs service.CandleService, ss service.SignalEventService
 }
 
 func (du *dataFrameUsecase) Get(params *model.TradeParams, candleLimit int64, backtestEnable bool) (*model.DataFrame, error) {
-	candles, err := du.candleService.FindAll(params.ProductCode(), candleLimit)
+	candles, err := du.candleService.FindAll(params.ProductCode(), du.candleService.Duration(), candleLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -44,6 +44,18 @@ func (du *dataFrameUsecase) Get(params *model.TradeParams, candleLimit int64, ba
 		return nil, err
 	}
 
+	addIndicators(df, params)
+
+	if backtestEnable {
+		du.dataFrameService.Backtest(df, params)
+	}
+
+	return df, nil
+}
+
+// パラメータで有効になっている指標を追加する
+// 計算できなかった指標はパラメータ側で無効にする
+func addIndicators(df *model.DataFrame, params *model.TradeParams) {
 	if params.SMAEnable() {
 		ok1 := df.AddSMA(params.SMAPeriod1())
 		ok2 := df.AddSMA(params.SMAPeriod2())
@@ -64,7 +76,7 @@ func (du *dataFrameUsecase) Get(params *model.TradeParams, candleLimit int64, ba
 	}
 
 	if params.IchimokuEnable() {
-		ok := df.AddIchimoku()
+		ok := df.AddIchimoku(params.IchimokuTenkanPeriod(), params.IchimokuKijunPeriod(), params.IchimokuSenkouBPeriod())
 		params.EnableIchimoku(ok)
 	}
 
@@ -78,9 +90,48 @@ func (du *dataFrameUsecase) Get(params *model.TradeParams, candleLimit int64, ba
 		params.EnableMACD(ok)
 	}
 
-	if backtestEnable {
-		du.dataFrameService.Backtest(df, params)
+	if params.ATREnable() {
+		ok := df.AddATR(params.ATRPeriod())
+		params.EnableATR(ok)
 	}
 
-	return df, nil
+	if params.StochEnable() {
+		ok := df.AddStochastic(params.StochFastKPeriod(), params.StochSlowKPeriod(), params.StochSlowDPeriod())
+		params.EnableStoch(ok)
+	}
+
+	if params.ADXEnable() {
+		ok := df.AddADX(params.ADXPeriod())
+		params.EnableADX(ok)
+	}
+
+	if params.OBVEnable() {
+		ok := df.AddOBV(params.OBVPeriod())
+		params.EnableOBV(ok)
+	}
+
+	if params.VWAPEnable() {
+		ok := df.AddVWAP(params.VWAPPeriod())
+		params.EnableVWAP(ok)
+	}
+
+	if params.SAREnable() {
+		ok := df.AddParabolicSAR(params.SARAcceleration(), params.SARMaximum())
+		params.EnableSAR(ok)
+	}
+
+	if params.DonchianEnable() {
+		ok := df.AddDonchianChannel(params.DonchianPeriod())
+		params.EnableDonchian(ok)
+	}
+
+	if params.KeltnerEnable() {
+		ok := df.AddKeltnerChannel(params.KeltnerPeriod(), params.KeltnerMultiplier())
+		params.EnableKeltner(ok)
+	}
+
+	if params.HeikinAshiEnable() {
+		ok := df.AddAverageCandle()
+		params.EnableHeikinAshi(ok)
+	}
 }
@@ -15,6 +15,9 @@ func TestDataFrameUsecase(t *testing.T) {
 	defer tx.Rollback()
 
 	candleRepository := persistence.NewCandleMockRepository(config.CandleTableName, config.TimeFormat, config.ProductCode, config.CandleDuration)
+	if candleRepository == nil {
+		t.Fatal("NewCandleMockRepository() returns nil")
+	}
 	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
 
 	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+)
+
+type GridBacktestUsecase interface {
+	Backtest(grid model.Grid, candleLimit int64) (*model.GridBacktestResult, []model.GridLevel, error)
+}
+
+type gridBacktestUsecase struct {
+	candleService service.CandleService
+}
+
+func NewGridBacktestUsecase(cs service.CandleService) GridBacktestUsecase {
+	return &gridBacktestUsecase{
+		candleService: cs,
+	}
+}
+
+func (gu *gridBacktestUsecase) Backtest(grid model.Grid, candleLimit int64) (*model.GridBacktestResult, []model.GridLevel, error) {
+	candles, err := gu.candleService.FindAll(grid.ProductCode(), gu.candleService.Duration(), candleLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, levels := grid.Backtest(candles)
+	return result, levels, nil
+}
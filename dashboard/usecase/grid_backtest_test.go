@@ -0,0 +1,34 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestGridBacktestUsecase(t *testing.T) {
+	candleRepository := persistence.NewCandleMockRepository(config.CandleTableName, config.TimeFormat, config.ProductCode, config.CandleDuration)
+	if candleRepository == nil {
+		t.Fatal("NewCandleMockRepository() returns nil")
+	}
+
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+
+	gridBacktestUsecase := usecase.NewGridBacktestUsecase(candleService)
+
+	t.Run("backtest", func(t *testing.T) {
+		grid := model.NewGrid(config.ProductCode, 200000, 600000, 21, 0.01)
+		result, levels, err := gridBacktestUsecase.Backtest(*grid, 365)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(levels) != 20 {
+			t.Fatalf("len(levels)=%d", len(levels))
+		}
+		t.Log("fills:", len(result.Fills()), "profit:", result.Profit(), "total profit:", result.TotalProfit(levels))
+	})
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+)
+
+type PortfolioUsecase interface {
+	Get(productCode string) (*model.Portfolio, error)
+	FindSnapshots(productCode string, limit int64) ([]model.EquitySnapshot, error)
+}
+
+type portfolioUsecase struct {
+	portfolioService service.PortfolioService
+}
+
+func NewPortfolioUsecase(ps service.PortfolioService) PortfolioUsecase {
+	return &portfolioUsecase{
+		portfolioService: ps,
+	}
+}
+
+func (pu *portfolioUsecase) Get(productCode string) (*model.Portfolio, error) {
+	return pu.portfolioService.Get(productCode)
+}
+
+func (pu *portfolioUsecase) FindSnapshots(productCode string, limit int64) ([]model.EquitySnapshot, error) {
+	return pu.portfolioService.FindSnapshots(productCode, limit)
+}
@@ -0,0 +1,45 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestPortfolio(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	balanceRepository := bitflyer.NewBitFlyerBalanceMockRepository()
+	tickerRepository := bitflyer.NewBitflyerTickerMockRepository()
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	equitySnapshotRepository := persistence.NewEquitySnapshotRepository(tx, config.TimeFormat)
+
+	portfolioService := service.NewPortfolioService(balanceRepository, tickerRepository, signalEventRepository, equitySnapshotRepository, config.CommissionRate)
+
+	portfolioUsecase := usecase.NewPortfolioUsecase(portfolioService)
+
+	t.Run("get portfolio", func(t *testing.T) {
+		portfolio, err := portfolioUsecase.Get(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if portfolio == nil {
+			t.Fatal("Get() returns nil")
+		}
+	})
+
+	t.Run("find snapshots", func(t *testing.T) {
+		snapshots, err := portfolioUsecase.FindSnapshots(config.ProductCode, 10)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if snapshots == nil {
+			t.Fatal("FindSnapshots() returns nil")
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/repository"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+)
+
+var ErrSlackCommandForbidden = errors.New("permission denied")
+
+type SlackCommandUsecase interface {
+	// Slackのユーザが送ったコマンドを実行して，返信する文章を返す
+	Execute(productCode, userID, text string) (string, error)
+}
+
+type slackCommandUsecase struct {
+	portfolioService      service.PortfolioService
+	balanceRepository     repository.BalanceRepository
+	tradeParamsRepository repository.TradeParamsRepository
+	roles                 map[string]model.SlackRole
+}
+
+// rolesはSlackのユーザIDごとの権限．含まれないユーザのコマンドは実行しない
+func NewSlackCommandUsecase(ps service.PortfolioService, br repository.BalanceRepository, tr repository.TradeParamsRepository, roles map[string]model.SlackRole) SlackCommandUsecase {
+	return &slackCommandUsecase{
+		portfolioService:      ps,
+		balanceRepository:     br,
+		tradeParamsRepository: tr,
+		roles:                 roles,
+	}
+}
+
+func (su *slackCommandUsecase) Execute(productCode, userID, text string) (string, error) {
+	action, err := model.ParseSlackCommandAction(text)
+	if err != nil {
+		return "", err
+	}
+
+	if !su.roles[userID].Allows(action) {
+		return "", ErrSlackCommandForbidden
+	}
+
+	switch action {
+	case model.SlackCommandActionStatus:
+		return su.status(productCode)
+	case model.SlackCommandActionBalance:
+		return su.balance()
+	case model.SlackCommandActionPause:
+		return su.setTradeEnable(productCode, false)
+	case model.SlackCommandActionResume:
+		return su.setTradeEnable(productCode, true)
+	default:
+		return slackCommandHelp, nil
+	}
+}
+
+const slackCommandHelp = "/bot status: 取引の状態と運用成績\n" +
+	"/bot balance: 取引所の残高\n" +
+	"/bot pause: 取引（売買サイン・グリッド・積立）を停止する\n" +
+	"/bot resume: 取引（売買サイン・グリッド・積立）を再開する"
+
+func (su *slackCommandUsecase) status(productCode string) (string, error) {
+	params, err := su.tradeParamsRepository.Find(productCode)
+	if err != nil {
+		return "", err
+	}
+	if params == nil {
+		return "", fmt.Errorf("trade params of %s is not found", productCode)
+	}
+
+	portfolio, err := su.portfolioService.Get(productCode)
+	if err != nil {
+		return "", err
+	}
+
+	state := "稼働中"
+	if !params.TradeEnable() {
+		state = "停止中"
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s: %s", productCode, state),
+		fmt.Sprintf("Price: %f", portfolio.CurrentPrice()),
+		fmt.Sprintf("Position: %f", portfolio.Position()),
+		fmt.Sprintf("Equity: %.0f", portfolio.Equity()),
+		fmt.Sprintf("PnL: %+.0f (realized %+.0f, unrealized %+.0f)", portfolio.TotalPnL(), portfolio.RealizedPnL(), portfolio.UnrealizedPnL()),
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// 残高のない通貨は省く
+func (su *slackCommandUsecase) balance() (string, error) {
+	balances, err := su.balanceRepository.FetchAll()
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]string, 0)
+	for _, balance := range balances {
+		if balance.Amount() <= 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s: %f (available %f)", balance.CurrencyCode(), balance.Amount(), balance.Available()))
+	}
+	if len(lines) == 0 {
+		return "残高はありません", nil
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// 次の取引から反映される（traderは取引のたびにパラメータを読み込む）
+// traderは売買サインの取引だけでなく，グリッド取引と積立でもtrade_enableを確認する
+func (su *slackCommandUsecase) setTradeEnable(productCode string, tradeEnable bool) (string, error) {
+	params, err := su.tradeParamsRepository.Find(productCode)
+	if err != nil {
+		return "", err
+	}
+	if params == nil {
+		return "", fmt.Errorf("trade params of %s is not found", productCode)
+	}
+
+	if tradeEnable {
+		if params.TradeEnable() {
+			return fmt.Sprintf("%sの取引はすでに稼働中です", productCode), nil
+		}
+		if err := su.tradeParamsRepository.Save(*params.WithTradeEnable(true)); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%sの取引（売買サイン・グリッド・積立）を再開しました", productCode), nil
+	}
+
+	if !params.TradeEnable() {
+		return fmt.Sprintf("%sの取引はすでに停止中です", productCode), nil
+	}
+	if err := su.tradeParamsRepository.Save(*params.WithTradeEnable(false)); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%sの取引（売買サイン・グリッド・積立）を停止しました", productCode), nil
+}
@@ -0,0 +1,104 @@
+package usecase_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestSlackCommandUsecase(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	balanceRepository := bitflyer.NewBitFlyerBalanceMockRepository()
+	tickerRepository := bitflyer.NewBitflyerTickerMockRepository()
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	equitySnapshotRepository := persistence.NewEquitySnapshotRepository(tx, config.TimeFormat)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+
+	portfolioService := service.NewPortfolioService(balanceRepository, tickerRepository, signalEventRepository, equitySnapshotRepository, config.CommissionRate)
+
+	slackCommandUsecase := usecase.NewSlackCommandUsecase(portfolioService, balanceRepository, tradeParamsRepository, map[string]model.SlackRole{
+		"UOPERATOR": model.SlackRoleOperator,
+		"UVIEWER":   model.SlackRoleViewer,
+	})
+
+	// 取引している状態から始める
+	params := model.NewBasicTradeParams(config.ProductCode, 0.01)
+	if err := tradeParamsRepository.Save(*params); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	t.Run("status", func(t *testing.T) {
+		text, err := slackCommandUsecase.Execute(config.ProductCode, "UVIEWER", "status")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !strings.Contains(text, "稼働中") || !strings.Contains(text, "Equity:") {
+			t.Fatalf("text=%q", text)
+		}
+	})
+
+	t.Run("balance", func(t *testing.T) {
+		_, err := slackCommandUsecase.Execute(config.ProductCode, "UVIEWER", "balance")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+
+	t.Run("viewer cannot pause", func(t *testing.T) {
+		_, err := slackCommandUsecase.Execute(config.ProductCode, "UVIEWER", "pause")
+		if err != usecase.ErrSlackCommandForbidden {
+			t.Fatalf("err=%v", err)
+		}
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := slackCommandUsecase.Execute(config.ProductCode, "USTRANGER", "status")
+		if err != usecase.ErrSlackCommandForbidden {
+			t.Fatalf("err=%v", err)
+		}
+	})
+
+	t.Run("pause and resume", func(t *testing.T) {
+		// 最新のパラメータはcreated_at（秒単位）で決まるので，保存する時刻をずらす
+		time.Sleep(time.Second)
+		if _, err := slackCommandUsecase.Execute(config.ProductCode, "UOPERATOR", "pause"); err != nil {
+			t.Fatal(err.Error())
+		}
+		params, err := tradeParamsRepository.Find(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if params.TradeEnable() {
+			t.Fatal("trade should be paused")
+		}
+
+		time.Sleep(time.Second)
+
+		if _, err := slackCommandUsecase.Execute(config.ProductCode, "UOPERATOR", "resume"); err != nil {
+			t.Fatal(err.Error())
+		}
+		params, err = tradeParamsRepository.Find(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !params.TradeEnable() {
+			t.Fatal("trade should be resumed")
+		}
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		_, err := slackCommandUsecase.Execute(config.ProductCode, "UOPERATOR", "sell")
+		if err != model.ErrUnknownSlackCommand {
+			t.Fatalf("err=%v", err)
+		}
+	})
+}
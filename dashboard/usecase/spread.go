@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/repository"
+)
+
+type SpreadUsecase interface {
+	FindAll(productCode string, limit int64) ([]model.Spread, error)
+}
+
+type spreadUsecase struct {
+	spreadRepository repository.SpreadRepository
+}
+
+// 価格差はtraderが記録する
+func NewSpreadUsecase(sr repository.SpreadRepository) SpreadUsecase {
+	return &spreadUsecase{
+		spreadRepository: sr,
+	}
+}
+
+func (su *spreadUsecase) FindAll(productCode string, limit int64) ([]model.Spread, error) {
+	return su.spreadRepository.FindAll(productCode, limit)
+}
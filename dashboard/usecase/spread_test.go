@@ -0,0 +1,36 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestSpread(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	spreadRepository := persistence.NewSpreadRepository(tx, config.TimeFormat)
+
+	spreadUsecase := usecase.NewSpreadUsecase(spreadRepository)
+
+	t.Run("find all", func(t *testing.T) {
+		spread := model.NewSpread(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), config.ProductCode, "bitflyer", "coincheck", 500000, 505000)
+		err := spreadRepository.Save(*spread)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		spreads, err := spreadUsecase.FindAll(config.ProductCode, 1)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(spreads) != 1 || spreads[0] != *spread {
+			t.Fatalf("spreads=%+v", spreads)
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/repository"
+)
+
+type StrategyRuleUsecase interface {
+	Get(productCode string) (*model.StrategyRule, error)
+	Save(rule model.StrategyRule) error
+}
+
+type strategyRuleUsecase struct {
+	strategyRuleRepository repository.StrategyRuleRepository
+}
+
+func NewStrategyRuleUsecase(sr repository.StrategyRuleRepository) StrategyRuleUsecase {
+	return &strategyRuleUsecase{
+		strategyRuleRepository: sr,
+	}
+}
+
+func (su *strategyRuleUsecase) Get(productCode string) (*model.StrategyRule, error) {
+	return su.strategyRuleRepository.Find(productCode)
+}
+
+func (su *strategyRuleUsecase) Save(rule model.StrategyRule) error {
+	return su.strategyRuleRepository.Save(rule)
+}
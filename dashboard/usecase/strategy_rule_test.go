@@ -0,0 +1,40 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestStrategyRule(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	strategyRuleRepository := persistence.NewStrategyRuleRepository(tx)
+
+	strategyRuleUsecase := usecase.NewStrategyRuleUsecase(strategyRuleRepository)
+
+	t.Run("save strategy_rule", func(t *testing.T) {
+		rule := model.NewStrategyRule(config.ProductCode, "rsi(14) < 30", "rsi(14) > 70")
+		err := strategyRuleUsecase.Save(*rule)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+
+	t.Run("get strategy_rule", func(t *testing.T) {
+		rule, err := strategyRuleUsecase.Get(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if rule == nil {
+			t.Fatal("strategy_rule is not found")
+		}
+		if rule.BuyRule().String() != "rsi(14) < 30" || rule.SellRule().String() != "rsi(14) > 70" {
+			t.Fatalf("buy=%q, sell=%q", rule.BuyRule().String(), rule.SellRule().String())
+		}
+	})
+}
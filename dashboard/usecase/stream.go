@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/repository"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+)
+
+type StreamEventType string
+
+const (
+	StreamEventTypeCandle = StreamEventType("candle")
+	StreamEventTypeSignal = StreamEventType("signal")
+	// 取引パラメータが変わったことだけを知らせる（中身は公開しない）
+	StreamEventTypeTradeParams = StreamEventType("params")
+)
+
+// クライアントに配信する更新内容
+// Typeに対応するフィールドだけが入る
+type StreamEvent struct {
+	Type        StreamEventType
+	Candle      *model.Candle
+	SignalEvent *model.SignalEvent
+}
+
+type StreamUsecase interface {
+	Subscribe() (<-chan StreamEvent, func())
+	Poll(productCode string) error
+	Run(ctx context.Context, productCode string, interval time.Duration)
+}
+
+type streamUsecase struct {
+	candleService         service.CandleService
+	signalEventService    service.SignalEventService
+	tradeParamsRepository repository.TradeParamsRepository
+	bufferSize            int
+
+	mu          sync.Mutex
+	subscribers map[chan StreamEvent]struct{}
+
+	// 前回ポーリング時の状態
+	initialized    bool
+	lastCandle     *model.Candle
+	lastSignalTime time.Time
+	lastParams     *model.TradeParams
+}
+
+// DBをポーリングして，変化があったものを購読者に配信する
+// bufferSizeは購読者ごとのバッファで，溢れた購読者は切断する
+func NewStreamUsecase(cs service.CandleService, ss service.SignalEventService, tr repository.TradeParamsRepository, bufferSize int) StreamUsecase {
+	return &streamUsecase{
+		candleService:         cs,
+		signalEventService:    ss,
+		tradeParamsRepository: tr,
+		bufferSize:            bufferSize,
+		subscribers:           make(map[chan StreamEvent]struct{}),
+	}
+}
+
+// 購読を開始する
+// 返り値の関数で購読を解除する
+func (su *streamUsecase) Subscribe() (<-chan StreamEvent, func()) {
+	ch := make(chan StreamEvent, su.bufferSize)
+
+	su.mu.Lock()
+	su.subscribers[ch] = struct{}{}
+	su.mu.Unlock()
+
+	unsubscribe := func() {
+		su.mu.Lock()
+		defer su.mu.Unlock()
+		if _, ok := su.subscribers[ch]; ok {
+			delete(su.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+func (su *streamUsecase) publish(event StreamEvent) {
+	su.mu.Lock()
+	defer su.mu.Unlock()
+
+	for ch := range su.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// 受信が追いつかない購読者は切断し，再接続で取り直してもらう
+			delete(su.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (su *streamUsecase) Poll(productCode string) error {
+	events := make([]StreamEvent, 0)
+
+	// 最新のキャンドル
+	candles, err := su.candleService.FindAll(productCode, su.candleService.Duration(), 1)
+	if err != nil {
+		return err
+	}
+	if len(candles) > 0 {
+		candle := candles[len(candles)-1]
+		if su.lastCandle == nil || *su.lastCandle != candle {
+			if su.initialized {
+				events = append(events, StreamEvent{Type: StreamEventTypeCandle, Candle: &candle})
+			}
+			su.lastCandle = &candle
+		}
+	}
+
+	// 前回以降の取引
+	signals, err := su.signalEventService.FindAllAfterTime(productCode, su.lastSignalTime)
+	if err != nil {
+		return err
+	}
+	for i := range signals {
+		signal := signals[i]
+		if !signal.Time().After(su.lastSignalTime) {
+			continue
+		}
+		if su.initialized {
+			events = append(events, StreamEvent{Type: StreamEventTypeSignal, SignalEvent: &signal})
+		}
+		su.lastSignalTime = signal.Time()
+	}
+
+	// 取引パラメータ
+	if su.tradeParamsRepository != nil {
+		params, err := su.tradeParamsRepository.Find(productCode)
+		if err != nil {
+			return err
+		}
+		if su.lastParams == nil || *su.lastParams != *params {
+			if su.initialized {
+				events = append(events, StreamEvent{Type: StreamEventTypeTradeParams})
+			}
+			su.lastParams = params
+		}
+	}
+
+	// 初回は現在の状態を記録するだけ
+	su.initialized = true
+
+	for _, event := range events {
+		su.publish(event)
+	}
+
+	return nil
+}
+
+func (su *streamUsecase) Run(ctx context.Context, productCode string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := su.Poll(productCode); err != nil {
+			fmt.Println("[StreamUsecase]", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestStream(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	candleRepository := persistence.NewCandleRepository(tx, config.CandleTableName, config.TimeFormat)
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	signalEventService := service.NewSignalEventService(signalEventRepository)
+
+	// パラメータがないとポーリングに失敗するので用意しておく
+	params := model.NewBasicTradeParams(config.ProductCode, 0.01)
+	tradeParamsRepository.Save(*params)
+
+	streamUsecase := usecase.NewStreamUsecase(candleService, signalEventService, tradeParamsRepository, 1)
+
+	events, unsubscribe := streamUsecase.Subscribe()
+	defer unsubscribe()
+
+	t.Run("first poll", func(t *testing.T) {
+		err := streamUsecase.Poll(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(events) != 0 {
+			t.Fatalf("%d != %d", len(events), 0)
+		}
+	})
+
+	t.Run("publish new signal", func(t *testing.T) {
+		signal := model.NewSignalEvent(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), config.ProductCode, model.OrderSideBuy, 1000, 0.01)
+		signalEventService.Save(*signal)
+
+		err := streamUsecase.Poll(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		event := <-events
+		if event.Type != usecase.StreamEventTypeSignal {
+			t.Fatalf("%s != %s", event.Type, usecase.StreamEventTypeSignal)
+		}
+		if *event.SignalEvent != *signal {
+			t.Fatalf("%+v != %+v", *event.SignalEvent, *signal)
+		}
+	})
+
+	t.Run("publish params changed", func(t *testing.T) {
+		// created_atが秒単位なので，前のパラメータと作成時刻をずらす
+		time.Sleep(time.Second)
+		changed := model.NewBasicTradeParams(config.ProductCode, 0.02)
+		tradeParamsRepository.Save(*changed)
+
+		err := streamUsecase.Poll(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		event := <-events
+		if event.Type != usecase.StreamEventTypeTradeParams {
+			t.Fatalf("%s != %s", event.Type, usecase.StreamEventTypeTradeParams)
+		}
+	})
+
+	t.Run("disconnect slow subscriber", func(t *testing.T) {
+		// バッファ(1)を超える数の取引を発生させる
+		for i := 2; i <= 3; i++ {
+			signal := model.NewSignalEvent(time.Date(2100, 1, i, 0, 0, 0, 0, time.UTC), config.ProductCode, model.OrderSideBuy, 1000, 0.01)
+			signalEventService.Save(*signal)
+		}
+
+		err := streamUsecase.Poll(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		<-events
+		if _, ok := <-events; ok {
+			t.Fatal("subscriber is not disconnected")
+		}
+	})
+}
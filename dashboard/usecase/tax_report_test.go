@@ -0,0 +1,51 @@
+package usecase_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/dashboard/usecase"
+)
+
+func TestTaxReportUsecase(t *testing.T) {
+	tx := persistence.NewSQLiteTransaction(config.DSN())
+	defer tx.Rollback()
+
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	signalEventService := service.NewSignalEventService(signalEventRepository)
+
+	taxReportUsecase := usecase.NewTaxReportUsecase(signalEventService, config.CommissionRate, config.LocalTime)
+
+	event := model.NewSignalEvent(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), config.ProductCode, model.OrderSideBuy, 100000, 0.1)
+	signalEventService.Save(*event)
+
+	t.Run("invalid method", func(t *testing.T) {
+		_, err := taxReportUsecase.Get(config.ProductCode, model.CostMethod("fifo"))
+		if err == nil {
+			t.Fatal("Get() returns no error")
+		}
+	})
+
+	t.Run("write csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := taxReportUsecase.WriteCSV(&buf, config.ProductCode, model.CostMethodTotalAverage)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		// ヘッダ + 1年分以上
+		if len(records) < 2 {
+			t.Fatal("len(records) < 2")
+		}
+	})
+}
@@ -8,6 +8,13 @@ new Vue({
       productCode: 'ETH_JPY',
       tradeParams: null,
       newTradeParams: null,
+      strategyRule: null,
+      newStrategyRule: null,
+      strategyRuleError: '',
+      alertRules: null,
+      newAlertRule: null,
+      alertRuleError: '',
+      alertKinds: ['price_above', 'price_below', 'price_change', 'indicator'],
       balance: null,
       tradeParamsRules: {
         size: [
@@ -46,6 +53,31 @@ new Vue({
           v => !!v || 'bbandsK is required',
           v => (v && parseFloat(v) > 0) || 'bbandsK is must be more than 0',
         ],
+        ichimokuPeriod: [
+          v => !!v || 'ichimokuPeriod is required',
+          v => (v && v > 0) || 'ichimokuPeriod is must be more than 0',
+        ],
+        indicatorPeriod: [
+          v => !!v || 'period is required',
+          v => (v && v > 0) || 'period is must be more than 0',
+        ],
+        indicatorMultiplier: [
+          v => !!v || 'value is required',
+          v => (v && parseFloat(v) > 0) || 'value is must be more than 0',
+        ],
+        signalWeight: [
+          v => (v !== '' && v !== null) || 'weight is required',
+          v => (parseFloat(v) >= 0) || 'weight is must be more than 0',
+        ],
+        voteThreshold: [
+          v => !!v || 'threshold is required',
+          v => (v && parseFloat(v) > 0) || 'threshold is must be more than 0',
+        ],
+        indicatorThread: [
+          v => (v !== '' && v !== null) || 'thread is required',
+          v => (parseFloat(v) >= 0) || 'thread is must be more than 0',
+          v => (parseFloat(v) <= 100) || 'thread is must be less than 100',
+        ],
         rsiPeriod: [
           v => !!v || 'rsiPeriod is required',
           v => (v && v > 0) || 'rsiPeriod is must be more than 0',
@@ -72,6 +104,10 @@ new Vue({
           v => !!v || 'macdSignalPeriod is required',
           v => (v && v > 0) || 'macdSignalPeriod is must be more than 0',
         ],
+        maxLots: [
+          v => !!v || 'maxLots is required',
+          v => (v && v > 0) || 'maxLots is must be more than 0',
+        ],
         stopLimitPercent: [
           v => !!v || 'stopLimitPercent is required',
           v => (v && parseFloat(v) >= 0) || 'stopLimitPercent is must be more than 0',
@@ -118,6 +154,113 @@ new Vue({
     resetTradeParams() {
       this.newTradeParams = _.cloneDeep(this.tradeParams)
     },
+    async getStrategyRule() {
+      const params = {
+        "productCode": this.productCode,
+      }
+      return await axios.get('/admin/api/strategy-rule', {
+        params: params,
+      }).then(res => {
+        return res.data
+      }).catch(err => {
+        console.log(err)
+        return null
+      })
+    },
+    async updateStrategyRule() {
+      // 式の誤りはサーバで検証してメッセージを表示する
+      const ok = await axios.post('/admin/api/strategy-rule', {
+        ...this.newStrategyRule,
+        productCode: this.productCode,
+      }).then(res => {
+        this.strategyRuleError = ''
+        return true
+      }).catch(err => {
+        console.log(err)
+        this.strategyRuleError = (err.response && err.response.data) || 'failed to update'
+        return false
+      })
+      if (!ok) {
+        return
+      }
+      const strategyRule = await this.getStrategyRule()
+      this.strategyRule = _.cloneDeep(strategyRule)
+      this.newStrategyRule = _.cloneDeep(strategyRule)
+    },
+    resetStrategyRule() {
+      this.newStrategyRule = _.cloneDeep(this.strategyRule) || { buyRule: '', sellRule: '' }
+      this.strategyRuleError = ''
+    },
+    async getAlertRules() {
+      const params = {
+        "productCode": this.productCode,
+      }
+      return await axios.get('/admin/api/alert-rule', {
+        params: params,
+      }).then(res => {
+        return res.data
+      }).catch(err => {
+        console.log(err)
+        return null
+      })
+    },
+    alertRuleDescription(rule) {
+      switch (rule.kind) {
+        case 'price_above':
+          return `price >= ${rule.threshold}`
+        case 'price_below':
+          return `price <= ${rule.threshold}`
+        case 'price_change':
+          return `|change in ${rule.hours}h| >= ${rule.threshold}%`
+        default:
+          return rule.expression
+      }
+    },
+    async saveAlertRule() {
+      // 式の誤りはサーバで検証してメッセージを表示する
+      const ok = await axios.post('/admin/api/alert-rule', {
+        ...this.newAlertRule,
+        productCode: this.productCode,
+      }).then(res => {
+        this.alertRuleError = ''
+        return true
+      }).catch(err => {
+        console.log(err)
+        this.alertRuleError = (err.response && err.response.data) || 'failed to save'
+        return false
+      })
+      if (!ok) {
+        return
+      }
+      this.alertRules = await this.getAlertRules()
+      this.resetAlertRule()
+    },
+    async deleteAlertRule(rule) {
+      if (!confirm(`delete alert: ${this.alertRuleDescription(rule)}?`)) {
+        return
+      }
+      const ok = await axios.delete('/admin/api/alert-rule', {
+        params: { "id": rule.id },
+      }).then(res => {
+        return true
+      }).catch(err => {
+        console.log(err)
+        return false
+      })
+      if (!ok) {
+        alert('failed to delete')
+        return
+      }
+      this.alertRules = await this.getAlertRules()
+    },
+    editAlertRule(rule) {
+      this.newAlertRule = _.cloneDeep(rule)
+      this.alertRuleError = ''
+    },
+    resetAlertRule() {
+      this.newAlertRule = { id: 0, kind: 'price_above', threshold: 0, hours: 24, expression: '', cooldownMinutes: 60, enable: true }
+      this.alertRuleError = ''
+    },
     async getBalance() {
       return await axios.get('/admin/api/balance', {
       }).then(res => {
@@ -133,6 +276,12 @@ new Vue({
     this.tradeParams = _.cloneDeep(tradeParams)
     this.newTradeParams = _.cloneDeep(tradeParams)
 
+    this.strategyRule = await this.getStrategyRule()
+    this.resetStrategyRule()
+
+    this.alertRules = await this.getAlertRules()
+    this.resetAlertRule()
+
     this.balance = await this.getBalance()
   },
 })
@@ -26,6 +26,7 @@ new Vue({
   data() {
     return {
       candle: null,
+      spreads: null,
       validConfig: true,
       config: {
         limit: 30,
@@ -45,6 +46,7 @@ new Vue({
         },
         ichimoku: {
           enable: false,
+          periods: [9, 26, 52],
         },
         rsi: {
           enable: false,
@@ -56,6 +58,50 @@ new Vue({
           enable: false,
           periods: [12, 26, 9],
         },
+        atr: {
+          enable: false,
+          period: 14,
+          multiplier: 2,
+        },
+        stoch: {
+          enable: false,
+          fastKPeriod: 14,
+          slowKPeriod: 3,
+          slowDPeriod: 3,
+          buyThread: 20,
+          sellThread: 80,
+        },
+        adx: {
+          enable: false,
+          period: 14,
+          thread: 25,
+        },
+        obv: {
+          enable: false,
+          period: 20,
+        },
+        vwap: {
+          enable: false,
+          period: 20,
+        },
+        sar: {
+          enable: false,
+          acceleration: 0.02,
+          maximum: 0.2,
+        },
+        donchian: {
+          enable: false,
+          period: 20,
+        },
+        keltner: {
+          enable: false,
+          period: 20,
+          multiplier: 2,
+        },
+        heikinAshi: {
+          enable: false,
+          period: 3,
+        },
         stopLimitPercent: 0.95,
         backtest: {
           enable: false,
@@ -86,6 +132,10 @@ new Vue({
           v => !!v || 'bbandsK is required',
           v => (v && parseFloat(v) > 0) || 'bbandsK is must be more than 0',
         ],
+        ichimokuPeriod: [
+          v => !!v || 'period is required',
+          v => (v && v > 0) || 'period is must be more than 0',
+        ],
         rsiPeriod: [
           v => !!v || 'rsiPeriod is required',
           v => (v && v > 0) || 'rsiPeriod is must be more than 0',
@@ -104,6 +154,19 @@ new Vue({
           v => !!v || 'period is required',
           v => (v && v > 0) || 'period is must be more than 0',
         ],
+        indicatorPeriod: [
+          v => !!v || 'period is required',
+          v => (v && v > 0) || 'period is must be more than 0',
+        ],
+        indicatorMultiplier: [
+          v => !!v || 'value is required',
+          v => (v && parseFloat(v) > 0) || 'value is must be more than 0',
+        ],
+        indicatorThread: [
+          v => (v !== '' && v !== null) || 'thread is required',
+          v => (parseFloat(v) >= 0) || 'thread is must be more than 0',
+          v => (parseFloat(v) <= 100) || 'thread is must be less than 100',
+        ],
         stopLimitPercent: [
           v => !!v || 'stopLimitPercent is required',
           v => (v && parseFloat(v) >= 0) || 'stopLimitPercent is must be more than 0',
@@ -129,6 +192,9 @@ new Vue({
         "bbandsN": this.config.bbands.n,
         "bbandsK": this.config.bbands.k,
         "ichimoku": this.config.ichimoku.enable,
+        "ichimokuTenkanPeriod": this.config.ichimoku.periods[0],
+        "ichimokuKijunPeriod": this.config.ichimoku.periods[1],
+        "ichimokuSenkouBPeriod": this.config.ichimoku.periods[2],
         "rsi": this.config.rsi.enable,
         "rsiPeriod": this.config.rsi.period,
         "rsiBuyThread": this.config.rsi.buyThread,
@@ -137,6 +203,32 @@ new Vue({
         "macdPeriod1": this.config.macd.periods[0],
         "macdPeriod2": this.config.macd.periods[1],
         "macdPeriod3": this.config.macd.periods[2],
+        "atr": this.config.atr.enable,
+        "atrPeriod": this.config.atr.period,
+        "atrMultiplier": this.config.atr.multiplier,
+        "stoch": this.config.stoch.enable,
+        "stochFastKPeriod": this.config.stoch.fastKPeriod,
+        "stochSlowKPeriod": this.config.stoch.slowKPeriod,
+        "stochSlowDPeriod": this.config.stoch.slowDPeriod,
+        "stochBuyThread": this.config.stoch.buyThread,
+        "stochSellThread": this.config.stoch.sellThread,
+        "adx": this.config.adx.enable,
+        "adxPeriod": this.config.adx.period,
+        "adxThread": this.config.adx.thread,
+        "obv": this.config.obv.enable,
+        "obvPeriod": this.config.obv.period,
+        "vwap": this.config.vwap.enable,
+        "vwapPeriod": this.config.vwap.period,
+        "sar": this.config.sar.enable,
+        "sarAcceleration": this.config.sar.acceleration,
+        "sarMaximum": this.config.sar.maximum,
+        "donchian": this.config.donchian.enable,
+        "donchianPeriod": this.config.donchian.period,
+        "keltner": this.config.keltner.enable,
+        "keltnerPeriod": this.config.keltner.period,
+        "keltnerMultiplier": this.config.keltner.multiplier,
+        "heikinAshi": this.config.heikinAshi.enable,
+        "heikinAshiPeriod": this.config.heikinAshi.period,
         "stopLimitPercent": this.config.stopLimitPercent,
         "backtest": this.config.backtest.enable,
       }
@@ -149,9 +241,47 @@ new Vue({
         return null
       })
     },
+    async getSpread() {
+      return await axios.get('/api/spread').then(res => {
+        return res.data
+      }).catch(err => {
+        console.log(err)
+        return null
+      })
+    },
     async update() {
       // キャンドルデータとインディケータを取得
       this.candle = await this.getCandle()
+      this.spreads = await this.getSpread()
+    },
+    // サーバからの更新を受け取ってチャートに反映する
+    subscribe() {
+      const source = new EventSource('/api/stream')
+      source.addEventListener('candle', e => {
+        if (!this.candle || !this.candle.candles) {
+          return
+        }
+        const candle = JSON.parse(e.data)
+        const candles = this.candle.candles
+        if (candles.length > 0 && candles[candles.length-1].time == candle.time) {
+          this.$set(candles, candles.length-1, candle)
+        } else {
+          candles.push(candle)
+        }
+      })
+      source.addEventListener('signal', e => {
+        if (!this.candle) {
+          return
+        }
+        const signal = JSON.parse(e.data)
+        if (!this.candle.events) {
+          this.$set(this.candle, 'events', { signals: [], profit: 0 })
+        }
+        if (!this.candle.events.signals) {
+          this.$set(this.candle.events, 'signals', [])
+        }
+        this.candle.events.signals.push(signal)
+      })
     },
     timeInJST(dateString) {
       const localTime = new Date(dateString).getTime()
@@ -181,6 +311,58 @@ new Vue({
         data: data,
       }]
     },
+    // 平均足
+    heikinAshiSeries() {
+      if (!this.candle || !this.candle.candles || !this.candle.heikinAshi) {
+        return [{
+          data: []
+        }]
+      }
+      const ha = this.candle.heikinAshi
+      const data = this.candle.candles.map((c, i) => {
+        return {
+          x: this.timeInJST(c['time']),
+          y: [ha.opens[i], ha.highs[i], ha.lows[i], ha.closes[i]],
+        }
+      })
+      return [{
+        data: data,
+      }]
+    },
+    // 取引所ごとの価格差の割合（%）
+    spreadSeries() {
+      if (!this.spreads) {
+        return []
+      }
+      const series = {}
+      for (const s of this.spreads) {
+        if (!series[s.exchange]) {
+          series[s.exchange] = {
+            name: `${s.exchange} - ${s.baseExchange}`,
+            data: [],
+          }
+        }
+        series[s.exchange].data.push({
+          x: this.timeInJST(s['time']),
+          y: Math.round(s['rate'] * 10000) / 100,
+        })
+      }
+      return Object.values(series)
+    },
+    spreadChartOptions() {
+      return {
+        ...chartOptionsBase,
+        chart: {
+          type: 'line',
+          height: 300,
+        },
+        yaxis: {
+          labels: {
+            formatter: v => `${v}%`,
+          },
+        },
+      }
+    },
     chartOptions() {
       const annotations = {
         xaxis: [
@@ -260,5 +442,6 @@ new Vue({
   },
   mounted: async function() {
     await this.update()
+    this.subscribe()
   },
 })
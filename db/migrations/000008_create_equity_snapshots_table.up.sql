@@ -0,0 +1,12 @@
+USE trading_db;
+
+CREATE TABLE IF NOT EXISTS equity_snapshots (
+  time DATETIME NOT NULL,
+  product_code VARCHAR(50) NOT NULL,
+  equity DOUBLE NOT NULL,
+  realized_pnl DOUBLE NOT NULL,
+  unrealized_pnl DOUBLE NOT NULL,
+  position DOUBLE NOT NULL,
+  price DOUBLE NOT NULL,
+  PRIMARY KEY (time, product_code)
+);
@@ -0,0 +1,6 @@
+USE trading_db;
+
+ALTER TABLE trade_params
+  DROP COLUMN ichimoku_tenkan_period,
+  DROP COLUMN ichimoku_kijun_period,
+  DROP COLUMN ichimoku_senkou_b_period;
@@ -0,0 +1,6 @@
+USE trading_db;
+
+ALTER TABLE trade_params
+  ADD COLUMN ichimoku_tenkan_period INT NOT NULL DEFAULT 9 AFTER ichimoku_enable,
+  ADD COLUMN ichimoku_kijun_period INT NOT NULL DEFAULT 26 AFTER ichimoku_tenkan_period,
+  ADD COLUMN ichimoku_senkou_b_period INT NOT NULL DEFAULT 52 AFTER ichimoku_kijun_period;
@@ -0,0 +1,27 @@
+USE trading_db;
+
+ALTER TABLE trade_params
+  DROP COLUMN atr_enable,
+  DROP COLUMN atr_period,
+  DROP COLUMN atr_multiplier,
+  DROP COLUMN stoch_enable,
+  DROP COLUMN stoch_fast_k_period,
+  DROP COLUMN stoch_slow_k_period,
+  DROP COLUMN stoch_slow_d_period,
+  DROP COLUMN stoch_buy_thread,
+  DROP COLUMN stoch_sell_thread,
+  DROP COLUMN adx_enable,
+  DROP COLUMN adx_period,
+  DROP COLUMN adx_thread,
+  DROP COLUMN obv_enable,
+  DROP COLUMN obv_period,
+  DROP COLUMN vwap_enable,
+  DROP COLUMN vwap_period,
+  DROP COLUMN sar_enable,
+  DROP COLUMN sar_acceleration,
+  DROP COLUMN sar_maximum,
+  DROP COLUMN donchian_enable,
+  DROP COLUMN donchian_period,
+  DROP COLUMN keltner_enable,
+  DROP COLUMN keltner_period,
+  DROP COLUMN keltner_multiplier;
@@ -0,0 +1,27 @@
+USE trading_db;
+
+ALTER TABLE trade_params
+  ADD COLUMN atr_enable BOOLEAN NOT NULL DEFAULT 0 AFTER macd_signal_period,
+  ADD COLUMN atr_period INT NOT NULL DEFAULT 14 AFTER atr_enable,
+  ADD COLUMN atr_multiplier DOUBLE NOT NULL DEFAULT 2 AFTER atr_period,
+  ADD COLUMN stoch_enable BOOLEAN NOT NULL DEFAULT 0 AFTER atr_multiplier,
+  ADD COLUMN stoch_fast_k_period INT NOT NULL DEFAULT 14 AFTER stoch_enable,
+  ADD COLUMN stoch_slow_k_period INT NOT NULL DEFAULT 3 AFTER stoch_fast_k_period,
+  ADD COLUMN stoch_slow_d_period INT NOT NULL DEFAULT 3 AFTER stoch_slow_k_period,
+  ADD COLUMN stoch_buy_thread DOUBLE NOT NULL DEFAULT 20 AFTER stoch_slow_d_period,
+  ADD COLUMN stoch_sell_thread DOUBLE NOT NULL DEFAULT 80 AFTER stoch_buy_thread,
+  ADD COLUMN adx_enable BOOLEAN NOT NULL DEFAULT 0 AFTER stoch_sell_thread,
+  ADD COLUMN adx_period INT NOT NULL DEFAULT 14 AFTER adx_enable,
+  ADD COLUMN adx_thread DOUBLE NOT NULL DEFAULT 25 AFTER adx_period,
+  ADD COLUMN obv_enable BOOLEAN NOT NULL DEFAULT 0 AFTER adx_thread,
+  ADD COLUMN obv_period INT NOT NULL DEFAULT 20 AFTER obv_enable,
+  ADD COLUMN vwap_enable BOOLEAN NOT NULL DEFAULT 0 AFTER obv_period,
+  ADD COLUMN vwap_period INT NOT NULL DEFAULT 20 AFTER vwap_enable,
+  ADD COLUMN sar_enable BOOLEAN NOT NULL DEFAULT 0 AFTER vwap_period,
+  ADD COLUMN sar_acceleration DOUBLE NOT NULL DEFAULT 0.02 AFTER sar_enable,
+  ADD COLUMN sar_maximum DOUBLE NOT NULL DEFAULT 0.2 AFTER sar_acceleration,
+  ADD COLUMN donchian_enable BOOLEAN NOT NULL DEFAULT 0 AFTER sar_maximum,
+  ADD COLUMN donchian_period INT NOT NULL DEFAULT 20 AFTER donchian_enable,
+  ADD COLUMN keltner_enable BOOLEAN NOT NULL DEFAULT 0 AFTER donchian_period,
+  ADD COLUMN keltner_period INT NOT NULL DEFAULT 20 AFTER keltner_enable,
+  ADD COLUMN keltner_multiplier DOUBLE NOT NULL DEFAULT 2 AFTER keltner_period;
@@ -0,0 +1,5 @@
+USE trading_db;
+
+ALTER TABLE trade_params
+  DROP COLUMN heikin_ashi_enable,
+  DROP COLUMN heikin_ashi_period;
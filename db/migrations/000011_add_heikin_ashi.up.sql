@@ -0,0 +1,5 @@
+USE trading_db;
+
+ALTER TABLE trade_params
+  ADD COLUMN heikin_ashi_enable BOOLEAN NOT NULL DEFAULT 0 AFTER keltner_multiplier,
+  ADD COLUMN heikin_ashi_period INT NOT NULL DEFAULT 3 AFTER heikin_ashi_enable;
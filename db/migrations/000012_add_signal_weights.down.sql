@@ -0,0 +1,19 @@
+USE trading_db;
+
+ALTER TABLE trade_params
+  DROP COLUMN ema_weight,
+  DROP COLUMN bbands_weight,
+  DROP COLUMN ichimoku_weight,
+  DROP COLUMN rsi_weight,
+  DROP COLUMN macd_weight,
+  DROP COLUMN atr_weight,
+  DROP COLUMN stoch_weight,
+  DROP COLUMN adx_weight,
+  DROP COLUMN obv_weight,
+  DROP COLUMN vwap_weight,
+  DROP COLUMN sar_weight,
+  DROP COLUMN donchian_weight,
+  DROP COLUMN keltner_weight,
+  DROP COLUMN heikin_ashi_weight,
+  DROP COLUMN buy_vote_threshold,
+  DROP COLUMN sell_vote_threshold;
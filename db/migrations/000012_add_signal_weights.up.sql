@@ -0,0 +1,19 @@
+USE trading_db;
+
+ALTER TABLE trade_params
+  ADD COLUMN ema_weight DOUBLE NOT NULL DEFAULT 1 AFTER heikin_ashi_period,
+  ADD COLUMN bbands_weight DOUBLE NOT NULL DEFAULT 1 AFTER ema_weight,
+  ADD COLUMN ichimoku_weight DOUBLE NOT NULL DEFAULT 1 AFTER bbands_weight,
+  ADD COLUMN rsi_weight DOUBLE NOT NULL DEFAULT 1 AFTER ichimoku_weight,
+  ADD COLUMN macd_weight DOUBLE NOT NULL DEFAULT 1 AFTER rsi_weight,
+  ADD COLUMN atr_weight DOUBLE NOT NULL DEFAULT 1 AFTER macd_weight,
+  ADD COLUMN stoch_weight DOUBLE NOT NULL DEFAULT 1 AFTER atr_weight,
+  ADD COLUMN adx_weight DOUBLE NOT NULL DEFAULT 1 AFTER stoch_weight,
+  ADD COLUMN obv_weight DOUBLE NOT NULL DEFAULT 1 AFTER adx_weight,
+  ADD COLUMN vwap_weight DOUBLE NOT NULL DEFAULT 1 AFTER obv_weight,
+  ADD COLUMN sar_weight DOUBLE NOT NULL DEFAULT 1 AFTER vwap_weight,
+  ADD COLUMN donchian_weight DOUBLE NOT NULL DEFAULT 1 AFTER sar_weight,
+  ADD COLUMN keltner_weight DOUBLE NOT NULL DEFAULT 1 AFTER donchian_weight,
+  ADD COLUMN heikin_ashi_weight DOUBLE NOT NULL DEFAULT 1 AFTER keltner_weight,
+  ADD COLUMN buy_vote_threshold DOUBLE NOT NULL DEFAULT 2 AFTER heikin_ashi_weight,
+  ADD COLUMN sell_vote_threshold DOUBLE NOT NULL DEFAULT 2 AFTER buy_vote_threshold;
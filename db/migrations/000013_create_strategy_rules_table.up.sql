@@ -0,0 +1,9 @@
+USE trading_db;
+
+CREATE TABLE IF NOT EXISTS strategy_rules (
+  product_code VARCHAR(50) NOT NULL,
+  buy_rule TEXT NOT NULL,
+  sell_rule TEXT NOT NULL,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (product_code)
+);
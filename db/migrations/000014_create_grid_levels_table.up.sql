@@ -0,0 +1,13 @@
+USE trading_db;
+
+CREATE TABLE IF NOT EXISTS grid_levels (
+  product_code VARCHAR(50) NOT NULL,
+  level_index INT NOT NULL,
+  buy_price DOUBLE NOT NULL,
+  sell_price DOUBLE NOT NULL,
+  size DOUBLE NOT NULL,
+  state VARCHAR(10) NOT NULL,
+  order_id VARCHAR(50) NOT NULL DEFAULT '',
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (product_code, level_index)
+);
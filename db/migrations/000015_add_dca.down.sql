@@ -0,0 +1,6 @@
+USE trading_db;
+
+DROP TABLE IF EXISTS dca_params;
+
+ALTER TABLE signal_events
+  DROP COLUMN tag;
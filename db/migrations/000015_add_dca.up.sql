@@ -0,0 +1,16 @@
+USE trading_db;
+
+ALTER TABLE signal_events
+  ADD COLUMN tag VARCHAR(10) NOT NULL DEFAULT 'STRATEGY' AFTER size;
+
+CREATE TABLE IF NOT EXISTS dca_params (
+  product_code VARCHAR(50) NOT NULL,
+  enable BOOLEAN NOT NULL DEFAULT 0,
+  amount DOUBLE NOT NULL,
+  interval_days INT NOT NULL DEFAULT 1,
+  rsi_period INT NOT NULL DEFAULT 0,
+  rsi_threshold DOUBLE NOT NULL DEFAULT 30,
+  rsi_multiplier DOUBLE NOT NULL DEFAULT 1,
+  min_size DOUBLE NOT NULL,
+  PRIMARY KEY (product_code)
+);
@@ -0,0 +1,5 @@
+USE trading_db;
+
+ALTER TABLE trade_params
+  DROP COLUMN max_lots,
+  DROP COLUMN lot_matching;
@@ -0,0 +1,5 @@
+USE trading_db;
+
+ALTER TABLE trade_params
+  ADD COLUMN max_lots INT NOT NULL DEFAULT 1 AFTER sell_vote_threshold,
+  ADD COLUMN lot_matching VARCHAR(10) NOT NULL DEFAULT 'FIFO' AFTER max_lots;
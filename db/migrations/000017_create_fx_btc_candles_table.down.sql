@@ -0,0 +1,3 @@
+USE trading_db;
+
+DROP TABLE IF EXISTS fx_btc_candles;
@@ -0,0 +1,10 @@
+USE trading_db;
+
+CREATE TABLE IF NOT EXISTS fx_btc_candles (
+  time DATETIME PRIMARY KEY NOT NULL,
+  open FLOAT,
+  close FLOAT,
+  high FLOAT,
+  low FLOAT,
+  volume FLOAT
+);
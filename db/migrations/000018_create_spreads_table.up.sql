@@ -0,0 +1,11 @@
+USE trading_db;
+
+CREATE TABLE IF NOT EXISTS spreads (
+  time DATETIME NOT NULL,
+  product_code VARCHAR(50) NOT NULL,
+  base_exchange VARCHAR(50) NOT NULL,
+  exchange VARCHAR(50) NOT NULL,
+  base_price DOUBLE NOT NULL,
+  price DOUBLE NOT NULL,
+  PRIMARY KEY (time, product_code, exchange)
+);
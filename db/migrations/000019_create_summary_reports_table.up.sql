@@ -0,0 +1,9 @@
+USE trading_db;
+
+CREATE TABLE IF NOT EXISTS summary_reports (
+  time DATETIME NOT NULL,
+  product_code VARCHAR(50) NOT NULL,
+  period VARCHAR(20) NOT NULL,
+  params TEXT NOT NULL,
+  PRIMARY KEY (product_code, period, time)
+);
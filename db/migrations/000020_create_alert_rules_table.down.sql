@@ -0,0 +1,4 @@
+USE trading_db;
+
+DROP TABLE IF EXISTS price_points;
+DROP TABLE IF EXISTS alert_rules;
@@ -0,0 +1,23 @@
+USE trading_db;
+
+CREATE TABLE IF NOT EXISTS alert_rules (
+  id BIGINT NOT NULL AUTO_INCREMENT,
+  product_code VARCHAR(50) NOT NULL,
+  kind VARCHAR(20) NOT NULL,
+  threshold DOUBLE NOT NULL DEFAULT 0,
+  hours INT NOT NULL DEFAULT 0,
+  expression TEXT NOT NULL,
+  cooldown_minutes INT NOT NULL DEFAULT 60,
+  enable BOOLEAN NOT NULL DEFAULT TRUE,
+  active BOOLEAN NOT NULL DEFAULT FALSE,
+  notified_at DATETIME NULL,
+  PRIMARY KEY (id),
+  INDEX (product_code)
+);
+
+CREATE TABLE IF NOT EXISTS price_points (
+  time DATETIME NOT NULL,
+  product_code VARCHAR(50) NOT NULL,
+  price DOUBLE NOT NULL,
+  PRIMARY KEY (product_code, time)
+);
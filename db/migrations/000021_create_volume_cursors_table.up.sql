@@ -0,0 +1,7 @@
+USE trading_db;
+
+CREATE TABLE IF NOT EXISTS volume_cursors (
+  product_code VARCHAR(50) NOT NULL,
+  execution_id BIGINT NOT NULL,
+  PRIMARY KEY (product_code)
+);
@@ -7,43 +7,54 @@ import (
 	"github.com/robfig/cron/v3"
 )
 
-func traderFetchTicker() {
-	url := "http://trading_trader:8080/fetch-ticker"
-	req, err := http.NewRequest("POST", url, nil)
-	if err != nil {
-		return
-	}
+const traderURL = "http://trading_trader:8080"
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return
-	}
-	defer resp.Body.Close()
-	log.Println("[cron]", resp.StatusCode, resp.Request.URL)
-}
+// traderのエンドポイントを呼び出す
+func post(path string) func() {
+	return func() {
+		req, err := http.NewRequest("POST", traderURL+path, nil)
+		if err != nil {
+			log.Println("[cron]", err)
+			return
+		}
 
-func traderTrade() {
-	url := "http://trading_trader:8080/trade"
-	req, err := http.NewRequest("POST", url, nil)
-	if err != nil {
-		return
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		if err != nil {
+			log.Println("[cron]", err)
+			return
+		}
+		defer resp.Body.Close()
+		log.Println("[cron]", resp.StatusCode, resp.Request.URL)
 	}
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return
-	}
-	defer resp.Body.Close()
-	log.Println("[cron]", resp.StatusCode, resp.Request.URL)
+// 定期実行するエンドポイントと，そのcron式
+var jobs = []struct {
+	spec string
+	path string
+}{
+	{"*/5 * * * *", "/fetch-ticker"},
+	{"0 0 * * *", "/snapshot-equity"},
+	// 運用成績のまとめは取引する時刻（9時）に送る
+	{"0 9 * * *", "/summary/daily"},
+	{"0 9 * * 1", "/summary/weekly"},
+	// 予期せぬ取引を避けるため，ローカルで動かすのはやめておく
+	// {"*/10 * * * *", "/trade"},
+	// {"* * * * *", "/grid"},
+	// {"0 9 * * *", "/dca"},
+	// {"*/10 * * * *", "/fx-derisk"},
+	// 取引所間の価格差は，SPREAD_EXCHANGESを指定したときだけ記録する
+	// {"* * * * *", "/spread"},
 }
 
 func main() {
 	c := cron.New()
-	c.AddFunc("*/5 * * * *", traderFetchTicker)
-	// 予期せぬ取引を避けるため，ローカルで動かすのはやめておく
-	// c.AddFunc("*/10 * * * *", traderTrade)
+	for _, job := range jobs {
+		if _, err := c.AddFunc(job.spec, post(job.path)); err != nil {
+			log.Fatal(err)
+		}
+	}
 	c.Start()
 
 	http.HandleFunc("/", func(res http.ResponseWriter, req *http.Request) {})
@@ -14,6 +14,7 @@ CREATE TABLE `signal_events` (
   `side` TEXT DEFAULT NULL,
   `price` REAL DEFAULT NULL,
   `size` REAL DEFAULT NULL,
+  `tag` TEXT NOT NULL DEFAULT 'STRATEGY',
   PRIMARY KEY (`time`)
 );
 
@@ -42,5 +43,116 @@ CREATE TABLE `trade_params` (
   `macd_slow_period` INTEGER NOT NULL,
   `macd_signal_period` INTEGER NOT NULL,
   `created_at` TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
-  `stop_limit_percent` REAL NOT NULL DEFAULT 0
+  `stop_limit_percent` REAL NOT NULL DEFAULT 0,
+  `ichimoku_tenkan_period` INTEGER NOT NULL DEFAULT 9,
+  `ichimoku_kijun_period` INTEGER NOT NULL DEFAULT 26,
+  `ichimoku_senkou_b_period` INTEGER NOT NULL DEFAULT 52,
+  `atr_enable` INTEGER NOT NULL DEFAULT '0',
+  `atr_period` INTEGER NOT NULL DEFAULT 14,
+  `atr_multiplier` REAL NOT NULL DEFAULT 2,
+  `stoch_enable` INTEGER NOT NULL DEFAULT '0',
+  `stoch_fast_k_period` INTEGER NOT NULL DEFAULT 14,
+  `stoch_slow_k_period` INTEGER NOT NULL DEFAULT 3,
+  `stoch_slow_d_period` INTEGER NOT NULL DEFAULT 3,
+  `stoch_buy_thread` REAL NOT NULL DEFAULT 20,
+  `stoch_sell_thread` REAL NOT NULL DEFAULT 80,
+  `adx_enable` INTEGER NOT NULL DEFAULT '0',
+  `adx_period` INTEGER NOT NULL DEFAULT 14,
+  `adx_thread` REAL NOT NULL DEFAULT 25,
+  `obv_enable` INTEGER NOT NULL DEFAULT '0',
+  `obv_period` INTEGER NOT NULL DEFAULT 20,
+  `vwap_enable` INTEGER NOT NULL DEFAULT '0',
+  `vwap_period` INTEGER NOT NULL DEFAULT 20,
+  `sar_enable` INTEGER NOT NULL DEFAULT '0',
+  `sar_acceleration` REAL NOT NULL DEFAULT 0.02,
+  `sar_maximum` REAL NOT NULL DEFAULT 0.2,
+  `donchian_enable` INTEGER NOT NULL DEFAULT '0',
+  `donchian_period` INTEGER NOT NULL DEFAULT 20,
+  `keltner_enable` INTEGER NOT NULL DEFAULT '0',
+  `keltner_period` INTEGER NOT NULL DEFAULT 20,
+  `keltner_multiplier` REAL NOT NULL DEFAULT 2,
+  `heikin_ashi_enable` INTEGER NOT NULL DEFAULT '0',
+  `heikin_ashi_period` INTEGER NOT NULL DEFAULT 3,
+  `ema_weight` REAL NOT NULL DEFAULT 1,
+  `bbands_weight` REAL NOT NULL DEFAULT 1,
+  `ichimoku_weight` REAL NOT NULL DEFAULT 1,
+  `rsi_weight` REAL NOT NULL DEFAULT 1,
+  `macd_weight` REAL NOT NULL DEFAULT 1,
+  `atr_weight` REAL NOT NULL DEFAULT 1,
+  `stoch_weight` REAL NOT NULL DEFAULT 1,
+  `adx_weight` REAL NOT NULL DEFAULT 1,
+  `obv_weight` REAL NOT NULL DEFAULT 1,
+  `vwap_weight` REAL NOT NULL DEFAULT 1,
+  `sar_weight` REAL NOT NULL DEFAULT 1,
+  `donchian_weight` REAL NOT NULL DEFAULT 1,
+  `keltner_weight` REAL NOT NULL DEFAULT 1,
+  `heikin_ashi_weight` REAL NOT NULL DEFAULT 1,
+  `buy_vote_threshold` REAL NOT NULL DEFAULT 2,
+  `sell_vote_threshold` REAL NOT NULL DEFAULT 2,
+  `max_lots` INTEGER NOT NULL DEFAULT 1,
+  `lot_matching` TEXT NOT NULL DEFAULT 'FIFO'
+);
+
+CREATE TABLE `equity_snapshots` (
+  `time` TEXT NOT NULL,
+  `product_code` TEXT NOT NULL,
+  `equity` REAL NOT NULL,
+  `realized_pnl` REAL NOT NULL,
+  `unrealized_pnl` REAL NOT NULL,
+  `position` REAL NOT NULL,
+  `price` REAL NOT NULL,
+  PRIMARY KEY (`time`, `product_code`)
+);
+
+CREATE TABLE `backtest_jobs` (
+  `id` TEXT NOT NULL,
+  `product_code` TEXT NOT NULL,
+  `strategy` TEXT NOT NULL,
+  `params` TEXT NOT NULL,
+  `start_time` TEXT NOT NULL,
+  `end_time` TEXT NOT NULL,
+  `status` TEXT NOT NULL,
+  `error_message` TEXT NOT NULL DEFAULT '',
+  `created_at` TEXT NOT NULL,
+  `finished_at` TEXT NOT NULL DEFAULT '',
+  `result` TEXT NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`)
+);
+
+CREATE TABLE `strategy_rules` (
+  `product_code` TEXT NOT NULL,
+  `buy_rule` TEXT NOT NULL,
+  `sell_rule` TEXT NOT NULL,
+  `updated_at` TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`product_code`)
+);
+
+CREATE TABLE `spreads` (
+  `time` TEXT NOT NULL,
+  `product_code` TEXT NOT NULL,
+  `base_exchange` TEXT NOT NULL,
+  `exchange` TEXT NOT NULL,
+  `base_price` REAL NOT NULL,
+  `price` REAL NOT NULL,
+  PRIMARY KEY (`time`, `product_code`, `exchange`)
+);
+
+CREATE TABLE `alert_rules` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `product_code` TEXT NOT NULL,
+  `kind` TEXT NOT NULL,
+  `threshold` REAL NOT NULL DEFAULT '0',
+  `hours` INTEGER NOT NULL DEFAULT '0',
+  `expression` TEXT NOT NULL DEFAULT '',
+  `cooldown_minutes` INTEGER NOT NULL DEFAULT '60',
+  `enable` INTEGER NOT NULL DEFAULT '1',
+  `active` INTEGER NOT NULL DEFAULT '0',
+  `notified_at` TEXT NULL
+);
+
+CREATE TABLE `price_points` (
+  `time` TEXT NOT NULL,
+  `product_code` TEXT NOT NULL,
+  `price` REAL NOT NULL,
+  PRIMARY KEY (`product_code`, `time`)
 );
@@ -0,0 +1,71 @@
+// bitFlyerの約定履歴からキャンドルを作り直して保存する
+// 約定履歴は直近31日分しか取得できない
+//
+//	go run ./cmd/backfill -start 2021-11-01 -end 2021-11-10
+//	go run ./cmd/backfill -start 2021-11-01 -gaps
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+const dateFormat = "2006-01-02"
+
+func main() {
+	productCode := flag.String("product", config.ProductCode, "product code")
+	duration := flag.Duration("duration", config.CandleDuration, "candle duration")
+	table := flag.String("table", config.CandleTableName, "candle table name")
+	startStr := flag.String("start", "", "start date (YYYY-MM-DD, local time)")
+	endStr := flag.String("end", "", "end date (YYYY-MM-DD, local time, default: now)")
+	gaps := flag.Bool("gaps", false, "only report missing candle times")
+	flag.Parse()
+
+	start, err := time.ParseInLocation(dateFormat, *startStr, config.LocalTime)
+	if err != nil {
+		exit(err)
+	}
+	end := time.Now()
+	if *endStr != "" {
+		end, err = time.ParseInLocation(dateFormat, *endStr, config.LocalTime)
+		if err != nil {
+			exit(err)
+		}
+	}
+
+	// 日足は取引時刻で区切る
+	origin := time.Date(2000, 1, 1, config.TradeHour, 0, 0, 0, config.LocalTime)
+
+	bitflyerClient := bitflyer.NewClient(config.APIKey, config.APISecret)
+	executionRepository := bitflyer.NewBitflyerExecutionRepository(bitflyerClient)
+	candleRepository := persistence.NewCandleRepository(config.DB, *table, config.TimeFormat)
+	backfillUsecase := usecase.NewBackfillUsecase(executionRepository, candleRepository, *duration, origin, time.Second)
+
+	if !*gaps {
+		n, covered, err := backfillUsecase.Backfill(*productCode, start, end)
+		if err != nil {
+			exit(err)
+		}
+		fmt.Fprintf(os.Stderr, "%d candles saved since %s\n", n, covered.In(config.LocalTime).Format(time.RFC3339))
+	}
+
+	missing, err := backfillUsecase.FindGaps(*productCode, start, end)
+	if err != nil {
+		exit(err)
+	}
+	for _, candleTime := range missing {
+		fmt.Println(candleTime.Time().In(config.LocalTime).Format(time.RFC3339))
+	}
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,166 @@
+// キャンドル（-signalsを付けると売買履歴）をCSVまたはParquetファイルにエクスポート・インポートする
+// ファイル形式は拡張子で判定する
+//
+//	go run ./cmd/candles export -out candles.parquet -start 2021-01-01 -end 2021-12-31
+//	go run ./cmd/candles export -signals -out signal_events.csv
+//	go run ./cmd/candles import -in candles.parquet
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+)
+
+const dateFormat = "2006-01-02"
+
+func main() {
+	if len(os.Args) < 2 {
+		exit(fmt.Errorf("usage: %s export|import [flags]", os.Args[0]))
+	}
+	command := os.Args[1]
+
+	fs := flag.NewFlagSet(command, flag.ExitOnError)
+	productCode := fs.String("product", config.ProductCode, "product code")
+	duration := fs.Duration("duration", config.CandleDuration, "candle duration")
+	table := fs.String("table", config.CandleTableName, "candle table name")
+	startStr := fs.String("start", "", "start date (YYYY-MM-DD, local time)")
+	endStr := fs.String("end", "", "end date (YYYY-MM-DD, local time, inclusive)")
+	signals := fs.Bool("signals", false, "export/import signal events instead of candles")
+	out := fs.String("out", "", "output file (.csv or .parquet)")
+	in := fs.String("in", "", "input file (.csv or .parquet)")
+	fs.Parse(os.Args[2:])
+
+	var start, end time.Time
+	var err error
+	if *startStr != "" {
+		start, err = time.ParseInLocation(dateFormat, *startStr, config.LocalTime)
+		if err != nil {
+			exit(err)
+		}
+	}
+	if *endStr != "" {
+		end, err = time.ParseInLocation(dateFormat, *endStr, config.LocalTime)
+		if err != nil {
+			exit(err)
+		}
+		end = end.AddDate(0, 0, 1)
+	}
+	inRange := func(t time.Time) bool {
+		return (start.IsZero() || !t.Before(start)) && (end.IsZero() || t.Before(end))
+	}
+
+	candleRepository := persistence.NewCandleRepository(config.DB, *table, config.TimeFormat)
+	signalEventRepository := persistence.NewSignalEventRepository(config.DB, config.TimeFormat)
+
+	switch command {
+	case "export":
+		format, err := persistence.FileFormatFromPath(*out)
+		if err != nil {
+			exit(err)
+		}
+		fp, err := os.Create(*out)
+		if err != nil {
+			exit(err)
+		}
+		defer fp.Close()
+
+		if *signals {
+			signalEvents, err := signalEventRepository.FindAll(*productCode)
+			if err != nil {
+				exit(err)
+			}
+			filtered := make([]model.SignalEvent, 0)
+			for _, signal := range signalEvents {
+				if inRange(signal.Time()) {
+					filtered = append(filtered, signal)
+				}
+			}
+			err = persistence.WriteSignalEvents(fp, format, filtered, config.TimeFormat)
+			if err != nil {
+				exit(err)
+			}
+			fmt.Fprintf(os.Stderr, "%d signal events exported\n", len(filtered))
+			return
+		}
+
+		// FindAllは最新から数えるので，startまで届く件数を取得する
+		limit := int64(math.MaxInt32)
+		if !start.IsZero() {
+			limit = int64(time.Since(start) / *duration) + 1
+		}
+		candles, err := candleRepository.FindAll(*productCode, *duration, limit)
+		if err != nil {
+			exit(err)
+		}
+		filtered := make([]model.Candle, 0)
+		for _, candle := range candles {
+			if inRange(candle.Time().Time()) {
+				filtered = append(filtered, candle)
+			}
+		}
+		err = persistence.WriteCandles(fp, format, filtered, config.TimeFormat)
+		if err != nil {
+			exit(err)
+		}
+		fmt.Fprintf(os.Stderr, "%d candles exported\n", len(filtered))
+	case "import":
+		format, err := persistence.FileFormatFromPath(*in)
+		if err != nil {
+			exit(err)
+		}
+		fp, err := os.Open(*in)
+		if err != nil {
+			exit(err)
+		}
+		defer fp.Close()
+
+		if *signals {
+			signalEvents, err := persistence.ReadSignalEvents(fp, format, config.TimeFormat)
+			if err != nil {
+				exit(err)
+			}
+			n := 0
+			for _, signal := range signalEvents {
+				if signal.ProductCode() != *productCode || !inRange(signal.Time()) {
+					continue
+				}
+				if err := signalEventRepository.Save(signal); err != nil {
+					exit(err)
+				}
+				n++
+			}
+			fmt.Fprintf(os.Stderr, "%d signal events imported\n", n)
+			return
+		}
+
+		candles, err := persistence.ReadCandles(fp, format, *productCode, *duration, config.TimeFormat)
+		if err != nil {
+			exit(err)
+		}
+		n := 0
+		for _, candle := range candles {
+			if !inRange(candle.Time().Time()) {
+				continue
+			}
+			if err := candleRepository.Save(candle); err != nil {
+				exit(err)
+			}
+			n++
+		}
+		fmt.Fprintf(os.Stderr, "%d candles imported\n", n)
+	default:
+		exit(fmt.Errorf("unknown command: %s", command))
+	}
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,127 @@
+// ローカルで動かすbitFlyerのAPIの偽物
+// traderはBITFLYER_BASE_URL=http://localhost:8081/v1/ で接続する
+// 価格は-pricesか-candles（キャンドルの終値）の順に，-intervalごとまたは POST /fake/step で進む
+//
+//	go run ./cmd/fakebitflyer -prices 540000,530000,550000 -interval 1m
+//	go run ./cmd/fakebitflyer -candles candles.csv -clock-step 24h -start 2021-01-01
+//	curl -X POST 'localhost:8081/fake/fail?path=me/sendchildorder&status=500&count=1'
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer/fake"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+)
+
+const dateFormat = "2006-01-02"
+
+func main() {
+	addr := flag.String("addr", ":8081", "listen address")
+	key := flag.String("key", config.APIKey, "API key to accept")
+	secret := flag.String("secret", config.APISecret, "API secret to verify signatures")
+	productCode := flag.String("product", config.ProductCode, "product code")
+	pricesStr := flag.String("prices", "", "comma separated price path")
+	candles := flag.String("candles", "", "candle file (.csv or .parquet) whose close prices are used as the price path")
+	spread := flag.Float64("spread", 100, "best ask - best bid")
+	jpy := flag.Float64("jpy", 1000000, "initial JPY balance")
+	base := flag.Float64("base", 0, "initial balance of the base currency (e.g. ETH)")
+	collateral := flag.Float64("collateral", 1000000, "initial collateral for FX products")
+	commission := flag.Float64("commission", config.CommissionRate, "commission rate")
+	interval := flag.Duration("interval", 0, "advance the price path every interval (0: only by POST /fake/step)")
+	clockStep := flag.Duration("clock-step", 0, "advance the clock by this duration per step (0: use the real time)")
+	startStr := flag.String("start", "", "start date of the clock (YYYY-MM-DD, UTC)")
+	flag.Parse()
+
+	prices, err := readPrices(*pricesStr, *candles, *productCode)
+	if err != nil {
+		exit(err)
+	}
+
+	var start time.Time
+	if *startStr != "" {
+		start, err = time.Parse(dateFormat, *startStr)
+		if err != nil {
+			exit(err)
+		}
+	}
+
+	baseCurrency := strings.Split(strings.TrimPrefix(*productCode, "FX_"), "_")[0]
+	server := fake.NewServer(fake.Config{
+		Key:            *key,
+		Secret:         *secret,
+		Prices:         map[string][]float64{*productCode: prices},
+		Spread:         *spread,
+		Balances:       map[string]float64{"JPY": *jpy, baseCurrency: *base},
+		Collateral:     *collateral,
+		CommissionRate: *commission,
+		Start:          start,
+		StepDuration:   *clockStep,
+	})
+	if server == nil {
+		exit(errors.New("invalid config (key, secret and prices are required)"))
+	}
+
+	if *interval > 0 {
+		go func() {
+			for range time.Tick(*interval) {
+				server.Step()
+			}
+		}()
+	}
+
+	fmt.Fprintf(os.Stderr, "fake bitflyer: %s, %d prices, listening on %s\n", *productCode, len(prices), *addr)
+	if err := http.ListenAndServe(*addr, server); err != nil {
+		exit(err)
+	}
+}
+
+func readPrices(pricesStr, candles, productCode string) ([]float64, error) {
+	if candles != "" {
+		format, err := persistence.FileFormatFromPath(candles)
+		if err != nil {
+			return nil, err
+		}
+		f, err := os.Open(candles)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		cs, err := persistence.ReadCandles(f, format, productCode, config.CandleDuration, config.TimeFormat)
+		if err != nil {
+			return nil, err
+		}
+		prices := make([]float64, len(cs))
+		for i, candle := range cs {
+			prices[i] = candle.Close()
+		}
+		return prices, nil
+	}
+
+	if pricesStr == "" {
+		return nil, errors.New("-prices or -candles is required")
+	}
+	prices := make([]float64, 0)
+	for _, s := range strings.Split(pricesStr, ",") {
+		price, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, err
+		}
+		prices = append(prices, price)
+	}
+	return prices, nil
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,32 @@
+// 確定申告用に，取引履歴から年ごとの売買損益をCSVで出力する
+//
+//	go run ./cmd/taxreport -method total_average > tax_report.csv
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+func main() {
+	productCode := flag.String("product", config.ProductCode, "product code")
+	method := flag.String("method", string(model.CostMethodMovingAverage), "cost method (moving_average or total_average)")
+	flag.Parse()
+
+	signalEventRepository := persistence.NewSignalEventRepository(config.DB, config.TimeFormat)
+	signalEventService := service.NewSignalEventService(signalEventRepository)
+	taxReportUsecase := usecase.NewTaxReportUsecase(signalEventService, config.CommissionRate, config.LocalTime)
+
+	err := taxReportUsecase.WriteCSV(os.Stdout, *productCode, model.CostMethod(*method))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -11,12 +11,18 @@ var (
 	ProductCode    string
 	CandleDuration time.Duration
 	TradeHour      int
+	CommissionRate float64
+	// bitFlyerのAPIの接続先（空ならbitFlyer，ローカルの偽物の取引所に向けるときに指定する）
+	APIBaseURL string
 )
 
 func init() {
 	APIKey = os.Getenv("BITFLYER_API_KEY")
 	APISecret = os.Getenv("BITFLYER_API_SECRET")
+	APIBaseURL = os.Getenv("BITFLYER_BASE_URL")
 	ProductCode = os.Getenv("PRODUCT_CODE")
 	CandleDuration = 24 * time.Hour
 	TradeHour = 9
+	// 取引手数料率（bitFlyer: 0.15%）
+	CommissionRate = 0.0015
 }
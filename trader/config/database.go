@@ -21,8 +21,9 @@ var (
 )
 
 const (
-	CandleTableName = "eth_candles"
-	TimeFormat      = "2006-01-02 15:04:05"
+	CandleTableName   = "eth_candles"
+	FXCandleTableName = "fx_btc_candles"
+	TimeFormat        = "2006-01-02 15:04:05"
 )
 
 func DSN() string {
@@ -0,0 +1,19 @@
+package config
+
+import "os"
+
+var (
+	// 取引所（bitflyer, coincheck）
+	Exchange           string
+	CoincheckAPIKey    string
+	CoincheckAPISecret string
+)
+
+func init() {
+	Exchange = os.Getenv("EXCHANGE")
+	if Exchange == "" {
+		Exchange = "bitflyer"
+	}
+	CoincheckAPIKey = os.Getenv("COINCHECK_API_KEY")
+	CoincheckAPISecret = os.Getenv("COINCHECK_API_SECRET")
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+var (
+	// 証拠金維持率がこれを下回ったら建玉を減らす
+	FXMinKeepRate float64
+	// 建玉を減らすときに戻す証拠金維持率
+	FXTargetKeepRate float64
+)
+
+func init() {
+	FXMinKeepRate = 1.5
+	if rate, err := strconv.ParseFloat(os.Getenv("FX_MIN_KEEP_RATE"), 64); err == nil && rate > 0 {
+		FXMinKeepRate = rate
+	}
+	FXTargetKeepRate = 2
+	if rate, err := strconv.ParseFloat(os.Getenv("FX_TARGET_KEEP_RATE"), 64); err == nil && rate > 0 {
+		FXTargetKeepRate = rate
+	}
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+var (
+	// グリッド取引の価格の下限と上限（どちらかが0ならグリッド取引をしない）
+	GridLowerPrice float64
+	GridUpperPrice float64
+	// 上下限を含む価格の本数
+	GridLevels int
+	// 1段あたりの注文数量
+	GridSize float64
+)
+
+func init() {
+	GridLowerPrice, _ = strconv.ParseFloat(os.Getenv("GRID_LOWER_PRICE"), 64)
+	GridUpperPrice, _ = strconv.ParseFloat(os.Getenv("GRID_UPPER_PRICE"), 64)
+	GridLevels, _ = strconv.Atoi(os.Getenv("GRID_LEVELS"))
+	GridSize, _ = strconv.ParseFloat(os.Getenv("GRID_SIZE"), 64)
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// 通知先（slack, discord, line, webhook のカンマ区切り．すべてに同じ通知を送る）
+	Notifiers              []string
+	DiscordWebhookURL      string
+	LineChannelAccessToken string
+	LineTo                 string
+	WebhookURL             string
+	// Slack以外の通知先に送るときのタイムアウトと再送の回数
+	NotificationTimeout time.Duration
+	NotificationRetries int
+)
+
+func init() {
+	Notifiers = make([]string, 0)
+	for _, notifier := range strings.Split(os.Getenv("NOTIFIERS"), ",") {
+		notifier = strings.TrimSpace(notifier)
+		if notifier != "" {
+			Notifiers = append(Notifiers, notifier)
+		}
+	}
+	if len(Notifiers) == 0 {
+		Notifiers = []string{"slack"}
+	}
+
+	DiscordWebhookURL = os.Getenv("DISCORD_WEBHOOK_URL")
+	LineChannelAccessToken = os.Getenv("LINE_CHANNEL_ACCESS_TOKEN")
+	LineTo = os.Getenv("LINE_TO")
+	WebhookURL = os.Getenv("WEBHOOK_URL")
+
+	NotificationTimeout = 10 * time.Second
+	if timeout, err := time.ParseDuration(os.Getenv("NOTIFICATION_TIMEOUT")); err == nil && timeout > 0 {
+		NotificationTimeout = timeout
+	}
+
+	NotificationRetries = 3
+	if retries, err := strconv.Atoi(os.Getenv("NOTIFICATION_RETRIES")); err == nil && retries >= 0 {
+		NotificationRetries = retries
+	}
+}
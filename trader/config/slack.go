@@ -1,13 +1,30 @@
 package config
 
-import "os"
+import (
+	"os"
+	"time"
+)
 
 var (
 	SlackBotToken  string
 	SlackChannelID string
+	// 重要度ごとの通知先（指定がなければSlackChannelID）
+	SlackInfoChannelID  string
+	SlackWarnChannelID  string
+	SlackErrorChannelID string
+	// 同じ内容の失敗・見送りを通知しない間隔
+	NotificationDedupInterval time.Duration
 )
 
 func init() {
 	SlackBotToken = os.Getenv("SLACK_BOT_TOKEN")
 	SlackChannelID = os.Getenv("SLACK_CHANNEL_ID")
+	SlackInfoChannelID = os.Getenv("SLACK_INFO_CHANNEL_ID")
+	SlackWarnChannelID = os.Getenv("SLACK_WARN_CHANNEL_ID")
+	SlackErrorChannelID = os.Getenv("SLACK_ERROR_CHANNEL_ID")
+
+	NotificationDedupInterval = time.Hour
+	if interval, err := time.ParseDuration(os.Getenv("NOTIFICATION_DEDUP_INTERVAL")); err == nil && interval >= 0 {
+		NotificationDedupInterval = interval
+	}
 }
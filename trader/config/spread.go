@@ -0,0 +1,29 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	// 取引所間の価格差を比べる取引所（カンマ区切り．EXCHANGEと同じものは除く）
+	SpreadExchanges []string
+	// 価格差の割合の絶対値がこれ以上なら通知する
+	SpreadThreshold float64
+)
+
+func init() {
+	SpreadExchanges = make([]string, 0)
+	for _, exchange := range strings.Split(os.Getenv("SPREAD_EXCHANGES"), ",") {
+		exchange = strings.TrimSpace(exchange)
+		if exchange != "" && exchange != Exchange {
+			SpreadExchanges = append(SpreadExchanges, exchange)
+		}
+	}
+
+	SpreadThreshold = 0.01
+	if threshold, err := strconv.ParseFloat(os.Getenv("SPREAD_THRESHOLD"), 64); err == nil && threshold > 0 {
+		SpreadThreshold = threshold
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+var (
+	// 売買サインの判定方法（mr_base, default, weighted, rule）
+	Strategy string
+	// 買いサインを上位の時間足のトレンドで確認する（0なら確認しない）
+	// 日足の整数倍であること（日足より短い時間足には対応していない）
+	TrendDuration time.Duration
+	// 上位の時間足のトレンドの判定に使うEMAの期間
+	TrendEMAPeriod int
+)
+
+func init() {
+	Strategy = os.Getenv("STRATEGY")
+	if Strategy == "" {
+		Strategy = "mr_base"
+	}
+
+	TrendDuration, _ = time.ParseDuration(os.Getenv("TREND_DURATION"))
+
+	TrendEMAPeriod, _ = strconv.Atoi(os.Getenv("TREND_EMA_PERIOD"))
+	if TrendEMAPeriod <= 0 {
+		TrendEMAPeriod = 10
+	}
+}
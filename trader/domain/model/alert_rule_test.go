@@ -0,0 +1,117 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+)
+
+func TestNewAlertRule(t *testing.T) {
+	var zero time.Time
+
+	cases := []struct {
+		name       string
+		kind       model.AlertKind
+		threshold  float64
+		hours      int
+		expression string
+		ok         bool
+	}{
+		{"price above", model.AlertKindPriceAbove, 300000, 0, "", true},
+		{"price below without threshold", model.AlertKindPriceBelow, 0, 0, "", false},
+		{"price change", model.AlertKindPriceChange, 5, 24, "", true},
+		{"price change too long", model.AlertKindPriceChange, 5, model.MaxAlertHours + 1, "", false},
+		{"indicator", model.AlertKindIndicator, 0, 0, "rsi(14) < 25", true},
+		{"invalid expression", model.AlertKindIndicator, 0, 0, "rsi(14) <", false},
+		{"invalid macd periods", model.AlertKindIndicator, 0, 0, "macd(1, 1, 1) > 0", false},
+		{"unknown kind", "volume_above", 100, 0, "", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rule := model.NewAlertRule(0, "ETH_JPY", c.kind, c.threshold, c.hours, c.expression, time.Hour, true, false, zero)
+			if (rule != nil) != c.ok {
+				t.Fatalf("rule=%+v", rule)
+			}
+			if rule != nil {
+				if err := rule.Check(); err != nil {
+					t.Fatal(err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestAlertRuleMatch(t *testing.T) {
+	var zero time.Time
+
+	above := model.NewAlertRule(1, "ETH_JPY", model.AlertKindPriceAbove, 300000, 0, "", 0, true, false, zero)
+	if !above.Match(300000, 0, nil, 0) || above.Match(299999, 0, nil, 0) {
+		t.Fatal("price_above")
+	}
+
+	change := model.NewAlertRule(2, "ETH_JPY", model.AlertKindPriceChange, 5, 24, "", 0, true, false, zero)
+	if !change.Match(95000, 100000, nil, 0) || change.Match(104000, 100000, nil, 0) {
+		t.Fatal("price_change")
+	}
+	// 過去の価格が分からなければ成り立たない
+	if change.Match(200000, 0, nil, 0) {
+		t.Fatal("price_change without past price")
+	}
+
+	candles := make([]model.Candle, 0)
+	for i, price := range []float64{100, 90, 80, 70} {
+		candleTime := model.NewCandleTime(time.Date(2021, 1, i+1, 0, 0, 0, 0, time.UTC))
+		candles = append(candles, *model.NewCandle("ETH_JPY", 24*time.Hour, candleTime, price, price, price, price, 1))
+	}
+	ctx := model.NewRuleContext(model.NewDataFrame("ETH_JPY", candles, nil))
+	indicator := model.NewAlertRule(3, "ETH_JPY", model.AlertKindIndicator, 0, 0, "close < prev(close, 1)", 0, true, false, zero)
+	if !indicator.Match(70, 0, ctx, 3) {
+		t.Fatal("indicator")
+	}
+}
+
+func TestAlertRuleNext(t *testing.T) {
+	var zero time.Time
+	now := time.Date(2021, 11, 9, 0, 0, 0, 0, time.UTC)
+
+	rule := model.NewAlertRule(1, "ETH_JPY", model.AlertKindPriceAbove, 300000, 0, "", time.Hour, true, false, zero)
+
+	// 成り立つようになったら通知する
+	rule, notify := rule.Next(now, true)
+	if !notify || !rule.Active() || !rule.NotifiedAt().Equal(now) {
+		t.Fatalf("notify=%v, rule=%+v", notify, rule)
+	}
+
+	// 成り立ち続けている間は通知しない
+	if rule, notify = rule.Next(now.Add(2*time.Hour), true); notify {
+		t.Fatal("notified while active")
+	}
+
+	// 一度外れて再び成り立っても，cooldownの間は通知しない
+	rule, _ = rule.Next(now.Add(10*time.Minute), false)
+	if rule, notify = rule.Next(now.Add(20*time.Minute), true); notify {
+		t.Fatal("notified in cooldown")
+	}
+
+	rule, _ = rule.Next(now.Add(90*time.Minute), false)
+	if _, notify = rule.Next(now.Add(100*time.Minute), true); !notify {
+		t.Fatal("not notified after cooldown")
+	}
+}
+
+func TestAlertNotification(t *testing.T) {
+	var zero time.Time
+	now := time.Date(2021, 11, 9, 0, 0, 0, 0, time.UTC)
+
+	rule := model.NewAlertRule(1, "ETH_JPY", model.AlertKindPriceChange, 5, 24, "", time.Hour, true, false, zero)
+	alert := model.NewAlert(now, *rule, 110000, 100000)
+
+	notification := model.NewAlertNotification(*alert)
+	if notification.Level() != model.NotificationLevelWarn || notification.Title() != "アラート: |change in 24h| >= 5%" {
+		t.Fatalf("notification=%+v", notification)
+	}
+	if notification.Message() != "Price: 110000.000000\n24h ago: 100000.000000 (+10.00%)" {
+		t.Fatalf("message=%s", notification.Message())
+	}
+}
@@ -179,3 +179,36 @@ func TestNewCandle(t *testing.T) {
 		}
 	}
 }
+
+func TestResampleCandles(t *testing.T) {
+	origin := time.Date(2100, 1, 4, 0, 0, 0, 0, time.UTC)
+	// originの前日から10日分の日足
+	candles := make([]model.Candle, 10)
+	currentTime := origin.Add(-24 * time.Hour)
+	for i := range candles {
+		price := float64(100 + i)
+		candleTime := model.NewCandleTime(currentTime)
+		candles[i] = *model.NewCandle(config.ProductCode, 24*time.Hour, candleTime, price, price+0.5, price+1, price-1, 1)
+		currentTime = currentTime.Add(24 * time.Hour)
+	}
+
+	resampled := model.ResampleCandles(candles, 7*24*time.Hour, origin)
+	// 先頭の欠けた週は捨てる
+	if len(resampled) != 2 {
+		t.Fatalf("%d != %d", len(resampled), 2)
+	}
+	week := resampled[0]
+	if !week.Time().Time().Equal(origin) {
+		t.Fatalf("%s != %s", week.Time().Time(), origin)
+	}
+	if week.Open() != 101 || week.Close() != 107.5 || week.High() != 108 || week.Low() != 100 || week.Volume() != 7 {
+		t.Fatalf("%+v", week)
+	}
+	if resampled[1].Volume() != 2 {
+		t.Fatalf("%f != %d", resampled[1].Volume(), 2)
+	}
+
+	if model.ResampleCandles(candles, 36*time.Hour, origin) != nil {
+		t.Fatal("ResampleCandles() returns not nil")
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"time"
 )
 
+// Tickerの時刻の形式（UTC）
+// 取引所ごとの時刻はこの形式に変換してTickerに入れる
+const TimestampFormat = "2006-01-02T15:04:05"
+
 type CandleTime time.Time
 
 func NewCandleTime(timeTime time.Time) CandleTime {
@@ -13,7 +17,7 @@ func NewCandleTime(timeTime time.Time) CandleTime {
 
 // UTCでパースして返す
 func NewCandleTimeByString(timeString string) CandleTime {
-	timeTime, err := time.Parse("2006-01-02T15:04:05", timeString)
+	timeTime, err := time.Parse(TimestampFormat, timeString)
 	if err != nil {
 		return NewCandleTime(time.Time{})
 	}
@@ -0,0 +1,76 @@
+package model
+
+import "math"
+
+// 証拠金の状態（getcollateral）
+type Collateral struct {
+	collateral        float64
+	openPositionPnL   float64
+	requireCollateral float64
+	keepRate          float64
+}
+
+func NewCollateral(collateral, openPositionPnL, requireCollateral, keepRate float64) *Collateral {
+	if collateral < 0 {
+		return nil
+	}
+
+	if requireCollateral < 0 || keepRate < 0 {
+		return nil
+	}
+
+	return &Collateral{
+		collateral:        collateral,
+		openPositionPnL:   openPositionPnL,
+		requireCollateral: requireCollateral,
+		keepRate:          keepRate,
+	}
+}
+
+// 預け入れた証拠金
+func (c *Collateral) Collateral() float64 {
+	return c.collateral
+}
+
+// 建玉の評価損益
+func (c *Collateral) OpenPositionPnL() float64 {
+	return c.openPositionPnL
+}
+
+// 現在の必要証拠金
+func (c *Collateral) RequireCollateral() float64 {
+	return c.requireCollateral
+}
+
+// 証拠金維持率（(証拠金 + 評価損益) / 必要証拠金）
+func (c *Collateral) KeepRate() float64 {
+	return c.keepRate
+}
+
+// 証拠金維持率がminKeepRateを下回っていれば，建玉を減らす
+// 建玉がなければ必要証拠金が0になる
+func (c *Collateral) ShouldDeRisk(minKeepRate float64) bool {
+	return c.requireCollateral > 0 && c.keepRate < minKeepRate
+}
+
+// 証拠金維持率をtargetKeepRateまで戻すために決済する数量
+// 必要証拠金は建玉の数量に比例するものとし，最小注文数量の単位で切り上げる
+func (c *Collateral) DeRiskSize(position *FXNetPosition, targetKeepRate float64) float64 {
+	size := position.Size()
+	if c.requireCollateral <= 0 || size <= 0 || targetKeepRate <= 0 {
+		return 0
+	}
+
+	equity := c.collateral + c.openPositionPnL
+	if equity <= 0 {
+		return size
+	}
+
+	ratio := 1 - equity/targetKeepRate/c.requireCollateral
+	if ratio <= 0 {
+		return 0
+	}
+
+	closeSize := math.Ceil(size*ratio/FXMinOrderSize-1e-9) * FXMinOrderSize
+	return math.Min(closeSize, size)
+}
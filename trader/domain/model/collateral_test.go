@@ -0,0 +1,53 @@
+package model_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+)
+
+func TestCollateral(t *testing.T) {
+	if model.NewCollateral(-1, 0, 0, 0) != nil {
+		t.Fatal("NewCollateral() returns not nil")
+	}
+
+	position := model.NewFXNetPosition(nil)
+	position.Apply(model.OrderSideSell, 5000000, 0.1)
+
+	t.Run("no position", func(t *testing.T) {
+		collateral := model.NewCollateral(100000, 0, 0, 0)
+		if collateral.ShouldDeRisk(1.5) {
+			t.Fatal("ShouldDeRisk() returns true")
+		}
+	})
+
+	t.Run("keep rate is enough", func(t *testing.T) {
+		collateral := model.NewCollateral(500000, 0, 250000, 2)
+		if collateral.ShouldDeRisk(1.5) {
+			t.Fatal("ShouldDeRisk() returns true")
+		}
+		if size := collateral.DeRiskSize(position, 2); size != 0 {
+			t.Fatalf("size=%f", size)
+		}
+	})
+
+	t.Run("keep rate is low", func(t *testing.T) {
+		// (300000 - 60000) / 250000 = 0.96
+		collateral := model.NewCollateral(300000, -60000, 250000, 0.96)
+		if !collateral.ShouldDeRisk(1.5) {
+			t.Fatal("ShouldDeRisk() returns false")
+		}
+		// 必要証拠金を 240000 / 2 = 120000 まで減らすので，0.1 * (1 - 120000/250000) = 0.052 → 0.06
+		if size := collateral.DeRiskSize(position, 2); math.Abs(size-0.06) > 1e-9 {
+			t.Fatalf("size=%f", size)
+		}
+	})
+
+	t.Run("no equity", func(t *testing.T) {
+		collateral := model.NewCollateral(100000, -150000, 250000, 0)
+		if size := collateral.DeRiskSize(position, 2); size != position.Size() {
+			t.Fatalf("size=%f", size)
+		}
+	})
+}
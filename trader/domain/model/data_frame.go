@@ -1,5 +1,10 @@
 package model
 
+import (
+	"sort"
+	"time"
+)
+
 type DataFrame struct {
 	productCode    string
 	candles        []Candle
@@ -10,8 +15,18 @@ type DataFrame struct {
 	ichimokuCloud  *IchimokuCloud
 	rsi            *RSI
 	macd           *MACD
+	atr            *ATR
+	stochastic     *Stochastic
+	adx            *ADX
+	obv            *OBV
+	vwap           *VWAP
+	parabolicSAR   *ParabolicSAR
+	donchian       *DonchianChannel
+	keltner        *KeltnerChannel
 	averageCandle  *AverageCandle
 	backtestEvents *SignalEvents
+	// 上位の時間足のDataFrame
+	timeframes map[time.Duration]*DataFrame
 }
 
 func NewDataFrame(productCode string, candles []Candle, events *SignalEvents) *DataFrame {
@@ -30,6 +45,14 @@ func (df *DataFrame) ProductCode() string {
 	return df.productCode
 }
 
+// キャンドルの時間足．キャンドルが無ければ0
+func (df *DataFrame) Duration() time.Duration {
+	if len(df.candles) == 0 {
+		return 0
+	}
+	return df.candles[0].Duration()
+}
+
 func (df *DataFrame) Candles() []Candle {
 	return df.candles
 }
@@ -110,6 +133,38 @@ func (df *DataFrame) MACD() *MACD {
 	return df.macd
 }
 
+func (df *DataFrame) ATR() *ATR {
+	return df.atr
+}
+
+func (df *DataFrame) Stochastic() *Stochastic {
+	return df.stochastic
+}
+
+func (df *DataFrame) ADX() *ADX {
+	return df.adx
+}
+
+func (df *DataFrame) OBV() *OBV {
+	return df.obv
+}
+
+func (df *DataFrame) VWAP() *VWAP {
+	return df.vwap
+}
+
+func (df *DataFrame) ParabolicSAR() *ParabolicSAR {
+	return df.parabolicSAR
+}
+
+func (df *DataFrame) DonchianChannel() *DonchianChannel {
+	return df.donchian
+}
+
+func (df *DataFrame) KeltnerChannel() *KeltnerChannel {
+	return df.keltner
+}
+
 func (df *DataFrame) AverageCandle() *AverageCandle {
 	return df.averageCandle
 }
@@ -156,8 +211,8 @@ func (df *DataFrame) AddBBands(n int, k float64) bool {
 	return true
 }
 
-func (df *DataFrame) AddIchimoku() bool {
-	ichimoku := NewIchimokuCloud(df.Closes())
+func (df *DataFrame) AddIchimoku(tenkanPeriod, kijunPeriod, senkouBPeriod int) bool {
+	ichimoku := NewIchimokuCloud(df.Highs(), df.Lows(), df.Closes(), tenkanPeriod, kijunPeriod, senkouBPeriod)
 	if ichimoku == nil {
 		return false
 	}
@@ -186,6 +241,86 @@ func (df *DataFrame) AddMACD(inFastPeriod, inSlowPeriod, inSignalPeriod int) boo
 	return true
 }
 
+func (df *DataFrame) AddATR(period int) bool {
+	atr := NewATR(df.Highs(), df.Lows(), df.Closes(), period)
+	if atr == nil {
+		return false
+	}
+
+	df.atr = atr
+	return true
+}
+
+func (df *DataFrame) AddStochastic(fastKPeriod, slowKPeriod, slowDPeriod int) bool {
+	stochastic := NewStochastic(df.Highs(), df.Lows(), df.Closes(), fastKPeriod, slowKPeriod, slowDPeriod)
+	if stochastic == nil {
+		return false
+	}
+
+	df.stochastic = stochastic
+	return true
+}
+
+func (df *DataFrame) AddADX(period int) bool {
+	adx := NewADX(df.Highs(), df.Lows(), df.Closes(), period)
+	if adx == nil {
+		return false
+	}
+
+	df.adx = adx
+	return true
+}
+
+func (df *DataFrame) AddOBV(period int) bool {
+	obv := NewOBV(df.Closes(), df.Volumes(), period)
+	if obv == nil {
+		return false
+	}
+
+	df.obv = obv
+	return true
+}
+
+func (df *DataFrame) AddVWAP(period int) bool {
+	vwap := NewVWAP(df.Highs(), df.Lows(), df.Closes(), df.Volumes(), period)
+	if vwap == nil {
+		return false
+	}
+
+	df.vwap = vwap
+	return true
+}
+
+func (df *DataFrame) AddParabolicSAR(acceleration, maximum float64) bool {
+	sar := NewParabolicSAR(df.Highs(), df.Lows(), acceleration, maximum)
+	if sar == nil {
+		return false
+	}
+
+	df.parabolicSAR = sar
+	return true
+}
+
+func (df *DataFrame) AddDonchianChannel(period int) bool {
+	donchian := NewDonchianChannel(df.Highs(), df.Lows(), period)
+	if donchian == nil {
+		return false
+	}
+
+	df.donchian = donchian
+	return true
+}
+
+func (df *DataFrame) AddKeltnerChannel(period int, multiplier float64) bool {
+	keltner := NewKeltnerChannel(df.Highs(), df.Lows(), df.Closes(), period, multiplier)
+	if keltner == nil {
+		return false
+	}
+
+	df.keltner = keltner
+	return true
+}
+
 func (df *DataFrame) AddAverageCandle() bool {
 	averageCandle := NewAverageCandle(df.candles)
 	if averageCandle == nil {
@@ -196,6 +331,45 @@ func (df *DataFrame) AddAverageCandle() bool {
 	return true
 }
 
+// 上位の時間足のDataFrameを追加する
+// 同じ時間足を追加すると置き換える
+func (df *DataFrame) AddTimeframe(higher *DataFrame) bool {
+	if higher == nil || higher.productCode != df.productCode {
+		return false
+	}
+	if df.Duration() == 0 || higher.Duration() <= df.Duration() {
+		return false
+	}
+
+	if df.timeframes == nil {
+		df.timeframes = make(map[time.Duration]*DataFrame)
+	}
+	df.timeframes[higher.Duration()] = higher
+	return true
+}
+
+func (df *DataFrame) Timeframe(duration time.Duration) *DataFrame {
+	return df.timeframes[duration]
+}
+
+// 時点"at"のキャンドルが確定した時点で，確定している上位の時間足のキャンドルのうち最新のもののインデックス
+// 未確定の上位のキャンドルを使うと，バックテストで未来の値を参照してしまう
+// 該当するキャンドルが無ければ-1
+func (df *DataFrame) TimeframeIndex(duration time.Duration, at int) int {
+	higher := df.Timeframe(duration)
+	if higher == nil || at < 0 || at >= len(df.candles) {
+		return -1
+	}
+
+	closeTime := df.candles[at].Time().Time().Add(df.Duration())
+	candles := higher.Candles()
+	// 確定時刻がcloseTimeより後になる最初のキャンドル
+	i := sort.Search(len(candles), func(i int) bool {
+		return candles[i].Time().Time().Add(duration).After(closeTime)
+	})
+	return i - 1
+}
+
 func (df *DataFrame) AddBacktestEvents(events *SignalEvents) {
 	df.backtestEvents = events
 }
@@ -64,11 +64,29 @@ func TestDataFrame(t *testing.T) {
 
 		df.AddBBands(20, 2)
 
-		df.AddIchimoku()
+		df.AddIchimoku(9, 26, 52)
 
 		df.AddRSI(14)
 
 		df.AddMACD(12, 26, 9)
+
+		df.AddATR(14)
+
+		df.AddStochastic(14, 3, 3)
+
+		df.AddADX(14)
+
+		df.AddOBV(20)
+
+		df.AddVWAP(20)
+
+		df.AddParabolicSAR(0.02, 0.2)
+
+		df.AddDonchianChannel(20)
+
+		df.AddKeltnerChannel(20, 2)
+
+		df.AddAverageCandle()
 	})
 
 	t.Run("add signal_events", func(t *testing.T) {
@@ -124,3 +142,58 @@ func candlesByCloses(closes []float64) []model.Candle {
 	}
 	return candles
 }
+
+func TestDataFrameTimeframe(t *testing.T) {
+	closes := make([]float64, 21)
+	for i := range closes {
+		closes[i] = float64(100 + i)
+	}
+	candles := candlesByCloses(closes)
+	df := model.NewDataFrame(config.ProductCode, candles, nil)
+
+	origin := candles[0].Time().Time()
+	weekly := model.NewDataFrame(config.ProductCode, model.ResampleCandles(candles, 7*config.CandleDuration, origin), nil)
+	if len(weekly.Candles()) != 3 {
+		t.Fatalf("%d != %d", len(weekly.Candles()), 3)
+	}
+
+	if !df.AddTimeframe(weekly) {
+		t.Fatal("AddTimeframe() returns false")
+	}
+	if df.Timeframe(7*config.CandleDuration) != weekly {
+		t.Fatal("Timeframe() does not return the added DataFrame")
+	}
+
+	// 同じか短い時間足は追加できない
+	if df.AddTimeframe(model.NewDataFrame(config.ProductCode, candles, nil)) {
+		t.Fatal("AddTimeframe() returns true")
+	}
+	if df.AddTimeframe(nil) {
+		t.Fatal("AddTimeframe() returns true")
+	}
+
+	t.Run("timeframe index", func(t *testing.T) {
+		// 週足は7本目の日足が確定した時点で確定する
+		table := []struct {
+			at       int
+			expected int
+		}{
+			{0, -1},
+			{5, -1},
+			{6, 0},
+			{12, 0},
+			{13, 1},
+			{20, 2},
+		}
+		for _, row := range table {
+			i := df.TimeframeIndex(7*config.CandleDuration, row.at)
+			if i != row.expected {
+				t.Fatalf("at %d: %d != %d", row.at, i, row.expected)
+			}
+		}
+
+		if df.TimeframeIndex(30*config.CandleDuration, 20) != -1 {
+			t.Fatal("TimeframeIndex() of unknown timeframe should be -1")
+		}
+	})
+}
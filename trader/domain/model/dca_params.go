@@ -0,0 +1,126 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// 積立（ドルコスト平均法）の設定
+type DCAParams struct {
+	productCode   string
+	enable        bool
+	amount        float64 // 1回あたりの購入金額（JPY）
+	intervalDays  int
+	rsiPeriod     int     // 0ならRSIで金額を変えない
+	rsiThreshold  float64 // RSIがこれより低いときは金額を増やす
+	rsiMultiplier float64
+	minSize       float64 // 最小注文数量
+}
+
+func NewDCAParams(productCode string, enable bool, amount float64, intervalDays int, rsiPeriod int, rsiThreshold, rsiMultiplier, minSize float64) *DCAParams {
+	if productCode == "" {
+		return nil
+	}
+
+	if amount <= 0 {
+		return nil
+	}
+
+	if intervalDays <= 0 {
+		return nil
+	}
+
+	if rsiPeriod < 0 {
+		return nil
+	}
+
+	if rsiThreshold < 0 || rsiThreshold > 100 {
+		return nil
+	}
+
+	if rsiMultiplier < 1 {
+		return nil
+	}
+
+	if minSize <= 0 {
+		return nil
+	}
+
+	return &DCAParams{
+		productCode:   productCode,
+		enable:        enable,
+		amount:        amount,
+		intervalDays:  intervalDays,
+		rsiPeriod:     rsiPeriod,
+		rsiThreshold:  rsiThreshold,
+		rsiMultiplier: rsiMultiplier,
+		minSize:       minSize,
+	}
+}
+
+func (p *DCAParams) ProductCode() string {
+	return p.productCode
+}
+
+func (p *DCAParams) Enable() bool {
+	return p.enable
+}
+
+func (p *DCAParams) Amount() float64 {
+	return p.amount
+}
+
+func (p *DCAParams) IntervalDays() int {
+	return p.intervalDays
+}
+
+func (p *DCAParams) RSIPeriod() int {
+	return p.rsiPeriod
+}
+
+func (p *DCAParams) RSIThreshold() float64 {
+	return p.rsiThreshold
+}
+
+func (p *DCAParams) RSIMultiplier() float64 {
+	return p.rsiMultiplier
+}
+
+func (p *DCAParams) MinSize() float64 {
+	return p.minSize
+}
+
+// 時刻nowが積立を行う日か
+// 前回の積立からintervalDays日経っていればよい（日の区切りはhour時）
+func (p *DCAParams) IsDue(last *SignalEvent, now time.Time, localTime *time.Location, hour int) bool {
+	if last == nil {
+		return true
+	}
+
+	lastDay := NewCandleTime(last.Time()).TruncateHour(localTime, hour).Time()
+	nowDay := NewCandleTime(now).TruncateHour(localTime, hour).Time()
+	return !nowDay.Before(lastDay.AddDate(0, 0, p.intervalDays))
+}
+
+// 購入金額
+// RSIが閾値より低いときはrsiMultiplier倍にする（rsiが0以下なら値がないものとして扱う）
+func (p *DCAParams) AmountAt(rsi float64) float64 {
+	if p.rsiPeriod > 0 && rsi > 0 && rsi < p.rsiThreshold {
+		return p.amount * p.rsiMultiplier
+	}
+	return p.amount
+}
+
+// 購入数量（小数点以下8桁で切り捨て）
+// 最小注文数量に満たなければ0
+func (p *DCAParams) Size(price, rsi float64) float64 {
+	if price <= 0 {
+		return 0
+	}
+
+	size := math.Floor(p.AmountAt(rsi)/price*1e8) / 1e8
+	if size < p.minSize {
+		return 0
+	}
+	return size
+}
@@ -0,0 +1,72 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+)
+
+func TestDCAParams(t *testing.T) {
+	t.Run("new dca params", func(t *testing.T) {
+		if model.NewDCAParams(config.ProductCode, true, 10000, 7, 14, 30, 2, 0.01) == nil {
+			t.Fatal("NewDCAParams() returns nil")
+		}
+
+		invalid := []*model.DCAParams{
+			model.NewDCAParams("", true, 10000, 7, 14, 30, 2, 0.01),
+			model.NewDCAParams(config.ProductCode, true, 0, 7, 14, 30, 2, 0.01),
+			model.NewDCAParams(config.ProductCode, true, 10000, 0, 14, 30, 2, 0.01),
+			model.NewDCAParams(config.ProductCode, true, 10000, 7, -1, 30, 2, 0.01),
+			model.NewDCAParams(config.ProductCode, true, 10000, 7, 14, 101, 2, 0.01),
+			model.NewDCAParams(config.ProductCode, true, 10000, 7, 14, 30, 0.5, 0.01),
+			model.NewDCAParams(config.ProductCode, true, 10000, 7, 14, 30, 2, 0),
+		}
+		for i, params := range invalid {
+			if params != nil {
+				t.Fatalf("invalid[%d]: NewDCAParams() returns not nil", i)
+			}
+		}
+	})
+
+	t.Run("is due", func(t *testing.T) {
+		params := model.NewDCAParams(config.ProductCode, true, 10000, 7, 0, 0, 1, 0.01)
+		// 9時に積立
+		last := model.NewSignalEventWithTag(time.Date(2022, 1, 1, 9, 0, 30, 0, config.LocalTime), config.ProductCode, model.OrderSideBuy, 500000, 0.02, model.SignalEventTagDCA)
+
+		if !params.IsDue(nil, time.Date(2022, 1, 1, 9, 0, 0, 0, config.LocalTime), config.LocalTime, 9) {
+			t.Fatal("IsDue() returns false without last signal")
+		}
+		// 7日後の9時より少し前に呼ばれても，同じ日として扱う
+		if !params.IsDue(last, time.Date(2022, 1, 8, 9, 0, 0, 0, config.LocalTime), config.LocalTime, 9) {
+			t.Fatal("IsDue() returns false after 7 days")
+		}
+		if params.IsDue(last, time.Date(2022, 1, 8, 8, 59, 0, 0, config.LocalTime), config.LocalTime, 9) {
+			t.Fatal("IsDue() returns true before 7 days")
+		}
+	})
+
+	t.Run("size", func(t *testing.T) {
+		params := model.NewDCAParams(config.ProductCode, true, 10000, 7, 14, 30, 2, 0.01)
+
+		if size := params.Size(500000, 50); size != 0.02 {
+			t.Fatalf("size=%f", size)
+		}
+		// RSIが低いときは2倍
+		if size := params.Size(500000, 20); size != 0.04 {
+			t.Fatalf("size=%f", size)
+		}
+		// RSIの値がないときはそのまま
+		if size := params.Size(500000, 0); size != 0.02 {
+			t.Fatalf("size=%f", size)
+		}
+		// 最小注文数量に満たない
+		if size := params.Size(2000000, 50); size != 0 {
+			t.Fatalf("size=%f", size)
+		}
+		if size := params.Size(300000, 50); size != 0.03333333 {
+			t.Fatalf("size=%f", size)
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// 約定履歴の1件
+type Execution struct {
+	id       int64
+	side     OrderSide
+	price    float64
+	size     float64
+	execDate time.Time
+}
+
+// 板寄せの約定はsideが空になる
+func NewExecution(id int64, side OrderSide, price, size float64, execDate time.Time) *Execution {
+	if id <= 0 {
+		return nil
+	}
+
+	if side != "" && side != OrderSideBuy && side != OrderSideSell {
+		return nil
+	}
+
+	if price <= 0 {
+		return nil
+	}
+
+	if size <= 0 {
+		return nil
+	}
+
+	if execDate.IsZero() {
+		return nil
+	}
+
+	return &Execution{
+		id:       id,
+		side:     side,
+		price:    price,
+		size:     size,
+		execDate: execDate.In(time.UTC),
+	}
+}
+
+func (e *Execution) ID() int64 {
+	return e.id
+}
+
+func (e *Execution) Side() OrderSide {
+	return e.side
+}
+
+func (e *Execution) Price() float64 {
+	return e.price
+}
+
+func (e *Execution) Size() float64 {
+	return e.size
+}
+
+func (e *Execution) ExecDate() time.Time {
+	return e.execDate
+}
+
+// originを基準にduration単位で切り捨てた時刻
+// 日足ならoriginを取引時刻（例: 日本時間9:00）にする
+func TruncateCandleTime(timeTime time.Time, duration time.Duration, origin time.Time) CandleTime {
+	elapsed := timeTime.Sub(origin) % duration
+	if elapsed < 0 {
+		elapsed += duration
+	}
+	return NewCandleTime(timeTime.Add(-elapsed))
+}
+
+// 約定履歴をキャンドルにまとめる
+// executionsの順序は問わず，結果は時刻の昇順になる
+func NewCandlesFromExecutions(productCode string, duration time.Duration, origin time.Time, executions []Execution) []Candle {
+	if productCode == "" || duration <= 0 {
+		return nil
+	}
+
+	sorted := make([]Execution, len(executions))
+	copy(sorted, executions)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].id < sorted[j].id
+	})
+
+	candles := make([]Candle, 0)
+	for _, e := range sorted {
+		candleTime := TruncateCandleTime(e.execDate, duration, origin)
+
+		last := len(candles) - 1
+		if last >= 0 && candles[last].Time().Equal(candleTime) {
+			c := &candles[last]
+			c.close = e.price
+			if c.high < e.price {
+				c.high = e.price
+			}
+			if c.low > e.price {
+				c.low = e.price
+			}
+			c.volume += e.size
+			continue
+		}
+
+		candle := NewCandle(productCode, duration, candleTime, e.price, e.price, e.price, e.price, e.size)
+		if candle == nil {
+			continue
+		}
+		candles = append(candles, *candle)
+	}
+
+	return candles
+}
+
+// [start, end]の範囲で抜けているキャンドルの時刻
+// start, endはキャンドルの時刻に揃えておく
+func MissingCandleTimes(candles []Candle, duration time.Duration, start, end CandleTime) []CandleTime {
+	exists := make(map[int64]bool)
+	for _, candle := range candles {
+		exists[candle.Time().Time().Unix()] = true
+	}
+
+	missing := make([]CandleTime, 0)
+	if duration <= 0 {
+		return missing
+	}
+	for t := start.Time(); !t.After(end.Time()); t = t.Add(duration) {
+		if !exists[t.Unix()] {
+			missing = append(missing, NewCandleTime(t))
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,107 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+)
+
+func TestExecution(t *testing.T) {
+	execDate := time.Date(2021, 11, 9, 2, 31, 11, 797000000, time.UTC)
+
+	cases := []struct {
+		name      string
+		id        int64
+		side      model.OrderSide
+		price     float64
+		size      float64
+		execDate  time.Time
+		expectNil bool
+	}{
+		{"buy", 1, model.OrderSideBuy, 540000, 0.1, execDate, false},
+		{"itayose", 2, "", 540000, 0.1, execDate, false},
+		{"invalid id", 0, model.OrderSideBuy, 540000, 0.1, execDate, true},
+		{"invalid side", 3, model.OrderSide("HOLD"), 540000, 0.1, execDate, true},
+		{"invalid price", 4, model.OrderSideSell, 0, 0.1, execDate, true},
+		{"invalid size", 5, model.OrderSideSell, 540000, 0, execDate, true},
+		{"invalid date", 6, model.OrderSideSell, 540000, 0.1, time.Time{}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			execution := model.NewExecution(c.id, c.side, c.price, c.size, c.execDate)
+			if (execution == nil) != c.expectNil {
+				t.Fatalf("NewExecution() = %v", execution)
+			}
+		})
+	}
+}
+
+func TestNewCandlesFromExecutions(t *testing.T) {
+	// 日本時間9:00区切り
+	origin := time.Date(2021, 1, 1, config.TradeHour, 0, 0, 0, config.LocalTime)
+	day1 := time.Date(2021, 11, 9, config.TradeHour, 0, 0, 0, config.LocalTime)
+	day2 := day1.Add(24 * time.Hour)
+
+	executions := []model.Execution{
+		// 順不同
+		*model.NewExecution(3, model.OrderSideSell, 120, 0.3, day1.Add(3*time.Hour)),
+		*model.NewExecution(1, model.OrderSideBuy, 100, 0.1, day1.Add(1*time.Hour)),
+		*model.NewExecution(2, model.OrderSideBuy, 90, 0.2, day1.Add(2*time.Hour)),
+		*model.NewExecution(4, model.OrderSideBuy, 110, 0.4, day1.Add(23*time.Hour)),
+		*model.NewExecution(5, model.OrderSideSell, 130, 0.5, day2.Add(1*time.Minute)),
+	}
+
+	candles := model.NewCandlesFromExecutions(config.ProductCode, 24*time.Hour, origin, executions)
+	if len(candles) != 2 {
+		t.Fatalf("%d != %d", len(candles), 2)
+	}
+
+	c := candles[0]
+	if !c.Time().Time().Equal(day1) {
+		t.Fatalf("%v != %v", c.Time().Time(), day1)
+	}
+	if c.Open() != 100 || c.Close() != 110 || c.High() != 120 || c.Low() != 90 {
+		t.Fatalf("unexpected OHLC: %v", c)
+	}
+	if diff := c.Volume() - 1.0; diff > 1e-9 || diff < -1e-9 {
+		t.Fatalf("%v != %v", c.Volume(), 1.0)
+	}
+
+	c = candles[1]
+	if !c.Time().Time().Equal(day2) {
+		t.Fatalf("%v != %v", c.Time().Time(), day2)
+	}
+	if c.Open() != 130 || c.Close() != 130 || c.Volume() != 0.5 {
+		t.Fatalf("unexpected candle: %v", c)
+	}
+
+	t.Run("1 minute", func(t *testing.T) {
+		candles := model.NewCandlesFromExecutions(config.ProductCode, time.Minute, origin, executions)
+		if len(candles) != len(executions) {
+			t.Fatalf("%d != %d", len(candles), len(executions))
+		}
+	})
+}
+
+func TestMissingCandleTimes(t *testing.T) {
+	start := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+	candles := make([]model.Candle, 0)
+	for _, day := range []int{0, 1, 3, 4} {
+		candleTime := model.NewCandleTime(start.AddDate(0, 0, day))
+		candles = append(candles, *model.NewCandle(config.ProductCode, 24*time.Hour, candleTime, 100, 100, 100, 100, 0))
+	}
+
+	missing := model.MissingCandleTimes(candles, 24*time.Hour, model.NewCandleTime(start), model.NewCandleTime(start.AddDate(0, 0, 5)))
+	expected := []time.Time{start.AddDate(0, 0, 2), start.AddDate(0, 0, 5)}
+	if len(missing) != len(expected) {
+		t.Fatalf("%v != %v", missing, expected)
+	}
+	for i := range missing {
+		if !missing[i].Time().Equal(expected[i]) {
+			t.Fatalf("%v != %v", missing[i].Time(), expected[i])
+		}
+	}
+}
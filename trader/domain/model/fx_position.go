@@ -0,0 +1,270 @@
+package model
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// FX（Lightning FX/CFD）の最小注文数量
+const FXMinOrderSize = 0.01
+
+// 証拠金取引の銘柄か（FX_BTC_JPYなど）
+func IsFXProduct(productCode string) bool {
+	return strings.HasPrefix(productCode, "FX_")
+}
+
+// 建玉（getpositionsの1件）
+type FXPosition struct {
+	productCode       string
+	side              OrderSide
+	price             float64
+	size              float64
+	pnl               float64
+	requireCollateral float64
+	openDate          time.Time
+}
+
+func NewFXPosition(productCode string, side OrderSide, price, size, pnl, requireCollateral float64, openDate time.Time) *FXPosition {
+	if productCode == "" {
+		return nil
+	}
+
+	if side != OrderSideBuy && side != OrderSideSell {
+		return nil
+	}
+
+	if price <= 0 || size <= 0 {
+		return nil
+	}
+
+	if requireCollateral < 0 {
+		return nil
+	}
+
+	return &FXPosition{
+		productCode:       productCode,
+		side:              side,
+		price:             price,
+		size:              size,
+		pnl:               pnl,
+		requireCollateral: requireCollateral,
+		openDate:          openDate,
+	}
+}
+
+func (p *FXPosition) ProductCode() string {
+	return p.productCode
+}
+
+func (p *FXPosition) Side() OrderSide {
+	return p.side
+}
+
+func (p *FXPosition) Price() float64 {
+	return p.price
+}
+
+func (p *FXPosition) Size() float64 {
+	return p.size
+}
+
+// 評価損益
+func (p *FXPosition) PnL() float64 {
+	return p.pnl
+}
+
+// 必要証拠金
+func (p *FXPosition) RequireCollateral() float64 {
+	return p.requireCollateral
+}
+
+func (p *FXPosition) OpenDate() time.Time {
+	return p.openDate
+}
+
+// 建玉をまとめた正味のポジション
+// 買い（ロング）と売り（ショート）を同時には持たない
+type FXNetPosition struct {
+	// ロングは正，ショートは負の数量
+	size           float64
+	averagePrice   float64
+	realizedProfit float64
+}
+
+func NewFXNetPosition(positions []FXPosition) *FXNetPosition {
+	np := &FXNetPosition{}
+	for _, position := range positions {
+		np.Apply(position.side, position.price, position.size)
+	}
+	// 建玉をまとめただけなので，決済の損益は含めない
+	np.realizedProfit = 0
+	return np
+}
+
+// ロングならBUY，ショートならSELL，持っていなければ空文字
+func (np *FXNetPosition) Side() OrderSide {
+	switch {
+	case np.size > 0:
+		return OrderSideBuy
+	case np.size < 0:
+		return OrderSideSell
+	default:
+		return ""
+	}
+}
+
+func (np *FXNetPosition) Size() float64 {
+	return math.Abs(np.size)
+}
+
+func (np *FXNetPosition) AveragePrice() float64 {
+	return np.averagePrice
+}
+
+func (np *FXNetPosition) RealizedProfit() float64 {
+	return np.realizedProfit
+}
+
+// 価格priceで全て決済したときの損益
+func (np *FXNetPosition) UnrealizedProfit(price float64) float64 {
+	return (price - np.averagePrice) * np.size
+}
+
+// 約定をポジションに反映し，決済した分の損益を返す
+// 反対方向に保有している数量より多く約定したときは，残りで新しく建てる（ドテン）
+func (np *FXNetPosition) Apply(side OrderSide, price, size float64) float64 {
+	if price <= 0 || size <= 0 {
+		return 0
+	}
+
+	delta := size
+	if side == OrderSideSell {
+		delta = -size
+	} else if side != OrderSideBuy {
+		return 0
+	}
+
+	// 浮動小数点の誤差で残った数量は持っていないものとみなす
+	newSize := np.size + delta
+	if math.Abs(newSize) < FXMinOrderSize*1e-6 {
+		newSize = 0
+	}
+
+	profit := 0.0
+	switch {
+	case np.size == 0 || (np.size > 0) == (delta > 0):
+		// 新規または買い増し・売り増し
+		np.averagePrice = (np.averagePrice*math.Abs(np.size) + price*size) / (math.Abs(np.size) + size)
+	case newSize == 0 || (newSize > 0) == (np.size > 0):
+		// 一部または全部の決済
+		profit = (price - np.averagePrice) * -delta
+		if newSize == 0 {
+			np.averagePrice = 0
+		}
+	default:
+		// 全て決済して反対方向に建てる
+		profit = (price - np.averagePrice) * np.size
+		np.averagePrice = price
+	}
+	np.size = newSize
+
+	np.realizedProfit += profit
+	return profit
+}
+
+// 売買サインから出す注文
+// 買いサインでショートを持っていれば，決済してsizeだけロングを建てる（売りサインも同様）
+// すでに同じ方向に持っているときは注文しない
+func (np *FXNetPosition) OrderFor(buy, sell bool, size float64) (OrderSide, float64) {
+	if buy == sell || size <= 0 {
+		return "", 0
+	}
+
+	side := OrderSideBuy
+	if sell {
+		side = OrderSideSell
+	}
+
+	switch np.Side() {
+	case side:
+		return "", 0
+	case "":
+		return side, size
+	default:
+		return side, np.Size() + size
+	}
+}
+
+// 損切りすべきか判断する
+// ロングは平均取得単価からstopLimitPercent倍まで，ショートは1/stopLimitPercent倍まで不利に動いたとき
+func (np *FXNetPosition) ShouldCutLoss(currentPrice, stopLimitPercent float64) bool {
+	if stopLimitPercent <= 0 {
+		return false
+	}
+
+	switch np.Side() {
+	case OrderSideBuy:
+		return currentPrice < np.averagePrice*stopLimitPercent
+	case OrderSideSell:
+		return currentPrice > np.averagePrice/stopLimitPercent
+	default:
+		return false
+	}
+}
+
+// 全て決済する注文
+func (np *FXNetPosition) CloseOrder() (OrderSide, float64) {
+	switch np.Side() {
+	case OrderSideBuy:
+		return OrderSideSell, np.Size()
+	case OrderSideSell:
+		return OrderSideBuy, np.Size()
+	default:
+		return "", 0
+	}
+}
+
+// ショートを含むバックテストの結果
+type FXBacktestResult struct {
+	signals  []SignalEvent
+	position *FXNetPosition
+	price    float64
+}
+
+func NewFXBacktestResult() *FXBacktestResult {
+	return &FXBacktestResult{
+		signals:  make([]SignalEvent, 0),
+		position: NewFXNetPosition(nil),
+	}
+}
+
+func (r *FXBacktestResult) Signals() []SignalEvent {
+	return r.signals
+}
+
+// 期間の終わりのポジション
+func (r *FXBacktestResult) Position() *FXNetPosition {
+	return r.position
+}
+
+// 決済済みの損益
+func (r *FXBacktestResult) Profit() float64 {
+	return r.position.RealizedProfit()
+}
+
+// 期間の終わりのポジションの評価損益を含めた損益
+func (r *FXBacktestResult) TotalProfit() float64 {
+	return r.position.RealizedProfit() + r.position.UnrealizedProfit(r.price)
+}
+
+func (r *FXBacktestResult) Add(signal SignalEvent) {
+	r.signals = append(r.signals, signal)
+	r.position.Apply(signal.side, signal.price, signal.size)
+	r.price = signal.price
+}
+
+// 最後のキャンドルの終値（評価損益の計算に使う）
+func (r *FXBacktestResult) SetLastPrice(price float64) {
+	r.price = price
+}
@@ -0,0 +1,130 @@
+package model_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+)
+
+func TestFXPosition(t *testing.T) {
+	productCode := "FX_BTC_JPY"
+	openDate := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if !model.IsFXProduct(productCode) || model.IsFXProduct("BTC_JPY") {
+		t.Fatal("IsFXProduct() returns wrong value")
+	}
+
+	if model.NewFXPosition(productCode, "", 5000000, 0.01, 0, 25000, openDate) != nil {
+		t.Fatal("NewFXPosition() returns not nil")
+	}
+	if model.NewFXPosition(productCode, model.OrderSideSell, 5000000, 0, 0, 25000, openDate) != nil {
+		t.Fatal("NewFXPosition() returns not nil")
+	}
+
+	t.Run("net position", func(t *testing.T) {
+		positions := []model.FXPosition{
+			*model.NewFXPosition(productCode, model.OrderSideSell, 5000000, 0.01, 0, 25000, openDate),
+			*model.NewFXPosition(productCode, model.OrderSideSell, 6000000, 0.01, 0, 30000, openDate),
+		}
+		net := model.NewFXNetPosition(positions)
+		if net.Side() != model.OrderSideSell || net.Size() != 0.02 || net.AveragePrice() != 5500000 {
+			t.Fatalf("side=%s, size=%f, averagePrice=%f", net.Side(), net.Size(), net.AveragePrice())
+		}
+		if net.RealizedProfit() != 0 {
+			t.Fatalf("realizedProfit=%f", net.RealizedProfit())
+		}
+		// ショートは価格が下がると利益になる
+		if math.Abs(net.UnrealizedProfit(5000000)-10000) > 1e-6 {
+			t.Fatalf("unrealizedProfit=%f", net.UnrealizedProfit(5000000))
+		}
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		net := model.NewFXNetPosition(nil)
+		if net.Side() != "" {
+			t.Fatalf("side=%s", net.Side())
+		}
+
+		net.Apply(model.OrderSideSell, 5000000, 0.02)
+
+		// 一部の決済
+		profit := net.Apply(model.OrderSideBuy, 4000000, 0.01)
+		if math.Abs(profit-10000) > 1e-6 || net.Side() != model.OrderSideSell || math.Abs(net.Size()-0.01) > 1e-9 {
+			t.Fatalf("profit=%f, side=%s, size=%f", profit, net.Side(), net.Size())
+		}
+
+		// ドテン
+		profit = net.Apply(model.OrderSideBuy, 6000000, 0.03)
+		if math.Abs(profit+10000) > 1e-6 {
+			t.Fatalf("profit=%f", profit)
+		}
+		if net.Side() != model.OrderSideBuy || math.Abs(net.Size()-0.02) > 1e-9 || net.AveragePrice() != 6000000 {
+			t.Fatalf("side=%s, size=%f, averagePrice=%f", net.Side(), net.Size(), net.AveragePrice())
+		}
+		if math.Abs(net.RealizedProfit()) > 1e-6 {
+			t.Fatalf("realizedProfit=%f", net.RealizedProfit())
+		}
+
+		// 全て決済
+		net.Apply(model.OrderSideSell, 6500000, 0.02)
+		if net.Side() != "" || net.AveragePrice() != 0 {
+			t.Fatalf("side=%s, averagePrice=%f", net.Side(), net.AveragePrice())
+		}
+	})
+
+	t.Run("order for signal", func(t *testing.T) {
+		net := model.NewFXNetPosition(nil)
+		if side, size := net.OrderFor(false, true, 0.01); side != model.OrderSideSell || size != 0.01 {
+			t.Fatalf("side=%s, size=%f", side, size)
+		}
+
+		net.Apply(model.OrderSideSell, 5000000, 0.01)
+		if _, size := net.OrderFor(false, true, 0.01); size != 0 {
+			t.Fatalf("size=%f", size)
+		}
+		if side, size := net.OrderFor(true, false, 0.01); side != model.OrderSideBuy || size != 0.02 {
+			t.Fatalf("side=%s, size=%f", side, size)
+		}
+		if _, size := net.OrderFor(true, true, 0.01); size != 0 {
+			t.Fatalf("size=%f", size)
+		}
+	})
+
+	t.Run("cut loss", func(t *testing.T) {
+		short := model.NewFXNetPosition(nil)
+		short.Apply(model.OrderSideSell, 4000000, 0.01)
+		if short.ShouldCutLoss(4500000, 0.8) || !short.ShouldCutLoss(5500000, 0.8) {
+			t.Fatal("ShouldCutLoss() returns wrong value")
+		}
+		if side, size := short.CloseOrder(); side != model.OrderSideBuy || size != 0.01 {
+			t.Fatalf("side=%s, size=%f", side, size)
+		}
+
+		long := model.NewFXNetPosition(nil)
+		long.Apply(model.OrderSideBuy, 4000000, 0.01)
+		if long.ShouldCutLoss(3500000, 0.8) || !long.ShouldCutLoss(3000000, 0.8) {
+			t.Fatal("ShouldCutLoss() returns wrong value")
+		}
+	})
+}
+
+func TestFXBacktestResult(t *testing.T) {
+	productCode := "FX_BTC_JPY"
+	result := model.NewFXBacktestResult()
+
+	result.Add(*model.NewSignalEvent(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), productCode, model.OrderSideSell, 5000000, 0.01))
+	result.Add(*model.NewSignalEvent(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), productCode, model.OrderSideBuy, 4000000, 0.02))
+	result.SetLastPrice(4500000)
+
+	if len(result.Signals()) != 2 {
+		t.Fatalf("len(signals)=%d", len(result.Signals()))
+	}
+	if math.Abs(result.Profit()-10000) > 1e-6 {
+		t.Fatalf("profit=%f", result.Profit())
+	}
+	if math.Abs(result.TotalProfit()-15000) > 1e-6 {
+		t.Fatalf("totalProfit=%f", result.TotalProfit())
+	}
+}
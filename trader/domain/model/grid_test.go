@@ -0,0 +1,173 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+)
+
+func TestGrid(t *testing.T) {
+	t.Run("new grid", func(t *testing.T) {
+		grid := model.NewGrid(config.ProductCode, 100, 200, 5, 0.01)
+		if grid == nil {
+			t.Fatal("NewGrid() returns nil")
+		}
+
+		prices := grid.Prices()
+		expected := []float64{100, 125, 150, 175, 200}
+		if len(prices) != len(expected) {
+			t.Fatalf("prices=%v", prices)
+		}
+		for i := range prices {
+			if prices[i] != expected[i] {
+				t.Fatalf("prices=%v", prices)
+			}
+		}
+
+		levels := grid.NewLevels()
+		if len(levels) != 4 {
+			t.Fatalf("len(levels)=%d", len(levels))
+		}
+		for i, level := range levels {
+			if level.Index() != i || level.BuyPrice() != expected[i] || level.SellPrice() != expected[i+1] ||
+				level.Size() != 0.01 || level.State() != model.GridLevelStateWaiting || level.OrderID() != "" {
+				t.Fatalf("levels[%d]=%+v", i, level)
+			}
+		}
+		if !grid.Matches(levels) {
+			t.Fatal("grid does not match its levels")
+		}
+
+		other := model.NewGrid(config.ProductCode, 100, 210, 5, 0.01)
+		if other.Matches(levels) {
+			t.Fatal("grid matches other levels")
+		}
+
+		invalid := []*model.Grid{
+			model.NewGrid("", 100, 200, 5, 0.01),
+			model.NewGrid(config.ProductCode, 0, 200, 5, 0.01),
+			model.NewGrid(config.ProductCode, 200, 100, 5, 0.01),
+			model.NewGrid(config.ProductCode, 100, 200, 1, 0.01),
+			model.NewGrid(config.ProductCode, 100, 200, 5, 0),
+		}
+		for i, grid := range invalid {
+			if grid != nil {
+				t.Fatalf("invalid[%d]: NewGrid() returns not nil", i)
+			}
+		}
+	})
+
+	t.Run("grid level", func(t *testing.T) {
+		level := model.NewGridLevel(config.ProductCode, 0, 100, 125, 0.01, model.GridLevelStateWaiting, "")
+		if level == nil {
+			t.Fatal("NewGridLevel() returns nil")
+		}
+
+		if level.ShouldPlaceBuy(100) {
+			t.Fatal("should not place buy at buy price")
+		}
+		if !level.ShouldPlaceBuy(110) {
+			t.Fatal("should place buy above buy price")
+		}
+		if level.PlaceSell("id") {
+			t.Fatal("placed sell while waiting")
+		}
+		if !level.PlaceBuy("buy-id") || level.State() != model.GridLevelStateBuying || level.OrderID() != "buy-id" {
+			t.Fatalf("level=%+v", level)
+		}
+		if level.PlaceBuy("buy-id") {
+			t.Fatal("placed buy while buying")
+		}
+		// 買い注文が約定し，売り注文を出す前
+		level.Bought()
+		if level.State() != model.GridLevelStateBought || level.OrderID() != "" || !level.Holding() {
+			t.Fatalf("level=%+v", level)
+		}
+		if !level.PlaceSell("sell-id") || level.State() != model.GridLevelStateSelling || level.OrderID() != "sell-id" {
+			t.Fatalf("level=%+v", level)
+		}
+		level.Wait()
+		if level.State() != model.GridLevelStateWaiting || level.OrderID() != "" {
+			t.Fatalf("level=%+v", level)
+		}
+
+		if model.NewGridLevel(config.ProductCode, 0, 100, 125, 0.01, model.GridLevelStateBuying, "") != nil {
+			t.Fatal("NewGridLevel() returns not nil")
+		}
+		if model.NewGridLevel(config.ProductCode, 0, 100, 125, 0.01, model.GridLevelStateBought, "id") != nil {
+			t.Fatal("NewGridLevel() returns not nil")
+		}
+		if model.NewGridLevel(config.ProductCode, 0, 100, 125, 0.01, model.GridLevelStateWaiting, "id") != nil {
+			t.Fatal("NewGridLevel() returns not nil")
+		}
+		if model.NewGridLevel(config.ProductCode, 0, 125, 100, 0.01, model.GridLevelStateWaiting, "") != nil {
+			t.Fatal("NewGridLevel() returns not nil")
+		}
+		if model.NewGridLevel(config.ProductCode, 0, 100, 125, 0.01, "UNKNOWN", "") != nil {
+			t.Fatal("NewGridLevel() returns not nil")
+		}
+	})
+
+	t.Run("backtest", func(t *testing.T) {
+		grid := model.NewGrid(config.ProductCode, 100, 200, 5, 1)
+
+		// {open, close, high, low}
+		ohlc := [][4]float64{
+			{160, 160, 165, 155}, // 100, 125, 150の段に買い注文
+			{160, 130, 160, 120}, // 150, 125で買い
+			{130, 140, 152, 128}, // 150で売り，125の段に買い注文
+			{140, 110, 140, 95},  // 125, 100で買い
+			{110, 180, 180, 110}, // 125, 150, 175で売り
+		}
+		candles := make([]model.Candle, len(ohlc))
+		currentTime := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+		for i, c := range ohlc {
+			candles[i] = *model.NewCandle(config.ProductCode, config.CandleDuration, model.NewCandleTime(currentTime), c[0], c[1], c[2], c[3], 1)
+			currentTime = currentTime.Add(config.CandleDuration)
+		}
+
+		result, levels := grid.Backtest(candles)
+
+		expected := []struct {
+			level int
+			side  model.OrderSide
+			price float64
+		}{
+			{1, model.OrderSideBuy, 125},
+			{2, model.OrderSideBuy, 150},
+			{1, model.OrderSideSell, 150},
+			{0, model.OrderSideBuy, 100},
+			{1, model.OrderSideBuy, 125},
+			{0, model.OrderSideSell, 125},
+			{1, model.OrderSideSell, 150},
+			{2, model.OrderSideSell, 175},
+		}
+		fills := result.Fills()
+		if len(fills) != len(expected) {
+			t.Fatalf("len(fills)=%d, fills=%+v", len(fills), fills)
+		}
+		for i, e := range expected {
+			if fills[i].Level() != e.level || fills[i].Side() != e.side || fills[i].Price() != e.price {
+				t.Fatalf("fills[%d]=%+v", i, fills[i])
+			}
+		}
+
+		if result.Profit() != 100 {
+			t.Fatalf("profit=%f", result.Profit())
+		}
+		if result.Position() != 0 {
+			t.Fatalf("position=%f", result.Position())
+		}
+		if result.TotalProfit(levels) != 100 {
+			t.Fatalf("total profit=%f", result.TotalProfit(levels))
+		}
+		// 終値180より下の段にはすべて買い注文が出ている
+		for _, level := range levels {
+			if level.State() != model.GridLevelStateBuying {
+				t.Fatalf("level=%+v", level)
+			}
+		}
+	})
+}
@@ -1,6 +1,10 @@
 package model
 
-import "github.com/markcheno/go-talib"
+import (
+	"math"
+
+	"github.com/markcheno/go-talib"
+)
 
 // 単純移動平均
 type SMA struct {
@@ -112,12 +116,17 @@ func (bbands *BBands) Down() []float64 {
 }
 
 // 一目均衡表
+// 先行スパンは基準線の期間だけ未来にずらすので，キャンドルより基準線の期間分だけ長い
+// 遅行スパンは終値を基準線の期間だけ過去にずらしたもので，末尾の基準線の期間分は0
 type IchimokuCloud struct {
-	tenkan  []float64
-	kijun   []float64
-	senkouA []float64
-	senkouB []float64
-	chikou  []float64
+	tenkanPeriod  int
+	kijunPeriod   int
+	senkouBPeriod int
+	tenkan        []float64
+	kijun         []float64
+	senkouA       []float64
+	senkouB       []float64
+	chikou        []float64
 }
 
 func minMax(inReal []float64) (float64, float64) {
@@ -134,51 +143,79 @@ func minMax(inReal []float64) (float64, float64) {
 	return min, max
 }
 
-func min(x, y int) int {
-	if x < y {
-		return x
+// 直近period本（現在の足を含む）の高値と安値の中値
+func midPrices(inHigh, inLow []float64, period int) []float64 {
+	mids := make([]float64, len(inHigh))
+	for i := period - 1; i < len(inHigh); i++ {
+		_, high := minMax(inHigh[i-period+1 : i+1])
+		low, _ := minMax(inLow[i-period+1 : i+1])
+		mids[i] = (high + low) / 2
 	}
-	return y
+	return mids
 }
 
-func NewIchimokuCloud(inReal []float64) *IchimokuCloud {
-	length := len(inReal)
-	if length < 52 {
+func NewIchimokuCloud(inHigh, inLow, inClose []float64, tenkanPeriod, kijunPeriod, senkouBPeriod int) *IchimokuCloud {
+	if tenkanPeriod <= 0 || kijunPeriod <= 0 || senkouBPeriod <= 0 {
 		return nil
 	}
 
-	tenkan := make([]float64, min(9, length))
-	kijun := make([]float64, min(26, length))
-	senkouA := make([]float64, min(26, length))
-	senkouB := make([]float64, min(52, length))
-	chikou := make([]float64, min(26, length))
+	length := len(inClose)
+	if len(inHigh) != length || len(inLow) != length {
+		return nil
+	}
+	// 先行スパン2が1つ以上求まる長さ
+	if length < senkouBPeriod+kijunPeriod {
+		return nil
+	}
+
+	tenkan := midPrices(inHigh, inLow, tenkanPeriod)
+	kijun := midPrices(inHigh, inLow, kijunPeriod)
+	senkouBMids := midPrices(inHigh, inLow, senkouBPeriod)
 
-	for i := range inReal {
-		if i >= 9 {
-			min, max := minMax(inReal[i-9 : i])
-			tenkan = append(tenkan, (min+max)/2)
+	senkouA := make([]float64, length+kijunPeriod)
+	senkouB := make([]float64, length+kijunPeriod)
+	chikou := make([]float64, length)
+	for i := 0; i < length; i++ {
+		if i >= tenkanPeriod-1 && i >= kijunPeriod-1 {
+			senkouA[i+kijunPeriod] = (tenkan[i] + kijun[i]) / 2
 		}
-		if i >= 26 {
-			min, max := minMax(inReal[i-26 : i])
-			kijun = append(kijun, (min+max)/2)
-			senkouA = append(senkouA, (tenkan[i]+kijun[i])/2)
-			chikou = append(chikou, inReal[i-26])
+		if i >= senkouBPeriod-1 {
+			senkouB[i+kijunPeriod] = senkouBMids[i]
 		}
-		if i >= 52 {
-			min, max := minMax(inReal[i-52 : i])
-			senkouB = append(senkouB, (min+max)/2)
+		if i >= kijunPeriod {
+			chikou[i-kijunPeriod] = inClose[i]
 		}
 	}
 
 	return &IchimokuCloud{
-		tenkan:  tenkan,
-		kijun:   kijun,
-		senkouA: senkouA,
-		senkouB: senkouB,
-		chikou:  chikou,
+		tenkanPeriod:  tenkanPeriod,
+		kijunPeriod:   kijunPeriod,
+		senkouBPeriod: senkouBPeriod,
+		tenkan:        tenkan,
+		kijun:         kijun,
+		senkouA:       senkouA,
+		senkouB:       senkouB,
+		chikou:        chikou,
 	}
 }
 
+func (ichimokuCloud *IchimokuCloud) TenkanPeriod() int {
+	return ichimokuCloud.tenkanPeriod
+}
+
+func (ichimokuCloud *IchimokuCloud) KijunPeriod() int {
+	return ichimokuCloud.kijunPeriod
+}
+
+func (ichimokuCloud *IchimokuCloud) SenkouBPeriod() int {
+	return ichimokuCloud.senkouBPeriod
+}
+
+// 先行スパン・遅行スパンのずらし幅
+func (ichimokuCloud *IchimokuCloud) Displacement() int {
+	return ichimokuCloud.kijunPeriod
+}
+
 func (ichimokuCloud *IchimokuCloud) Tenkan() []float64 {
 	return ichimokuCloud.tenkan
 }
@@ -289,7 +326,381 @@ func (macd *MACD) MacdHist() []float64 {
 	return macd.macdHist
 }
 
-// 平均足
+func sameLength(inReals ...[]float64) bool {
+	for _, inReal := range inReals {
+		if len(inReal) != len(inReals[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Average True Range: 平均的な値幅
+type ATR struct {
+	period int
+	values []float64
+}
+
+func NewATR(inHigh, inLow, inClose []float64, period int) *ATR {
+	if period <= 0 || len(inClose) <= period {
+		return nil
+	}
+
+	if !sameLength(inHigh, inLow, inClose) {
+		return nil
+	}
+
+	values := talib.Atr(inHigh, inLow, inClose, period)
+
+	return &ATR{
+		period: period,
+		values: values,
+	}
+}
+
+func (atr *ATR) Period() int {
+	return atr.period
+}
+
+func (atr *ATR) Values() []float64 {
+	return atr.values
+}
+
+// ストキャスティクス（スロー）
+type Stochastic struct {
+	fastKPeriod int
+	slowKPeriod int
+	slowDPeriod int
+	slowK       []float64
+	slowD       []float64
+}
+
+func NewStochastic(inHigh, inLow, inClose []float64, fastKPeriod, slowKPeriod, slowDPeriod int) *Stochastic {
+	if fastKPeriod <= 0 || slowKPeriod <= 0 || slowDPeriod <= 0 {
+		return nil
+	}
+
+	if len(inClose) <= fastKPeriod+slowKPeriod+slowDPeriod {
+		return nil
+	}
+
+	if !sameLength(inHigh, inLow, inClose) {
+		return nil
+	}
+
+	slowK, slowD := talib.Stoch(inHigh, inLow, inClose, fastKPeriod, slowKPeriod, talib.SMA, slowDPeriod, talib.SMA)
+
+	return &Stochastic{
+		fastKPeriod: fastKPeriod,
+		slowKPeriod: slowKPeriod,
+		slowDPeriod: slowDPeriod,
+		slowK:       slowK,
+		slowD:       slowD,
+	}
+}
+
+func (stoch *Stochastic) FastKPeriod() int {
+	return stoch.fastKPeriod
+}
+
+func (stoch *Stochastic) SlowKPeriod() int {
+	return stoch.slowKPeriod
+}
+
+func (stoch *Stochastic) SlowDPeriod() int {
+	return stoch.slowDPeriod
+}
+
+// 値が求まり始める位置
+func (stoch *Stochastic) Lookback() int {
+	return stoch.fastKPeriod + stoch.slowKPeriod + stoch.slowDPeriod - 3
+}
+
+func (stoch *Stochastic) SlowK() []float64 {
+	return stoch.slowK
+}
+
+func (stoch *Stochastic) SlowD() []float64 {
+	return stoch.slowD
+}
+
+// Average Directional Index と Directional Movement Index
+type ADX struct {
+	period  int
+	adx     []float64
+	plusDI  []float64
+	minusDI []float64
+}
+
+func NewADX(inHigh, inLow, inClose []float64, period int) *ADX {
+	// ADXはDXをさらに平滑化するので，期間の2倍のデータが必要
+	if period <= 0 || len(inClose) <= 2*period {
+		return nil
+	}
+
+	if !sameLength(inHigh, inLow, inClose) {
+		return nil
+	}
+
+	return &ADX{
+		period:  period,
+		adx:     talib.Adx(inHigh, inLow, inClose, period),
+		plusDI:  talib.PlusDI(inHigh, inLow, inClose, period),
+		minusDI: talib.MinusDI(inHigh, inLow, inClose, period),
+	}
+}
+
+func (adx *ADX) Period() int {
+	return adx.period
+}
+
+func (adx *ADX) ADX() []float64 {
+	return adx.adx
+}
+
+func (adx *ADX) PlusDI() []float64 {
+	return adx.plusDI
+}
+
+func (adx *ADX) MinusDI() []float64 {
+	return adx.minusDI
+}
+
+// On Balance Volume
+// シグナルはOBVの単純移動平均
+type OBV struct {
+	period int
+	values []float64
+	signal []float64
+}
+
+func NewOBV(inClose, inVolume []float64, period int) *OBV {
+	if period <= 0 || len(inClose) <= period {
+		return nil
+	}
+
+	if !sameLength(inClose, inVolume) {
+		return nil
+	}
+
+	values := talib.Obv(inClose, inVolume)
+	signal := talib.Sma(values, period)
+
+	return &OBV{
+		period: period,
+		values: values,
+		signal: signal,
+	}
+}
+
+func (obv *OBV) Period() int {
+	return obv.period
+}
+
+func (obv *OBV) Values() []float64 {
+	return obv.values
+}
+
+func (obv *OBV) Signal() []float64 {
+	return obv.signal
+}
+
+// 出来高加重平均価格
+// 直近period本の典型価格（高値・安値・終値の平均）を出来高で加重平均する
+type VWAP struct {
+	period int
+	values []float64
+}
+
+func NewVWAP(inHigh, inLow, inClose, inVolume []float64, period int) *VWAP {
+	if period <= 0 || len(inClose) < period {
+		return nil
+	}
+
+	if !sameLength(inHigh, inLow, inClose, inVolume) {
+		return nil
+	}
+
+	typicals := make([]float64, len(inClose))
+	for i := range inClose {
+		typicals[i] = (inHigh[i] + inLow[i] + inClose[i]) / 3
+	}
+
+	values := make([]float64, len(inClose))
+	for i := period - 1; i < len(inClose); i++ {
+		var priceVolume, volume float64
+		for j := i - period + 1; j <= i; j++ {
+			priceVolume += typicals[j] * inVolume[j]
+			volume += inVolume[j]
+		}
+		// 出来高が無い期間は典型価格をそのまま使う
+		if volume == 0 {
+			values[i] = typicals[i]
+			continue
+		}
+		values[i] = priceVolume / volume
+	}
+
+	return &VWAP{
+		period: period,
+		values: values,
+	}
+}
+
+func (vwap *VWAP) Period() int {
+	return vwap.period
+}
+
+func (vwap *VWAP) Values() []float64 {
+	return vwap.values
+}
+
+// パラボリックSAR
+type ParabolicSAR struct {
+	acceleration float64
+	maximum      float64
+	values       []float64
+}
+
+func NewParabolicSAR(inHigh, inLow []float64, acceleration, maximum float64) *ParabolicSAR {
+	if acceleration <= 0 || maximum < acceleration {
+		return nil
+	}
+
+	if len(inHigh) < 2 || !sameLength(inHigh, inLow) {
+		return nil
+	}
+
+	values := talib.Sar(inHigh, inLow, acceleration, maximum)
+
+	return &ParabolicSAR{
+		acceleration: acceleration,
+		maximum:      maximum,
+		values:       values,
+	}
+}
+
+func (sar *ParabolicSAR) Acceleration() float64 {
+	return sar.acceleration
+}
+
+func (sar *ParabolicSAR) Maximum() float64 {
+	return sar.maximum
+}
+
+func (sar *ParabolicSAR) Values() []float64 {
+	return sar.values
+}
+
+// ドンチャン・チャネル
+// 直近period本（現在の足を含む）の最高値と最安値
+type DonchianChannel struct {
+	period int
+	up     []float64
+	mid    []float64
+	down   []float64
+}
+
+func NewDonchianChannel(inHigh, inLow []float64, period int) *DonchianChannel {
+	if period <= 0 || len(inHigh) <= period {
+		return nil
+	}
+
+	if !sameLength(inHigh, inLow) {
+		return nil
+	}
+
+	up := talib.Max(inHigh, period)
+	down := talib.Min(inLow, period)
+	mid := make([]float64, len(up))
+	for i := range up {
+		mid[i] = (up[i] + down[i]) / 2
+	}
+
+	return &DonchianChannel{
+		period: period,
+		up:     up,
+		mid:    mid,
+		down:   down,
+	}
+}
+
+func (dc *DonchianChannel) Period() int {
+	return dc.period
+}
+
+func (dc *DonchianChannel) Up() []float64 {
+	return dc.up
+}
+
+func (dc *DonchianChannel) Mid() []float64 {
+	return dc.mid
+}
+
+func (dc *DonchianChannel) Down() []float64 {
+	return dc.down
+}
+
+// ケルトナー・チャネル
+// 終値のEMAを中心に，ATRのmultiplier倍だけ上下に幅を取る
+type KeltnerChannel struct {
+	period     int
+	multiplier float64
+	up         []float64
+	mid        []float64
+	down       []float64
+}
+
+func NewKeltnerChannel(inHigh, inLow, inClose []float64, period int, multiplier float64) *KeltnerChannel {
+	if multiplier <= 0 {
+		return nil
+	}
+
+	atr := NewATR(inHigh, inLow, inClose, period)
+	if atr == nil {
+		return nil
+	}
+
+	mid := talib.Ema(inClose, period)
+	up := make([]float64, len(mid))
+	down := make([]float64, len(mid))
+	for i := range mid {
+		up[i] = mid[i] + multiplier*atr.Values()[i]
+		down[i] = mid[i] - multiplier*atr.Values()[i]
+	}
+
+	return &KeltnerChannel{
+		period:     period,
+		multiplier: multiplier,
+		up:         up,
+		mid:        mid,
+		down:       down,
+	}
+}
+
+func (kc *KeltnerChannel) Period() int {
+	return kc.period
+}
+
+func (kc *KeltnerChannel) Multiplier() float64 {
+	return kc.multiplier
+}
+
+func (kc *KeltnerChannel) Up() []float64 {
+	return kc.up
+}
+
+func (kc *KeltnerChannel) Mid() []float64 {
+	return kc.mid
+}
+
+func (kc *KeltnerChannel) Down() []float64 {
+	return kc.down
+}
+
+// 平均足（Heikin-Ashi）
+// 始値は1本前の平均足の始値と終値の中値，終値は四本値の平均
+// 高値・安値は実際の高値・安値と平均足の始値・終値のうち最も外側の値
 type AverageCandle struct {
 	opens  []float64
 	closes []float64
@@ -299,6 +710,9 @@ type AverageCandle struct {
 
 func NewAverageCandle(candles []Candle) *AverageCandle {
 	lenCandle := len(candles)
+	if lenCandle == 0 {
+		return nil
+	}
 
 	opens := make([]float64, lenCandle)
 	closes := make([]float64, lenCandle)
@@ -307,16 +721,16 @@ func NewAverageCandle(candles []Candle) *AverageCandle {
 	for i, candle := range candles {
 		// open
 		if i == 0 {
-			opens[i] = candle.Open()
+			opens[i] = (candle.Open() + candle.Close()) / 2.0
 		} else {
-			opens[i] = (candles[i-1].Open() + candles[i-1].Close()) / 2.0
+			opens[i] = (opens[i-1] + closes[i-1]) / 2.0
 		}
 		// close
 		closes[i] = (candle.Open() + candle.Close() + candle.High() + candle.Low()) / 4.0
 		// high
-		highs[i] = candle.High()
+		highs[i] = math.Max(candle.High(), math.Max(opens[i], closes[i]))
 		// low
-		lows[i] = candle.Low()
+		lows[i] = math.Min(candle.Low(), math.Min(opens[i], closes[i]))
 	}
 
 	return &AverageCandle{
@@ -335,10 +749,30 @@ func (ac *AverageCandle) Closes() []float64 {
 	return ac.closes
 }
 
-func (ac *AverageCandle) High() []float64 {
+func (ac *AverageCandle) Highs() []float64 {
 	return ac.highs
 }
 
 func (ac *AverageCandle) Lows() []float64 {
 	return ac.lows
 }
+
+// 陽線かどうか
+func (ac *AverageCandle) IsBullish(at int) bool {
+	return ac.closes[at] > ac.opens[at]
+}
+
+// 陰線かどうか
+func (ac *AverageCandle) IsBearish(at int) bool {
+	return ac.closes[at] < ac.opens[at]
+}
+
+// 下ヒゲが無いかどうか（強い上昇）
+func (ac *AverageCandle) HasNoLowerWick(at int) bool {
+	return ac.lows[at] >= math.Min(ac.opens[at], ac.closes[at])
+}
+
+// 上ヒゲが無いかどうか（強い下降）
+func (ac *AverageCandle) HasNoUpperWick(at int) bool {
+	return ac.highs[at] <= math.Max(ac.opens[at], ac.closes[at])
+}
@@ -2,7 +2,9 @@ package model_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
 )
 
@@ -84,20 +86,72 @@ func TestBBands(t *testing.T) {
 }
 
 func TestIchimokuCloud(t *testing.T) {
-	var inReal []float64
-	var ichimokuCloud *model.IchimokuCloud
+	newHighsLows := func(closes []float64) ([]float64, []float64) {
+		highs := make([]float64, len(closes))
+		lows := make([]float64, len(closes))
+		for i, c := range closes {
+			highs[i] = c + 1
+			lows[i] = c - 1
+		}
+		return highs, lows
+	}
 
-	inReal = newSequence(52)
-	ichimokuCloud = model.NewIchimokuCloud(inReal)
+	// 先行スパン2が1つ以上求まる長さ: 52+26
+	closes := newSequence(78)
+	highs, lows := newHighsLows(closes)
+	ichimokuCloud := model.NewIchimokuCloud(highs, lows, closes, 9, 26, 52)
 	if ichimokuCloud == nil {
 		t.Fatal("NewIchimokuCloud() returns nil")
 	}
 
-	inReal = newSequence(51)
-	ichimokuCloud = model.NewIchimokuCloud(inReal)
-	if ichimokuCloud != nil {
-		t.Fatal("NewIchimokuCloud() returns not nil")
-	}
+	t.Run("high/low based", func(t *testing.T) {
+		// 転換線: 現在の足を含む直近9本の(最高値+最安値)/2
+		if v := ichimokuCloud.Tenkan()[8]; v != (10+0)/2.0 {
+			t.Fatalf("%v != %v", v, (10+0)/2.0)
+		}
+		// 基準線: 直近26本
+		if v := ichimokuCloud.Kijun()[25]; v != (27+0)/2.0 {
+			t.Fatalf("%v != %v", v, (27+0)/2.0)
+		}
+	})
+
+	t.Run("displacement", func(t *testing.T) {
+		if ichimokuCloud.Displacement() != 26 {
+			t.Fatalf("%v != %v", ichimokuCloud.Displacement(), 26)
+		}
+		// 先行スパンは26本先まで求まる
+		if len(ichimokuCloud.SenkouA()) != len(closes)+26 || len(ichimokuCloud.SenkouB()) != len(closes)+26 {
+			t.Fatalf("len(senkou) != %d", len(closes)+26)
+		}
+		senkouA := (ichimokuCloud.Tenkan()[25] + ichimokuCloud.Kijun()[25]) / 2
+		if v := ichimokuCloud.SenkouA()[25+26]; v != senkouA {
+			t.Fatalf("%v != %v", v, senkouA)
+		}
+		if v := ichimokuCloud.SenkouB()[51+26]; v != (53+0)/2.0 {
+			t.Fatalf("%v != %v", v, (53+0)/2.0)
+		}
+		// 遅行スパンは26本前にずらした終値
+		if v := ichimokuCloud.Chikou()[0]; v != closes[26] {
+			t.Fatalf("%v != %v", v, closes[26])
+		}
+		if v := ichimokuCloud.Chikou()[len(closes)-1]; v != 0 {
+			t.Fatalf("%v != %v", v, 0)
+		}
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		closes := newSequence(77)
+		highs, lows := newHighsLows(closes)
+		if model.NewIchimokuCloud(highs, lows, closes, 9, 26, 52) != nil {
+			t.Fatal("NewIchimokuCloud() returns not nil")
+		}
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		if model.NewIchimokuCloud(highs, lows, closes, 0, 26, 52) != nil {
+			t.Fatal("NewIchimokuCloud() returns not nil")
+		}
+	})
 }
 
 func newSequence(length int) []float64 {
@@ -169,3 +223,282 @@ func TestMACD(t *testing.T) {
 		t.Fatal("NewMACD() returns not nil")
 	}
 }
+
+func TestATR(t *testing.T) {
+	inHigh := []float64{2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	inLow := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	inClose := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	var atr *model.ATR
+
+	atr = model.NewATR(inHigh, inLow, inClose, 3)
+	if atr == nil {
+		t.Fatal("NewATR() returns nil")
+	}
+	// 真の値幅は常に2
+	if atr.Values()[9] != 2 {
+		t.Fatalf("%f != %f", atr.Values()[9], 2.0)
+	}
+
+	atr = model.NewATR(inHigh, inLow, inClose, -1)
+	if atr != nil {
+		t.Fatal("NewATR() returns not nil")
+	}
+
+	atr = model.NewATR(inHigh, inLow, inClose, 20)
+	if atr != nil {
+		t.Fatal("NewATR() returns not nil")
+	}
+
+	atr = model.NewATR(inHigh[1:], inLow, inClose, 3)
+	if atr != nil {
+		t.Fatal("NewATR() returns not nil")
+	}
+}
+
+func TestStochastic(t *testing.T) {
+	inClose := newSequence(30)
+	inHigh := make([]float64, len(inClose))
+	inLow := make([]float64, len(inClose))
+	for i, c := range inClose {
+		inHigh[i] = c + 1
+		inLow[i] = c - 1
+	}
+
+	var stoch *model.Stochastic
+
+	stoch = model.NewStochastic(inHigh, inLow, inClose, 14, 3, 3)
+	if stoch == nil {
+		t.Fatal("NewStochastic() returns nil")
+	}
+	if stoch.Lookback() != 17 {
+		t.Fatalf("%d != %d", stoch.Lookback(), 17)
+	}
+	// 上昇が続くと%Kは高止まりする
+	if k := stoch.SlowK()[len(inClose)-1]; k < 80 {
+		t.Fatalf("slowK=%f should be more than 80", k)
+	}
+
+	stoch = model.NewStochastic(inHigh, inLow, inClose, -1, 3, 3)
+	if stoch != nil {
+		t.Fatal("NewStochastic() returns not nil")
+	}
+
+	stoch = model.NewStochastic(inHigh, inLow, inClose, 14, 10, 10)
+	if stoch != nil {
+		t.Fatal("NewStochastic() returns not nil")
+	}
+}
+
+func TestADX(t *testing.T) {
+	inClose := newSequence(30)
+	inHigh := make([]float64, len(inClose))
+	inLow := make([]float64, len(inClose))
+	for i, c := range inClose {
+		inHigh[i] = c + 1
+		inLow[i] = c - 1
+	}
+
+	var adx *model.ADX
+
+	adx = model.NewADX(inHigh, inLow, inClose, 7)
+	if adx == nil {
+		t.Fatal("NewADX() returns nil")
+	}
+	last := len(inClose) - 1
+	if adx.PlusDI()[last] <= adx.MinusDI()[last] {
+		t.Fatalf("+DI(%f) should be more than -DI(%f) in uptrend", adx.PlusDI()[last], adx.MinusDI()[last])
+	}
+
+	adx = model.NewADX(inHigh, inLow, inClose, -1)
+	if adx != nil {
+		t.Fatal("NewADX() returns not nil")
+	}
+
+	adx = model.NewADX(inHigh, inLow, inClose, 15)
+	if adx != nil {
+		t.Fatal("NewADX() returns not nil")
+	}
+}
+
+func TestOBV(t *testing.T) {
+	inClose := []float64{1, 2, 1, 2, 3}
+	inVolume := []float64{10, 20, 30, 40, 50}
+
+	var obv *model.OBV
+
+	obv = model.NewOBV(inClose, inVolume, 2)
+	if obv == nil {
+		t.Fatal("NewOBV() returns nil")
+	}
+	expected := []float64{10, 30, 0, 40, 90}
+	for i := range expected {
+		if obv.Values()[i] != expected[i] {
+			t.Fatalf("obv[%d]: %f != %f", i, obv.Values()[i], expected[i])
+		}
+	}
+	if obv.Signal()[4] != 65 {
+		t.Fatalf("%f != %f", obv.Signal()[4], 65.0)
+	}
+
+	obv = model.NewOBV(inClose, inVolume, -1)
+	if obv != nil {
+		t.Fatal("NewOBV() returns not nil")
+	}
+
+	obv = model.NewOBV(inClose, inVolume[1:], 2)
+	if obv != nil {
+		t.Fatal("NewOBV() returns not nil")
+	}
+}
+
+func TestVWAP(t *testing.T) {
+	inHigh := []float64{10, 20, 30}
+	inLow := []float64{10, 20, 30}
+	inClose := []float64{10, 20, 30}
+	inVolume := []float64{1, 3, 0}
+
+	var vwap *model.VWAP
+
+	vwap = model.NewVWAP(inHigh, inLow, inClose, inVolume, 2)
+	if vwap == nil {
+		t.Fatal("NewVWAP() returns nil")
+	}
+	// (10*1 + 20*3) / 4
+	if vwap.Values()[1] != 17.5 {
+		t.Fatalf("%f != %f", vwap.Values()[1], 17.5)
+	}
+	if vwap.Values()[2] != 20 {
+		t.Fatalf("%f != %f", vwap.Values()[2], 20.0)
+	}
+
+	vwap = model.NewVWAP(inHigh, inLow, inClose, inVolume, 5)
+	if vwap != nil {
+		t.Fatal("NewVWAP() returns not nil")
+	}
+}
+
+func TestParabolicSAR(t *testing.T) {
+	inHigh := newSequence(10)
+	inLow := make([]float64, len(inHigh))
+	for i, h := range inHigh {
+		inLow[i] = h - 1
+	}
+
+	var sar *model.ParabolicSAR
+
+	sar = model.NewParabolicSAR(inHigh, inLow, 0.02, 0.2)
+	if sar == nil {
+		t.Fatal("NewParabolicSAR() returns nil")
+	}
+	// 上昇トレンドではSARは安値より下にある
+	if sar.Values()[9] >= inLow[9] {
+		t.Fatalf("sar=%f should be less than low=%f", sar.Values()[9], inLow[9])
+	}
+
+	sar = model.NewParabolicSAR(inHigh, inLow, 0, 0.2)
+	if sar != nil {
+		t.Fatal("NewParabolicSAR() returns not nil")
+	}
+
+	sar = model.NewParabolicSAR(inHigh, inLow, 0.3, 0.2)
+	if sar != nil {
+		t.Fatal("NewParabolicSAR() returns not nil")
+	}
+}
+
+func TestDonchianChannel(t *testing.T) {
+	inHigh := []float64{5, 3, 4, 8, 6}
+	inLow := []float64{1, 2, 0, 5, 4}
+
+	var donchian *model.DonchianChannel
+
+	donchian = model.NewDonchianChannel(inHigh, inLow, 3)
+	if donchian == nil {
+		t.Fatal("NewDonchianChannel() returns nil")
+	}
+	if donchian.Up()[4] != 8 || donchian.Down()[4] != 0 || donchian.Mid()[4] != 4 {
+		t.Fatalf("up=%f, mid=%f, down=%f", donchian.Up()[4], donchian.Mid()[4], donchian.Down()[4])
+	}
+
+	donchian = model.NewDonchianChannel(inHigh, inLow, -1)
+	if donchian != nil {
+		t.Fatal("NewDonchianChannel() returns not nil")
+	}
+
+	donchian = model.NewDonchianChannel(inHigh, inLow, 10)
+	if donchian != nil {
+		t.Fatal("NewDonchianChannel() returns not nil")
+	}
+}
+
+func TestKeltnerChannel(t *testing.T) {
+	inHigh := []float64{2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	inLow := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	inClose := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	var keltner *model.KeltnerChannel
+
+	keltner = model.NewKeltnerChannel(inHigh, inLow, inClose, 3, 2)
+	if keltner == nil {
+		t.Fatal("NewKeltnerChannel() returns nil")
+	}
+	// ATRは2なので，上下の幅はそれぞれ4
+	if keltner.Up()[9]-keltner.Mid()[9] != 4 || keltner.Mid()[9]-keltner.Down()[9] != 4 {
+		t.Fatalf("up=%f, mid=%f, down=%f", keltner.Up()[9], keltner.Mid()[9], keltner.Down()[9])
+	}
+
+	keltner = model.NewKeltnerChannel(inHigh, inLow, inClose, 3, -1)
+	if keltner != nil {
+		t.Fatal("NewKeltnerChannel() returns not nil")
+	}
+
+	keltner = model.NewKeltnerChannel(inHigh, inLow, inClose, 20, 2)
+	if keltner != nil {
+		t.Fatal("NewKeltnerChannel() returns not nil")
+	}
+}
+
+func TestAverageCandle(t *testing.T) {
+	// open, close, high, low
+	ohlc := [][4]float64{
+		{10, 12, 13, 9},
+		{12, 14, 15, 11},
+		{14, 16, 17, 13.5},
+	}
+	candles := make([]model.Candle, len(ohlc))
+	currentTime := time.Now()
+	for i, v := range ohlc {
+		candleTime := model.NewCandleTime(currentTime)
+		candles[i] = *model.NewCandle(config.ProductCode, config.CandleDuration, candleTime, v[0], v[1], v[2], v[3], 1)
+		currentTime = currentTime.Add(config.CandleDuration)
+	}
+
+	var averageCandle *model.AverageCandle
+
+	averageCandle = model.NewAverageCandle(candles)
+	if averageCandle == nil {
+		t.Fatal("NewAverageCandle() returns nil")
+	}
+	// 始値は1本前の平均足から計算する
+	if averageCandle.Opens()[1] != 11 || averageCandle.Opens()[2] != 12 {
+		t.Fatalf("opens=%v", averageCandle.Opens())
+	}
+	if averageCandle.Closes()[2] != 15.125 {
+		t.Fatalf("closes=%v", averageCandle.Closes())
+	}
+	if averageCandle.Highs()[2] != 17 || averageCandle.Lows()[2] != 12 {
+		t.Fatalf("highs=%v, lows=%v", averageCandle.Highs(), averageCandle.Lows())
+	}
+	if !averageCandle.IsBullish(2) || averageCandle.IsBearish(2) {
+		t.Fatal("averageCandle at 2 should be bullish")
+	}
+	if !averageCandle.HasNoLowerWick(2) || averageCandle.HasNoUpperWick(2) {
+		t.Fatal("averageCandle at 2 should have only upper wick")
+	}
+
+	averageCandle = model.NewAverageCandle([]model.Candle{})
+	if averageCandle != nil {
+		t.Fatal("NewAverageCandle() returns not nil")
+	}
+}
@@ -93,3 +93,46 @@ func NewSellOrder(productCode string, size float64) *Order {
 		TimeInForce:     TimeInForceGTC,
 	}
 }
+
+// 指値の買い注文
+// 約定するまで待つので，有効期限は最長の30日にする
+func NewLimitBuyOrder(productCode string, price, size float64) *Order {
+	if productCode == "" {
+		return nil
+	}
+
+	if price <= 0 || size <= 0 {
+		return nil
+	}
+
+	return &Order{
+		ProductCode:     productCode,
+		ChildOrderType:  ChildOrderTypeLimit,
+		Side:            OrderSideBuy,
+		Price:           price,
+		Size:            size,
+		MinuteToExpires: 43200,
+		TimeInForce:     TimeInForceGTC,
+	}
+}
+
+// 指値の売り注文
+func NewLimitSellOrder(productCode string, price, size float64) *Order {
+	if productCode == "" {
+		return nil
+	}
+
+	if price <= 0 || size <= 0 {
+		return nil
+	}
+
+	return &Order{
+		ProductCode:     productCode,
+		ChildOrderType:  ChildOrderTypeLimit,
+		Side:            OrderSideSell,
+		Price:           price,
+		Size:            size,
+		MinuteToExpires: 43200,
+		TimeInForce:     TimeInForceGTC,
+	}
+}
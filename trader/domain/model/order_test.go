@@ -45,4 +45,33 @@ func TestOrder(t *testing.T) {
 			t.Fatal("NewSellOrder() returns not nil")
 		}
 	})
+	t.Run("new limit order", func(t *testing.T) {
+		var order *model.Order
+
+		order = model.NewLimitBuyOrder(config.ProductCode, 100, 1)
+		if order == nil {
+			t.Fatal("NewLimitBuyOrder() returns nil")
+		}
+		if order.ChildOrderType != model.ChildOrderTypeLimit || order.Side != model.OrderSideBuy || order.Price != 100 {
+			t.Fatalf("order=%+v", order)
+		}
+
+		order = model.NewLimitBuyOrder(config.ProductCode, 0, 1)
+		if order != nil {
+			t.Fatal("NewLimitBuyOrder() returns not nil")
+		}
+
+		order = model.NewLimitSellOrder(config.ProductCode, 100, 1)
+		if order == nil {
+			t.Fatal("NewLimitSellOrder() returns nil")
+		}
+		if order.ChildOrderType != model.ChildOrderTypeLimit || order.Side != model.OrderSideSell || order.Price != 100 {
+			t.Fatalf("order=%+v", order)
+		}
+
+		order = model.NewLimitSellOrder("", 100, 1)
+		if order != nil {
+			t.Fatal("NewLimitSellOrder() returns not nil")
+		}
+	})
 }
@@ -0,0 +1,209 @@
+package model
+
+import "time"
+
+// 取引履歴と現在価格から計算した損益
+type Portfolio struct {
+	productCode   string
+	position      float64 // 保有中の数量
+	averagePrice  float64 // 保有中の平均取得単価（手数料込み）
+	currentPrice  float64
+	realizedPnL   float64 // 実現損益（手数料控除後）
+	unrealizedPnL float64 // 含み損益
+	commission    float64 // 支払った手数料の合計（JPY）
+	equity        float64 // 全残高のJPY換算額
+}
+
+// 買って売ってを繰り返した履歴から，移動平均法で損益を計算する
+// 手数料は約定代金にcommissionRateを掛けた額をJPYで支払ったものとみなす
+func NewPortfolio(productCode string, signals []SignalEvent, currentPrice, commissionRate, equity float64) *Portfolio {
+	if productCode == "" {
+		return nil
+	}
+
+	if currentPrice <= 0 {
+		return nil
+	}
+
+	if commissionRate < 0 || 1 < commissionRate {
+		return nil
+	}
+
+	if equity < 0 {
+		return nil
+	}
+
+	var position, cost, realizedPnL, commission float64
+	for _, signal := range signals {
+		if signal.ProductCode() != productCode {
+			continue
+		}
+
+		fee := signal.Price() * signal.Size() * commissionRate
+		commission += fee
+
+		switch signal.Side() {
+		case OrderSideBuy:
+			position += signal.Size()
+			cost += signal.Price()*signal.Size() + fee
+		case OrderSideSell:
+			// 売却分の取得原価は平均取得単価で計算
+			size := signal.Size()
+			if size > position {
+				size = position
+			}
+			var averagePrice float64
+			if position > 0 {
+				averagePrice = cost / position
+			}
+			realizedPnL += signal.Price()*size - averagePrice*size - fee
+			position -= size
+			cost -= averagePrice * size
+		}
+	}
+
+	var averagePrice, unrealizedPnL float64
+	if position > 0 {
+		averagePrice = cost / position
+		unrealizedPnL = currentPrice*position - cost
+	}
+
+	return &Portfolio{
+		productCode:   productCode,
+		position:      position,
+		averagePrice:  averagePrice,
+		currentPrice:  currentPrice,
+		realizedPnL:   realizedPnL,
+		unrealizedPnL: unrealizedPnL,
+		commission:    commission,
+		equity:        equity,
+	}
+}
+
+func (p *Portfolio) ProductCode() string {
+	return p.productCode
+}
+
+func (p *Portfolio) Position() float64 {
+	return p.position
+}
+
+func (p *Portfolio) AveragePrice() float64 {
+	return p.averagePrice
+}
+
+func (p *Portfolio) CurrentPrice() float64 {
+	return p.currentPrice
+}
+
+func (p *Portfolio) RealizedPnL() float64 {
+	return p.realizedPnL
+}
+
+func (p *Portfolio) UnrealizedPnL() float64 {
+	return p.unrealizedPnL
+}
+
+func (p *Portfolio) TotalPnL() float64 {
+	return p.realizedPnL + p.unrealizedPnL
+}
+
+func (p *Portfolio) Commission() float64 {
+	return p.commission
+}
+
+func (p *Portfolio) Equity() float64 {
+	return p.equity
+}
+
+// 全残高をJPYに換算した合計
+// pricesには通貨コードごとのJPY建て価格を渡す．JPYは1として扱う
+// 価格が分からない通貨は合計に含めない
+func TotalEquity(balances []Balance, prices map[string]float64) float64 {
+	total := 0.0
+	for _, balance := range balances {
+		if balance.CurrencyCode() == "JPY" {
+			total += balance.Amount()
+			continue
+		}
+		price, ok := prices[balance.CurrencyCode()]
+		if !ok {
+			continue
+		}
+		total += balance.Amount() * price
+	}
+	return total
+}
+
+// 日次の資産スナップショット
+type EquitySnapshot struct {
+	time          time.Time
+	productCode   string
+	equity        float64
+	realizedPnL   float64
+	unrealizedPnL float64
+	position      float64
+	price         float64
+}
+
+func NewEquitySnapshot(timeTime time.Time, productCode string, equity, realizedPnL, unrealizedPnL, position, price float64) *EquitySnapshot {
+	if productCode == "" {
+		return nil
+	}
+
+	if equity < 0 {
+		return nil
+	}
+
+	if position < 0 {
+		return nil
+	}
+
+	if price <= 0 {
+		return nil
+	}
+
+	timeTime = timeTime.In(time.UTC)
+
+	return &EquitySnapshot{
+		time:          timeTime,
+		productCode:   productCode,
+		equity:        equity,
+		realizedPnL:   realizedPnL,
+		unrealizedPnL: unrealizedPnL,
+		position:      position,
+		price:         price,
+	}
+}
+
+func (p *Portfolio) Snapshot(timeTime time.Time) *EquitySnapshot {
+	return NewEquitySnapshot(timeTime, p.productCode, p.equity, p.realizedPnL, p.unrealizedPnL, p.position, p.currentPrice)
+}
+
+func (s *EquitySnapshot) Time() time.Time {
+	return s.time
+}
+
+func (s *EquitySnapshot) ProductCode() string {
+	return s.productCode
+}
+
+func (s *EquitySnapshot) Equity() float64 {
+	return s.equity
+}
+
+func (s *EquitySnapshot) RealizedPnL() float64 {
+	return s.realizedPnL
+}
+
+func (s *EquitySnapshot) UnrealizedPnL() float64 {
+	return s.unrealizedPnL
+}
+
+func (s *EquitySnapshot) Position() float64 {
+	return s.position
+}
+
+func (s *EquitySnapshot) Price() float64 {
+	return s.price
+}
@@ -0,0 +1,126 @@
+package model_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+)
+
+func TestPortfolio(t *testing.T) {
+	table := []struct {
+		time  time.Time
+		side  model.OrderSide
+		price float64
+		size  float64
+	}{
+		{
+			time:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			side:  model.OrderSideBuy,
+			price: 1000,
+			size:  1,
+		},
+		{
+			time:  time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			side:  model.OrderSideSell,
+			price: 2000,
+			size:  1,
+		},
+		{
+			time:  time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+			side:  model.OrderSideBuy,
+			price: 3000,
+			size:  2,
+		},
+	}
+
+	signals := make([]model.SignalEvent, 0)
+	for _, s := range table {
+		signal := model.NewSignalEvent(s.time, config.ProductCode, s.side, s.price, s.size)
+		signals = append(signals, *signal)
+	}
+
+	t.Run("NewPortfolio", func(t *testing.T) {
+		if model.NewPortfolio("", signals, 4000, 0, 0) != nil {
+			t.Fatal("NewPortfolio() returns not nil")
+		}
+		if model.NewPortfolio(config.ProductCode, signals, 0, 0, 0) != nil {
+			t.Fatal("NewPortfolio() returns not nil")
+		}
+		if model.NewPortfolio(config.ProductCode, signals, 4000, -0.1, 0) != nil {
+			t.Fatal("NewPortfolio() returns not nil")
+		}
+	})
+
+	t.Run("without commission", func(t *testing.T) {
+		portfolio := model.NewPortfolio(config.ProductCode, signals, 4000, 0, 10000)
+		if portfolio == nil {
+			t.Fatal("NewPortfolio() returns nil")
+		}
+		if portfolio.RealizedPnL() != 1000 {
+			t.Fatalf("%v != %v", portfolio.RealizedPnL(), 1000)
+		}
+		if portfolio.Position() != 2 {
+			t.Fatalf("%v != %v", portfolio.Position(), 2)
+		}
+		if portfolio.AveragePrice() != 3000 {
+			t.Fatalf("%v != %v", portfolio.AveragePrice(), 3000)
+		}
+		if portfolio.UnrealizedPnL() != 2000 {
+			t.Fatalf("%v != %v", portfolio.UnrealizedPnL(), 2000)
+		}
+		if portfolio.TotalPnL() != 3000 {
+			t.Fatalf("%v != %v", portfolio.TotalPnL(), 3000)
+		}
+	})
+
+	t.Run("with commission", func(t *testing.T) {
+		rate := 0.001
+		portfolio := model.NewPortfolio(config.ProductCode, signals, 4000, rate, 10000)
+		if portfolio == nil {
+			t.Fatal("NewPortfolio() returns nil")
+		}
+		// 手数料: 1 + 2 + 6
+		if math.Abs(portfolio.Commission()-9) > 1e-9 {
+			t.Fatalf("%v != %v", portfolio.Commission(), 9)
+		}
+		// 2000 - (1000 + 1) - 2
+		if math.Abs(portfolio.RealizedPnL()-997) > 1e-9 {
+			t.Fatalf("%v != %v", portfolio.RealizedPnL(), 997)
+		}
+		// 4000*2 - (6000 + 6)
+		if math.Abs(portfolio.UnrealizedPnL()-1994) > 1e-9 {
+			t.Fatalf("%v != %v", portfolio.UnrealizedPnL(), 1994)
+		}
+	})
+
+	t.Run("Snapshot", func(t *testing.T) {
+		portfolio := model.NewPortfolio(config.ProductCode, signals, 4000, 0, 10000)
+		snapshot := portfolio.Snapshot(time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC))
+		if snapshot == nil {
+			t.Fatal("Snapshot() returns nil")
+		}
+		if snapshot.Equity() != portfolio.Equity() {
+			t.Fatalf("%v != %v", snapshot.Equity(), portfolio.Equity())
+		}
+	})
+}
+
+func TestTotalEquity(t *testing.T) {
+	balances := []model.Balance{
+		*model.NewBalance("JPY", 10000, 10000),
+		*model.NewBalance("ETH", 0.5, 0.5),
+		*model.NewBalance("XRP", 100, 100),
+	}
+	prices := map[string]float64{
+		"ETH": 400000,
+	}
+
+	// 価格が分からないXRPは含めない
+	equity := model.TotalEquity(balances, prices)
+	if equity != 210000 {
+		t.Fatalf("%v != %v", equity, 210000)
+	}
+}
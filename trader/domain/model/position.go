@@ -0,0 +1,175 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// 決済するロットの選び方
+type LotMatching string
+
+const (
+	LotMatchingFIFO    LotMatching = "FIFO"    // 古いロットから決済する
+	LotMatchingAverage LotMatching = "AVERAGE" // 平均取得単価で決済する
+)
+
+func (lm LotMatching) Valid() bool {
+	return lm == LotMatchingFIFO || lm == LotMatchingAverage
+}
+
+// 残りがエントリー時の数量のこの割合より小さいロットは決済済みとして扱う
+// 手数料の分だけ保有量が減り，売り注文の数量が足りなくなるため
+const lotDustRatio = 0.01
+
+// 1回の買いで建てたポジション
+type Lot struct {
+	time      time.Time
+	price     float64
+	size      float64
+	entrySize float64
+}
+
+func (l *Lot) Time() time.Time {
+	return l.time
+}
+
+func (l *Lot) Price() float64 {
+	return l.price
+}
+
+// 決済されていない数量
+func (l *Lot) Size() float64 {
+	return l.size
+}
+
+func (l *Lot) EntrySize() float64 {
+	return l.entrySize
+}
+
+func (l *Lot) isDust() bool {
+	return l.size < l.entrySize*lotDustRatio
+}
+
+// 複数のロットからなるポジション
+type Position struct {
+	lots           []Lot
+	matching       LotMatching
+	realizedProfit float64
+}
+
+func NewPosition(matching LotMatching) *Position {
+	if !matching.Valid() {
+		return nil
+	}
+
+	return &Position{
+		lots:     make([]Lot, 0),
+		matching: matching,
+	}
+}
+
+func (p *Position) Lots() []Lot {
+	return p.lots
+}
+
+func (p *Position) Matching() LotMatching {
+	return p.matching
+}
+
+// 保有している数量
+func (p *Position) Size() float64 {
+	size := 0.0
+	for _, lot := range p.lots {
+		size += lot.size
+	}
+	return size
+}
+
+// 平均取得単価（ポジションがなければ0）
+func (p *Position) AveragePrice() float64 {
+	size := p.Size()
+	if size <= 0 {
+		return 0
+	}
+
+	cost := 0.0
+	for _, lot := range p.lots {
+		cost += lot.price * lot.size
+	}
+	return cost / size
+}
+
+// 決済済みの損益
+func (p *Position) RealizedProfit() float64 {
+	return p.realizedProfit
+}
+
+// 価格priceで全て決済したときの損益
+func (p *Position) UnrealizedProfit(price float64) float64 {
+	return (price - p.AveragePrice()) * p.Size()
+}
+
+func (p *Position) Open(timeTime time.Time, price, size float64) bool {
+	if price <= 0 || size <= 0 {
+		return false
+	}
+
+	p.lots = append(p.lots, Lot{
+		time:      timeTime,
+		price:     price,
+		size:      size,
+		entrySize: size,
+	})
+	return true
+}
+
+// 価格priceで数量sizeを決済し，その損益を返す
+// 保有している数量より多ければ，保有している分だけ決済する
+func (p *Position) Close(price, size float64) float64 {
+	if price <= 0 || size <= 0 {
+		return 0
+	}
+	size = math.Min(size, p.Size())
+	if size <= 0 {
+		return 0
+	}
+
+	profit := 0.0
+	switch p.matching {
+	case LotMatchingFIFO:
+		rest := size
+		for i := range p.lots {
+			if rest <= 0 {
+				break
+			}
+			closed := math.Min(rest, p.lots[i].size)
+			profit += (price - p.lots[i].price) * closed
+			p.lots[i].size -= closed
+			rest -= closed
+		}
+	case LotMatchingAverage:
+		profit = (price - p.AveragePrice()) * size
+		// 平均取得単価の1つのロットにまとめて減らす
+		// ロットの数を減らさないと，一部を決済しても上限まで買えないままになる
+		lot := Lot{
+			time:  p.lots[0].time,
+			price: p.AveragePrice(),
+			size:  p.Size() - size,
+		}
+		for _, l := range p.lots {
+			lot.entrySize += l.entrySize
+		}
+		p.lots = []Lot{lot}
+	}
+
+	lots := make([]Lot, 0, len(p.lots))
+	for _, lot := range p.lots {
+		if !lot.isDust() {
+			lots = append(lots, lot)
+		}
+	}
+	p.lots = lots
+
+	p.realizedProfit += profit
+	return profit
+}
@@ -0,0 +1,89 @@
+package model_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+)
+
+func TestPosition(t *testing.T) {
+	if model.NewPosition("UNKNOWN") != nil {
+		t.Fatal("NewPosition() returns not nil")
+	}
+
+	open := func(position *model.Position) {
+		position.Open(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), 1000, 1)
+		position.Open(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), 2000, 1)
+	}
+
+	t.Run("FIFO", func(t *testing.T) {
+		position := model.NewPosition(model.LotMatchingFIFO)
+		open(position)
+		if position.Size() != 2 || position.AveragePrice() != 1500 {
+			t.Fatalf("size=%f, averagePrice=%f", position.Size(), position.AveragePrice())
+		}
+
+		// 古いロットの全部と新しいロットの半分を決済する
+		profit := position.Close(3000, 1.5)
+		if profit != 2000*1+1000*0.5 {
+			t.Fatalf("profit=%f", profit)
+		}
+		lots := position.Lots()
+		if len(lots) != 1 || lots[0].Price() != 2000 || lots[0].Size() != 0.5 {
+			t.Fatalf("lots=%+v", lots)
+		}
+
+		// 保有している数量より多くは決済しない
+		profit = position.Close(1000, 10)
+		if profit != -1000*0.5 {
+			t.Fatalf("profit=%f", profit)
+		}
+		if len(position.Lots()) != 0 || position.RealizedProfit() != 2000 {
+			t.Fatalf("lots=%+v, realizedProfit=%f", position.Lots(), position.RealizedProfit())
+		}
+	})
+
+	t.Run("AVERAGE", func(t *testing.T) {
+		position := model.NewPosition(model.LotMatchingAverage)
+		open(position)
+
+		profit := position.Close(3000, 1)
+		if profit != 1500 {
+			t.Fatalf("profit=%f", profit)
+		}
+		// 決済しても平均取得単価は変わらず，ロットは1つにまとまる
+		if position.Size() != 1 || position.AveragePrice() != 1500 || len(position.Lots()) != 1 {
+			t.Fatalf("size=%f, averagePrice=%f, lots=%+v", position.Size(), position.AveragePrice(), position.Lots())
+		}
+		if position.UnrealizedProfit(2000) != 500 {
+			t.Fatalf("unrealizedProfit=%f", position.UnrealizedProfit(2000))
+		}
+	})
+
+	t.Run("AVERAGE dust", func(t *testing.T) {
+		position := model.NewPosition(model.LotMatchingAverage)
+		open(position)
+
+		// 手数料で保有量が少し減ったときの売り
+		position.Close(3000, 1.999)
+		if len(position.Lots()) != 0 {
+			t.Fatalf("lots=%+v", position.Lots())
+		}
+	})
+
+	t.Run("dust", func(t *testing.T) {
+		position := model.NewPosition(model.LotMatchingFIFO)
+		position.Open(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), 1000, 1)
+
+		// 手数料で保有量が少し減ったときの売り
+		position.Close(2000, 0.999)
+		if len(position.Lots()) != 0 {
+			t.Fatalf("lots=%+v", position.Lots())
+		}
+		if math.Abs(position.RealizedProfit()-999) > 1e-9 {
+			t.Fatalf("realizedProfit=%f", position.RealizedProfit())
+		}
+	})
+}
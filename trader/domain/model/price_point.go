@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// 価格の履歴（価格の変化のアラートに使う）
+type PricePoint struct {
+	time        time.Time
+	productCode string
+	price       float64
+}
+
+func NewPricePoint(timeTime time.Time, productCode string, price float64) *PricePoint {
+	if productCode == "" {
+		return nil
+	}
+
+	if price <= 0 {
+		return nil
+	}
+
+	timeTime = timeTime.In(time.UTC)
+
+	return &PricePoint{
+		time:        timeTime,
+		productCode: productCode,
+		price:       price,
+	}
+}
+
+func (pp *PricePoint) Time() time.Time {
+	return pp.time
+}
+
+func (pp *PricePoint) ProductCode() string {
+	return pp.productCode
+}
+
+func (pp *PricePoint) Price() float64 {
+	return pp.price
+}
@@ -0,0 +1,1148 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 売買ルールを記述する式
+// 例: crossover(ema(7), ema(21)) and rsi(14) < 35
+//
+// 演算子は優先順位の低い順に or, and, not, 比較(< <= > >= == !=), + -, * /, 単項マイナス
+// 指標の引数には定数のみ指定できる
+// データが足りずに値が決まらない時点では，式全体を偽とする
+type Rule struct {
+	source string
+	root   ruleNode
+}
+
+func ParseRule(source string) (*Rule, error) {
+	tokens, err := tokenizeRule(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &ruleParser{
+		tokens: tokens,
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != ruleTokenEOF {
+		return nil, fmt.Errorf("unexpected %q at %d", tok.text, tok.pos)
+	}
+	if root.typ() != ruleTypeBool {
+		return nil, fmt.Errorf("rule must be a condition, not a number")
+	}
+
+	return &Rule{
+		source: source,
+		root:   root,
+	}, nil
+}
+
+func (r *Rule) String() string {
+	return r.source
+}
+
+// 時点"at"でルールが成り立つかどうか
+func (r *Rule) Evaluate(ctx *RuleContext, at int) bool {
+	if r == nil || ctx == nil {
+		return false
+	}
+	if at < 0 || at >= len(ctx.df.Candles()) {
+		return false
+	}
+
+	value, ok := r.root.eval(ctx, at)
+	return ok && value != 0
+}
+
+// 合成した価格で全ての足について評価してみて，失敗（panic）しないか確かめる
+// 保存する前に呼び，評価のたびに失敗する式を弾く
+func (r *Rule) Check() (err error) {
+	candles := make([]Candle, ruleCheckLength)
+	currentTime := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range candles {
+		price := 100 + 10*math.Sin(float64(i)/3) + float64(i%7)
+		candles[i] = *NewCandle("CHECK", 24*time.Hour, NewCandleTime(currentTime), price, price+1, price+2, price-2, float64(1+i%5))
+		currentTime = currentTime.Add(24 * time.Hour)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("failed to evaluate %q: %v", r.String(), p)
+		}
+	}()
+
+	ctx := NewRuleContext(NewDataFrame("CHECK", candles, nil))
+	for at := range candles {
+		r.Evaluate(ctx, at)
+	}
+	return nil
+}
+
+// Checkで評価する足の本数
+const ruleCheckLength = 100
+
+// ルールの評価に使う指標の計算結果をキャッシュする
+// 同じDataFrameに対して複数の時点で評価するときは使い回す
+type RuleContext struct {
+	df     *DataFrame
+	series map[string]*ruleSeriesValue
+}
+
+type ruleSeriesValue struct {
+	values   []float64
+	lookback int
+}
+
+func NewRuleContext(df *DataFrame) *RuleContext {
+	if df == nil {
+		return nil
+	}
+
+	return &RuleContext{
+		df:     df,
+		series: make(map[string]*ruleSeriesValue),
+	}
+}
+
+func (ctx *RuleContext) seriesValue(name string, args []float64) *ruleSeriesValue {
+	key := fmt.Sprint(name, args)
+	if value, ok := ctx.series[key]; ok {
+		return value
+	}
+
+	values, lookback := ruleSeriesFuncs[name].build(ctx.df, args)
+	var value *ruleSeriesValue
+	if values != nil {
+		value = &ruleSeriesValue{
+			values:   values,
+			lookback: lookback,
+		}
+	}
+	ctx.series[key] = value
+	return value
+}
+
+// 字句解析
+
+type ruleTokenKind int
+
+const (
+	ruleTokenEOF ruleTokenKind = iota
+	ruleTokenNumber
+	ruleTokenIdent
+	ruleTokenSymbol
+)
+
+type ruleToken struct {
+	kind ruleTokenKind
+	text string
+	pos  int
+}
+
+func tokenizeRule(source string) ([]ruleToken, error) {
+	tokens := make([]ruleToken, 0)
+	i := 0
+	for i < len(source) {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isRuleDigit(c) || c == '.':
+			start := i
+			for i < len(source) && (isRuleDigit(source[i]) || source[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, ruleToken{kind: ruleTokenNumber, text: source[start:i], pos: start})
+		case isRuleLetter(c):
+			start := i
+			for i < len(source) && (isRuleLetter(source[i]) || isRuleDigit(source[i])) {
+				i++
+			}
+			tokens = append(tokens, ruleToken{kind: ruleTokenIdent, text: strings.ToLower(source[start:i]), pos: start})
+		case strings.IndexByte("<>=!", c) >= 0:
+			start := i
+			i++
+			if i < len(source) && source[i] == '=' {
+				i++
+			}
+			text := source[start:i]
+			if text == "=" || text == "!" {
+				return nil, fmt.Errorf("unexpected %q at %d", text, start)
+			}
+			tokens = append(tokens, ruleToken{kind: ruleTokenSymbol, text: text, pos: start})
+		case strings.IndexByte("()+-*/,", c) >= 0:
+			tokens = append(tokens, ruleToken{kind: ruleTokenSymbol, text: string(c), pos: i})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected %q at %d", string(c), i)
+		}
+	}
+	tokens = append(tokens, ruleToken{kind: ruleTokenEOF, text: "end of rule", pos: len(source)})
+	return tokens, nil
+}
+
+func isRuleDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isRuleLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
+
+// 構文解析
+
+type ruleParser struct {
+	tokens []ruleToken
+	pos    int
+}
+
+func (p *ruleParser) peek() ruleToken {
+	return p.tokens[p.pos]
+}
+
+func (p *ruleParser) next() ruleToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != ruleTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *ruleParser) accept(kind ruleTokenKind, text string) bool {
+	tok := p.peek()
+	if tok.kind == kind && tok.text == text {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *ruleParser) expect(text string) error {
+	tok := p.next()
+	if tok.kind != ruleTokenSymbol || tok.text != text {
+		return fmt.Errorf("expected %q but got %q at %d", text, tok.text, tok.pos)
+	}
+	return nil
+}
+
+func (p *ruleParser) parseOr() (ruleNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if !p.accept(ruleTokenIdent, "or") {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if left.typ() != ruleTypeBool || right.typ() != ruleTypeBool {
+			return nil, fmt.Errorf("operands of \"or\" must be conditions at %d", tok.pos)
+		}
+		left = &ruleLogicalNode{op: "or", left: left, right: right}
+	}
+}
+
+func (p *ruleParser) parseAnd() (ruleNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if !p.accept(ruleTokenIdent, "and") {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if left.typ() != ruleTypeBool || right.typ() != ruleTypeBool {
+			return nil, fmt.Errorf("operands of \"and\" must be conditions at %d", tok.pos)
+		}
+		left = &ruleLogicalNode{op: "and", left: left, right: right}
+	}
+}
+
+func (p *ruleParser) parseNot() (ruleNode, error) {
+	tok := p.peek()
+	if p.accept(ruleTokenIdent, "not") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if operand.typ() != ruleTypeBool {
+			return nil, fmt.Errorf("operand of \"not\" must be a condition at %d", tok.pos)
+		}
+		return &ruleNotNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *ruleParser) parseComparison() (ruleNode, error) {
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	switch tok.text {
+	case "<", "<=", ">", ">=", "==", "!=":
+		if tok.kind != ruleTokenSymbol {
+			return left, nil
+		}
+	default:
+		return left, nil
+	}
+	p.next()
+
+	right, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if left.typ() != ruleTypeNumber || right.typ() != ruleTypeNumber {
+		return nil, fmt.Errorf("operands of %q must be numbers at %d", tok.text, tok.pos)
+	}
+	return &ruleComparisonNode{op: tok.text, left: left, right: right}, nil
+}
+
+func (p *ruleParser) parseSum() (ruleNode, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok.kind != ruleTokenSymbol || (tok.text != "+" && tok.text != "-") {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		if left.typ() != ruleTypeNumber || right.typ() != ruleTypeNumber {
+			return nil, fmt.Errorf("operands of %q must be numbers at %d", tok.text, tok.pos)
+		}
+		left = &ruleArithmeticNode{op: tok.text, left: left, right: right}
+	}
+}
+
+func (p *ruleParser) parseTerm() (ruleNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok.kind != ruleTokenSymbol || (tok.text != "*" && tok.text != "/") {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if left.typ() != ruleTypeNumber || right.typ() != ruleTypeNumber {
+			return nil, fmt.Errorf("operands of %q must be numbers at %d", tok.text, tok.pos)
+		}
+		left = &ruleArithmeticNode{op: tok.text, left: left, right: right}
+	}
+}
+
+func (p *ruleParser) parseUnary() (ruleNode, error) {
+	tok := p.peek()
+	if p.accept(ruleTokenSymbol, "-") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if operand.typ() != ruleTypeNumber {
+			return nil, fmt.Errorf("operand of \"-\" must be a number at %d", tok.pos)
+		}
+		// 定数はそのまま畳み込む（指標の引数に負の値を渡せるように）
+		if number, ok := operand.(*ruleNumberNode); ok {
+			return &ruleNumberNode{value: -number.value}, nil
+		}
+		return &ruleArithmeticNode{op: "-", left: &ruleNumberNode{value: 0}, right: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *ruleParser) parsePrimary() (ruleNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case ruleTokenNumber:
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at %d", tok.text, tok.pos)
+		}
+		return &ruleNumberNode{value: value}, nil
+	case ruleTokenIdent:
+		switch tok.text {
+		case "true":
+			return &ruleBoolNode{value: true}, nil
+		case "false":
+			return &ruleBoolNode{value: false}, nil
+		case "and", "or", "not":
+			return nil, fmt.Errorf("unexpected %q at %d", tok.text, tok.pos)
+		}
+		var args []ruleNode
+		if p.accept(ruleTokenSymbol, "(") {
+			var err error
+			args, err = p.parseArgs()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return newRuleFuncNode(tok, args)
+	case ruleTokenSymbol:
+		if tok.text == "(" {
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return node, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at %d", tok.text, tok.pos)
+}
+
+// "("の直後から")"までを読む
+func (p *ruleParser) parseArgs() ([]ruleNode, error) {
+	args := make([]ruleNode, 0)
+	if p.accept(ruleTokenSymbol, ")") {
+		return args, nil
+	}
+	for {
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.accept(ruleTokenSymbol, ",") {
+			continue
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return args, nil
+	}
+}
+
+func newRuleFuncNode(tok ruleToken, args []ruleNode) (ruleNode, error) {
+	name := tok.text
+
+	if series, ok := ruleSeriesFuncs[name]; ok {
+		if len(args) != len(series.args) {
+			return nil, fmt.Errorf("%s() takes %d arguments but got %d at %d", name, len(series.args), len(args), tok.pos)
+		}
+		values := make([]float64, len(args))
+		for i, arg := range args {
+			number, ok := arg.(*ruleNumberNode)
+			if !ok {
+				return nil, fmt.Errorf("arguments of %s() must be constants at %d", name, tok.pos)
+			}
+			if number.value <= 0 {
+				return nil, fmt.Errorf("arguments of %s() must be more than 0 at %d", name, tok.pos)
+			}
+			if series.args[i] == ruleArgPeriod && number.value != math.Trunc(number.value) {
+				return nil, fmt.Errorf("periods of %s() must be integers at %d", name, tok.pos)
+			}
+			if series.args[i] == ruleArgPeriod && number.value < float64(series.minPeriod) {
+				return nil, fmt.Errorf("periods of %s() must be at least %d at %d", name, series.minPeriod, tok.pos)
+			}
+			values[i] = number.value
+		}
+		if series.check != nil {
+			if err := series.check(values); err != nil {
+				return nil, fmt.Errorf("%s() %s at %d", name, err.Error(), tok.pos)
+			}
+		}
+		return &ruleSeriesNode{name: name, args: values}, nil
+	}
+
+	for _, arg := range args {
+		if arg.typ() != ruleTypeNumber {
+			return nil, fmt.Errorf("arguments of %s() must be numbers at %d", name, tok.pos)
+		}
+	}
+
+	switch name {
+	case "crossover", "crossunder":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("%s() takes 2 arguments but got %d at %d", name, len(args), tok.pos)
+		}
+		return &ruleCrossNode{over: name == "crossover", left: args[0], right: args[1]}, nil
+	case "prev":
+		if len(args) != 1 && len(args) != 2 {
+			return nil, fmt.Errorf("prev() takes 1 or 2 arguments but got %d at %d", len(args), tok.pos)
+		}
+		n := 1
+		if len(args) == 2 {
+			number, ok := args[1].(*ruleNumberNode)
+			if !ok || number.value < 1 || number.value != math.Trunc(number.value) {
+				return nil, fmt.Errorf("second argument of prev() must be a positive integer at %d", tok.pos)
+			}
+			n = int(number.value)
+		}
+		return &rulePrevNode{operand: args[0], n: n}, nil
+	case "abs":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("abs() takes 1 argument but got %d at %d", len(args), tok.pos)
+		}
+		return &ruleMathNode{name: name, args: args}, nil
+	case "min", "max":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("%s() takes 2 arguments but got %d at %d", name, len(args), tok.pos)
+		}
+		return &ruleMathNode{name: name, args: args}, nil
+	}
+
+	return nil, fmt.Errorf("unknown function %q at %d", name, tok.pos)
+}
+
+// 構文木
+
+type ruleType int
+
+const (
+	ruleTypeNumber ruleType = iota
+	ruleTypeBool
+)
+
+// 真偽値は1/0で表す
+// 値が決まらない場合は第2戻り値がfalse
+type ruleNode interface {
+	typ() ruleType
+	eval(ctx *RuleContext, at int) (float64, bool)
+}
+
+func ruleBool(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+type ruleNumberNode struct {
+	value float64
+}
+
+func (n *ruleNumberNode) typ() ruleType {
+	return ruleTypeNumber
+}
+
+func (n *ruleNumberNode) eval(ctx *RuleContext, at int) (float64, bool) {
+	return n.value, true
+}
+
+type ruleBoolNode struct {
+	value bool
+}
+
+func (n *ruleBoolNode) typ() ruleType {
+	return ruleTypeBool
+}
+
+func (n *ruleBoolNode) eval(ctx *RuleContext, at int) (float64, bool) {
+	return ruleBool(n.value), true
+}
+
+// 片方だけで結果が決まる場合は，もう片方の値が決まらなくてもよい
+type ruleLogicalNode struct {
+	op    string
+	left  ruleNode
+	right ruleNode
+}
+
+func (n *ruleLogicalNode) typ() ruleType {
+	return ruleTypeBool
+}
+
+func (n *ruleLogicalNode) eval(ctx *RuleContext, at int) (float64, bool) {
+	left, leftOk := n.left.eval(ctx, at)
+	right, rightOk := n.right.eval(ctx, at)
+
+	if n.op == "and" {
+		if (leftOk && left == 0) || (rightOk && right == 0) {
+			return 0, true
+		}
+		return 1, leftOk && rightOk
+	}
+
+	if (leftOk && left != 0) || (rightOk && right != 0) {
+		return 1, true
+	}
+	return 0, leftOk && rightOk
+}
+
+type ruleNotNode struct {
+	operand ruleNode
+}
+
+func (n *ruleNotNode) typ() ruleType {
+	return ruleTypeBool
+}
+
+func (n *ruleNotNode) eval(ctx *RuleContext, at int) (float64, bool) {
+	value, ok := n.operand.eval(ctx, at)
+	return ruleBool(value == 0), ok
+}
+
+type ruleComparisonNode struct {
+	op    string
+	left  ruleNode
+	right ruleNode
+}
+
+func (n *ruleComparisonNode) typ() ruleType {
+	return ruleTypeBool
+}
+
+func (n *ruleComparisonNode) eval(ctx *RuleContext, at int) (float64, bool) {
+	left, ok := n.left.eval(ctx, at)
+	if !ok {
+		return 0, false
+	}
+	right, ok := n.right.eval(ctx, at)
+	if !ok {
+		return 0, false
+	}
+
+	switch n.op {
+	case "<":
+		return ruleBool(left < right), true
+	case "<=":
+		return ruleBool(left <= right), true
+	case ">":
+		return ruleBool(left > right), true
+	case ">=":
+		return ruleBool(left >= right), true
+	case "==":
+		return ruleBool(left == right), true
+	case "!=":
+		return ruleBool(left != right), true
+	}
+	return 0, false
+}
+
+type ruleArithmeticNode struct {
+	op    string
+	left  ruleNode
+	right ruleNode
+}
+
+func (n *ruleArithmeticNode) typ() ruleType {
+	return ruleTypeNumber
+}
+
+func (n *ruleArithmeticNode) eval(ctx *RuleContext, at int) (float64, bool) {
+	left, ok := n.left.eval(ctx, at)
+	if !ok {
+		return 0, false
+	}
+	right, ok := n.right.eval(ctx, at)
+	if !ok {
+		return 0, false
+	}
+
+	switch n.op {
+	case "+":
+		return left + right, true
+	case "-":
+		return left - right, true
+	case "*":
+		return left * right, true
+	case "/":
+		if right == 0 {
+			return 0, false
+		}
+		return left / right, true
+	}
+	return 0, false
+}
+
+type ruleMathNode struct {
+	name string
+	args []ruleNode
+}
+
+func (n *ruleMathNode) typ() ruleType {
+	return ruleTypeNumber
+}
+
+func (n *ruleMathNode) eval(ctx *RuleContext, at int) (float64, bool) {
+	values := make([]float64, len(n.args))
+	for i, arg := range n.args {
+		value, ok := arg.eval(ctx, at)
+		if !ok {
+			return 0, false
+		}
+		values[i] = value
+	}
+
+	switch n.name {
+	case "abs":
+		return math.Abs(values[0]), true
+	case "min":
+		return math.Min(values[0], values[1]), true
+	case "max":
+		return math.Max(values[0], values[1]), true
+	}
+	return 0, false
+}
+
+// 1本前にleftがright以下で，現在はleftがrightを上回っている（crossover）
+// またはその逆（crossunder）
+type ruleCrossNode struct {
+	over  bool
+	left  ruleNode
+	right ruleNode
+}
+
+func (n *ruleCrossNode) typ() ruleType {
+	return ruleTypeBool
+}
+
+func (n *ruleCrossNode) eval(ctx *RuleContext, at int) (float64, bool) {
+	if at < 1 {
+		return 0, false
+	}
+
+	values := make([]float64, 4)
+	for i, v := range []struct {
+		node ruleNode
+		at   int
+	}{
+		{n.left, at - 1},
+		{n.right, at - 1},
+		{n.left, at},
+		{n.right, at},
+	} {
+		value, ok := v.node.eval(ctx, v.at)
+		if !ok {
+			return 0, false
+		}
+		values[i] = value
+	}
+	prevLeft, prevRight, left, right := values[0], values[1], values[2], values[3]
+
+	if n.over {
+		return ruleBool(prevLeft <= prevRight && left > right), true
+	}
+	return ruleBool(prevLeft >= prevRight && left < right), true
+}
+
+// n本前の値
+type rulePrevNode struct {
+	operand ruleNode
+	n       int
+}
+
+func (n *rulePrevNode) typ() ruleType {
+	return ruleTypeNumber
+}
+
+func (n *rulePrevNode) eval(ctx *RuleContext, at int) (float64, bool) {
+	if at < n.n {
+		return 0, false
+	}
+	return n.operand.eval(ctx, at-n.n)
+}
+
+type ruleSeriesNode struct {
+	name string
+	args []float64
+}
+
+func (n *ruleSeriesNode) typ() ruleType {
+	return ruleTypeNumber
+}
+
+func (n *ruleSeriesNode) eval(ctx *RuleContext, at int) (float64, bool) {
+	series := ctx.seriesValue(n.name, n.args)
+	if series == nil {
+		return 0, false
+	}
+	if at < series.lookback || at >= len(series.values) {
+		return 0, false
+	}
+	return series.values[at], true
+}
+
+// 指標
+
+type ruleArgKind int
+
+const (
+	ruleArgPeriod ruleArgKind = iota
+	ruleArgReal
+)
+
+// 系列と，値が決まる最初のインデックスを返す
+// minPeriodはTA-Libが受け付ける期間の下限（0なら1）で，checkは引数どうしの関係を確かめる
+type ruleSeriesFunc struct {
+	args      []ruleArgKind
+	minPeriod int
+	check     func(args []float64) error
+	build     func(df *DataFrame, args []float64) ([]float64, int)
+}
+
+var ruleSeriesFuncs = map[string]ruleSeriesFunc{
+	"open": {
+		args:  []ruleArgKind{},
+		build: func(df *DataFrame, args []float64) ([]float64, int) { return df.Opens(), 0 },
+	},
+	"close": {
+		args:  []ruleArgKind{},
+		build: func(df *DataFrame, args []float64) ([]float64, int) { return df.Closes(), 0 },
+	},
+	"high": {
+		args:  []ruleArgKind{},
+		build: func(df *DataFrame, args []float64) ([]float64, int) { return df.Highs(), 0 },
+	},
+	"low": {
+		args:  []ruleArgKind{},
+		build: func(df *DataFrame, args []float64) ([]float64, int) { return df.Lows(), 0 },
+	},
+	"volume": {
+		args:  []ruleArgKind{},
+		build: func(df *DataFrame, args []float64) ([]float64, int) { return df.Volumes(), 0 },
+	},
+	"sma": {
+		args:      []ruleArgKind{ruleArgPeriod},
+		minPeriod: 2,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			period := int(args[0])
+			sma := NewSMA(df.Closes(), period)
+			if sma == nil {
+				return nil, 0
+			}
+			return sma.Values(), period - 1
+		},
+	},
+	"ema": {
+		args:      []ruleArgKind{ruleArgPeriod},
+		minPeriod: 2,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			period := int(args[0])
+			ema := NewEMA(df.Closes(), period)
+			if ema == nil {
+				return nil, 0
+			}
+			return ema.Values(), period - 1
+		},
+	},
+	"rsi": {
+		args:      []ruleArgKind{ruleArgPeriod},
+		minPeriod: 2,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			period := int(args[0])
+			rsi := NewRSI(df.Closes(), period)
+			if rsi == nil {
+				return nil, 0
+			}
+			return rsi.Values(), period
+		},
+	},
+	"macd": {
+		args:      []ruleArgKind{ruleArgPeriod, ruleArgPeriod, ruleArgPeriod},
+		minPeriod: 2,
+		check:     checkRuleMACD,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			macd, lookback := ruleMACD(df, args)
+			if macd == nil {
+				return nil, 0
+			}
+			return macd.Macd(), lookback
+		},
+	},
+	"macd_signal": {
+		args:      []ruleArgKind{ruleArgPeriod, ruleArgPeriod, ruleArgPeriod},
+		minPeriod: 2,
+		check:     checkRuleMACD,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			macd, lookback := ruleMACD(df, args)
+			if macd == nil {
+				return nil, 0
+			}
+			return macd.MacdSignal(), lookback
+		},
+	},
+	"macd_hist": {
+		args:      []ruleArgKind{ruleArgPeriod, ruleArgPeriod, ruleArgPeriod},
+		minPeriod: 2,
+		check:     checkRuleMACD,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			macd, lookback := ruleMACD(df, args)
+			if macd == nil {
+				return nil, 0
+			}
+			return macd.MacdHist(), lookback
+		},
+	},
+	"bb_upper": {
+		args:      []ruleArgKind{ruleArgPeriod, ruleArgReal},
+		minPeriod: 2,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			bbands := NewBBands(df.Closes(), int(args[0]), args[1])
+			if bbands == nil {
+				return nil, 0
+			}
+			return bbands.Up(), int(args[0]) - 1
+		},
+	},
+	"bb_middle": {
+		args:      []ruleArgKind{ruleArgPeriod, ruleArgReal},
+		minPeriod: 2,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			bbands := NewBBands(df.Closes(), int(args[0]), args[1])
+			if bbands == nil {
+				return nil, 0
+			}
+			return bbands.Mid(), int(args[0]) - 1
+		},
+	},
+	"bb_lower": {
+		args:      []ruleArgKind{ruleArgPeriod, ruleArgReal},
+		minPeriod: 2,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			bbands := NewBBands(df.Closes(), int(args[0]), args[1])
+			if bbands == nil {
+				return nil, 0
+			}
+			return bbands.Down(), int(args[0]) - 1
+		},
+	},
+	"atr": {
+		args: []ruleArgKind{ruleArgPeriod},
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			period := int(args[0])
+			atr := NewATR(df.Highs(), df.Lows(), df.Closes(), period)
+			if atr == nil {
+				return nil, 0
+			}
+			return atr.Values(), period
+		},
+	},
+	"adx": {
+		args:      []ruleArgKind{ruleArgPeriod},
+		minPeriod: 2,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			period := int(args[0])
+			adx := NewADX(df.Highs(), df.Lows(), df.Closes(), period)
+			if adx == nil {
+				return nil, 0
+			}
+			return adx.ADX(), 2*period - 1
+		},
+	},
+	"plus_di": {
+		args:      []ruleArgKind{ruleArgPeriod},
+		minPeriod: 2,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			period := int(args[0])
+			adx := NewADX(df.Highs(), df.Lows(), df.Closes(), period)
+			if adx == nil {
+				return nil, 0
+			}
+			return adx.PlusDI(), period
+		},
+	},
+	"minus_di": {
+		args:      []ruleArgKind{ruleArgPeriod},
+		minPeriod: 2,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			period := int(args[0])
+			adx := NewADX(df.Highs(), df.Lows(), df.Closes(), period)
+			if adx == nil {
+				return nil, 0
+			}
+			return adx.MinusDI(), period
+		},
+	},
+	"stoch_k": {
+		args: []ruleArgKind{ruleArgPeriod, ruleArgPeriod, ruleArgPeriod},
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			stoch := NewStochastic(df.Highs(), df.Lows(), df.Closes(), int(args[0]), int(args[1]), int(args[2]))
+			if stoch == nil {
+				return nil, 0
+			}
+			return stoch.SlowK(), stoch.Lookback()
+		},
+	},
+	"stoch_d": {
+		args: []ruleArgKind{ruleArgPeriod, ruleArgPeriod, ruleArgPeriod},
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			stoch := NewStochastic(df.Highs(), df.Lows(), df.Closes(), int(args[0]), int(args[1]), int(args[2]))
+			if stoch == nil {
+				return nil, 0
+			}
+			return stoch.SlowD(), stoch.Lookback()
+		},
+	},
+	"obv": {
+		args: []ruleArgKind{},
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			obv := NewOBV(df.Closes(), df.Volumes(), 1)
+			if obv == nil {
+				return nil, 0
+			}
+			return obv.Values(), 0
+		},
+	},
+	"obv_signal": {
+		args:      []ruleArgKind{ruleArgPeriod},
+		minPeriod: 2,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			period := int(args[0])
+			obv := NewOBV(df.Closes(), df.Volumes(), period)
+			if obv == nil {
+				return nil, 0
+			}
+			return obv.Signal(), period - 1
+		},
+	},
+	"vwap": {
+		args: []ruleArgKind{ruleArgPeriod},
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			period := int(args[0])
+			vwap := NewVWAP(df.Highs(), df.Lows(), df.Closes(), df.Volumes(), period)
+			if vwap == nil {
+				return nil, 0
+			}
+			return vwap.Values(), period - 1
+		},
+	},
+	"sar": {
+		args: []ruleArgKind{ruleArgReal, ruleArgReal},
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			sar := NewParabolicSAR(df.Highs(), df.Lows(), args[0], args[1])
+			if sar == nil {
+				return nil, 0
+			}
+			return sar.Values(), 1
+		},
+	},
+	"donchian_upper": {
+		args:      []ruleArgKind{ruleArgPeriod},
+		minPeriod: 2,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			period := int(args[0])
+			donchian := NewDonchianChannel(df.Highs(), df.Lows(), period)
+			if donchian == nil {
+				return nil, 0
+			}
+			return donchian.Up(), period - 1
+		},
+	},
+	"donchian_lower": {
+		args:      []ruleArgKind{ruleArgPeriod},
+		minPeriod: 2,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			period := int(args[0])
+			donchian := NewDonchianChannel(df.Highs(), df.Lows(), period)
+			if donchian == nil {
+				return nil, 0
+			}
+			return donchian.Down(), period - 1
+		},
+	},
+	"keltner_upper": {
+		args:      []ruleArgKind{ruleArgPeriod, ruleArgReal},
+		minPeriod: 2,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			period := int(args[0])
+			keltner := NewKeltnerChannel(df.Highs(), df.Lows(), df.Closes(), period, args[1])
+			if keltner == nil {
+				return nil, 0
+			}
+			return keltner.Up(), period
+		},
+	},
+	"keltner_lower": {
+		args:      []ruleArgKind{ruleArgPeriod, ruleArgReal},
+		minPeriod: 2,
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			period := int(args[0])
+			keltner := NewKeltnerChannel(df.Highs(), df.Lows(), df.Closes(), period, args[1])
+			if keltner == nil {
+				return nil, 0
+			}
+			return keltner.Down(), period
+		},
+	},
+	"ha_open": {
+		args: []ruleArgKind{},
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			averageCandle := NewAverageCandle(df.Candles())
+			if averageCandle == nil {
+				return nil, 0
+			}
+			return averageCandle.Opens(), 0
+		},
+	},
+	"ha_close": {
+		args: []ruleArgKind{},
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			averageCandle := NewAverageCandle(df.Candles())
+			if averageCandle == nil {
+				return nil, 0
+			}
+			return averageCandle.Closes(), 0
+		},
+	},
+	"ha_high": {
+		args: []ruleArgKind{},
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			averageCandle := NewAverageCandle(df.Candles())
+			if averageCandle == nil {
+				return nil, 0
+			}
+			return averageCandle.Highs(), 0
+		},
+	},
+	"ha_low": {
+		args: []ruleArgKind{},
+		build: func(df *DataFrame, args []float64) ([]float64, int) {
+			averageCandle := NewAverageCandle(df.Candles())
+			if averageCandle == nil {
+				return nil, 0
+			}
+			return averageCandle.Lows(), 0
+		},
+	},
+}
+
+// 短期の期間は長期より短くする
+func checkRuleMACD(args []float64) error {
+	if args[0] >= args[1] {
+		return errors.New("fast period must be less than slow period")
+	}
+	return nil
+}
+
+func ruleMACD(df *DataFrame, args []float64) (*MACD, int) {
+	fastPeriod, slowPeriod, signalPeriod := int(args[0]), int(args[1]), int(args[2])
+	macd := NewMACD(df.Closes(), fastPeriod, slowPeriod, signalPeriod)
+	if macd == nil {
+		return nil, 0
+	}
+	return macd, slowPeriod + signalPeriod - 2
+}
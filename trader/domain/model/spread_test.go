@@ -0,0 +1,38 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+)
+
+func TestSpread(t *testing.T) {
+	timeTime := time.Date(2021, 11, 9, 0, 0, 0, 0, time.UTC)
+
+	if model.NewSpread(timeTime, "ETH_JPY", "bitflyer", "bitflyer", 500000, 500000) != nil {
+		t.Fatal("NewSpread() with the same exchanges returns not nil")
+	}
+	if model.NewSpread(timeTime, "ETH_JPY", "bitflyer", "coincheck", 0, 500000) != nil {
+		t.Fatal("NewSpread() with zero price returns not nil")
+	}
+
+	cases := []struct {
+		price   float64
+		rate    float64
+		exceeds bool
+	}{
+		{price: 510000, rate: 0.02, exceeds: true},
+		{price: 495000, rate: -0.01, exceeds: true},
+		{price: 502500, rate: 0.005, exceeds: false},
+	}
+	for _, c := range cases {
+		spread := model.NewSpread(timeTime, "ETH_JPY", "bitflyer", "coincheck", 500000, c.price)
+		if spread.Rate() != c.rate {
+			t.Fatalf("price=%f: rate=%f, want %f", c.price, spread.Rate(), c.rate)
+		}
+		if spread.Exceeds(0.01) != c.exceeds {
+			t.Fatalf("price=%f: Exceeds(0.01)=%v", c.price, spread.Exceeds(0.01))
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package model
+
+// 銘柄ごとの売買ルール
+type StrategyRule struct {
+	productCode string
+	buyRule     *Rule
+	sellRule    *Rule
+}
+
+func NewStrategyRule(productCode, buyRule, sellRule string) *StrategyRule {
+	if productCode == "" {
+		return nil
+	}
+
+	buy, err := ParseRule(buyRule)
+	if err != nil {
+		return nil
+	}
+	sell, err := ParseRule(sellRule)
+	if err != nil {
+		return nil
+	}
+
+	return &StrategyRule{
+		productCode: productCode,
+		buyRule:     buy,
+		sellRule:    sell,
+	}
+}
+
+func (sr *StrategyRule) ProductCode() string {
+	return sr.productCode
+}
+
+func (sr *StrategyRule) BuyRule() *Rule {
+	return sr.buyRule
+}
+
+func (sr *StrategyRule) SellRule() *Rule {
+	return sr.sellRule
+}
+
+// 時点"at"での買いサインと売りサイン
+func (sr *StrategyRule) Analyze(ctx *RuleContext, at int) (bool, bool) {
+	return sr.buyRule.Evaluate(ctx, at), sr.sellRule.Evaluate(ctx, at)
+}
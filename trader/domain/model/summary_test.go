@@ -0,0 +1,56 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+)
+
+func TestSummary(t *testing.T) {
+	timeTime := time.Date(2021, 11, 9, 0, 0, 0, 0, time.UTC)
+	since := timeTime.Add(-model.SummaryPeriodDaily.Duration())
+
+	trades := []model.SignalEvent{
+		*model.NewSignalEvent(since.Add(time.Hour), "ETH_JPY", model.OrderSideBuy, 500000, 0.01),
+	}
+	portfolio := model.NewPortfolio("ETH_JPY", trades, 510000, 0, 100000)
+	indicators := []model.IndicatorState{
+		*model.NewIndicatorState("RSI", model.OrderSideBuy),
+		*model.NewIndicatorState("MACD", ""),
+	}
+
+	if model.NewSummary(timeTime, "monthly", since, *portfolio, trades, indicators, nil, nil) != nil {
+		t.Fatal("NewSummary() with unknown period returns not nil")
+	}
+	if model.NewSummary(since, model.SummaryPeriodDaily, timeTime, *portfolio, trades, indicators, nil, nil) != nil {
+		t.Fatal("NewSummary() since the future returns not nil")
+	}
+	if model.NewIndicatorState("RSI", "HOLD") != nil {
+		t.Fatal("NewIndicatorState() with unknown side returns not nil")
+	}
+
+	summary := model.NewSummary(timeTime, model.SummaryPeriodDaily, since, *portfolio, trades, indicators, []string{"RSI: 14, 30.0, 70.0"}, []string{"RSI: 12, 30.0, 70.0 → RSI: 14, 30.0, 70.0"})
+	if summary == nil {
+		t.Fatal("NewSummary() returns nil")
+	}
+	if !summary.ParamsChanged() || summary.Title() != "日次レポート" {
+		t.Fatalf("summary=%+v", summary)
+	}
+
+	report := summary.Report()
+	if report.ProductCode() != "ETH_JPY" || report.Period() != model.SummaryPeriodDaily || len(report.ParamsLines()) != 1 {
+		t.Fatalf("report=%+v", report)
+	}
+
+	notification := model.NewSummaryNotification(*summary)
+	if notification.Level() != model.NotificationLevelInfo || notification.ProductCode() != "ETH_JPY" {
+		t.Fatalf("notification=%+v", notification)
+	}
+	for _, want := range []string{"Trades: 1", "RSI: BUY", "MACD: -", "Params changed:", "PnL: +100"} {
+		if !strings.Contains(notification.Message(), want) {
+			t.Fatalf("message does not contain %q: %s", want, notification.Message())
+		}
+	}
+}
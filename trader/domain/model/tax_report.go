@@ -0,0 +1,223 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// 暗号資産の取得価額の計算方法
+type CostMethod string
+
+const (
+	CostMethodMovingAverage = CostMethod("moving_average") // 移動平均法
+	CostMethodTotalAverage  = CostMethod("total_average")  // 総平均法
+)
+
+func (m CostMethod) IsValid() bool {
+	return m == CostMethodMovingAverage || m == CostMethodTotalAverage
+}
+
+// 1年分（1月1日〜12月31日）の売買損益
+type TaxReport struct {
+	year          int
+	productCode   string
+	method        CostMethod
+	buySize       float64 // 購入数量
+	buyAmount     float64 // 購入額（購入時の手数料込み）
+	sellSize      float64 // 売却数量
+	sellAmount    float64 // 売却額
+	cost          float64 // 売却原価
+	commission    float64 // 支払った手数料の合計
+	gain          float64 // 所得金額 = 売却額 - 売却原価 - 売却時の手数料
+	endPosition   float64 // 年末の保有数量
+	endCostAmount float64 // 年末の保有分の取得価額
+}
+
+func (r *TaxReport) Year() int {
+	return r.year
+}
+
+func (r *TaxReport) ProductCode() string {
+	return r.productCode
+}
+
+func (r *TaxReport) Method() CostMethod {
+	return r.method
+}
+
+func (r *TaxReport) BuySize() float64 {
+	return r.buySize
+}
+
+func (r *TaxReport) BuyAmount() float64 {
+	return r.buyAmount
+}
+
+func (r *TaxReport) SellSize() float64 {
+	return r.sellSize
+}
+
+func (r *TaxReport) SellAmount() float64 {
+	return r.sellAmount
+}
+
+func (r *TaxReport) Cost() float64 {
+	return r.cost
+}
+
+func (r *TaxReport) Commission() float64 {
+	return r.commission
+}
+
+func (r *TaxReport) Gain() float64 {
+	return r.gain
+}
+
+func (r *TaxReport) EndPosition() float64 {
+	return r.endPosition
+}
+
+func (r *TaxReport) EndCostAmount() float64 {
+	return r.endCostAmount
+}
+
+// 取引履歴から年ごとの売買損益を計算する
+// 年の区切りはlocationで判定する（日本の確定申告ならAsia/Tokyo）
+// 保有数量を超える売却は，超えた分を取引履歴にない取得とみなして計算に含めない
+// 手数料は約定代金にcommissionRateを掛けた額をJPYで支払ったものとみなし，
+// 購入時の手数料は取得価額に含め，売却時の手数料は所得金額から差し引く
+func NewTaxReports(productCode string, signals []SignalEvent, commissionRate float64, method CostMethod, location *time.Location) []TaxReport {
+	if productCode == "" {
+		return nil
+	}
+
+	if commissionRate < 0 || 1 < commissionRate {
+		return nil
+	}
+
+	if !method.IsValid() {
+		return nil
+	}
+
+	if location == nil {
+		return nil
+	}
+
+	// 年ごとに取引を分ける
+	signalsByYear := make(map[int][]SignalEvent)
+	years := make([]int, 0)
+	for _, signal := range signals {
+		if signal.ProductCode() != productCode {
+			continue
+		}
+		year := signal.Time().In(location).Year()
+		if _, ok := signalsByYear[year]; !ok {
+			years = append(years, year)
+		}
+		signalsByYear[year] = append(signalsByYear[year], signal)
+	}
+	sort.Ints(years)
+
+	reports := make([]TaxReport, 0)
+	// 前年から繰り越した保有数量と取得価額
+	var position, costAmount float64
+	for _, year := range years {
+		report := TaxReport{
+			year:        year,
+			productCode: productCode,
+			method:      method,
+		}
+
+		switch method {
+		case CostMethodMovingAverage:
+			position, costAmount = report.applyMovingAverage(signalsByYear[year], commissionRate, position, costAmount)
+		case CostMethodTotalAverage:
+			position, costAmount = report.applyTotalAverage(signalsByYear[year], commissionRate, position, costAmount)
+		}
+
+		report.endPosition = position
+		report.endCostAmount = costAmount
+		reports = append(reports, report)
+	}
+
+	return reports
+}
+
+// 移動平均法: 購入の都度，平均取得単価を更新する
+func (r *TaxReport) applyMovingAverage(signals []SignalEvent, commissionRate, position, costAmount float64) (float64, float64) {
+	for _, signal := range signals {
+		switch signal.Side() {
+		case OrderSideBuy:
+			amount := signal.Price() * signal.Size()
+			fee := amount * commissionRate
+			r.commission += fee
+			r.buySize += signal.Size()
+			r.buyAmount += amount + fee
+			position += signal.Size()
+			costAmount += amount + fee
+		case OrderSideSell:
+			size := signal.Size()
+			if size > position {
+				size = position
+			}
+			amount := signal.Price() * size
+			fee := amount * commissionRate
+			r.commission += fee
+			var averagePrice float64
+			if position > 0 {
+				averagePrice = costAmount / position
+			}
+			r.sellSize += size
+			r.sellAmount += amount
+			r.cost += averagePrice * size
+			r.gain += amount - averagePrice*size - fee
+			position -= size
+			costAmount -= averagePrice * size
+		}
+	}
+
+	return position, costAmount
+}
+
+// 総平均法: (年初の取得価額 + 年中の購入額) / (年初の数量 + 年中の購入数量) を年間の平均取得単価とする
+func (r *TaxReport) applyTotalAverage(signals []SignalEvent, commissionRate, position, costAmount float64) (float64, float64) {
+	var sellFee float64
+	for _, signal := range signals {
+		amount := signal.Price() * signal.Size()
+		fee := amount * commissionRate
+		r.commission += fee
+
+		switch signal.Side() {
+		case OrderSideBuy:
+			r.buySize += signal.Size()
+			r.buyAmount += amount + fee
+		case OrderSideSell:
+			r.sellSize += signal.Size()
+			r.sellAmount += amount
+			sellFee += fee
+		}
+	}
+
+	totalSize := position + r.buySize
+	totalAmount := costAmount + r.buyAmount
+	var averagePrice float64
+	if totalSize > 0 {
+		averagePrice = totalAmount / totalSize
+	}
+
+	// 売却額と手数料は，保有数量までの割合で減らす
+	if r.sellSize > totalSize {
+		rate := totalSize / r.sellSize
+		r.commission -= sellFee * (1 - rate)
+		r.sellAmount *= rate
+		sellFee *= rate
+		r.sellSize = totalSize
+	}
+	r.cost = averagePrice * r.sellSize
+	r.gain = r.sellAmount - r.cost - sellFee
+
+	position = totalSize - r.sellSize
+	costAmount = averagePrice * position
+
+	return position, costAmount
+}
@@ -121,3 +121,7 @@ func (t *Ticker) BestBid() float64 {
 func (t *Ticker) Volume() float64 {
 	return t.volume
 }
+
+func (t *Ticker) Ltp() float64 {
+	return t.ltp
+}
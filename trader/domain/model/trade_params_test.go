@@ -24,6 +24,9 @@ func TestTradeParams(t *testing.T) {
 		20,
 		2,
 		true,
+		9,
+		26,
+		52,
 		true,
 		14,
 		30,
@@ -32,7 +35,38 @@ func TestTradeParams(t *testing.T) {
 		12,
 		26,
 		9,
+		true,
+		14,
+		2,
+		true,
+		14,
+		3,
+		3,
+		20,
+		80,
+		true,
+		14,
+		25,
+		true,
+		20,
+		true,
+		20,
+		true,
+		0.02,
+		0.2,
+		true,
+		20,
+		true,
+		20,
+		2,
+		true,
+		3,
+		*model.NewSignalWeights(1, 1, 1, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1),
+		2.5,
+		2.5,
 		0.75,
+		3,
+		model.LotMatchingAverage,
 	)
 	if params == nil {
 		t.Fatal("NewTradeParams() returns nil")
@@ -68,5 +102,79 @@ func TestTradeParams(t *testing.T) {
 		if params.MACDEnable() {
 			t.Fatal("EnableMACD(false) should disable macd")
 		}
+
+		params.EnableATR(false)
+		if params.ATREnable() {
+			t.Fatal("EnableATR(false) should disable atr")
+		}
+
+		params.EnableStoch(false)
+		if params.StochEnable() {
+			t.Fatal("EnableStoch(false) should disable stochastic")
+		}
+
+		params.EnableADX(false)
+		if params.ADXEnable() {
+			t.Fatal("EnableADX(false) should disable adx")
+		}
+
+		params.EnableOBV(false)
+		if params.OBVEnable() {
+			t.Fatal("EnableOBV(false) should disable obv")
+		}
+
+		params.EnableVWAP(false)
+		if params.VWAPEnable() {
+			t.Fatal("EnableVWAP(false) should disable vwap")
+		}
+
+		params.EnableSAR(false)
+		if params.SAREnable() {
+			t.Fatal("EnableSAR(false) should disable parabolic_sar")
+		}
+
+		params.EnableDonchian(false)
+		if params.DonchianEnable() {
+			t.Fatal("EnableDonchian(false) should disable donchian_channel")
+		}
+
+		params.EnableKeltner(false)
+		if params.KeltnerEnable() {
+			t.Fatal("EnableKeltner(false) should disable keltner_channel")
+		}
+
+		params.EnableHeikinAshi(false)
+		if params.HeikinAshiEnable() {
+			t.Fatal("EnableHeikinAshi(false) should disable heikin_ashi")
+		}
+	})
+
+	t.Run("set signal weights", func(t *testing.T) {
+		weights := model.NewUniformSignalWeights()
+		params.SetSignalWeights(*weights)
+		if params.SignalWeights() != *weights {
+			t.Fatal("SetSignalWeights() should set weights")
+		}
+	})
+
+	t.Run("lots", func(t *testing.T) {
+		if params.MaxLots() != 3 || params.LotMatching() != model.LotMatchingAverage {
+			t.Fatalf("maxLots=%d, lotMatching=%s", params.MaxLots(), params.LotMatching())
+		}
+
+		basic := model.NewBasicTradeParams(config.ProductCode, 0.01)
+		if basic.MaxLots() != 1 || basic.LotMatching() != model.LotMatchingFIFO {
+			t.Fatalf("maxLots=%d, lotMatching=%s", basic.MaxLots(), basic.LotMatching())
+		}
+	})
+
+	t.Run("with trade enable", func(t *testing.T) {
+		paused := params.WithTradeEnable(false)
+		if paused.TradeEnable() || !params.TradeEnable() {
+			t.Fatalf("paused=%t, params=%t", paused.TradeEnable(), params.TradeEnable())
+		}
+		if paused.Size() != params.Size() || paused.EMAPeriod1() != params.EMAPeriod1() {
+			t.Fatal("WithTradeEnable() should keep other params")
+		}
 	})
 }
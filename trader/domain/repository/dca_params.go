@@ -0,0 +1,8 @@
+package repository
+
+import "github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+
+type DCAParamsRepository interface {
+	Save(params model.DCAParams) error
+	Find(productCode string) (*model.DCAParams, error)
+}
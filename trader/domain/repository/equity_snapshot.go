@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+)
+
+type EquitySnapshotRepository interface {
+	Save(snapshot model.EquitySnapshot) error
+	FindAll(productCode string, limit int64) ([]model.EquitySnapshot, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+// 取引所のAPIをまとめたもの（取引所ごとに実装し，設定で切り替える）
+// 銘柄コードと通貨コードはbitFlyerの形式（ETH_JPY，JPY）で受け渡し，取引所の形式への変換は実装の中で行う
+type ExchangeRepository interface {
+	// 取引所の名前（bitflyer，coincheck）
+	Name() string
+	Ticker() TickerRepository
+	Balance() BalanceRepository
+	Order() OrderRepository
+	Execution() ExecutionRepository
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+)
+
+type ExecutionRepository interface {
+	// before, afterは約定IDで，0なら指定しない
+	// 新しい順にcount件まで返す
+	FetchAll(productCode string, count int, before, after int64) ([]model.Execution, error)
+}
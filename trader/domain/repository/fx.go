@@ -0,0 +1,9 @@
+package repository
+
+import "github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+
+// 証拠金取引の建玉と証拠金
+type FXRepository interface {
+	FetchPositions(productCode string) ([]model.FXPosition, error)
+	FetchCollateral() (*model.Collateral, error)
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+
+type GridLevelRepository interface {
+	Save(level model.GridLevel) error
+	// 段の番号順に返す
+	FindAll(productCode string) ([]model.GridLevel, error)
+	DeleteAll(productCode string) error
+}
@@ -7,4 +7,8 @@ import (
 type NotificationRepository interface {
 	NotifyOfTradingSuccess(event model.SignalEvent) error
 	NotifyOfTradingFailure(productCode string, err error) error
+	NotifyOfSpread(spread model.Spread) error
+	NotifyOfSummary(summary model.Summary) error
+	// 重要度に応じた通知先に送る
+	Notify(notification model.Notification) error
 }
@@ -3,5 +3,12 @@ package repository
 import "github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
 
 type OrderRepository interface {
+	// 注文を出して，約定するまで待つ
 	Send(order model.Order) (*model.Order, error)
+	// 注文を出して，約定を待たずにchild_order_acceptance_idを返す
+	Place(order model.Order) (string, error)
+	// 注文の状況を取得する（注文が見つからなければnil）
+	Find(productCode, acceptanceID string) (*model.Order, error)
+	// 約定していない注文を取り消す
+	Cancel(productCode, acceptanceID string) error
 }
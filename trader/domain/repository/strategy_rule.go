@@ -0,0 +1,8 @@
+package repository
+
+import "github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+
+type StrategyRuleRepository interface {
+	Save(sr model.StrategyRule) error
+	Find(productCode string) (*model.StrategyRule, error)
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+)
+
+type SummaryReportRepository interface {
+	Save(report model.SummaryReport) error
+	// 最後に送ったまとめ．なければnilを返す
+	FindLatest(productCode string, period model.SummaryPeriod) (*model.SummaryReport, error)
+}
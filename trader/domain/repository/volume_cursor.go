@@ -0,0 +1,10 @@
+package repository
+
+// 出来高をどの約定まで数えたか
+type VolumeCursorRepository interface {
+	// 数え終わった最後の約定ID．まだなければ0を返す
+	Find(productCode string) (int64, error)
+	// 約定IDがbeforeのままならafterに進めてtrueを返す
+	// 別のインスタンスが先に進めていれば何もせずfalseを返す
+	Advance(productCode string, before, after int64) (bool, error)
+}
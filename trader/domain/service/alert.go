@@ -0,0 +1,124 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type AlertService interface {
+	// 時刻timeTimeの価格priceで有効なアラートを評価し，通知するものを返す
+	// 指標のアラートは，過去pastPeriod本の足から計算する
+	// 一部のルールで失敗しても，評価できたアラートは返す
+	Evaluate(productCode string, price float64, timeTime time.Time, pastPeriod int) ([]model.Alert, error)
+}
+
+type alertService struct {
+	alertRuleRepository  repository.AlertRuleRepository
+	pricePointRepository repository.PricePointRepository
+	candleService        CandleService
+}
+
+func NewAlertService(ar repository.AlertRuleRepository, pr repository.PricePointRepository, cs CandleService) AlertService {
+	return &alertService{
+		alertRuleRepository:  ar,
+		pricePointRepository: pr,
+		candleService:        cs,
+	}
+}
+
+func (as *alertService) Evaluate(productCode string, price float64, timeTime time.Time, pastPeriod int) ([]model.Alert, error) {
+	point := model.NewPricePoint(timeTime, productCode, price)
+	if point == nil {
+		return nil, errors.New(fmt.Sprint("invalid price_point:", timeTime, productCode, price))
+	}
+
+	rules, err := as.alertRuleRepository.FindAll(productCode)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]model.Alert, 0)
+	errs := make([]string, 0)
+	// 指標のアラートがあるときだけ日足を読み込む
+	var ctx *model.RuleContext
+	at := -1
+	for _, rule := range rules {
+		if !rule.Enable() {
+			continue
+		}
+
+		var pastPrice float64
+		switch rule.Kind() {
+		case model.AlertKindPriceChange:
+			past, err := as.pricePointRepository.FindLatestBefore(productCode, timeTime.Add(-time.Duration(rule.Hours())*time.Hour))
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("alert %d: %s", rule.ID(), err.Error()))
+				continue
+			}
+			if past != nil {
+				pastPrice = past.Price()
+			}
+		case model.AlertKindIndicator:
+			if ctx == nil {
+				candles, err := as.candleService.FindAll(productCode, as.candleService.Duration(), int64(pastPeriod))
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("alert %d: %s", rule.ID(), err.Error()))
+					continue
+				}
+				ctx = model.NewRuleContext(model.NewDataFrame(productCode, candles, nil))
+				at = len(candles) - 1
+			}
+		}
+
+		// 足がなければ成り立たないものとする
+		matched := false
+		if rule.Kind() != model.AlertKindIndicator || at >= 0 {
+			var err error
+			matched, err = matchAlertRule(rule, price, pastPrice, ctx, at)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("alert %d: %s", rule.ID(), err.Error()))
+				continue
+			}
+		}
+
+		next, notify := rule.Next(timeTime, matched)
+		if next.Active() != rule.Active() || !next.NotifiedAt().Equal(rule.NotifiedAt()) {
+			if err := as.alertRuleRepository.UpdateState(*next); err != nil {
+				errs = append(errs, fmt.Sprintf("alert %d: %s", rule.ID(), err.Error()))
+				continue
+			}
+		}
+		if notify {
+			alerts = append(alerts, *model.NewAlert(timeTime, *next, price, pastPrice))
+		}
+	}
+
+	// 価格の変化を見るために履歴を残し，使わない古いものは消す
+	if err := as.pricePointRepository.Save(*point); err != nil {
+		errs = append(errs, err.Error())
+	} else if err := as.pricePointRepository.DeleteBefore(productCode, timeTime.Add(-model.MaxAlertHours*time.Hour)); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) > 0 {
+		return alerts, errors.New(strings.Join(errs, "; "))
+	}
+
+	return alerts, nil
+}
+
+// 評価に失敗する（panicする）ルールがあっても，ほかのルールは評価する
+func matchAlertRule(rule model.AlertRule, price, pastPrice float64, ctx *model.RuleContext, at int) (matched bool, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("failed to evaluate %q: %v", rule.Expression(), p)
+		}
+	}()
+
+	return rule.Match(price, pastPrice, ctx, at), nil
+}
@@ -0,0 +1,72 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+)
+
+func TestAlertService(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	candleRepository := persistence.NewCandleRepository(tx, config.CandleTableName, config.TimeFormat)
+	alertRuleRepository := persistence.NewAlertRuleRepository(tx, config.TimeFormat)
+	pricePointRepository := persistence.NewPricePointRepository(tx, config.TimeFormat)
+
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	alertService := service.NewAlertService(alertRuleRepository, pricePointRepository, candleService)
+
+	// 他のテストのルールと混ざらない銘柄
+	productCode := "ALERT_TEST"
+	rules := []model.AlertRule{
+		*model.NewAlertRule(0, productCode, model.AlertKindPriceAbove, 120, 0, "", time.Hour, true, false, time.Time{}),
+		*model.NewAlertRule(0, productCode, model.AlertKindPriceChange, 10, 1, "", time.Hour, true, false, time.Time{}),
+		*model.NewAlertRule(0, productCode, model.AlertKindPriceBelow, 1000, 0, "", time.Hour, false, false, time.Time{}),
+	}
+	for _, rule := range rules {
+		if _, err := alertRuleRepository.Save(rule); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	// 日時は2100年1月1日以降かつ昇順
+	timeTime := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("not matched", func(t *testing.T) {
+		alerts, err := alertService.Evaluate(productCode, 100, timeTime, 365)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(alerts) != 0 {
+			t.Fatalf("alerts=%+v", alerts)
+		}
+	})
+
+	t.Run("matched", func(t *testing.T) {
+		alerts, err := alertService.Evaluate(productCode, 125, timeTime.Add(2*time.Hour), 365)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(alerts) != 2 {
+			t.Fatalf("alerts=%+v", alerts)
+		}
+		if rule := alerts[1].Rule(); rule.Kind() != model.AlertKindPriceChange || alerts[1].PastPrice() != 100 {
+			t.Fatalf("alert=%+v", alerts[1])
+		}
+	})
+
+	t.Run("still matched", func(t *testing.T) {
+		alerts, err := alertService.Evaluate(productCode, 130, timeTime.Add(3*time.Hour), 365)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(alerts) != 0 {
+			t.Fatalf("alerts=%+v", alerts)
+		}
+	})
+}
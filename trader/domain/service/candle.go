@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
@@ -8,11 +9,12 @@ import (
 )
 
 type CandleService interface {
+	Duration() time.Duration
 	TickerToCandle(ticker model.Ticker) *model.Candle
 	Update(oldCandle, newCandle *model.Candle) *model.Candle
 	Save(candle model.Candle) error
 	FindByTime(productCode string, timeTime time.Time) (*model.Candle, error)
-	FindAll(productCode string, limit int64) ([]model.Candle, error)
+	FindAll(productCode string, duration time.Duration, limit int64) ([]model.Candle, error)
 }
 
 // 日足
@@ -40,9 +42,11 @@ func (cs *candleServicePerDay) TickerToCandle(ticker model.Ticker) *model.Candle
 	tickerTime := model.NewCandleTimeByString(ticker.Timestamp())
 	candleTime := tickerTime.TruncateHour(cs.localTime, cs.tradeHour)
 
-	return model.NewCandle(ticker.ProductCode(), cs.Duration(), candleTime, price, price, price, price, ticker.Volume())
+	// tickerのvolumeは直近24時間の出来高なので，キャンドルの出来高には使わない
+	return model.NewCandle(ticker.ProductCode(), cs.Duration(), candleTime, price, price, price, price, 0)
 }
 
+// newCandleの出来高は前回の更新以降の出来高として，oldCandleの出来高に足し合わせる
 func (cs *candleServicePerDay) Update(oldCandle, newCandle *model.Candle) *model.Candle {
 	if oldCandle == nil || newCandle == nil {
 		return newCandle
@@ -70,7 +74,7 @@ func (cs *candleServicePerDay) Update(oldCandle, newCandle *model.Candle) *model
 		low = newCandle.Low()
 	}
 
-	return model.NewCandle(oldCandle.ProductCode(), oldCandle.Duration(), oldCandle.Time(), oldCandle.Open(), newCandle.Close(), high, low, newCandle.Volume())
+	return model.NewCandle(oldCandle.ProductCode(), oldCandle.Duration(), oldCandle.Time(), oldCandle.Open(), newCandle.Close(), high, low, oldCandle.Volume()+newCandle.Volume())
 }
 
 func (cs *candleServicePerDay) Save(candle model.Candle) error {
@@ -82,6 +86,37 @@ func (cs *candleServicePerDay) FindByTime(productCode string, timeTime time.Time
 	return cs.candleRepository.FindByCandleTime(productCode, cs.Duration(), candleTime)
 }
 
-func (cs *candleServicePerDay) FindAll(productCode string, limit int64) ([]model.Candle, error) {
-	return cs.candleRepository.FindAll(productCode, cs.Duration(), limit)
+// durationが日足の整数倍なら，日足をまとめて返す
+// 日足より短い時間足は作れないので，エラーにする
+// limitが負なら全件
+func (cs *candleServicePerDay) FindAll(productCode string, duration time.Duration, limit int64) ([]model.Candle, error) {
+	if duration == cs.Duration() {
+		return cs.candleRepository.FindAll(productCode, duration, limit)
+	}
+	if duration <= 0 || duration%cs.Duration() != 0 {
+		return nil, fmt.Errorf("unsupported duration %s: must be a multiple of %s", duration, cs.Duration())
+	}
+
+	// 先頭の欠けたキャンドルは捨てられるので，1本分多く取得する
+	ratio := int64(duration / cs.Duration())
+	baseLimit := limit
+	if limit >= 0 {
+		baseLimit = (limit + 1) * ratio
+	}
+	candles, err := cs.candleRepository.FindAll(productCode, cs.Duration(), baseLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	resampled := model.ResampleCandles(candles, duration, cs.origin())
+	if limit >= 0 && int64(len(resampled)) > limit {
+		resampled = resampled[int64(len(resampled))-limit:]
+	}
+	return resampled, nil
+}
+
+// 日足より長い時間足の区切りの基準時刻
+// 週足が月曜日の取引時刻から始まるようにする
+func (cs *candleServicePerDay) origin() time.Time {
+	return time.Date(2000, 1, 3, cs.tradeHour, 0, 0, 0, cs.localTime)
 }
@@ -31,7 +31,7 @@ func TestDataFrameService(t *testing.T) {
 	})
 
 	t.Run("Ichimoku Cloud", func(t *testing.T) {
-		events := dataFrameService.BacktestIchimoku(df, 0.01)
+		events := dataFrameService.BacktestIchimoku(df, 9, 26, 52, 0.01)
 		t.Logf("BacktestIchimoku: %v", events)
 	})
 
@@ -45,12 +45,57 @@ func TestDataFrameService(t *testing.T) {
 		t.Logf("BacktestMACD: %v", events)
 	})
 
+	t.Run("ATR", func(t *testing.T) {
+		events := dataFrameService.BacktestATR(df, 14, 2, 0.01)
+		t.Logf("BacktestATR: %v", events)
+	})
+
+	t.Run("Stochastic", func(t *testing.T) {
+		events := dataFrameService.BacktestStochastic(df, 14, 3, 3, 20, 80, 0.01)
+		t.Logf("BacktestStochastic: %v", events)
+	})
+
+	t.Run("ADX", func(t *testing.T) {
+		events := dataFrameService.BacktestADX(df, 14, 25, 0.01)
+		t.Logf("BacktestADX: %v", events)
+	})
+
+	t.Run("OBV", func(t *testing.T) {
+		events := dataFrameService.BacktestOBV(df, 20, 0.01)
+		t.Logf("BacktestOBV: %v", events)
+	})
+
+	t.Run("VWAP", func(t *testing.T) {
+		events := dataFrameService.BacktestVWAP(df, 20, 0.01)
+		t.Logf("BacktestVWAP: %v", events)
+	})
+
+	t.Run("Parabolic SAR", func(t *testing.T) {
+		events := dataFrameService.BacktestParabolicSAR(df, 0.02, 0.2, 0.01)
+		t.Logf("BacktestParabolicSAR: %v", events)
+	})
+
+	t.Run("Donchian Channel", func(t *testing.T) {
+		events := dataFrameService.BacktestDonchian(df, 20, 0.01)
+		t.Logf("BacktestDonchian: %v", events)
+	})
+
+	t.Run("Keltner Channel", func(t *testing.T) {
+		events := dataFrameService.BacktestKeltner(df, 20, 2, 0.01)
+		t.Logf("BacktestKeltner: %v", events)
+	})
+
+	t.Run("Heikin-Ashi", func(t *testing.T) {
+		events := dataFrameService.BacktestHeikinAshi(df, 3, 0.01)
+		t.Logf("BacktestHeikinAshi: %v", events)
+	})
+
 	params := model.NewBasicTradeParams(config.ProductCode, 0.01)
 	// addXXX()するタイミングは再考の余地あり
 	df.AddEMA(params.EMAPeriod1())
 	df.AddEMA(params.EMAPeriod2())
 	df.AddBBands(params.BBandsN(), params.BBandsK())
-	df.AddIchimoku()
+	df.AddIchimoku(9, 26, 52)
 	df.AddRSI(params.RSIPeriod())
 	df.AddMACD(params.MACDFastPeriod(), params.MACDSlowPeriod(), params.MACDSlowPeriod())
 
@@ -100,3 +145,183 @@ func TestMRBaseDataFrameService(t *testing.T) {
 		t.Logf("Profit: %f", events.Profit())
 	})
 }
+
+func TestWeightedDataFrameService(t *testing.T) {
+	// 横ばいの後に急騰・急落するキャンドル
+	closes := []float64{100, 101, 100, 101, 100, 101, 100, 101, 110, 111, 90}
+	candles := candlesByCloses(closes)
+	df := model.NewDataFrame(config.ProductCode, candles, nil)
+	df.AddDonchianChannel(5)
+	df.AddATR(5)
+
+	indicatorService := service.NewIndicatorService()
+	dataFrameService := service.NewWeightedDataFrameService(indicatorService)
+
+	// DataFrameに追加されていない指標は投票に参加しない
+	params := model.NewBasicTradeParams(config.ProductCode, 0.01)
+	params.EnableDonchian(true)
+	params.EnableATR(true)
+
+	t.Run("uniform weights", func(t *testing.T) {
+		buy, sell := dataFrameService.Analyze(df, 8, params)
+		if !buy || sell {
+			t.Fatalf("Analyze at 8: buy=%t, sell=%t", buy, sell)
+		}
+		buy, sell = dataFrameService.Analyze(df, 10, params)
+		if buy || !sell {
+			t.Fatalf("Analyze at 10: buy=%t, sell=%t", buy, sell)
+		}
+	})
+
+	t.Run("zero weight", func(t *testing.T) {
+		weights := model.NewUniformSignalWeights().WithWeight(model.SignalIndicatorATR, 0)
+		params.SetSignalWeights(*weights)
+
+		// Donchianだけでは閾値に届かない
+		buy, _ := dataFrameService.Analyze(df, 8, params)
+		if buy {
+			t.Fatal("Analyze at 8 should not buy")
+		}
+	})
+
+	t.Run("Backtest", func(t *testing.T) {
+		params.SetSignalWeights(*model.NewUniformSignalWeights())
+		dataFrameService.Backtest(df, params)
+		events := df.BacktestEvents()
+		if events == nil {
+			t.Fatal("Backtest() does not set SignalEvents")
+		}
+		if len(events.Signals()) != 2 {
+			t.Fatalf("Signals: %v", events.Signals())
+		}
+	})
+}
+
+func TestRuleDataFrameService(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	strategyRuleRepository := persistence.NewStrategyRuleRepository(tx)
+	rule := model.NewStrategyRule(config.ProductCode, "crossover(close, sma(3))", "crossunder(close, sma(3))")
+	if err := strategyRuleRepository.Save(*rule); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	closes := []float64{10, 10, 10, 10, 10, 9, 8, 7, 9, 11, 13, 10}
+	candles := candlesByCloses(closes)
+	df := model.NewDataFrame(config.ProductCode, candles, nil)
+
+	indicatorService := service.NewIndicatorService()
+	dataFrameService := service.NewRuleDataFrameService(indicatorService, strategyRuleRepository)
+
+	params := model.NewBasicTradeParams(config.ProductCode, 0.01)
+
+	t.Run("Analyze", func(t *testing.T) {
+		buy, sell := dataFrameService.Analyze(df, 8, params)
+		if !buy || sell {
+			t.Fatalf("Analyze at 8: buy=%t, sell=%t", buy, sell)
+		}
+		buy, sell = dataFrameService.Analyze(df, 11, params)
+		if buy || !sell {
+			t.Fatalf("Analyze at 11: buy=%t, sell=%t", buy, sell)
+		}
+	})
+
+	t.Run("Backtest", func(t *testing.T) {
+		dataFrameService.Backtest(df, params)
+		events := df.BacktestEvents()
+		if events == nil {
+			t.Fatal("Backtest() does not set SignalEvents")
+		}
+		if len(events.Signals()) != 2 {
+			t.Fatalf("Signals: %v", events.Signals())
+		}
+	})
+}
+
+func TestTrendFilterDataFrameService(t *testing.T) {
+	// 横ばいの後に急騰・急落するキャンドル（TestWeightedDataFrameServiceと同じ）
+	closes := []float64{100, 101, 100, 101, 100, 101, 100, 101, 110, 111, 90}
+	candles := candlesByCloses(closes)
+
+	params := model.NewBasicTradeParams(config.ProductCode, 0.01)
+	params.EnableDonchian(true)
+	params.EnableATR(true)
+
+	duration := 2 * config.CandleDuration
+	indicatorService := service.NewIndicatorService()
+	dataFrameService := service.NewTrendFilterDataFrameService(service.NewWeightedDataFrameService(indicatorService), duration, 2)
+	if dataFrameService == nil {
+		t.Fatal("NewTrendFilterDataFrameService() returns nil")
+	}
+	if timeframes := dataFrameService.Timeframes(); len(timeframes) != 1 || timeframes[0] != duration {
+		t.Fatalf("Timeframes: %v", timeframes)
+	}
+
+	// 日足2本ずつの上位の時間足
+	newDataFrame := func(higherCloses []float64) *model.DataFrame {
+		df := model.NewDataFrame(config.ProductCode, candles, nil)
+		df.AddDonchianChannel(5)
+		df.AddATR(5)
+
+		higherCandles := make([]model.Candle, len(higherCloses))
+		for i, c := range higherCloses {
+			higherCandles[i] = *model.NewCandle(config.ProductCode, duration, candles[2*i].Time(), c, c, c, c, 1)
+		}
+		df.AddTimeframe(model.NewDataFrame(config.ProductCode, higherCandles, nil))
+		return df
+	}
+
+	t.Run("uptrend", func(t *testing.T) {
+		df := newDataFrame([]float64{50, 60, 70, 80, 90, 100})
+		buy, sell := dataFrameService.Analyze(df, 8, params)
+		if !buy || sell {
+			t.Fatalf("Analyze at 8: buy=%t, sell=%t", buy, sell)
+		}
+	})
+
+	t.Run("downtrend", func(t *testing.T) {
+		df := newDataFrame([]float64{90, 80, 70, 60, 50, 40})
+		buy, _ := dataFrameService.Analyze(df, 8, params)
+		if buy {
+			t.Fatal("Analyze at 8 should not buy")
+		}
+		// 売りサインはそのまま
+		_, sell := dataFrameService.Analyze(df, 10, params)
+		if !sell {
+			t.Fatal("Analyze at 10 should sell")
+		}
+	})
+
+	t.Run("no future candle", func(t *testing.T) {
+		// 日足8本目の時点で確定している上位の足は4本目まで
+		// 5本目以降だけが上昇していても買わない
+		df := newDataFrame([]float64{90, 80, 70, 60, 100, 110})
+		buy, _ := dataFrameService.Analyze(df, 8, params)
+		if buy {
+			t.Fatal("Analyze at 8 should not use unclosed candles")
+		}
+	})
+
+	t.Run("no timeframe", func(t *testing.T) {
+		df := model.NewDataFrame(config.ProductCode, candles, nil)
+		df.AddDonchianChannel(5)
+		df.AddATR(5)
+		buy, _ := dataFrameService.Analyze(df, 8, params)
+		if buy {
+			t.Fatal("Analyze at 8 should not buy without a higher timeframe")
+		}
+	})
+
+	t.Run("Backtest", func(t *testing.T) {
+		df := newDataFrame([]float64{50, 60, 70, 80, 90, 100})
+		dataFrameService.Backtest(df, params)
+		events := df.BacktestEvents()
+		if events == nil {
+			t.Fatal("Backtest() does not set SignalEvents")
+		}
+		if len(events.Signals()) != 2 {
+			t.Fatalf("Signals: %v", events.Signals())
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type DCAService interface {
+	// 積立の日なら買う
+	// 買ったときはそのSignalEventを返す（積立の日でなければnil）
+	Buy(productCode string, timeTime time.Time) (*model.SignalEvent, error)
+}
+
+type dcaService struct {
+	balanceRepository     repository.BalanceRepository
+	tickerRepository      repository.TickerRepository
+	orderRepository       repository.OrderRepository
+	signalEventRepository repository.SignalEventRepository
+	dcaParamsRepository   repository.DCAParamsRepository
+	tradeParamsRepository repository.TradeParamsRepository
+	candleService         CandleService
+	localTime             *time.Location
+	tradeHour             int
+}
+
+func NewDCAService(
+	br repository.BalanceRepository,
+	tr repository.TickerRepository,
+	or repository.OrderRepository,
+	sr repository.SignalEventRepository,
+	dr repository.DCAParamsRepository,
+	tpr repository.TradeParamsRepository,
+	cs CandleService,
+	lt *time.Location,
+	th int,
+) DCAService {
+	return &dcaService{
+		balanceRepository:     br,
+		tickerRepository:      tr,
+		orderRepository:       or,
+		signalEventRepository: sr,
+		dcaParamsRepository:   dr,
+		tradeParamsRepository: tpr,
+		candleService:         cs,
+		localTime:             lt,
+		tradeHour:             th,
+	}
+}
+
+func (ds *dcaService) Buy(productCode string, timeTime time.Time) (*model.SignalEvent, error) {
+	params, err := ds.dcaParamsRepository.Find(productCode)
+	if err != nil {
+		return nil, err
+	}
+	if params == nil || !params.Enable() {
+		return nil, fmt.Errorf("[DCA] %w", model.ErrTradeDisabled)
+	}
+	paused, err := tradePaused(ds.tradeParamsRepository, productCode)
+	if err != nil {
+		return nil, err
+	}
+	if paused {
+		return nil, fmt.Errorf("[DCA] %w", model.ErrTradeDisabled)
+	}
+
+	events, err := ds.signalEventRepository.FindAll(productCode)
+	if err != nil {
+		return nil, err
+	}
+	signalEvents := model.NewSignalEvents(events)
+	if signalEvents == nil {
+		return nil, errors.New("can't make a SignalEvents instance")
+	}
+	if !params.IsDue(signalEvents.LastDCASignal(), timeTime, ds.localTime, ds.tradeHour) {
+		return nil, nil
+	}
+
+	rsi, err := ds.currentRSI(productCode, params.RSIPeriod())
+	if err != nil {
+		return nil, err
+	}
+
+	// 現在の価格
+	ticker, err := ds.tickerRepository.Fetch(productCode)
+	if err != nil {
+		return nil, err
+	}
+	size := params.Size(ticker.BestAsk(), rsi)
+	if size <= 0 {
+		return nil, errors.New(fmt.Sprintf("[DCA] amount is below the minimum order size. amount: %f, min size: %f", params.AmountAt(rsi), params.MinSize()))
+	}
+
+	// 所持中の現金
+	codes := strings.Split(productCode, "_")
+	balance, err := ds.balanceRepository.FetchByCurrencyCode(codes[1])
+	if err != nil {
+		return nil, err
+	}
+	needCurrency := ticker.BestAsk() * size
+	if balance.Available() < needCurrency {
+		return nil, fmt.Errorf("[DCA] %w. available: %f, need: %f", model.ErrNotEnoughMoney, balance.Available(), needCurrency)
+	}
+
+	// 買い注文
+	order := model.NewBuyOrder(productCode, size)
+	if order == nil {
+		return nil, errors.New("[DCA] can't make a new order instance")
+	}
+	fmt.Printf("[DCA] order: %+v\n", order)
+
+	completedOrder, err := ds.orderRepository.Send(*order)
+	if err != nil {
+		fmt.Println("[DCA]", err)
+		return nil, err
+	}
+	fmt.Printf("[DCA] order completed: %+v\n", completedOrder)
+
+	// 同じ時刻に売買サインによる取引があっても上書きしないように，約定後の時刻で記録する
+	signalEvent := model.NewSignalEventWithTag(time.Now().UTC(), productCode, model.OrderSideBuy, completedOrder.AveragePrice, completedOrder.Size, model.SignalEventTagDCA)
+	if signalEvent == nil {
+		return nil, errors.New("[DCA] order send, but signal_event is nil")
+	}
+
+	err = ds.signalEventRepository.Save(*signalEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	return signalEvent, nil
+}
+
+// 最新のRSI（使わないときや計算できないときは0）
+func (ds *dcaService) currentRSI(productCode string, period int) (float64, error) {
+	if period <= 0 {
+		return 0, nil
+	}
+
+	// RSIが安定するように期間の3倍のキャンドルを使う
+	candles, err := ds.candleService.FindAll(productCode, ds.candleService.Duration(), int64(period*3))
+	if err != nil {
+		return 0, err
+	}
+
+	df := model.NewDataFrame(productCode, candles, nil)
+	if !df.AddRSI(period) {
+		return 0, nil
+	}
+	values := df.RSI().Values()
+	return values[len(values)-1], nil
+}
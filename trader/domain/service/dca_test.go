@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+)
+
+func TestDCAService(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	balanceRepository := bitflyer.NewBitFlyerBalanceMockRepository()
+	tickerRepository := bitflyer.NewBitflyerTickerMockRepository()
+	orderRepository := bitflyer.NewBitflyerOrderMockRepository()
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	dcaParamsRepository := persistence.NewDCAParamsRepository(tx)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+	candleRepository := persistence.NewCandleMockRepository(config.CandleTableName, config.TimeFormat, config.ProductCode, config.CandleDuration)
+
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	dcaService := service.NewDCAService(balanceRepository, tickerRepository, orderRepository, signalEventRepository, dcaParamsRepository, tradeParamsRepository, candleService, config.LocalTime, config.TradeHour)
+
+	t.Run("dca is not enabled", func(t *testing.T) {
+		params := model.NewDCAParams(config.ProductCode, false, 6000, 7, 14, 30, 1.5, 0.01)
+		dcaParamsRepository.Save(*params)
+
+		if _, err := dcaService.Buy(config.ProductCode, time.Now()); err == nil {
+			t.Fatal("Buy() returns no error")
+		}
+	})
+
+	params := model.NewDCAParams(config.ProductCode, true, 6000, 7, 14, 30, 1.5, 0.01)
+	dcaParamsRepository.Save(*params)
+
+	t.Run("buy", func(t *testing.T) {
+		event, err := dcaService.Buy(config.ProductCode, time.Now())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if event == nil || !event.IsDCA() || event.Side() != model.OrderSideBuy {
+			t.Fatalf("event=%+v", event)
+		}
+	})
+
+	t.Run("not due", func(t *testing.T) {
+		event, err := dcaService.Buy(config.ProductCode, time.Now().Add(24*time.Hour))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if event != nil {
+			t.Fatalf("event=%+v", event)
+		}
+	})
+
+	t.Run("due", func(t *testing.T) {
+		event, err := dcaService.Buy(config.ProductCode, time.Now().Add(7*24*time.Hour))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if event == nil {
+			t.Fatal("event is nil")
+		}
+	})
+
+	t.Run("below the minimum order size", func(t *testing.T) {
+		params := model.NewDCAParams(config.ProductCode, true, 1000, 7, 0, 30, 1, 0.01)
+		dcaParamsRepository.Save(*params)
+
+		if _, err := dcaService.Buy(config.ProductCode, time.Now().Add(14*24*time.Hour)); err == nil {
+			t.Fatal("Buy() returns no error")
+		}
+	})
+
+	t.Run("trade paused", func(t *testing.T) {
+		tradeParams := model.NewBasicTradeParams(config.ProductCode, 0.01).WithTradeEnable(false)
+		if err := tradeParamsRepository.Save(*tradeParams); err != nil {
+			t.Fatal(err.Error())
+		}
+		if _, err := dcaService.Buy(config.ProductCode, time.Now().Add(14*24*time.Hour)); !errors.Is(err, model.ErrTradeDisabled) {
+			t.Fatalf("err=%v", err)
+		}
+	})
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+// 証拠金取引（FX_BTC_JPYなど）で，ロングとショートの両方を建てる
+// 買いサインでロング，売りサインでショートを建て，反対のサインが出たら決済して反対方向に建て直す
+type FXTradeService interface {
+	TradeService
+	// 証拠金維持率が下がっていれば，建玉の一部を決済する
+	DeRisk(productCode string) error
+	// ショートも含めたバックテスト
+	Backtest(df *model.DataFrame, params *model.TradeParams) *model.FXBacktestResult
+}
+
+type fxTradeService struct {
+	fxRepository          repository.FXRepository
+	orderRepository       repository.OrderRepository
+	signalEventRepository repository.SignalEventRepository
+	candleService         CandleService
+	dataFrameService      DataFrameService
+	tradeParamsService    TradeParamsService
+	notificationService   NotificationService
+	minKeepRate           float64
+	targetKeepRate        float64
+}
+
+// 証拠金維持率がminKeepRateを下回ったら，targetKeepRateに戻るまで建玉を減らす
+func NewFXTradeService(
+	fr repository.FXRepository,
+	or repository.OrderRepository,
+	sr repository.SignalEventRepository,
+	cs CandleService,
+	ds DataFrameService,
+	ts TradeParamsService,
+	ns NotificationService,
+	minKeepRate float64,
+	targetKeepRate float64,
+) FXTradeService {
+	return &fxTradeService{
+		fxRepository:          fr,
+		orderRepository:       or,
+		signalEventRepository: sr,
+		candleService:         cs,
+		dataFrameService:      ds,
+		tradeParamsService:    ts,
+		notificationService:   ns,
+		minKeepRate:           minKeepRate,
+		targetKeepRate:        targetKeepRate,
+	}
+}
+
+func (fs *fxTradeService) Trade(productCode string, pastPeriod int) error {
+	params, err := fs.tradeParamsService.Find(productCode)
+	if err != nil {
+		return err
+	}
+	if !params.TradeEnable() {
+		return model.ErrTradeDisabled
+	}
+
+	// 証拠金維持率が低いときは，建玉を減らすだけで新しく建てない
+	deRisked, err := fs.deRisk(productCode)
+	if err != nil {
+		return err
+	}
+	if deRisked {
+		return nil
+	}
+
+	candles, err := fs.candleService.FindAll(productCode, fs.candleService.Duration(), int64(pastPeriod))
+	if err != nil {
+		return err
+	}
+	if len(candles) == 0 {
+		return errors.New("no candles")
+	}
+
+	df := model.NewDataFrame(productCode, candles, nil)
+
+	if err := addTimeframes(df, fs.candleService, fs.dataFrameService, pastPeriod); err != nil {
+		return err
+	}
+
+	addIndicators(df, params)
+
+	positions, err := fs.fxRepository.FetchPositions(productCode)
+	if err != nil {
+		return err
+	}
+	position := model.NewFXNetPosition(positions)
+
+	now := len(candles) - 1
+	buy, sell := fs.dataFrameService.Analyze(df, now, params)
+
+	// 損切りでは全て決済する
+	side, size := position.OrderFor(buy, sell, params.Size())
+	cutLoss := position.ShouldCutLoss(candles[now].Close(), params.StopLimitPercent())
+	if cutLoss {
+		side, size = position.CloseOrder()
+	}
+
+	nowTime := time.Now().UTC()
+	switch side {
+	case model.OrderSideBuy:
+		err = fs.Buy(nil, productCode, size, nowTime)
+	case model.OrderSideSell:
+		err = fs.Sell(nil, productCode, size, nowTime)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if cutLoss {
+		reason := fmt.Sprintf("[FX] 損切り: 価格 %f で平均取得単価 %f の建玉を決済しました", candles[now].Close(), position.AveragePrice())
+		if err := fs.notificationService.NotifyOfRiskGuard(productCode, reason); err != nil {
+			fmt.Println(err.Error())
+		}
+	}
+
+	return nil
+}
+
+// FXでは建玉を取引所から取得するので，eventsは使わない
+func (fs *fxTradeService) Buy(events *model.SignalEvents, productCode string, size float64, timeTime time.Time) error {
+	return fs.send(model.OrderSideBuy, productCode, size, timeTime)
+}
+
+// FXでは建玉を取引所から取得するので，eventsは使わない
+func (fs *fxTradeService) Sell(events *model.SignalEvents, productCode string, size float64, timeTime time.Time) error {
+	return fs.send(model.OrderSideSell, productCode, size, timeTime)
+}
+
+func (fs *fxTradeService) send(side model.OrderSide, productCode string, size float64, timeTime time.Time) error {
+	tag := fmt.Sprintf("[FX %s]", side)
+
+	if size < model.FXMinOrderSize {
+		return errors.New(fmt.Sprintf("%s size is less than minimum: %f", tag, size))
+	}
+
+	order := model.NewBuyOrder(productCode, size)
+	if side == model.OrderSideSell {
+		order = model.NewSellOrder(productCode, size)
+	}
+	if order == nil {
+		return errors.New(fmt.Sprint(tag, " can't make a new order instance"))
+	}
+	fmt.Printf("%s order: %+v\n", tag, order)
+
+	// 注文送信
+	completedOrder, err := fs.orderRepository.Send(*order)
+	if err != nil {
+		fmt.Println(tag, err)
+		return err
+	}
+	fmt.Printf("%s order completed: %+v\n", tag, completedOrder)
+
+	// SignalEvent
+	signalEvent := model.NewSignalEvent(timeTime, productCode, side, completedOrder.AveragePrice, completedOrder.Size)
+	if signalEvent == nil {
+		return errors.New(fmt.Sprint(tag, " order send, but signal_event is nil"))
+	}
+
+	// SingalEventをDBに保存
+	return fs.signalEventRepository.Save(*signalEvent)
+}
+
+func (fs *fxTradeService) DeRisk(productCode string) error {
+	_, err := fs.deRisk(productCode)
+	return err
+}
+
+// 建玉を減らしたらtrueを返す
+func (fs *fxTradeService) deRisk(productCode string) (bool, error) {
+	collateral, err := fs.fxRepository.FetchCollateral()
+	if err != nil {
+		return false, err
+	}
+	if !collateral.ShouldDeRisk(fs.minKeepRate) {
+		return false, nil
+	}
+
+	positions, err := fs.fxRepository.FetchPositions(productCode)
+	if err != nil {
+		return false, err
+	}
+	position := model.NewFXNetPosition(positions)
+
+	size := collateral.DeRiskSize(position, fs.targetKeepRate)
+	if size <= 0 {
+		return false, nil
+	}
+	fmt.Printf("[FX] keep rate %f is less than %f. close %f\n", collateral.KeepRate(), fs.minKeepRate, size)
+
+	side, _ := position.CloseOrder()
+	err = fs.send(side, productCode, size, time.Now().UTC())
+	if err != nil {
+		return false, err
+	}
+
+	reason := fmt.Sprintf("[FX] 証拠金維持率 %.2f が %.2f を下回ったので，%f 決済しました", collateral.KeepRate(), fs.minKeepRate, size)
+	if err := fs.notificationService.NotifyOfRiskGuard(productCode, reason); err != nil {
+		fmt.Println(err.Error())
+	}
+
+	return true, nil
+}
+
+// dfには有効な指標が追加済みであること
+func (fs *fxTradeService) Backtest(df *model.DataFrame, params *model.TradeParams) *model.FXBacktestResult {
+	result := model.NewFXBacktestResult()
+	if df == nil || params == nil {
+		return result
+	}
+
+	candles := df.Candles()
+	for i, candle := range candles {
+		buy, sell := fs.dataFrameService.Analyze(df, i, params)
+
+		position := result.Position()
+		side, size := position.OrderFor(buy, sell, params.Size())
+		if position.ShouldCutLoss(candle.Close(), params.StopLimitPercent()) {
+			side, size = position.CloseOrder()
+		}
+		if size <= 0 {
+			continue
+		}
+
+		signal := model.NewSignalEvent(candle.Time().Time(), df.ProductCode(), side, candle.Close(), size)
+		if signal != nil {
+			result.Add(*signal)
+		}
+	}
+
+	if len(candles) > 0 {
+		result.SetLastPrice(candles[len(candles)-1].Close())
+	}
+
+	return result
+}
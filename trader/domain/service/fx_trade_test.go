@@ -0,0 +1,118 @@
+package service_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/slack"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+)
+
+// 決められた時点で売買サインを出す
+type fxSignalDataFrameService struct {
+	service.DataFrameService
+	buys  map[int]bool
+	sells map[int]bool
+}
+
+func (ds *fxSignalDataFrameService) Analyze(df *model.DataFrame, at int, params *model.TradeParams) (bool, bool) {
+	return ds.buys[at], ds.sells[at]
+}
+
+func TestFXTradeService(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	productCode := "FX_BTC_JPY"
+
+	// 5000000円で約定し，証拠金は300000円（レバレッジ2倍）
+	orderRepository, fxRepository := bitflyer.NewBitflyerFXMockRepositories(5000000, 300000)
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	candleRepository := persistence.NewCandleMockRepository(config.CandleTableName, config.TimeFormat, productCode, config.CandleDuration)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+	notificationRepository := slack.NewSlackNotificationMockRepository(config.LocalTime)
+
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	dataFrameService := &fxSignalDataFrameService{
+		buys:  map[int]bool{2: true},
+		sells: map[int]bool{0: true, 1: true, 4: true},
+	}
+	tradeParamsService := service.NewTradeParamsService(tradeParamsRepository, dataFrameService)
+	notificationService := service.NewNotificationService(notificationRepository)
+	fxTradeService := service.NewFXTradeService(fxRepository, orderRepository, signalEventRepository, candleService, dataFrameService, tradeParamsService, notificationService, 1.5, 2)
+
+	netPosition := func() *model.FXNetPosition {
+		positions, err := fxRepository.FetchPositions(productCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		return model.NewFXNetPosition(positions)
+	}
+
+	t.Run("open short", func(t *testing.T) {
+		err := fxTradeService.Sell(nil, productCode, 0.1, time.Now().UTC())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if position := netPosition(); position.Side() != model.OrderSideSell || position.Size() != 0.1 {
+			t.Fatalf("side=%s, size=%f", position.Side(), position.Size())
+		}
+	})
+
+	t.Run("de-risk", func(t *testing.T) {
+		// 証拠金維持率は 300000 / 250000 = 1.2
+		// 必要証拠金を150000まで減らすので，0.1 * (1 - 150000/250000) = 0.04 を決済する
+		if err := fxTradeService.DeRisk(productCode); err != nil {
+			t.Fatal(err.Error())
+		}
+		if position := netPosition(); position.Side() != model.OrderSideSell || math.Abs(position.Size()-0.06) > 1e-9 {
+			t.Fatalf("side=%s, size=%f", position.Side(), position.Size())
+		}
+
+		// 証拠金維持率が戻ったので，これ以上は決済しない
+		if err := fxTradeService.DeRisk(productCode); err != nil {
+			t.Fatal(err.Error())
+		}
+		if position := netPosition(); math.Abs(position.Size()-0.06) > 1e-9 {
+			t.Fatalf("size=%f", position.Size())
+		}
+	})
+
+	t.Run("reject less than minimum size", func(t *testing.T) {
+		if err := fxTradeService.Buy(nil, productCode, 0.001, time.Now().UTC()); err == nil {
+			t.Fatal("Buy() returns nil")
+		}
+	})
+
+	t.Run("backtest with short", func(t *testing.T) {
+		candles := make([]model.Candle, 0)
+		for i, price := range []float64{5000000, 4800000, 4000000, 4500000, 5000000} {
+			candleTime := model.NewCandleTime(time.Date(2021, 1, i+1, 0, 0, 0, 0, time.UTC))
+			candles = append(candles, *model.NewCandle(productCode, config.CandleDuration, candleTime, price, price, price, price, 1))
+		}
+		df := model.NewDataFrame(productCode, candles, nil)
+		params := model.NewBasicTradeParams(productCode, 0.01)
+
+		result := fxTradeService.Backtest(df, params)
+		// 0日目に売り，1日目は売り増ししない，2日目に買い戻してロング，4日目にドテンでショート
+		signals := result.Signals()
+		if len(signals) != 3 {
+			t.Fatalf("signals=%+v", signals)
+		}
+		if signals[1].Side() != model.OrderSideBuy || signals[1].Size() != 0.02 {
+			t.Fatalf("signal=%+v", signals[1])
+		}
+		// (5000000 - 4000000) * 0.01 + (5000000 - 4000000) * 0.01
+		if math.Abs(result.Profit()-20000) > 1e-6 {
+			t.Fatalf("profit=%f", result.Profit())
+		}
+		if result.Position().Side() != model.OrderSideSell {
+			t.Fatalf("side=%s", result.Position().Side())
+		}
+	})
+}
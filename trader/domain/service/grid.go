@@ -0,0 +1,194 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type GridService interface {
+	// 出している注文の約定を確認して，反対注文と新しい買い注文を出す
+	// 今回確認できた約定を返す
+	Sync(grid model.Grid) ([]model.GridFill, error)
+	Levels(productCode string) ([]model.GridLevel, error)
+}
+
+type gridService struct {
+	tickerRepository      repository.TickerRepository
+	orderRepository       repository.OrderRepository
+	gridLevelRepository   repository.GridLevelRepository
+	signalEventRepository repository.SignalEventRepository
+	tradeParamsRepository repository.TradeParamsRepository
+	lastFillTime          time.Time
+}
+
+func NewGridService(tr repository.TickerRepository, or repository.OrderRepository, gr repository.GridLevelRepository, sr repository.SignalEventRepository, tpr repository.TradeParamsRepository) GridService {
+	return &gridService{
+		tickerRepository:      tr,
+		orderRepository:       or,
+		gridLevelRepository:   gr,
+		signalEventRepository: sr,
+		tradeParamsRepository: tpr,
+	}
+}
+
+// 取引を止めている間は注文を出さず，約定の確認もしない（出している注文は取引所に残る）
+func (gs *gridService) Sync(grid model.Grid) ([]model.GridFill, error) {
+	productCode := grid.ProductCode()
+
+	paused, err := tradePaused(gs.tradeParamsRepository, productCode)
+	if err != nil {
+		return nil, err
+	}
+	if paused {
+		return nil, fmt.Errorf("[Grid] %w", model.ErrTradeDisabled)
+	}
+
+	levels, err := gs.gridLevelRepository.FindAll(productCode)
+	if err != nil {
+		return nil, err
+	}
+	if len(levels) == 0 {
+		levels = grid.NewLevels()
+		for _, level := range levels {
+			if err := gs.gridLevelRepository.Save(level); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// 設定を変えたときに古い段の注文を見失わないように，作り直しは手動で行う
+	if !grid.Matches(levels) {
+		return nil, errors.New("grid levels do not match the grid config")
+	}
+
+	ticker, err := gs.tickerRepository.Fetch(productCode)
+	if err != nil {
+		return nil, err
+	}
+	price := ticker.Ltp()
+
+	fills := make([]model.GridFill, 0)
+	for i := range levels {
+		level := &levels[i]
+
+		switch level.State() {
+		case model.GridLevelStateBuying, model.GridLevelStateSelling:
+			fill, err := gs.syncOrder(level)
+			if fill != nil {
+				fills = append(fills, *fill)
+			}
+			if err != nil {
+				return fills, err
+			}
+		case model.GridLevelStateBought:
+			// 前回は売り注文を出せなかった
+			if err := gs.placeSell(level); err != nil {
+				return fills, err
+			}
+		}
+
+		if level.ShouldPlaceBuy(price) {
+			order := model.NewLimitBuyOrder(productCode, level.BuyPrice(), level.Size())
+			if order == nil {
+				return fills, errors.New(fmt.Sprint("invalid buy order:", productCode, level.BuyPrice(), level.Size()))
+			}
+			orderID, err := gs.orderRepository.Place(*order)
+			if err != nil {
+				return fills, err
+			}
+			level.PlaceBuy(orderID)
+			if err := gs.gridLevelRepository.Save(*level); err != nil {
+				return fills, err
+			}
+		}
+	}
+
+	return fills, nil
+}
+
+// 段の注文の状況を確認して，段の状態を進める
+// 約定したら段の状態を保存してから売買履歴に記録し，反対注文を出す
+// 反対注文を出せなくても，同じ約定を次の確認で二重に数えない
+func (gs *gridService) syncOrder(level *model.GridLevel) (*model.GridFill, error) {
+	order, err := gs.orderRepository.Find(level.ProductCode(), level.OrderID())
+	if err != nil {
+		return nil, err
+	}
+	// 注文がまだ一覧に反映されていない
+	if order == nil {
+		return nil, nil
+	}
+
+	var fill *model.GridFill
+	switch order.ChildOrderState {
+	case model.OrderStateCompleted:
+		if level.State() == model.GridLevelStateBuying {
+			fill = model.NewGridFill(gs.fillTime(), *level, model.OrderSideBuy)
+			level.Bought()
+		} else {
+			fill = model.NewGridFill(gs.fillTime(), *level, model.OrderSideSell)
+			level.Wait()
+		}
+	case model.OrderStateCanceled, model.OrderStateExpired, model.OrderStateRejected:
+		// 買い注文は出し直すかを価格で判断する
+		// 売り注文は買った分を持っているので，そのまま出し直す
+		if level.State() == model.GridLevelStateBuying {
+			level.Wait()
+		} else {
+			level.Bought()
+		}
+	default:
+		return nil, nil
+	}
+
+	if err := gs.gridLevelRepository.Save(*level); err != nil {
+		return nil, err
+	}
+
+	if fill != nil {
+		event := fill.SignalEvent(level.ProductCode())
+		if event == nil {
+			return fill, errors.New(fmt.Sprint("invalid grid fill:", level.ProductCode(), fill.Side(), fill.Price(), fill.Size()))
+		}
+		if err := gs.signalEventRepository.Save(*event); err != nil {
+			return fill, err
+		}
+	}
+
+	if level.State() == model.GridLevelStateBought {
+		if err := gs.placeSell(level); err != nil {
+			return fill, err
+		}
+	}
+	return fill, nil
+}
+
+// signal_eventsは秒単位の時刻が主キーなので，同じ秒の約定が記録から漏れないように1秒ずつずらす
+func (gs *gridService) fillTime() time.Time {
+	timeTime := time.Now().UTC().Truncate(time.Second)
+	if !timeTime.After(gs.lastFillTime) {
+		timeTime = gs.lastFillTime.Add(time.Second)
+	}
+	gs.lastFillTime = timeTime
+	return timeTime
+}
+
+func (gs *gridService) placeSell(level *model.GridLevel) error {
+	order := model.NewLimitSellOrder(level.ProductCode(), level.SellPrice(), level.Size())
+	if order == nil {
+		return errors.New(fmt.Sprint("invalid sell order:", level.ProductCode(), level.SellPrice(), level.Size()))
+	}
+	orderID, err := gs.orderRepository.Place(*order)
+	if err != nil {
+		return err
+	}
+	level.PlaceSell(orderID)
+	return gs.gridLevelRepository.Save(*level)
+}
+
+func (gs *gridService) Levels(productCode string) ([]model.GridLevel, error) {
+	return gs.gridLevelRepository.FindAll(productCode)
+}
@@ -0,0 +1,187 @@
+package service_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer/fake"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+)
+
+func TestGridService(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	tickerRepository := bitflyer.NewBitflyerTickerMockRepository()
+	orderRepository := bitflyer.NewBitflyerOrderMockRepository()
+	gridLevelRepository := persistence.NewGridLevelRepository(tx)
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+
+	gridService := service.NewGridService(tickerRepository, orderRepository, gridLevelRepository, signalEventRepository, tradeParamsRepository)
+
+	if err := gridLevelRepository.DeleteAll(config.ProductCode); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// モックのティッカーの価格は540284
+	grid := model.NewGrid(config.ProductCode, 500000, 600000, 5, 0.01)
+
+	countStates := func() map[model.GridLevelState]int {
+		levels, err := gridService.Levels(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		counts := make(map[model.GridLevelState]int)
+		for _, level := range levels {
+			counts[level.State()]++
+		}
+		return counts
+	}
+
+	t.Run("place buy orders", func(t *testing.T) {
+		fills, err := gridService.Sync(*grid)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(fills) != 0 {
+			t.Fatalf("len(fills)=%d", len(fills))
+		}
+		// 500000, 525000の段に買い注文
+		if counts := countStates(); counts[model.GridLevelStateBuying] != 2 || counts[model.GridLevelStateWaiting] != 2 {
+			t.Fatalf("counts=%v", counts)
+		}
+	})
+
+	t.Run("buy orders are filled", func(t *testing.T) {
+		fills, err := gridService.Sync(*grid)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(fills) != 2 || fills[0].Side() != model.OrderSideBuy || fills[0].Price() != 500000 {
+			t.Fatalf("fills=%+v", fills)
+		}
+		if counts := countStates(); counts[model.GridLevelStateSelling] != 2 {
+			t.Fatalf("counts=%v", counts)
+		}
+	})
+
+	t.Run("sell orders are filled", func(t *testing.T) {
+		fills, err := gridService.Sync(*grid)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(fills) != 2 || fills[0].Side() != model.OrderSideSell || fills[0].Price() != 525000 {
+			t.Fatalf("fills=%+v", fills)
+		}
+		// 売れた段には再び買い注文を出す
+		if counts := countStates(); counts[model.GridLevelStateBuying] != 2 {
+			t.Fatalf("counts=%v", counts)
+		}
+	})
+
+	t.Run("grid config changed", func(t *testing.T) {
+		other := model.NewGrid(config.ProductCode, 500000, 600000, 6, 0.01)
+		if _, err := gridService.Sync(*other); err == nil {
+			t.Fatal("Sync() returns no error")
+		}
+	})
+
+	t.Run("trade paused", func(t *testing.T) {
+		params := model.NewBasicTradeParams(config.ProductCode, 0.01).WithTradeEnable(false)
+		if err := tradeParamsRepository.Save(*params); err != nil {
+			t.Fatal(err.Error())
+		}
+		if _, err := gridService.Sync(*grid); !errors.Is(err, model.ErrTradeDisabled) {
+			t.Fatalf("err=%v", err)
+		}
+	})
+}
+
+// 偽物の取引所で，価格の推移に沿って指値注文が約定する
+func TestGridServiceWithFakeExchange(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	server := fake.NewServer(fake.Config{
+		Key:      "key",
+		Secret:   "secret",
+		Prices:   map[string][]float64{"ETH_JPY": {540000, 520000, 560000}},
+		Balances: map[string]float64{"JPY": 100000},
+	})
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	exchange := bitflyer.NewBitflyerExchange(bitflyer.NewClientWithBaseURL("key", "secret", ts.URL+"/v1/"))
+	gridLevelRepository := persistence.NewGridLevelRepository(tx)
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+	gridService := service.NewGridService(exchange.Ticker(), exchange.Order(), gridLevelRepository, signalEventRepository, tradeParamsRepository)
+
+	if err := gridLevelRepository.DeleteAll("ETH_JPY"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	grid := model.NewGrid("ETH_JPY", 500000, 600000, 5, 0.01)
+
+	// 540000: 500000, 525000の段に買い注文
+	if fills, err := gridService.Sync(*grid); err != nil || len(fills) != 0 {
+		t.Fatalf("fills=%+v, err=%v", fills, err)
+	}
+
+	// 520000: 525000の買い注文が約定したが，550000の売り注文を出せない
+	server.Step()
+	server.FailNext("me/sendchildorder", http.StatusInternalServerError, 1)
+	fills, err := gridService.Sync(*grid)
+	if err == nil {
+		t.Fatal("Sync() returns no error")
+	}
+	if len(fills) != 1 || fills[0].Side() != model.OrderSideBuy || fills[0].Price() != 525000 {
+		t.Fatalf("fills=%+v", fills)
+	}
+
+	// 同じ約定を二重に数えずに，売り注文を出し直す
+	fills, err = gridService.Sync(*grid)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(fills) != 0 {
+		t.Fatalf("fills=%+v", fills)
+	}
+
+	// 560000: 550000の売り注文が約定する
+	server.Step()
+	fills, err = gridService.Sync(*grid)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(fills) != 1 || fills[0].Side() != model.OrderSideSell || fills[0].Price() != 550000 {
+		t.Fatalf("fills=%+v", fills)
+	}
+
+	// 約定した価格は最良気配値なので，1段分より少し多く増える
+	if jpy := server.Balance("JPY"); jpy != 100000-0.01*520000+0.01*560000 {
+		t.Fatalf("JPY=%f", jpy)
+	}
+
+	// 約定はグリッド取引として売買履歴に残る
+	events, err := signalEventRepository.FindAll("ETH_JPY")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	grids := 0
+	for _, event := range events {
+		if event.Tag() == model.SignalEventTagGrid {
+			grids++
+		}
+	}
+	if grids != 2 {
+		t.Fatalf("events=%+v", events)
+	}
+}
@@ -13,6 +13,24 @@ type IndicatorService interface {
 	SellSignalOfRSI(rsi *model.RSI, sellThread float64, at int) bool
 	BuySignalOfMACD(macd *model.MACD, at int) bool
 	SellSignalOfMACD(macd *model.MACD, at int) bool
+	BuySignalOfATR(atr *model.ATR, multiplier float64, candles []model.Candle, at int) bool
+	SellSignalOfATR(atr *model.ATR, multiplier float64, candles []model.Candle, at int) bool
+	BuySignalOfStochastic(stoch *model.Stochastic, buyThread float64, at int) bool
+	SellSignalOfStochastic(stoch *model.Stochastic, sellThread float64, at int) bool
+	BuySignalOfADX(adx *model.ADX, thread float64, at int) bool
+	SellSignalOfADX(adx *model.ADX, thread float64, at int) bool
+	BuySignalOfOBV(obv *model.OBV, at int) bool
+	SellSignalOfOBV(obv *model.OBV, at int) bool
+	BuySignalOfVWAP(vwap *model.VWAP, candles []model.Candle, at int) bool
+	SellSignalOfVWAP(vwap *model.VWAP, candles []model.Candle, at int) bool
+	BuySignalOfParabolicSAR(sar *model.ParabolicSAR, candles []model.Candle, at int) bool
+	SellSignalOfParabolicSAR(sar *model.ParabolicSAR, candles []model.Candle, at int) bool
+	BuySignalOfDonchian(donchian *model.DonchianChannel, candles []model.Candle, at int) bool
+	SellSignalOfDonchian(donchian *model.DonchianChannel, candles []model.Candle, at int) bool
+	BuySignalOfKeltner(keltner *model.KeltnerChannel, candles []model.Candle, at int) bool
+	SellSignalOfKeltner(keltner *model.KeltnerChannel, candles []model.Candle, at int) bool
+	BuySignalOfHeikinAshi(averageCandle *model.AverageCandle, period int, at int) bool
+	SellSignalOfHeikinAshi(averageCandle *model.AverageCandle, period int, at int) bool
 }
 
 type indicatorService struct{}
@@ -63,27 +81,31 @@ func (is *indicatorService) SellSignalOfBBands(bbands *model.BBands, candles []m
 		bbands.Up()[at] >= candles[at].Close()
 }
 
+// 遅行スパンは現在の終値をずらしたものなので，ずらし幅だけ過去のキャンドルと比較する
+// 先行スパンは過去に計算されたものが現在の位置に来ている
 func (is *indicatorService) BuySignalOfIchimoku(ichimoku *model.IchimokuCloud, candles []model.Candle, at int) bool {
-	if at < 1 {
+	d := ichimoku.Displacement()
+	if at < d+ichimoku.SenkouBPeriod() || at >= len(candles) {
 		return false
 	}
 
 	// 三役好転
-	return ichimoku.Chikou()[at-1] < candles[at-1].High() &&
-		ichimoku.Chikou()[at] >= candles[at].High() &&
+	return ichimoku.Chikou()[at-d-1] < candles[at-d-1].High() &&
+		ichimoku.Chikou()[at-d] >= candles[at-d].High() &&
 		ichimoku.SenkouA()[at] < candles[at].Low() &&
 		ichimoku.SenkouB()[at] < candles[at].Low() &&
 		ichimoku.Tenkan()[at] > ichimoku.Kijun()[at]
 }
 
 func (is *indicatorService) SellSignalOfIchimoku(ichimoku *model.IchimokuCloud, candles []model.Candle, at int) bool {
-	if at < 1 {
+	d := ichimoku.Displacement()
+	if at < d+ichimoku.SenkouBPeriod() || at >= len(candles) {
 		return false
 	}
 
 	// 三役逆転
-	return ichimoku.Chikou()[at-1] > candles[at-1].Low() &&
-		ichimoku.Chikou()[at] <= candles[at].Low() &&
+	return ichimoku.Chikou()[at-d-1] > candles[at-d-1].Low() &&
+		ichimoku.Chikou()[at-d] <= candles[at-d].Low() &&
 		ichimoku.SenkouA()[at] > candles[at].High() &&
 		ichimoku.SenkouB()[at] > candles[at].High() &&
 		ichimoku.Tenkan()[at] < ichimoku.Kijun()[at]
@@ -120,3 +142,196 @@ func (is *indicatorService) SellSignalOfMACD(macd *model.MACD, at int) bool {
 		macd.Macd()[at-1] > macd.MacdSignal()[at-1] &&
 		macd.Macd()[at] <= macd.MacdSignal()[at]
 }
+
+// 前の足の終値からATRのmultiplier倍以上動いたらブレイクアウト
+func (is *indicatorService) BuySignalOfATR(atr *model.ATR, multiplier float64, candles []model.Candle, at int) bool {
+	if at <= atr.Period() || at >= len(candles) {
+		return false
+	}
+
+	return candles[at].Close()-candles[at-1].Close() >= multiplier*atr.Values()[at-1]
+}
+
+func (is *indicatorService) SellSignalOfATR(atr *model.ATR, multiplier float64, candles []model.Candle, at int) bool {
+	if at <= atr.Period() || at >= len(candles) {
+		return false
+	}
+
+	return candles[at-1].Close()-candles[at].Close() >= multiplier*atr.Values()[at-1]
+}
+
+// 売られすぎの領域で%Kが%Dを上抜けたら買い
+func (is *indicatorService) BuySignalOfStochastic(stoch *model.Stochastic, buyThread float64, at int) bool {
+	if at <= stoch.Lookback() {
+		return false
+	}
+
+	return stoch.SlowK()[at-1] < stoch.SlowD()[at-1] &&
+		stoch.SlowK()[at] >= stoch.SlowD()[at] &&
+		stoch.SlowK()[at] <= buyThread
+}
+
+func (is *indicatorService) SellSignalOfStochastic(stoch *model.Stochastic, sellThread float64, at int) bool {
+	if at <= stoch.Lookback() {
+		return false
+	}
+
+	return stoch.SlowK()[at-1] > stoch.SlowD()[at-1] &&
+		stoch.SlowK()[at] <= stoch.SlowD()[at] &&
+		stoch.SlowK()[at] >= sellThread
+}
+
+// トレンドが出ているときに+DIが-DIを上抜けたら買い
+func (is *indicatorService) BuySignalOfADX(adx *model.ADX, thread float64, at int) bool {
+	if at < 2*adx.Period() {
+		return false
+	}
+
+	return adx.PlusDI()[at-1] < adx.MinusDI()[at-1] &&
+		adx.PlusDI()[at] >= adx.MinusDI()[at] &&
+		adx.ADX()[at] >= thread
+}
+
+func (is *indicatorService) SellSignalOfADX(adx *model.ADX, thread float64, at int) bool {
+	if at < 2*adx.Period() {
+		return false
+	}
+
+	return adx.PlusDI()[at-1] > adx.MinusDI()[at-1] &&
+		adx.PlusDI()[at] <= adx.MinusDI()[at] &&
+		adx.ADX()[at] >= thread
+}
+
+// OBVがシグナルを上抜けたら買い
+func (is *indicatorService) BuySignalOfOBV(obv *model.OBV, at int) bool {
+	if at < obv.Period() {
+		return false
+	}
+
+	return obv.Values()[at-1] < obv.Signal()[at-1] &&
+		obv.Values()[at] >= obv.Signal()[at]
+}
+
+func (is *indicatorService) SellSignalOfOBV(obv *model.OBV, at int) bool {
+	if at < obv.Period() {
+		return false
+	}
+
+	return obv.Values()[at-1] > obv.Signal()[at-1] &&
+		obv.Values()[at] <= obv.Signal()[at]
+}
+
+// 終値がVWAPを上抜けたら買い
+func (is *indicatorService) BuySignalOfVWAP(vwap *model.VWAP, candles []model.Candle, at int) bool {
+	if at < vwap.Period() || at >= len(candles) {
+		return false
+	}
+
+	return candles[at-1].Close() < vwap.Values()[at-1] &&
+		candles[at].Close() >= vwap.Values()[at]
+}
+
+func (is *indicatorService) SellSignalOfVWAP(vwap *model.VWAP, candles []model.Candle, at int) bool {
+	if at < vwap.Period() || at >= len(candles) {
+		return false
+	}
+
+	return candles[at-1].Close() > vwap.Values()[at-1] &&
+		candles[at].Close() <= vwap.Values()[at]
+}
+
+// SARが価格の上から下に入れ替わったら買い
+func (is *indicatorService) BuySignalOfParabolicSAR(sar *model.ParabolicSAR, candles []model.Candle, at int) bool {
+	if at < 2 || at >= len(candles) {
+		return false
+	}
+
+	return sar.Values()[at-1] > candles[at-1].Close() &&
+		sar.Values()[at] <= candles[at].Close()
+}
+
+func (is *indicatorService) SellSignalOfParabolicSAR(sar *model.ParabolicSAR, candles []model.Candle, at int) bool {
+	if at < 2 || at >= len(candles) {
+		return false
+	}
+
+	return sar.Values()[at-1] < candles[at-1].Close() &&
+		sar.Values()[at] >= candles[at].Close()
+}
+
+// 終値が1本前までのチャネルを抜けたら，その方向にブレイクアウト
+func (is *indicatorService) BuySignalOfDonchian(donchian *model.DonchianChannel, candles []model.Candle, at int) bool {
+	if at <= donchian.Period() || at >= len(candles) {
+		return false
+	}
+
+	return candles[at-1].Close() <= donchian.Up()[at-2] &&
+		candles[at].Close() > donchian.Up()[at-1]
+}
+
+func (is *indicatorService) SellSignalOfDonchian(donchian *model.DonchianChannel, candles []model.Candle, at int) bool {
+	if at <= donchian.Period() || at >= len(candles) {
+		return false
+	}
+
+	return candles[at-1].Close() >= donchian.Down()[at-2] &&
+		candles[at].Close() < donchian.Down()[at-1]
+}
+
+// ボリンジャーバンドと同様に，下限を割った後に戻ってきたら買い
+func (is *indicatorService) BuySignalOfKeltner(keltner *model.KeltnerChannel, candles []model.Candle, at int) bool {
+	if at <= keltner.Period() || at >= len(candles) {
+		return false
+	}
+
+	return keltner.Down()[at-1] > candles[at-1].Close() &&
+		keltner.Down()[at] <= candles[at].Close()
+}
+
+func (is *indicatorService) SellSignalOfKeltner(keltner *model.KeltnerChannel, candles []model.Candle, at int) bool {
+	if at <= keltner.Period() || at >= len(candles) {
+		return false
+	}
+
+	return keltner.Up()[at-1] < candles[at-1].Close() &&
+		keltner.Up()[at] >= candles[at].Close()
+}
+
+// 平均足の陽線がperiod本連続し，かつ最新の足に下ヒゲが無ければ上昇トレンドとみなして買い
+// 1本前の時点で既に条件を満たしていた場合は買わない
+func (is *indicatorService) BuySignalOfHeikinAshi(averageCandle *model.AverageCandle, period int, at int) bool {
+	if period <= 0 || at < period || at >= len(averageCandle.Closes()) {
+		return false
+	}
+
+	return isHeikinAshiUptrend(averageCandle, period, at) &&
+		!isHeikinAshiUptrend(averageCandle, period, at-1)
+}
+
+// 平均足の陰線がperiod本連続し，かつ最新の足に上ヒゲが無ければ下降トレンドとみなして売り
+func (is *indicatorService) SellSignalOfHeikinAshi(averageCandle *model.AverageCandle, period int, at int) bool {
+	if period <= 0 || at < period || at >= len(averageCandle.Closes()) {
+		return false
+	}
+
+	return isHeikinAshiDowntrend(averageCandle, period, at) &&
+		!isHeikinAshiDowntrend(averageCandle, period, at-1)
+}
+
+func isHeikinAshiUptrend(averageCandle *model.AverageCandle, period int, at int) bool {
+	for i := at - period + 1; i <= at; i++ {
+		if !averageCandle.IsBullish(i) {
+			return false
+		}
+	}
+	return averageCandle.HasNoLowerWick(at)
+}
+
+func isHeikinAshiDowntrend(averageCandle *model.AverageCandle, period int, at int) bool {
+	for i := at - period + 1; i <= at; i++ {
+		if !averageCandle.IsBearish(i) {
+			return false
+		}
+	}
+	return averageCandle.HasNoUpperWick(at)
+}
@@ -2,11 +2,13 @@ package service_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/slack"
 )
@@ -30,4 +32,84 @@ func TestNotificationService(t *testing.T) {
 			t.Fatal(err.Error())
 		}
 	})
+	t.Run("notify of spread", func(t *testing.T) {
+		spread := model.NewSpread(time.Now(), config.ProductCode, "bitflyer", "coincheck", 500000, 510000)
+		err := notificationService.NotifyOfSpread(*spread)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+	t.Run("notify of params optimized", func(t *testing.T) {
+		before := model.NewBasicTradeParams(config.ProductCode, 0.01)
+		after := model.NewBasicTradeParams(config.ProductCode, 0.01)
+		err := notificationService.NotifyOfParamsOptimized(*before, *after)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+
+	t.Run("notify of risk guard", func(t *testing.T) {
+		err := notificationService.NotifyOfRiskGuard(config.ProductCode, "test of NotifyOfRiskGuard")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+
+	t.Run("notify of alert", func(t *testing.T) {
+		rule := model.NewAlertRule(1, config.ProductCode, model.AlertKindPriceAbove, 100, 0, "", time.Hour, true, false, time.Time{})
+		err := notificationService.NotifyOfAlert(*model.NewAlert(time.Now(), *rule, 120, 0))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+}
+
+// 送った通知を記録する
+type recordingNotificationRepository struct {
+	repository.NotificationRepository
+	notifications []model.Notification
+	failures      []error
+}
+
+func (rr *recordingNotificationRepository) NotifyOfTradingFailure(productCode string, err error) error {
+	rr.failures = append(rr.failures, err)
+	return nil
+}
+
+func (rr *recordingNotificationRepository) Notify(notification model.Notification) error {
+	rr.notifications = append(rr.notifications, notification)
+	return nil
+}
+
+func TestNotificationServiceDedup(t *testing.T) {
+	notificationRepository := &recordingNotificationRepository{}
+	notificationService := service.NewNotificationServiceWithDedup(notificationRepository, time.Hour)
+
+	t.Run("failure", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			err := notificationService.NotifyOfTradingFailed(config.ProductCode, errors.New("order failed"))
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+		if len(notificationRepository.failures) != 1 {
+			t.Fatalf("failures=%v", notificationRepository.failures)
+		}
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		// 金額が変わっても同じ理由なら1回だけ
+		for i := 0; i < 3; i++ {
+			err := fmt.Errorf("[Buy] %w. available: %d", model.ErrNotEnoughMoney, i)
+			if err := notificationService.NotifyOfTradingFailed(config.ProductCode, err); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+		if len(notificationRepository.notifications) != 1 || notificationRepository.notifications[0].Level() != model.NotificationLevelWarn {
+			t.Fatalf("notifications=%+v", notificationRepository.notifications)
+		}
+		if len(notificationRepository.failures) != 1 {
+			t.Fatalf("failures=%v", notificationRepository.failures)
+		}
+	})
 }
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type PortfolioService interface {
+	Get(productCode string) (*model.Portfolio, error)
+	SaveSnapshot(productCode string, timeTime time.Time) error
+	FindSnapshots(productCode string, limit int64) ([]model.EquitySnapshot, error)
+}
+
+type portfolioService struct {
+	balanceRepository        repository.BalanceRepository
+	tickerRepository         repository.TickerRepository
+	signalEventRepository    repository.SignalEventRepository
+	equitySnapshotRepository repository.EquitySnapshotRepository
+	commissionRate           float64
+}
+
+func NewPortfolioService(br repository.BalanceRepository, tr repository.TickerRepository, sr repository.SignalEventRepository, er repository.EquitySnapshotRepository, commissionRate float64) PortfolioService {
+	return &portfolioService{
+		balanceRepository:        br,
+		tickerRepository:         tr,
+		signalEventRepository:    sr,
+		equitySnapshotRepository: er,
+		commissionRate:           commissionRate,
+	}
+}
+
+func (ps *portfolioService) Get(productCode string) (*model.Portfolio, error) {
+	balances, err := ps.balanceRepository.FetchAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// 保有している通貨のJPY建て価格を取得
+	// 価格を取得できなかった通貨は資産の合計に含めない（取引対象の価格は後で取得し直す）
+	prices := make(map[string]float64)
+	for _, balance := range balances {
+		currencyCode := balance.CurrencyCode()
+		if currencyCode == "JPY" || balance.Amount() <= 0 {
+			continue
+		}
+		ticker, err := ps.tickerRepository.Fetch(currencyCode + "_JPY")
+		if err != nil {
+			fmt.Printf("[PortfolioService] skip %s in equity: %s\n", currencyCode, err)
+			continue
+		}
+		prices[currencyCode] = ticker.Ltp()
+	}
+	equity := model.TotalEquity(balances, prices)
+
+	// 取引対象の現在価格
+	currentPrice, ok := prices[strings.Split(productCode, "_")[0]]
+	if !ok {
+		ticker, err := ps.tickerRepository.Fetch(productCode)
+		if err != nil {
+			return nil, err
+		}
+		currentPrice = ticker.Ltp()
+	}
+
+	signals, err := ps.signalEventRepository.FindAll(productCode)
+	if err != nil {
+		return nil, err
+	}
+
+	portfolio := model.NewPortfolio(productCode, signals, currentPrice, ps.commissionRate, equity)
+	if portfolio == nil {
+		return nil, errors.New(fmt.Sprint("invalid portfolio:", productCode, currentPrice, ps.commissionRate, equity))
+	}
+
+	return portfolio, nil
+}
+
+func (ps *portfolioService) SaveSnapshot(productCode string, timeTime time.Time) error {
+	portfolio, err := ps.Get(productCode)
+	if err != nil {
+		return err
+	}
+
+	snapshot := portfolio.Snapshot(timeTime)
+	if snapshot == nil {
+		return errors.New("failed to take equity snapshot")
+	}
+
+	return ps.equitySnapshotRepository.Save(*snapshot)
+}
+
+func (ps *portfolioService) FindSnapshots(productCode string, limit int64) ([]model.EquitySnapshot, error) {
+	return ps.equitySnapshotRepository.FindAll(productCode, limit)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+// 取引所間の価格差を記録する（裁定取引の下調べで，売買はしない）
+type SpreadService interface {
+	// 各取引所のティッカーを取得して，基準の取引所との価格差を保存する
+	// 一部の取引所で失敗しても，取得できた価格差は保存して返す
+	Collect(productCode string, timeTime time.Time) ([]model.Spread, error)
+	FindAll(productCode string, limit int64) ([]model.Spread, error)
+}
+
+type spreadService struct {
+	baseExchange     repository.ExchangeRepository
+	exchanges        []repository.ExchangeRepository
+	spreadRepository repository.SpreadRepository
+}
+
+// baseは取引している取引所，exchangesは比べる取引所
+func NewSpreadService(base repository.ExchangeRepository, exchanges []repository.ExchangeRepository, sr repository.SpreadRepository) SpreadService {
+	return &spreadService{
+		baseExchange:     base,
+		exchanges:        exchanges,
+		spreadRepository: sr,
+	}
+}
+
+func (ss *spreadService) Collect(productCode string, timeTime time.Time) ([]model.Spread, error) {
+	baseTicker, err := ss.baseExchange.Ticker().Fetch(productCode)
+	if err != nil {
+		return nil, err
+	}
+
+	spreads := make([]model.Spread, 0)
+	errs := make([]string, 0)
+	for _, exchange := range ss.exchanges {
+		ticker, err := exchange.Ticker().Fetch(productCode)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", exchange.Name(), err.Error()))
+			continue
+		}
+
+		spread := model.NewSpread(timeTime, productCode, ss.baseExchange.Name(), exchange.Name(), baseTicker.MidPrice(), ticker.MidPrice())
+		if spread == nil {
+			errs = append(errs, fmt.Sprint("invalid spread:", exchange.Name(), baseTicker.MidPrice(), ticker.MidPrice()))
+			continue
+		}
+
+		if err := ss.spreadRepository.Save(*spread); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", exchange.Name(), err.Error()))
+			continue
+		}
+		spreads = append(spreads, *spread)
+	}
+
+	if len(errs) > 0 {
+		return spreads, errors.New(strings.Join(errs, "; "))
+	}
+
+	return spreads, nil
+}
+
+func (ss *spreadService) FindAll(productCode string, limit int64) ([]model.Spread, error) {
+	return ss.spreadRepository.FindAll(productCode, limit)
+}
@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer/fake"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+)
+
+// 偽のbitFlyerを別の取引所として扱う
+type namedExchange struct {
+	repository.ExchangeRepository
+	name string
+}
+
+func (ne *namedExchange) Name() string {
+	return ne.name
+}
+
+func newFakeExchange(t *testing.T, name string, price float64) (*fake.Server, repository.ExchangeRepository) {
+	server := fake.NewServer(fake.Config{
+		Key:    "key",
+		Secret: "secret",
+		Prices: map[string][]float64{"ETH_JPY": {price}},
+	})
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	exchange := bitflyer.NewBitflyerExchange(bitflyer.NewClientWithBaseURL("key", "secret", ts.URL+"/v1/"))
+	return server, &namedExchange{ExchangeRepository: exchange, name: name}
+}
+
+func TestSpreadService(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	_, base := newFakeExchange(t, "bitflyer", 500000)
+	_, higher := newFakeExchange(t, "higher", 510000)
+	failing, lower := newFakeExchange(t, "lower", 495000)
+
+	spreadRepository := persistence.NewSpreadRepository(tx, config.TimeFormat)
+	spreadService := service.NewSpreadService(base, []repository.ExchangeRepository{higher, lower}, spreadRepository)
+
+	timeTime := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("collect", func(t *testing.T) {
+		spreads, err := spreadService.Collect("ETH_JPY", timeTime)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(spreads) != 2 || spreads[0].Rate() != 0.02 || spreads[1].Rate() != -0.01 {
+			t.Fatalf("spreads=%+v", spreads)
+		}
+	})
+
+	t.Run("collect with a failing exchange", func(t *testing.T) {
+		failing.FailNext("ticker", http.StatusInternalServerError, 1)
+
+		spreads, err := spreadService.Collect("ETH_JPY", timeTime.Add(time.Minute))
+		if err == nil {
+			t.Fatal("Collect() returns no error")
+		}
+		if len(spreads) != 1 || spreads[0].Exchange() != "higher" {
+			t.Fatalf("spreads=%+v", spreads)
+		}
+	})
+
+	t.Run("find all", func(t *testing.T) {
+		spreads, err := spreadService.FindAll("ETH_JPY", 3)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(spreads) != 3 {
+			t.Fatalf("len(spreads)=%d", len(spreads))
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type SummaryService interface {
+	// 前回のまとめ（なければ1期間前）から時刻timeTimeまでの運用成績をまとめる
+	// 指標の売買サインは，過去pastPeriod本の足から計算する
+	Make(productCode string, period model.SummaryPeriod, timeTime time.Time, pastPeriod int) (*model.Summary, error)
+	// 送ったまとめを記録し，次のまとめの起点にする
+	Save(summary model.Summary) error
+}
+
+type summaryService struct {
+	portfolioService        PortfolioService
+	signalEventRepository   repository.SignalEventRepository
+	candleService           CandleService
+	indicatorService        IndicatorService
+	tradeParamsService      TradeParamsService
+	summaryReportRepository repository.SummaryReportRepository
+}
+
+func NewSummaryService(ps PortfolioService, sr repository.SignalEventRepository, cs CandleService, is IndicatorService, ts TradeParamsService, rr repository.SummaryReportRepository) SummaryService {
+	return &summaryService{
+		portfolioService:        ps,
+		signalEventRepository:   sr,
+		candleService:           cs,
+		indicatorService:        is,
+		tradeParamsService:      ts,
+		summaryReportRepository: rr,
+	}
+}
+
+func (ss *summaryService) Make(productCode string, period model.SummaryPeriod, timeTime time.Time, pastPeriod int) (*model.Summary, error) {
+	portfolio, err := ss.portfolioService.Get(productCode)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := ss.summaryReportRepository.FindLatest(productCode, period)
+	if err != nil {
+		return nil, err
+	}
+	since := timeTime.Add(-period.Duration())
+	if report != nil {
+		since = report.Time()
+	}
+
+	trades, err := ss.signalEventRepository.FindAllAfterTime(productCode, since)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := ss.tradeParamsService.Find(productCode)
+	if err != nil {
+		return nil, err
+	}
+
+	indicators, err := ss.indicatorStates(productCode, params, pastPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	// 初回は比べるものがないので，変わっていないとみなす
+	paramsLines := tradeParamsLines(params)
+	changes := make([]string, 0)
+	if report != nil {
+		changes = changedLines(report.ParamsLines(), paramsLines)
+	}
+
+	return model.NewSummary(timeTime, period, since, *portfolio, trades, indicators, paramsLines, changes), nil
+}
+
+// 有効な指標が最新の足で出している売買サイン
+func (ss *summaryService) indicatorStates(productCode string, params *model.TradeParams, pastPeriod int) ([]model.IndicatorState, error) {
+	candles, err := ss.candleService.FindAll(productCode, ss.candleService.Duration(), int64(pastPeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]model.IndicatorState, 0)
+	if len(candles) == 0 {
+		return states, nil
+	}
+
+	df := model.NewDataFrame(productCode, candles, nil)
+	addIndicators(df, params)
+
+	for _, signal := range indicatorSignals(ss.indicatorService, df, len(candles)-1, params, *model.NewUniformSignalWeights()) {
+		var side model.OrderSide
+		if signal.buy {
+			side = model.OrderSideBuy
+		} else if signal.sell {
+			side = model.OrderSideSell
+		}
+		states = append(states, *model.NewIndicatorState(signal.name, side))
+	}
+	return states, nil
+}
+
+func (ss *summaryService) Save(summary model.Summary) error {
+	return ss.summaryReportRepository.Save(*summary.Report())
+}
@@ -0,0 +1,95 @@
+package service_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+)
+
+func TestSummaryService(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	balanceRepository := bitflyer.NewBitFlyerBalanceMockRepository()
+	tickerRepository := bitflyer.NewBitflyerTickerMockRepository()
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	equitySnapshotRepository := persistence.NewEquitySnapshotRepository(tx, config.TimeFormat)
+	candleRepository := persistence.NewCandleRepository(tx, config.CandleTableName, config.TimeFormat)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+	summaryReportRepository := persistence.NewSummaryReportRepository(tx, config.TimeFormat)
+
+	portfolioService := service.NewPortfolioService(balanceRepository, tickerRepository, signalEventRepository, equitySnapshotRepository, config.CommissionRate)
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	indicatorService := service.NewIndicatorService()
+	tradeParamsService := service.NewTradeParamsService(tradeParamsRepository, service.NewDataFrameService(indicatorService))
+	summaryService := service.NewSummaryService(portfolioService, signalEventRepository, candleService, indicatorService, tradeParamsService, summaryReportRepository)
+
+	if err := tradeParamsService.Save(*model.NewBasicTradeParams(config.ProductCode, 0.01)); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// 日時は2100年1月1日以降かつ昇順
+	start := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 100; i++ {
+		price := 500000 + 10000*math.Sin(float64(i)/5)
+		candleTime := model.NewCandleTime(start.AddDate(0, 0, i))
+		candle := model.NewCandle(config.ProductCode, config.CandleDuration, candleTime, price, price, price, price, 1)
+		if err := candleRepository.Save(*candle); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	now := start.AddDate(0, 0, 100)
+
+	t.Run("first summary", func(t *testing.T) {
+		summary, err := summaryService.Make(config.ProductCode, model.SummaryPeriodDaily, now, 100)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !summary.Since().Equal(now.Add(-24 * time.Hour)) {
+			t.Fatalf("since=%v", summary.Since())
+		}
+		if summary.ParamsChanged() {
+			t.Fatalf("changes=%v", summary.Changes())
+		}
+		if len(summary.Indicators()) == 0 {
+			t.Fatal("len(indicators) == 0")
+		}
+
+		if err := summaryService.Save(*summary); err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+
+	t.Run("trades and params since last summary", func(t *testing.T) {
+		event := model.NewSignalEvent(now.Add(time.Hour), config.ProductCode, model.OrderSideBuy, 500000, 0.01)
+		if err := signalEventRepository.Save(*event); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		// 前回のまとめのときはEMAの期間が違っていた
+		report := model.NewSummaryReport(now.Add(30*time.Minute), config.ProductCode, model.SummaryPeriodDaily, []string{"EMA: 5, 10"})
+		if err := summaryReportRepository.Save(*report); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		summary, err := summaryService.Make(config.ProductCode, model.SummaryPeriodDaily, now.Add(24*time.Hour), 100)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !summary.Since().Equal(report.Time()) {
+			t.Fatalf("since=%v", summary.Since())
+		}
+		if len(summary.Trades()) != 1 {
+			t.Fatalf("trades=%+v", summary.Trades())
+		}
+		if changes := summary.Changes(); len(changes) == 0 || changes[0] != "EMA: 5, 10 → EMA: 7, 14" {
+			t.Fatalf("changes=%v", changes)
+		}
+	})
+}
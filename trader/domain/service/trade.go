@@ -24,6 +24,7 @@ type tradeService struct {
 	candleService         CandleService
 	dataFrameService      DataFrameService
 	tradeParamsService    TradeParamsService
+	notificationService   NotificationService
 }
 
 func NewTradeService(
@@ -34,6 +35,7 @@ func NewTradeService(
 	cs CandleService,
 	ds DataFrameService,
 	ts TradeParamsService,
+	ns NotificationService,
 ) TradeService {
 	return &tradeService{
 		balanceRepository:     br,
@@ -43,6 +45,7 @@ func NewTradeService(
 		candleService:         cs,
 		dataFrameService:      ds,
 		tradeParamsService:    ts,
+		notificationService:   ns,
 	}
 }
 
@@ -52,10 +55,10 @@ func (ts *tradeService) Trade(productCode string, pastPeriod int) error {
 		return err
 	}
 	if !params.TradeEnable() {
-		return errors.New("trade is not enabled")
+		return model.ErrTradeDisabled
 	}
 
-	candles, err := ts.candleService.FindAll(productCode, int64(pastPeriod))
+	candles, err := ts.candleService.FindAll(productCode, ts.candleService.Duration(), int64(pastPeriod))
 	if err != nil {
 		return err
 	}
@@ -64,13 +67,102 @@ func (ts *tradeService) Trade(productCode string, pastPeriod int) error {
 	if err != nil {
 		return err
 	}
-	signalEvents := model.NewSignalEvents(events)
+	signalEvents := model.NewSignalEventsWithLots(events, params.MaxLots(), params.LotMatching())
 	if signalEvents == nil {
 		return errors.New("can't make a SignalEvents instance")
 	}
 
 	df := model.NewDataFrame(productCode, candles, signalEvents)
 
+	if err := addTimeframes(df, ts.candleService, ts.dataFrameService, pastPeriod); err != nil {
+		return err
+	}
+
+	addIndicators(df, params)
+
+	now := len(candles) - 1
+	buy, sell := ts.dataFrameService.Analyze(df, now, params)
+
+	// 損切りでは全てのロットを，売りサインでは1ロット分を売る
+	// 買いより先に判断し，ロットの上限などで買えなくても損切りできるようにする
+	currentPrice := candles[now].Close()
+	sellSize := params.Size()
+	cutLoss := signalEvents.ShouldCutLoss(currentPrice, params.StopLimitPercent())
+	if cutLoss {
+		sell = true
+		sellSize = signalEvents.Position().Size()
+	}
+
+	if sell {
+		nowTime := time.Now().UTC()
+		averagePrice := signalEvents.Position().AveragePrice()
+		err := ts.Sell(signalEvents, productCode, sellSize, nowTime)
+		if err != nil {
+			return err
+		}
+
+		if cutLoss {
+			reason := fmt.Sprintf("損切り: 価格 %f が平均取得単価 %f の%.0f%%を下回りました", currentPrice, averagePrice, params.StopLimitPercent()*100)
+			if err := ts.notificationService.NotifyOfRiskGuard(productCode, reason); err != nil {
+				fmt.Println(err.Error())
+			}
+		}
+
+		// パラメータ更新
+		optimizedParams, changed := ts.tradeParamsService.OptimizeAll(df, params)
+		if changed {
+			err := ts.tradeParamsService.Save(*optimizedParams)
+			if err != nil {
+				return err
+			}
+			if err := ts.notificationService.NotifyOfParamsOptimized(*params, *optimizedParams); err != nil {
+				fmt.Println(err.Error())
+			}
+		}
+
+		return nil
+	}
+
+	if buy {
+		nowTime := time.Now().UTC()
+		err := ts.Buy(signalEvents, productCode, params.Size(), nowTime)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 売買パラメータのtrade_enableで取引を止めているか
+// 売買サインの取引だけでなく，グリッドと積立もこれに従う（パラメータがなければ止めない）
+func tradePaused(tr repository.TradeParamsRepository, productCode string) (bool, error) {
+	params, err := tr.Find(productCode)
+	if err != nil {
+		return false, err
+	}
+	return params != nil && !params.TradeEnable(), nil
+}
+
+// 上位の時間足が必要な戦略なら，その時間足のDataFrameを追加する
+func addTimeframes(df *model.DataFrame, cs CandleService, ds DataFrameService, pastPeriod int) error {
+	mtf, ok := ds.(MultiTimeframeDataFrameService)
+	if !ok {
+		return nil
+	}
+
+	for _, duration := range mtf.Timeframes() {
+		candles, err := cs.FindAll(df.ProductCode(), duration, int64(pastPeriod))
+		if err != nil {
+			return err
+		}
+		df.AddTimeframe(model.NewDataFrame(df.ProductCode(), candles, nil))
+	}
+	return nil
+}
+
+// 有効な指標をDataFrameに追加する（追加できなかった指標は無効にする）
+func addIndicators(df *model.DataFrame, params *model.TradeParams) {
 	if params.EMAEnable() {
 		ok1 := df.AddEMA(params.EMAPeriod1())
 		ok2 := df.AddEMA(params.EMAPeriod2())
@@ -83,7 +175,7 @@ func (ts *tradeService) Trade(productCode string, pastPeriod int) error {
 	}
 
 	if params.IchimokuEnable() {
-		ok := df.AddIchimoku()
+		ok := df.AddIchimoku(params.IchimokuTenkanPeriod(), params.IchimokuKijunPeriod(), params.IchimokuSenkouBPeriod())
 		params.EnableIchimoku(ok)
 	}
 
@@ -97,38 +189,50 @@ func (ts *tradeService) Trade(productCode string, pastPeriod int) error {
 		params.EnableRSI(ok)
 	}
 
-	now := len(candles) - 1
-	buy, sell := ts.dataFrameService.Analyze(df, now, params)
+	if params.ATREnable() {
+		ok := df.AddATR(params.ATRPeriod())
+		params.EnableATR(ok)
+	}
 
-	if buy {
-		nowTime := time.Now().UTC()
-		err := ts.Buy(signalEvents, productCode, params.Size(), nowTime)
-		if err != nil {
-			return err
-		}
+	if params.StochEnable() {
+		ok := df.AddStochastic(params.StochFastKPeriod(), params.StochSlowKPeriod(), params.StochSlowDPeriod())
+		params.EnableStoch(ok)
 	}
 
-	currentPrice := candles[now].Close()
-	if sell ||
-		signalEvents.ShouldCutLoss(currentPrice, params.StopLimitPercent()) {
-		nowTime := time.Now().UTC()
-		err := ts.Sell(signalEvents, productCode, params.Size(), nowTime)
-		if err != nil {
-			return err
-		}
+	if params.ADXEnable() {
+		ok := df.AddADX(params.ADXPeriod())
+		params.EnableADX(ok)
+	}
 
-		// パラメータ更新
-		var changed bool
-		params, changed = ts.tradeParamsService.OptimizeAll(df, params)
-		if changed {
-			err := ts.tradeParamsService.Save(*params)
-			if err != nil {
-				return err
-			}
-		}
+	if params.OBVEnable() {
+		ok := df.AddOBV(params.OBVPeriod())
+		params.EnableOBV(ok)
 	}
 
-	return nil
+	if params.VWAPEnable() {
+		ok := df.AddVWAP(params.VWAPPeriod())
+		params.EnableVWAP(ok)
+	}
+
+	if params.SAREnable() {
+		ok := df.AddParabolicSAR(params.SARAcceleration(), params.SARMaximum())
+		params.EnableSAR(ok)
+	}
+
+	if params.DonchianEnable() {
+		ok := df.AddDonchianChannel(params.DonchianPeriod())
+		params.EnableDonchian(ok)
+	}
+
+	if params.KeltnerEnable() {
+		ok := df.AddKeltnerChannel(params.KeltnerPeriod(), params.KeltnerMultiplier())
+		params.EnableKeltner(ok)
+	}
+
+	if params.HeikinAshiEnable() {
+		ok := df.AddAverageCandle()
+		params.EnableHeikinAshi(ok)
+	}
 }
 
 func (ts *tradeService) Buy(events *model.SignalEvents, productCode string, size float64, timeTime time.Time) error {
@@ -154,7 +258,7 @@ func (ts *tradeService) Buy(events *model.SignalEvents, productCode string, size
 
 	// お金が足りないときは購入しない
 	if availableCurrency < needCurrency {
-		return errors.New(fmt.Sprintf("[Buy] you don't have enough money. available: %f, need: %f", availableCurrency, needCurrency))
+		return fmt.Errorf("[Buy] %w. available: %f, need: %f", model.ErrNotEnoughMoney, availableCurrency, needCurrency)
 	}
 
 	// 買い注文
@@ -202,6 +306,11 @@ func (ts *tradeService) Sell(events *model.SignalEvents, productCode string, siz
 	}
 	availableCoin := balance.Available()
 
+	// 売買サインで建てたポジションより多くは売らない（積立の分を残す）
+	if positionSize := events.Position().Size(); positionSize < size {
+		size = positionSize
+	}
+
 	// パラメータに設定したサイズよりも保有量が足りないときは保有量だけ使う
 	if availableCoin < size {
 		size = availableCoin
@@ -8,6 +8,7 @@ import (
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/slack"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
 )
 
@@ -21,12 +22,14 @@ func TestTradeService(t *testing.T) {
 	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
 	candleRepository := persistence.NewCandleMockRepository(config.CandleTableName, config.TimeFormat, config.ProductCode, config.CandleDuration)
 	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+	notificationRepository := slack.NewSlackNotificationMockRepository(config.LocalTime)
 
 	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
 	indicatorService := service.NewIndicatorService()
 	dataFrameService := service.NewMRBaseDataFrameService(indicatorService)
 	tradeParamsService := service.NewTradeParamsService(tradeParamsRepository, dataFrameService)
-	tradeService := service.NewTradeService(balanceRepository, tickerRepository, orderRepository, signalEventRepository, candleService, dataFrameService, tradeParamsService)
+	notificationService := service.NewNotificationService(notificationRepository)
+	tradeService := service.NewTradeService(balanceRepository, tickerRepository, orderRepository, signalEventRepository, candleService, dataFrameService, tradeParamsService, notificationService)
 
 	events := make([]model.SignalEvent, 0)
 	signalEvents := model.NewSignalEvents(events)
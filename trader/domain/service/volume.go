@@ -0,0 +1,98 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+// getexecutionsで一度に取得する件数の上限
+const volumeExecutionPageSize = 500
+
+type VolumeService interface {
+	// 前回の呼び出し以降に約定した出来高のうち，since以降のもの
+	VolumeSince(productCode string, since time.Time) (float64, error)
+}
+
+// 約定履歴から出来高を求める
+// どこまで数えたか（約定ID）をDBに持つので，再起動しても続きから数える
+// 複数のインスタンスが同時に数えたときは，約定IDを先に進めた方だけが出来高を返す
+type volumeService struct {
+	executionRepository    repository.ExecutionRepository
+	volumeCursorRepository repository.VolumeCursorRepository
+}
+
+func NewVolumeService(er repository.ExecutionRepository, vr repository.VolumeCursorRepository) VolumeService {
+	return &volumeService{
+		executionRepository:    er,
+		volumeCursorRepository: vr,
+	}
+}
+
+func (vs *volumeService) VolumeSince(productCode string, since time.Time) (float64, error) {
+	after, err := vs.volumeCursorRepository.Find(productCode)
+	if err != nil {
+		return 0, err
+	}
+	if after == 0 {
+		// 基準となる約定IDだけ記録する
+		executions, err := vs.executionRepository.FetchAll(productCode, 1, 0, 0)
+		if err != nil {
+			return 0, err
+		}
+		if len(executions) > 0 {
+			if _, err := vs.volumeCursorRepository.Advance(productCode, 0, executions[0].ID()); err != nil {
+				return 0, err
+			}
+		}
+		return 0, nil
+	}
+
+	// afterより新しい約定を新しい方から順にページングする
+	var volume float64
+	var before, latest int64
+	for {
+		executions, err := vs.executionRepository.FetchAll(productCode, volumeExecutionPageSize, before, after)
+		if err != nil {
+			return 0, err
+		}
+		if len(executions) == 0 {
+			break
+		}
+
+		reachedSince := false
+		for _, execution := range executions {
+			if execution.ID() > latest {
+				latest = execution.ID()
+			}
+			if before == 0 || execution.ID() < before {
+				before = execution.ID()
+			}
+			// キャンドルの切り替わり前の約定は数えない
+			if execution.ExecDate().Before(since) {
+				reachedSince = true
+				continue
+			}
+			volume += execution.Size()
+		}
+
+		// 止まっていた間の約定が多くても，キャンドルの切り替わりより前は取得しない
+		if reachedSince || len(executions) < volumeExecutionPageSize {
+			break
+		}
+	}
+
+	if latest == 0 {
+		return 0, nil
+	}
+
+	ok, err := vs.volumeCursorRepository.Advance(productCode, after, latest)
+	if err != nil {
+		return 0, err
+	}
+	// 別のインスタンスが同じ約定を数えた
+	if !ok {
+		return 0, nil
+	}
+	return volume, nil
+}
@@ -0,0 +1,117 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+)
+
+// テストの途中で約定履歴を差し替えるためのrepository
+type swappableExecutionRepository struct {
+	repository.ExecutionRepository
+}
+
+// 約定IDをメモリに持つrepository
+type memoryVolumeCursorRepository struct {
+	executionIDs map[string]int64
+	// trueなら別のインスタンスが先に進めたものとする
+	conflict bool
+}
+
+func (mr *memoryVolumeCursorRepository) Find(productCode string) (int64, error) {
+	return mr.executionIDs[productCode], nil
+}
+
+func (mr *memoryVolumeCursorRepository) Advance(productCode string, before, after int64) (bool, error) {
+	if mr.conflict || mr.executionIDs[productCode] != before {
+		return false, nil
+	}
+	mr.executionIDs[productCode] = after
+	return true, nil
+}
+
+func TestVolumeService(t *testing.T) {
+	base := time.Date(2021, 11, 9, 0, 0, 0, 0, time.UTC)
+	newExecutions := func(from, to int64) []bitflyer.Execution {
+		executions := make([]bitflyer.Execution, 0)
+		for id := from; id <= to; id++ {
+			executions = append(executions, bitflyer.Execution{
+				ID:       id,
+				Side:     "BUY",
+				Price:    500000,
+				Size:     0.5,
+				ExecDate: base.Add(time.Duration(id) * time.Second).Format(bitflyer.ExecDateFormat),
+			})
+		}
+		return executions
+	}
+
+	executionRepository := &swappableExecutionRepository{}
+	volumeCursorRepository := &memoryVolumeCursorRepository{executionIDs: make(map[string]int64)}
+	volumeService := service.NewVolumeService(executionRepository, volumeCursorRepository)
+
+	t.Run("first call", func(t *testing.T) {
+		executionRepository.ExecutionRepository = bitflyer.NewBitflyerExecutionMockRepository(newExecutions(1, 10))
+		volume, err := volumeService.VolumeSince(config.ProductCode, base)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if volume != 0 {
+			t.Fatalf("%v != %v", volume, 0)
+		}
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		// 前回の呼び出し以降に1000件約定した
+		executionRepository.ExecutionRepository = bitflyer.NewBitflyerExecutionMockRepository(newExecutions(1, 1010))
+		volume, err := volumeService.VolumeSince(config.ProductCode, base)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if volume != 500 {
+			t.Fatalf("%v != %v", volume, 500)
+		}
+	})
+
+	t.Run("since", func(t *testing.T) {
+		executionRepository.ExecutionRepository = bitflyer.NewBitflyerExecutionMockRepository(newExecutions(1, 1020))
+		// 1016秒以降の約定だけ数える
+		volume, err := volumeService.VolumeSince(config.ProductCode, base.Add(1016*time.Second))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if volume != 2.5 {
+			t.Fatalf("%v != %v", volume, 2.5)
+		}
+	})
+	t.Run("restart", func(t *testing.T) {
+		// 約定IDはDBに残っているので，新しいインスタンスでも続きから数える
+		executionRepository.ExecutionRepository = bitflyer.NewBitflyerExecutionMockRepository(newExecutions(1, 1030))
+		restarted := service.NewVolumeService(executionRepository, volumeCursorRepository)
+		volume, err := restarted.VolumeSince(config.ProductCode, base)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if volume != 5 {
+			t.Fatalf("%v != %v", volume, 5)
+		}
+	})
+
+	t.Run("counted by another instance", func(t *testing.T) {
+		executionRepository.ExecutionRepository = bitflyer.NewBitflyerExecutionMockRepository(newExecutions(1, 1040))
+		volumeCursorRepository.conflict = true
+		defer func() { volumeCursorRepository.conflict = false }()
+
+		volume, err := volumeService.VolumeSince(config.ProductCode, base)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if volume != 0 {
+			t.Fatalf("%v != %v", volume, 0)
+		}
+	})
+}
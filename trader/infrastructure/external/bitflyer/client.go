@@ -18,13 +18,21 @@ const baseURL = "https://api.bitflyer.com/v1/"
 type Client struct {
 	key        string
 	secret     string
+	baseURL    string
 	httpClient *http.Client
 }
 
 func NewClient(key, secret string) *Client {
+	return NewClientWithBaseURL(key, secret, baseURL)
+}
+
+// 接続先を変える（テスト用のサーバなど）
+// baseURLは"/v1/"まで含める
+func NewClientWithBaseURL(key, secret, baseURL string) *Client {
 	c := &Client{
 		key:        key,
 		secret:     secret,
+		baseURL:    baseURL,
 		httpClient: &http.Client{},
 	}
 	return c
@@ -48,7 +56,7 @@ func (c *Client) header(method, path string, body []byte) map[string]string {
 }
 
 func (c *Client) doRequest(method, path string, query map[string]string, body []byte) (respBody []byte, err error) {
-	baseUrl, err := url.Parse(baseURL)
+	baseUrl, err := url.Parse(c.baseURL)
 	if err != nil {
 		return
 	}
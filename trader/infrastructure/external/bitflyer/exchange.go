@@ -0,0 +1,39 @@
+package bitflyer
+
+import "github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+
+type bitflyerExchange struct {
+	tickerRepository    repository.TickerRepository
+	balanceRepository   repository.BalanceRepository
+	orderRepository     repository.OrderRepository
+	executionRepository repository.ExecutionRepository
+}
+
+func NewBitflyerExchange(apiClient *Client) repository.ExchangeRepository {
+	return &bitflyerExchange{
+		tickerRepository:    NewBitflyerTickerRepository(apiClient),
+		balanceRepository:   NewBitFlyerBalanceRepository(apiClient),
+		orderRepository:     NewBitflyerOrderRepository(apiClient),
+		executionRepository: NewBitflyerExecutionRepository(apiClient),
+	}
+}
+
+func (be *bitflyerExchange) Name() string {
+	return "bitflyer"
+}
+
+func (be *bitflyerExchange) Ticker() repository.TickerRepository {
+	return be.tickerRepository
+}
+
+func (be *bitflyerExchange) Balance() repository.BalanceRepository {
+	return be.balanceRepository
+}
+
+func (be *bitflyerExchange) Order() repository.OrderRepository {
+	return be.orderRepository
+}
+
+func (be *bitflyerExchange) Execution() repository.ExecutionRepository {
+	return be.executionRepository
+}
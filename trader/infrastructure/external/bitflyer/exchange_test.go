@@ -0,0 +1,30 @@
+package bitflyer_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer/fake"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/exchangetest"
+)
+
+func TestBitflyerExchange(t *testing.T) {
+	server := fake.NewServer(fake.Config{
+		Key:      "key",
+		Secret:   "secret",
+		Prices:   map[string][]float64{"ETH_JPY": {540000, 541000, 542000, 543000}},
+		Spread:   100,
+		Balances: map[string]float64{"JPY": 100000, "ETH": 0.5},
+	})
+	// 約定履歴を作る
+	for i := 0; i < 3; i++ {
+		server.Step()
+	}
+
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	apiClient := bitflyer.NewClientWithBaseURL("key", "secret", ts.URL+"/v1/")
+	exchangetest.Run(t, bitflyer.NewBitflyerExchange(apiClient), "ETH_JPY")
+}
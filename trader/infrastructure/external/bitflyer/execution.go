@@ -0,0 +1,85 @@
+package bitflyer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+// 約定日時はミリ秒まで，タイムゾーンなし（UTC）で返される
+const ExecDateFormat = "2006-01-02T15:04:05.999999999"
+
+type Execution struct {
+	ID                         int64   `json:"id"`
+	Side                       string  `json:"side"`
+	Price                      float64 `json:"price"`
+	Size                       float64 `json:"size"`
+	ExecDate                   string  `json:"exec_date"`
+	BuyChildOrderAcceptanceID  string  `json:"buy_child_order_acceptance_id"`
+	SellChildOrderAcceptanceID string  `json:"sell_child_order_acceptance_id"`
+}
+
+func (execution *Execution) toDomainModelExecution() *model.Execution {
+	execDate, err := time.Parse(ExecDateFormat, execution.ExecDate)
+	if err != nil {
+		return nil
+	}
+
+	return model.NewExecution(
+		execution.ID,
+		model.OrderSide(execution.Side),
+		execution.Price,
+		execution.Size,
+		execDate,
+	)
+}
+
+type bitflyerExecutionRepository struct {
+	apiClient *Client
+}
+
+func NewBitflyerExecutionRepository(apiClient *Client) repository.ExecutionRepository {
+	return &bitflyerExecutionRepository{
+		apiClient: apiClient,
+	}
+}
+
+func (ber *bitflyerExecutionRepository) FetchAll(productCode string, count int, before, after int64) ([]model.Execution, error) {
+	path := "getexecutions"
+	query := map[string]string{
+		"product_code": productCode,
+		"count":        strconv.Itoa(count),
+	}
+	if before > 0 {
+		query["before"] = strconv.FormatInt(before, 10)
+	}
+	if after > 0 {
+		query["after"] = strconv.FormatInt(after, 10)
+	}
+	resp, err := ber.apiClient.doRequest("GET", path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var executions []Execution
+	err = json.Unmarshal(resp, &executions)
+	if err != nil {
+		return nil, err
+	}
+
+	domainModelExecutions := make([]model.Execution, 0, len(executions))
+	for i := range executions {
+		execution := executions[i].toDomainModelExecution()
+		if execution == nil {
+			return nil, errors.New(fmt.Sprint("invalid execution fetched:", executions[i]))
+		}
+		domainModelExecutions = append(domainModelExecutions, *execution)
+	}
+
+	return domainModelExecutions, nil
+}
@@ -0,0 +1,50 @@
+package bitflyer
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type bitflyerExecutionMockRepository struct {
+	executions []Execution
+}
+
+// 与えた約定履歴をbitFlyerと同じ規則でページングして返す
+func NewBitflyerExecutionMockRepository(executions []Execution) repository.ExecutionRepository {
+	sorted := make([]Execution, len(executions))
+	copy(sorted, executions)
+	// 新しい順
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID > sorted[j].ID
+	})
+
+	return &bitflyerExecutionMockRepository{
+		executions: sorted,
+	}
+}
+
+func (ber *bitflyerExecutionMockRepository) FetchAll(productCode string, count int, before, after int64) ([]model.Execution, error) {
+	domainModelExecutions := make([]model.Execution, 0)
+	for i := range ber.executions {
+		if len(domainModelExecutions) >= count {
+			break
+		}
+		if before > 0 && ber.executions[i].ID >= before {
+			continue
+		}
+		if after > 0 && ber.executions[i].ID <= after {
+			continue
+		}
+
+		execution := ber.executions[i].toDomainModelExecution()
+		if execution == nil {
+			return nil, errors.New("invalid execution fetched")
+		}
+		domainModelExecutions = append(domainModelExecutions, *execution)
+	}
+
+	return domainModelExecutions, nil
+}
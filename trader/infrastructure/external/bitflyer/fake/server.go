@@ -0,0 +1,742 @@
+// bitFlyerのAPIの偽物
+// bitflyer.Clientが使うエンドポイントを，署名を検証したうえでメモリ上の取引所として処理する
+// 価格はあらかじめ与えた推移に沿ってStepで進み，指値注文はそのときの最良気配値で約定する
+package fake
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+)
+
+const (
+	// 証拠金取引のレバレッジ
+	leverage = 2
+	// Stepごとに記録する，他の参加者の約定の数量
+	marketExecutionSize = 0.01
+	// 署名のタイムスタンプとして受け付ける時刻のずれ
+	timestampTolerance = 5 * time.Minute
+)
+
+type Config struct {
+	Key    string
+	Secret string
+	// 銘柄ごとの価格の推移
+	// Stepで次の価格に進み，最後まで進んだら最後の価格のままになる
+	Prices map[string][]float64
+	// 最良気配値の幅（best_ask - best_bid）
+	Spread float64
+	// 通貨ごとの最初の残高
+	Balances map[string]float64
+	// 証拠金取引の最初の証拠金
+	Collateral float64
+	// 取引手数料率（約定した数量に対する割合で，現物では受け取る通貨から差し引く）
+	CommissionRate float64
+	// 最初の時刻と，Stepで進める時間（StepDurationが0なら実際の時刻を使う）
+	Start        time.Time
+	StepDuration time.Duration
+}
+
+// 通貨ごとの残高と，指値注文で拘束している分
+type balance struct {
+	amount   float64
+	reserved float64
+}
+
+// 約定IDは銘柄をまたいで振るので，銘柄も記録しておく
+type execution struct {
+	productCode string
+	bitflyer.Execution
+}
+
+// 次のcount回のリクエストを失敗させる
+type failure struct {
+	status int
+	count  int
+}
+
+type Server struct {
+	mu         sync.Mutex
+	config     Config
+	step       int
+	states     map[string]bitflyer.BoardState
+	balances   map[string]*balance
+	collateral float64
+	orders     []*model.Order
+	executions []execution
+	positions  []bitflyer.Position
+	failures   map[string]*failure
+	mux        *http.ServeMux
+}
+
+func NewServer(config Config) *Server {
+	if config.Key == "" || config.Secret == "" {
+		return nil
+	}
+
+	if len(config.Prices) == 0 {
+		return nil
+	}
+	for _, prices := range config.Prices {
+		if len(prices) == 0 {
+			return nil
+		}
+		for _, price := range prices {
+			if price <= 0 {
+				return nil
+			}
+		}
+	}
+
+	if config.Spread < 0 || config.Collateral < 0 || config.CommissionRate < 0 || config.StepDuration < 0 {
+		return nil
+	}
+
+	if config.StepDuration > 0 && config.Start.IsZero() {
+		config.Start = time.Now().UTC().Truncate(time.Second)
+	}
+
+	s := &Server{
+		config:     config,
+		states:     make(map[string]bitflyer.BoardState),
+		balances:   make(map[string]*balance),
+		collateral: config.Collateral,
+		orders:     make([]*model.Order, 0),
+		executions: make([]execution, 0),
+		positions:  make([]bitflyer.Position, 0),
+		failures:   make(map[string]*failure),
+	}
+	for productCode := range config.Prices {
+		s.states[productCode] = bitflyer.BoardStateRunning
+	}
+	for currencyCode, amount := range config.Balances {
+		s.balances[currencyCode] = &balance{amount: amount}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/ticker", s.public(s.ticker))
+	mux.HandleFunc("/v1/getexecutions", s.public(s.getExecutions))
+	mux.HandleFunc("/v1/me/getbalance", s.private(s.getBalance))
+	mux.HandleFunc("/v1/me/sendchildorder", s.private(s.sendChildOrder))
+	mux.HandleFunc("/v1/me/getchildorders", s.private(s.getChildOrders))
+	mux.HandleFunc("/v1/me/cancelchildorder", s.private(s.cancelChildOrder))
+	mux.HandleFunc("/v1/me/getpositions", s.private(s.getPositions))
+	mux.HandleFunc("/v1/me/getcollateral", s.private(s.getCollateral))
+	// テストの操作用（署名は不要）
+	mux.HandleFunc("/fake/step", s.control(s.stepHandler))
+	mux.HandleFunc("/fake/fail", s.control(s.failHandler))
+	mux.HandleFunc("/fake/state", s.control(s.stateHandler))
+	s.mux = mux
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// 価格を1つ進め，他の参加者の約定を記録して，約定する価格になった指値注文を約定させる
+func (s *Server) Step() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before := make(map[string]float64)
+	for productCode := range s.config.Prices {
+		before[productCode] = s.price(productCode)
+	}
+	s.step++
+
+	productCodes := make([]string, 0, len(s.config.Prices))
+	for productCode := range s.config.Prices {
+		productCodes = append(productCodes, productCode)
+	}
+	sort.Strings(productCodes)
+
+	for _, productCode := range productCodes {
+		side := model.OrderSideBuy
+		if s.price(productCode) < before[productCode] {
+			side = model.OrderSideSell
+		}
+		s.addExecution(productCode, side, s.price(productCode), marketExecutionSize, "", "")
+
+		for _, order := range s.orders {
+			if order.ProductCode == productCode && order.ChildOrderState == model.OrderStateActive {
+				s.match(order)
+			}
+		}
+	}
+}
+
+// pathへの次のcount回のリクエストを，ステータスコードstatusで失敗させる
+// pathは"/v1/"より後ろ（例: "me/sendchildorder"）
+func (s *Server) FailNext(path string, status, count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if count <= 0 {
+		delete(s.failures, path)
+		return
+	}
+	s.failures[path] = &failure{status: status, count: count}
+}
+
+// 板の状態を変える（例: CLOSEDにするとティッカーの取得が失敗する）
+func (s *Server) SetState(productCode string, state bitflyer.BoardState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.states[productCode] = state
+}
+
+// 現在の最終取引価格
+func (s *Server) Price(productCode string) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.price(productCode)
+}
+
+// 通貨の残高（指値注文で拘束している分を含む）
+func (s *Server) Balance(currencyCode string) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.balances[currencyCode]
+	if !ok {
+		return 0
+	}
+	return b.amount
+}
+
+func (s *Server) price(productCode string) float64 {
+	prices, ok := s.config.Prices[productCode]
+	if !ok {
+		return 0
+	}
+	if s.step >= len(prices) {
+		return prices[len(prices)-1]
+	}
+	return prices[s.step]
+}
+
+func (s *Server) bestBid(productCode string) float64 {
+	return s.price(productCode) - s.config.Spread/2
+}
+
+func (s *Server) bestAsk(productCode string) float64 {
+	return s.price(productCode) + s.config.Spread/2
+}
+
+func (s *Server) now() time.Time {
+	if s.config.StepDuration == 0 {
+		return time.Now().UTC()
+	}
+	return s.config.Start.Add(time.Duration(s.step) * s.config.StepDuration).UTC()
+}
+
+func (s *Server) getBalanceOf(currencyCode string) *balance {
+	b, ok := s.balances[currencyCode]
+	if !ok {
+		b = &balance{}
+		s.balances[currencyCode] = b
+	}
+	return b
+}
+
+// ETH_JPYならETHとJPY
+func currencyCodes(productCode string) (string, string) {
+	codes := strings.Split(productCode, "_")
+	return codes[0], codes[len(codes)-1]
+}
+
+func (s *Server) addExecution(productCode string, side model.OrderSide, price, size float64, buyAcceptanceID, sellAcceptanceID string) {
+	s.executions = append(s.executions, execution{
+		productCode: productCode,
+		Execution: bitflyer.Execution{
+			ID:                         int64(len(s.executions) + 1),
+			Side:                       string(side),
+			Price:                      price,
+			Size:                       size,
+			ExecDate:                   s.now().Format(bitflyer.ExecDateFormat),
+			BuyChildOrderAcceptanceID:  buyAcceptanceID,
+			SellChildOrderAcceptanceID: sellAcceptanceID,
+		},
+	})
+}
+
+// 成行注文と，最良気配値が指値に届いた指値注文を約定させる
+func (s *Server) match(order *model.Order) {
+	price := s.bestAsk(order.ProductCode)
+	if order.Side == model.OrderSideSell {
+		price = s.bestBid(order.ProductCode)
+	}
+
+	if order.ChildOrderType == model.ChildOrderTypeLimit {
+		if order.Side == model.OrderSideBuy && price > order.Price {
+			return
+		}
+		if order.Side == model.OrderSideSell && price < order.Price {
+			return
+		}
+		s.release(order)
+	}
+
+	commission := order.Size * s.config.CommissionRate
+	if model.IsFXProduct(order.ProductCode) {
+		s.applyPosition(order.ProductCode, order.Side, price, order.Size)
+		commission = 0
+	} else {
+		base, quote := currencyCodes(order.ProductCode)
+		if order.Side == model.OrderSideBuy {
+			s.getBalanceOf(quote).amount -= price * order.Size
+			s.getBalanceOf(base).amount += order.Size - commission
+		} else {
+			s.getBalanceOf(base).amount -= order.Size
+			s.getBalanceOf(quote).amount += price * (order.Size - commission)
+		}
+	}
+
+	order.ChildOrderState = model.OrderStateCompleted
+	order.AveragePrice = price
+	order.ExecutedSize = order.Size
+	order.OutstandingSize = 0
+	order.TotalCommission = commission
+
+	buyAcceptanceID, sellAcceptanceID := order.ChildOrderAcceptanceID, ""
+	if order.Side == model.OrderSideSell {
+		buyAcceptanceID, sellAcceptanceID = "", order.ChildOrderAcceptanceID
+	}
+	s.addExecution(order.ProductCode, order.Side, price, order.Size, buyAcceptanceID, sellAcceptanceID)
+}
+
+// 現物の指値注文は，約定するまで代金または数量を拘束する
+func (s *Server) reserve(order *model.Order) {
+	if model.IsFXProduct(order.ProductCode) || order.ChildOrderType != model.ChildOrderTypeLimit {
+		return
+	}
+	base, quote := currencyCodes(order.ProductCode)
+	if order.Side == model.OrderSideBuy {
+		s.getBalanceOf(quote).reserved += order.Price * order.Size
+	} else {
+		s.getBalanceOf(base).reserved += order.Size
+	}
+}
+
+func (s *Server) release(order *model.Order) {
+	if model.IsFXProduct(order.ProductCode) || order.ChildOrderType != model.ChildOrderTypeLimit {
+		return
+	}
+	base, quote := currencyCodes(order.ProductCode)
+	if order.Side == model.OrderSideBuy {
+		s.getBalanceOf(quote).reserved -= order.Price * order.Size
+	} else {
+		s.getBalanceOf(base).reserved -= order.Size
+	}
+}
+
+// 反対方向の建玉を古い順に決済して損益を証拠金に加え，残りで新しく建てる
+func (s *Server) applyPosition(productCode string, side model.OrderSide, price, size float64) {
+	rest := size
+	positions := make([]bitflyer.Position, 0, len(s.positions)+1)
+	for _, position := range s.positions {
+		if rest > 0 && position.ProductCode == productCode && position.Side != bitflyer.OrderSide(side) {
+			closed := math.Min(rest, position.Size)
+			if position.Side == bitflyer.OrderSideBuy {
+				s.collateral += (price - position.Price) * closed
+			} else {
+				s.collateral += (position.Price - price) * closed
+			}
+			position.Size -= closed
+			position.RequireCollateral = position.Price * position.Size / leverage
+			rest -= closed
+		}
+		if position.Size > 1e-9 {
+			positions = append(positions, position)
+		}
+	}
+	if rest > 1e-9 {
+		positions = append(positions, bitflyer.Position{
+			ProductCode:       productCode,
+			Side:              bitflyer.OrderSide(side),
+			Price:             price,
+			Size:              rest,
+			RequireCollateral: price * rest / leverage,
+			OpenDate:          s.now().Format(bitflyer.TimestampFormat),
+			Leverage:          leverage,
+		})
+	}
+	s.positions = positions
+}
+
+func (s *Server) positionPnL(position bitflyer.Position) float64 {
+	pnl := (s.price(position.ProductCode) - position.Price) * position.Size
+	if position.Side == bitflyer.OrderSideSell {
+		return -pnl
+	}
+	return pnl
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// bitFlyerのエラーレスポンスの形式
+func writeError(w http.ResponseWriter, httpStatus, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":        status,
+		"error_message": message,
+		"data":          nil,
+	})
+}
+
+// FailNextで指定した失敗を返したらtrue
+func (s *Server) fail(w http.ResponseWriter, r *http.Request) bool {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	f, ok := s.failures[path]
+	if !ok {
+		return false
+	}
+	f.count--
+	if f.count <= 0 {
+		delete(s.failures, path)
+	}
+	writeError(w, f.status, -1, "fake failure")
+	return true
+}
+
+func (s *Server) public(handler func(w http.ResponseWriter, r *http.Request, body []byte)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.fail(w, r) {
+			return
+		}
+		handler(w, r, nil)
+	}
+}
+
+// 署名を検証してから処理する
+// 署名はタイムスタンプ，メソッド，クエリを含むパス，ボディをつなげた文字列のHMAC-SHA256
+func (s *Server) private(handler func(w http.ResponseWriter, r *http.Request, body []byte)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, -1, err.Error())
+			return
+		}
+
+		timestamp := r.Header.Get("ACCESS-TIMESTAMP")
+		unix, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil || math.Abs(float64(time.Since(time.Unix(unix, 0)))) > float64(timestampTolerance) {
+			writeError(w, http.StatusUnauthorized, -500, "Invalid timestamp")
+			return
+		}
+
+		mac := hmac.New(sha256.New, []byte(s.config.Secret))
+		mac.Write([]byte(timestamp + r.Method + r.URL.RequestURI() + string(body)))
+		sign := hex.EncodeToString(mac.Sum(nil))
+		if r.Header.Get("ACCESS-KEY") != s.config.Key || !hmac.Equal([]byte(r.Header.Get("ACCESS-SIGN")), []byte(sign)) {
+			writeError(w, http.StatusUnauthorized, -500, "Invalid signature")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.fail(w, r) {
+			return
+		}
+		handler(w, r, body)
+	}
+}
+
+func (s *Server) control(handler func(w http.ResponseWriter, r *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (s *Server) ticker(w http.ResponseWriter, r *http.Request, body []byte) {
+	productCode := r.URL.Query().Get("product_code")
+	if _, ok := s.config.Prices[productCode]; !ok {
+		writeError(w, http.StatusBadRequest, -205, "Invalid product")
+		return
+	}
+
+	volume := 0.0
+	for _, e := range s.executions {
+		if e.productCode == productCode {
+			volume += e.Size
+		}
+	}
+
+	writeJSON(w, bitflyer.Ticker{
+		ProductCode:     productCode,
+		State:           s.states[productCode],
+		Timestamp:       s.now().Format(bitflyer.TimestampFormat),
+		TickID:          s.step + 1,
+		BestBid:         s.bestBid(productCode),
+		BestAsk:         s.bestAsk(productCode),
+		BestBidSize:     1,
+		BestAskSize:     1,
+		TotalBidDepth:   100,
+		TotalAskDepth:   100,
+		Ltp:             s.price(productCode),
+		Volume:          volume,
+		VolumeByProduct: volume,
+	})
+}
+
+// 新しい順に返す
+func (s *Server) getExecutions(w http.ResponseWriter, r *http.Request, body []byte) {
+	query := r.URL.Query()
+	productCode := query.Get("product_code")
+	count, err := strconv.Atoi(query.Get("count"))
+	if err != nil || count <= 0 {
+		count = 100
+	}
+	before, _ := strconv.ParseInt(query.Get("before"), 10, 64)
+	after, _ := strconv.ParseInt(query.Get("after"), 10, 64)
+
+	executions := make([]bitflyer.Execution, 0)
+	for i := len(s.executions) - 1; i >= 0 && len(executions) < count; i-- {
+		e := s.executions[i]
+		if e.productCode != productCode {
+			continue
+		}
+		if (before > 0 && e.ID >= before) || (after > 0 && e.ID <= after) {
+			continue
+		}
+		executions = append(executions, e.Execution)
+	}
+
+	writeJSON(w, executions)
+}
+
+func (s *Server) getBalance(w http.ResponseWriter, r *http.Request, body []byte) {
+	currencyCodes := make([]string, 0, len(s.balances))
+	for currencyCode := range s.balances {
+		currencyCodes = append(currencyCodes, currencyCode)
+	}
+	sort.Strings(currencyCodes)
+
+	balances := make([]bitflyer.Balance, 0, len(currencyCodes))
+	for _, currencyCode := range currencyCodes {
+		b := s.balances[currencyCode]
+		balances = append(balances, bitflyer.Balance{
+			CurrencyCode: currencyCode,
+			Amount:       b.amount,
+			Available:    b.amount - b.reserved,
+		})
+	}
+
+	writeJSON(w, balances)
+}
+
+func (s *Server) sendChildOrder(w http.ResponseWriter, r *http.Request, body []byte) {
+	var order model.Order
+	if err := json.Unmarshal(body, &order); err != nil {
+		writeError(w, http.StatusBadRequest, -1, err.Error())
+		return
+	}
+
+	if _, ok := s.config.Prices[order.ProductCode]; !ok {
+		writeError(w, http.StatusBadRequest, -205, "Invalid product")
+		return
+	}
+	if s.states[order.ProductCode] != bitflyer.BoardStateRunning {
+		writeError(w, http.StatusBadRequest, -208, "Order is not accepted")
+		return
+	}
+	if order.Side != model.OrderSideBuy && order.Side != model.OrderSideSell {
+		writeError(w, http.StatusBadRequest, -1, "Invalid side")
+		return
+	}
+	if order.Size <= 0 {
+		writeError(w, http.StatusBadRequest, -110, "The minimum order size is 0.001")
+		return
+	}
+	switch order.ChildOrderType {
+	case model.ChildOrderTypeMarket:
+		order.Price = 0
+	case model.ChildOrderTypeLimit:
+		if order.Price <= 0 {
+			writeError(w, http.StatusBadRequest, -1, "Invalid price")
+			return
+		}
+	default:
+		writeError(w, http.StatusBadRequest, -1, "Invalid child_order_type")
+		return
+	}
+
+	// 現物は使える残高が足りなければ受け付けない
+	if !model.IsFXProduct(order.ProductCode) {
+		base, quote := currencyCodes(order.ProductCode)
+		if order.Side == model.OrderSideBuy {
+			price := order.Price
+			if order.ChildOrderType == model.ChildOrderTypeMarket {
+				price = s.bestAsk(order.ProductCode)
+			}
+			b := s.getBalanceOf(quote)
+			if b.amount-b.reserved < price*order.Size {
+				writeError(w, http.StatusBadRequest, -200, "Insufficient funds")
+				return
+			}
+		} else {
+			b := s.getBalanceOf(base)
+			if b.amount-b.reserved < order.Size {
+				writeError(w, http.StatusBadRequest, -200, "Insufficient funds")
+				return
+			}
+		}
+	}
+
+	now := s.now()
+	n := len(s.orders) + 1
+	order.ID = n
+	order.ChildOrderID = fmt.Sprintf("JOR%s-%06d", now.Format("20060102"), n)
+	order.ChildOrderAcceptanceID = fmt.Sprintf("JRF%s-%06d", now.Format("20060102"), n)
+	order.ChildOrderState = model.OrderStateActive
+	order.ChildOrderDate = now.Format(bitflyer.TimestampFormat)
+	order.OutstandingSize = order.Size
+	s.orders = append(s.orders, &order)
+
+	s.reserve(&order)
+	s.match(&order)
+
+	writeJSON(w, bitflyer.ResponseSendChildOrder{ChildOrderAcceptanceID: order.ChildOrderAcceptanceID})
+}
+
+// 新しい順に返す
+func (s *Server) getChildOrders(w http.ResponseWriter, r *http.Request, body []byte) {
+	query := r.URL.Query()
+	productCode := query.Get("product_code")
+	acceptanceID := query.Get("child_order_acceptance_id")
+	state := model.OrderState(query.Get("child_order_state"))
+
+	orders := make([]model.Order, 0)
+	for i := len(s.orders) - 1; i >= 0; i-- {
+		order := s.orders[i]
+		if order.ProductCode != productCode {
+			continue
+		}
+		if acceptanceID != "" && order.ChildOrderAcceptanceID != acceptanceID {
+			continue
+		}
+		if state != "" && order.ChildOrderState != state {
+			continue
+		}
+		orders = append(orders, *order)
+	}
+
+	writeJSON(w, orders)
+}
+
+// 成功したときはレスポンスが空になる
+func (s *Server) cancelChildOrder(w http.ResponseWriter, r *http.Request, body []byte) {
+	var request bitflyer.RequestCancelChildOrder
+	if err := json.Unmarshal(body, &request); err != nil {
+		writeError(w, http.StatusBadRequest, -1, err.Error())
+		return
+	}
+
+	for _, order := range s.orders {
+		if order.ProductCode != request.ProductCode || order.ChildOrderAcceptanceID != request.ChildOrderAcceptanceID {
+			continue
+		}
+		if order.ChildOrderState != model.OrderStateActive {
+			break
+		}
+		s.release(order)
+		order.ChildOrderState = model.OrderStateCanceled
+		order.CancelSize = order.OutstandingSize
+		order.OutstandingSize = 0
+		return
+	}
+
+	writeError(w, http.StatusBadRequest, -111, "Order not found")
+}
+
+func (s *Server) getPositions(w http.ResponseWriter, r *http.Request, body []byte) {
+	productCode := r.URL.Query().Get("product_code")
+
+	positions := make([]bitflyer.Position, 0)
+	for _, position := range s.positions {
+		if position.ProductCode != productCode {
+			continue
+		}
+		position.Pnl = s.positionPnL(position)
+		positions = append(positions, position)
+	}
+
+	writeJSON(w, positions)
+}
+
+func (s *Server) getCollateral(w http.ResponseWriter, r *http.Request, body []byte) {
+	pnl, require := 0.0, 0.0
+	for _, position := range s.positions {
+		pnl += s.positionPnL(position)
+		require += position.RequireCollateral
+	}
+
+	keepRate := 0.0
+	if require > 0 {
+		keepRate = (s.collateral + pnl) / require
+	}
+
+	writeJSON(w, bitflyer.Collateral{
+		Collateral:        s.collateral,
+		OpenPositionPnl:   pnl,
+		RequireCollateral: require,
+		KeepRate:          keepRate,
+	})
+}
+
+func (s *Server) stepHandler(w http.ResponseWriter, r *http.Request) {
+	s.Step()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ?path=me/sendchildorder&status=500&count=1
+func (s *Server) failHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	status, err := strconv.Atoi(query.Get("status"))
+	if err != nil || status < 400 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	count, err := strconv.Atoi(query.Get("count"))
+	if err != nil {
+		count = 1
+	}
+	s.FailNext(query.Get("path"), status, count)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ?product_code=ETH_JPY&state=CLOSED
+func (s *Server) stateHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.SetState(query.Get("product_code"), bitflyer.BoardState(query.Get("state")))
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,220 @@
+package fake_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer/fake"
+)
+
+func newFakeServer(t *testing.T) (*fake.Server, *bitflyer.Client) {
+	server := fake.NewServer(fake.Config{
+		Key:    "key",
+		Secret: "secret",
+		Prices: map[string][]float64{
+			"ETH_JPY":    {500000, 490000, 510000},
+			"FX_BTC_JPY": {6000000, 6100000},
+		},
+		Spread:       100,
+		Balances:     map[string]float64{"JPY": 100000, "ETH": 0.1},
+		Collateral:   1000000,
+		Start:        time.Date(2021, 11, 9, 0, 0, 0, 0, time.UTC),
+		StepDuration: time.Hour,
+	})
+	if server == nil {
+		t.Fatal("NewServer() returns nil")
+	}
+
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	return server, bitflyer.NewClientWithBaseURL("key", "secret", ts.URL+"/v1/")
+}
+
+func TestServer(t *testing.T) {
+	if fake.NewServer(fake.Config{Key: "key", Secret: "secret"}) != nil {
+		t.Fatal("NewServer() without prices returns not nil")
+	}
+
+	t.Run("ticker", func(t *testing.T) {
+		server, apiClient := newFakeServer(t)
+		tickerRepository := bitflyer.NewBitflyerTickerRepository(apiClient)
+
+		ticker, err := tickerRepository.Fetch("ETH_JPY")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ticker.Ltp() != 500000 || ticker.BestBid() != 499950 || ticker.BestAsk() != 500050 || ticker.Timestamp() != "2021-11-09T00:00:00" {
+			t.Fatalf("ticker=%+v", ticker)
+		}
+
+		// 価格の推移の最後まで進んだら，最後の価格のまま
+		for i := 0; i < 3; i++ {
+			server.Step()
+		}
+		ticker, err = tickerRepository.Fetch("ETH_JPY")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ticker.Ltp() != 510000 || ticker.Timestamp() != "2021-11-09T03:00:00" {
+			t.Fatalf("ticker=%+v", ticker)
+		}
+
+		server.SetState("ETH_JPY", bitflyer.BoardStateClosed)
+		if _, err := tickerRepository.Fetch("ETH_JPY"); err == nil {
+			t.Fatal("Fetch() returns no error while board is closed")
+		}
+	})
+
+	t.Run("limit order is filled on step", func(t *testing.T) {
+		server, apiClient := newFakeServer(t)
+		orderRepository := bitflyer.NewBitflyerOrderRepository(apiClient)
+		balanceRepository := bitflyer.NewBitFlyerBalanceRepository(apiClient)
+
+		acceptanceID, err := orderRepository.Place(*model.NewLimitBuyOrder("ETH_JPY", 495000, 0.1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		order, err := orderRepository.Find("ETH_JPY", acceptanceID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.ChildOrderState != model.OrderStateActive {
+			t.Fatalf("order=%+v", order)
+		}
+
+		// 注文中の代金は使えない
+		jpy, err := balanceRepository.FetchByCurrencyCode("JPY")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if jpy.Amount() != 100000 || jpy.Available() != 100000-49500 {
+			t.Fatalf("jpy=%+v", jpy)
+		}
+
+		// 490000まで下がったら最良気配値で約定する
+		server.Step()
+		order, err = orderRepository.Find("ETH_JPY", acceptanceID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.ChildOrderState != model.OrderStateCompleted || order.AveragePrice != 490050 {
+			t.Fatalf("order=%+v", order)
+		}
+		if server.Balance("JPY") != 100000-49005 || server.Balance("ETH") != 0.2 {
+			t.Fatalf("JPY=%f, ETH=%f", server.Balance("JPY"), server.Balance("ETH"))
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		_, apiClient := newFakeServer(t)
+		orderRepository := bitflyer.NewBitflyerOrderRepository(apiClient)
+
+		acceptanceID, err := orderRepository.Place(*model.NewLimitSellOrder("ETH_JPY", 600000, 0.1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := orderRepository.Cancel("ETH_JPY", acceptanceID); err != nil {
+			t.Fatal(err)
+		}
+		// 取り消し済みの注文は取り消せない
+		if err := orderRepository.Cancel("ETH_JPY", acceptanceID); err == nil {
+			t.Fatal("Cancel() returns no error")
+		}
+		// 数量が足りない
+		if _, err := orderRepository.Place(*model.NewLimitSellOrder("ETH_JPY", 600000, 1)); err == nil {
+			t.Fatal("Place() returns no error")
+		}
+	})
+
+	t.Run("executions", func(t *testing.T) {
+		server, apiClient := newFakeServer(t)
+		orderRepository := bitflyer.NewBitflyerOrderRepository(apiClient)
+		executionRepository := bitflyer.NewBitflyerExecutionRepository(apiClient)
+
+		server.Step()
+		if _, err := orderRepository.Place(*model.NewSellOrder("ETH_JPY", 0.05)); err != nil {
+			t.Fatal(err)
+		}
+		server.Step()
+
+		executions, err := executionRepository.FetchAll("ETH_JPY", 10, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Stepごとの約定と成行注文の約定（FX_BTC_JPYの約定は含まない）
+		if len(executions) != 3 {
+			t.Fatalf("executions=%+v", executions)
+		}
+		if executions[1].Side() != model.OrderSideSell || executions[1].Price() != 489950 || executions[1].Size() != 0.05 {
+			t.Fatalf("executions=%+v", executions)
+		}
+	})
+
+	t.Run("fx", func(t *testing.T) {
+		server, apiClient := newFakeServer(t)
+		orderRepository := bitflyer.NewBitflyerOrderRepository(apiClient)
+		fxRepository := bitflyer.NewBitflyerFXRepository(apiClient)
+
+		if _, err := orderRepository.Place(*model.NewSellOrder("FX_BTC_JPY", 0.2)); err != nil {
+			t.Fatal(err)
+		}
+		positions, err := fxRepository.FetchPositions("FX_BTC_JPY")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(positions) != 1 || positions[0].Side() != model.OrderSideSell || positions[0].Price() != 5999950 {
+			t.Fatalf("positions=%+v", positions)
+		}
+
+		// 価格が上がるとショートの評価損が出る
+		server.Step()
+		collateral, err := fxRepository.FetchCollateral()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if collateral.OpenPositionPnL() != -0.2*100050 || collateral.RequireCollateral() != 5999950*0.2/2 {
+			t.Fatalf("collateral=%+v", collateral)
+		}
+
+		// 決済すると損益が証拠金に加わる
+		if _, err := orderRepository.Place(*model.NewBuyOrder("FX_BTC_JPY", 0.2)); err != nil {
+			t.Fatal(err)
+		}
+		collateral, err = fxRepository.FetchCollateral()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if collateral.RequireCollateral() != 0 || collateral.Collateral() != 1000000-0.2*100100 {
+			t.Fatalf("collateral=%+v", collateral)
+		}
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		server, apiClient := newFakeServer(t)
+		orderRepository := bitflyer.NewBitflyerOrderRepository(apiClient)
+
+		server.FailNext("me/sendchildorder", http.StatusInternalServerError, 1)
+		if _, err := orderRepository.Place(*model.NewBuyOrder("ETH_JPY", 0.01)); err == nil {
+			t.Fatal("Place() returns no error")
+		}
+		if _, err := orderRepository.Place(*model.NewBuyOrder("ETH_JPY", 0.01)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		server, _ := newFakeServer(t)
+		ts := httptest.NewServer(server)
+		defer ts.Close()
+
+		balanceRepository := bitflyer.NewBitFlyerBalanceRepository(bitflyer.NewClientWithBaseURL("key", "invalid", ts.URL+"/v1/"))
+		if _, err := balanceRepository.FetchAll(); err == nil {
+			t.Fatal("FetchAll() returns no error")
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package bitflyer
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type Position struct {
+	ProductCode         string    `json:"product_code"`
+	Side                OrderSide `json:"side"`
+	Price               float64   `json:"price"`
+	Size                float64   `json:"size"`
+	Commission          float64   `json:"commission"`
+	SwapPointAccumulate float64   `json:"swap_point_accumulate"`
+	RequireCollateral   float64   `json:"require_collateral"`
+	OpenDate            string    `json:"open_date"`
+	Leverage            float64   `json:"leverage"`
+	Pnl                 float64   `json:"pnl"`
+	Sfd                 float64   `json:"sfd"`
+}
+
+func (p *Position) toDomainModelFXPosition() *model.FXPosition {
+	// 小数点以下の秒があっても読める
+	openDate, err := time.Parse(TimestampFormat, p.OpenDate)
+	if err != nil {
+		return nil
+	}
+
+	return model.NewFXPosition(
+		p.ProductCode,
+		model.OrderSide(p.Side),
+		p.Price,
+		p.Size,
+		p.Pnl,
+		p.RequireCollateral,
+		openDate,
+	)
+}
+
+type Collateral struct {
+	Collateral        float64 `json:"collateral"`
+	OpenPositionPnl   float64 `json:"open_position_pnl"`
+	RequireCollateral float64 `json:"require_collateral"`
+	KeepRate          float64 `json:"keep_rate"`
+}
+
+func (c *Collateral) toDomainModelCollateral() *model.Collateral {
+	return model.NewCollateral(c.Collateral, c.OpenPositionPnl, c.RequireCollateral, c.KeepRate)
+}
+
+type bitflyerFXRepository struct {
+	apiClient *Client
+}
+
+func NewBitflyerFXRepository(apiClient *Client) repository.FXRepository {
+	return &bitflyerFXRepository{
+		apiClient: apiClient,
+	}
+}
+
+func (bfr *bitflyerFXRepository) FetchPositions(productCode string) ([]model.FXPosition, error) {
+	path := "me/getpositions"
+	query := map[string]string{"product_code": productCode}
+	resp, err := bfr.apiClient.doRequest("GET", path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []Position
+	err = json.Unmarshal(resp, &positions)
+	if err != nil {
+		return nil, err
+	}
+
+	// ドメインモデルに移し替える
+	domainModelPositions := make([]model.FXPosition, len(positions))
+	for i, position := range positions {
+		domainModelPosition := position.toDomainModelFXPosition()
+		if domainModelPosition == nil {
+			return nil, errors.New("invalid position fetched")
+		}
+		domainModelPositions[i] = *domainModelPosition
+	}
+
+	return domainModelPositions, nil
+}
+
+func (bfr *bitflyerFXRepository) FetchCollateral() (*model.Collateral, error) {
+	path := "me/getcollateral"
+	resp, err := bfr.apiClient.doRequest("GET", path, map[string]string{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var collateral Collateral
+	err = json.Unmarshal(resp, &collateral)
+	if err != nil {
+		return nil, err
+	}
+
+	domainModelCollateral := collateral.toDomainModelCollateral()
+	if domainModelCollateral == nil {
+		return nil, errors.New("invalid collateral fetched")
+	}
+
+	return domainModelCollateral, nil
+}
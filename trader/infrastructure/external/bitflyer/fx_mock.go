@@ -0,0 +1,108 @@
+package bitflyer
+
+import (
+	"math"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+// Lightning FXのレバレッジ
+const fxMockLeverage = 2
+
+// 成行注文を建玉に反映する証拠金取引のモック
+type bitflyerFXMockRepository struct {
+	*bitflyerOrderMockRepository
+	price      float64
+	collateral float64
+	positions  []Position
+}
+
+// 注文を出すOrderRepositoryと，建玉を取得するFXRepositoryを返す
+// 成行注文は全てpriceで約定し，評価損益は出ない
+func NewBitflyerFXMockRepositories(price, collateral float64) (repository.OrderRepository, repository.FXRepository) {
+	mock := &bitflyerFXMockRepository{
+		bitflyerOrderMockRepository: &bitflyerOrderMockRepository{
+			orders: make(map[string]model.Order),
+		},
+		price:      price,
+		collateral: collateral,
+		positions:  make([]Position, 0),
+	}
+	return mock, mock
+}
+
+// 反対方向の建玉を古い順に決済し，残りで新しく建てる
+func (bfr *bitflyerFXMockRepository) Send(order model.Order) (*model.Order, error) {
+	rest := order.Size
+	positions := make([]Position, 0, len(bfr.positions)+1)
+	for _, position := range bfr.positions {
+		if rest > 0 && position.ProductCode == order.ProductCode && position.Side != OrderSide(order.Side) {
+			closed := math.Min(rest, position.Size)
+			position.Size -= closed
+			position.RequireCollateral = position.Price * position.Size / fxMockLeverage
+			rest -= closed
+		}
+		if position.Size > 1e-9 {
+			positions = append(positions, position)
+		}
+	}
+	if rest > 1e-9 {
+		positions = append(positions, Position{
+			ProductCode:       order.ProductCode,
+			Side:              OrderSide(order.Side),
+			Price:             bfr.price,
+			Size:              rest,
+			RequireCollateral: bfr.price * rest / fxMockLeverage,
+			OpenDate:          time.Now().UTC().Format(TimestampFormat),
+			Leverage:          fxMockLeverage,
+		})
+	}
+	bfr.positions = positions
+
+	completedOrder := &model.Order{
+		ProductCode:     order.ProductCode,
+		ChildOrderType:  order.ChildOrderType,
+		Side:            order.Side,
+		AveragePrice:    bfr.price,
+		Size:            order.Size,
+		MinuteToExpires: order.MinuteToExpires,
+		TimeInForce:     order.TimeInForce,
+		ChildOrderState: model.OrderState(OrderStateCompleted),
+		ChildOrderDate:  time.Now().Format(TimestampFormat),
+	}
+
+	return completedOrder, nil
+}
+
+func (bfr *bitflyerFXMockRepository) FetchPositions(productCode string) ([]model.FXPosition, error) {
+	// ドメインモデルに移し替える
+	domainModelPositions := make([]model.FXPosition, 0, len(bfr.positions))
+	for _, position := range bfr.positions {
+		if position.ProductCode != productCode {
+			continue
+		}
+		domainModelPositions = append(domainModelPositions, *position.toDomainModelFXPosition())
+	}
+
+	return domainModelPositions, nil
+}
+
+func (bfr *bitflyerFXMockRepository) FetchCollateral() (*model.Collateral, error) {
+	requireCollateral := 0.0
+	for _, position := range bfr.positions {
+		requireCollateral += position.RequireCollateral
+	}
+
+	collateral := Collateral{
+		Collateral:        bfr.collateral,
+		OpenPositionPnl:   0,
+		RequireCollateral: requireCollateral,
+	}
+	if requireCollateral > 0 {
+		collateral.KeepRate = bfr.collateral / requireCollateral
+	}
+
+	return collateral.toDomainModelCollateral(), nil
+}
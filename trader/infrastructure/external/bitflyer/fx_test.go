@@ -0,0 +1,76 @@
+package bitflyer_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+)
+
+func TestBitflyerFXRepository(t *testing.T) {
+	apiClient := bitflyer.NewClient(config.APIKey, config.APISecret)
+	fxRepository := bitflyer.NewBitflyerFXRepository(apiClient)
+
+	t.Run("fetch positions", func(t *testing.T) {
+		positions, err := fxRepository.FetchPositions("FX_BTC_JPY")
+		if err != nil {
+			t.Skip(err.Error())
+		}
+		t.Log(positions)
+	})
+
+	t.Run("fetch collateral", func(t *testing.T) {
+		collateral, err := fxRepository.FetchCollateral()
+		if err != nil {
+			t.Skip(err.Error())
+		}
+		t.Log(collateral)
+	})
+}
+
+func TestBitflyerFXMockRepository(t *testing.T) {
+	productCode := "FX_BTC_JPY"
+	orderRepository, fxRepository := bitflyer.NewBitflyerFXMockRepositories(5000000, 300000)
+
+	send := func(order *model.Order) {
+		if _, err := orderRepository.Send(*order); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	t.Run("open short", func(t *testing.T) {
+		send(model.NewSellOrder(productCode, 0.1))
+
+		positions, err := fxRepository.FetchPositions(productCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(positions) != 1 || positions[0].Side() != model.OrderSideSell || positions[0].Size() != 0.1 {
+			t.Fatalf("positions=%+v", positions)
+		}
+
+		// 必要証拠金は 5000000 * 0.1 / 2
+		collateral, err := fxRepository.FetchCollateral()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if collateral.RequireCollateral() != 250000 || collateral.KeepRate() != 1.2 {
+			t.Fatalf("collateral=%+v", collateral)
+		}
+	})
+
+	t.Run("close short and open long", func(t *testing.T) {
+		send(model.NewBuyOrder(productCode, 0.15))
+
+		positions, err := fxRepository.FetchPositions(productCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		net := model.NewFXNetPosition(positions)
+		if net.Side() != model.OrderSideBuy || math.Abs(net.Size()-0.05) > 1e-9 {
+			t.Fatalf("side=%s, size=%f", net.Side(), net.Size())
+		}
+	})
+}
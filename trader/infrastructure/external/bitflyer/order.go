@@ -3,6 +3,7 @@ package bitflyer
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
@@ -30,6 +31,11 @@ type ResponseSendChildOrder struct {
 	ChildOrderAcceptanceID string `json:"child_order_acceptance_id"`
 }
 
+type RequestCancelChildOrder struct {
+	ProductCode            string `json:"product_code"`
+	ChildOrderAcceptanceID string `json:"child_order_acceptance_id"`
+}
+
 type bitflyerOrderRepository struct {
 	apiClient *Client
 }
@@ -41,33 +47,75 @@ func NewBitflyerOrderRepository(apiClient *Client) repository.OrderRepository {
 }
 
 func (bor *bitflyerOrderRepository) Send(order model.Order) (*model.Order, error) {
-	data, err := json.Marshal(order)
+	childOrderAcceptanceId, err := bor.Place(order)
 	if err != nil {
 		return nil, err
 	}
 
+	completedOrder := bor.waitUntilOrderComplete(order.ProductCode, childOrderAcceptanceId)
+	if completedOrder == nil {
+		return nil, errors.New("order is not completed")
+	}
+
+	return completedOrder, nil
+}
+
+func (bor *bitflyerOrderRepository) Place(order model.Order) (string, error) {
+	data, err := json.Marshal(order)
+	if err != nil {
+		return "", err
+	}
+
 	url := "me/sendchildorder"
 	resp, err := bor.apiClient.doRequest("POST", url, map[string]string{}, data)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	var response ResponseSendChildOrder
 	if err = json.Unmarshal(resp, &response); err != nil {
+		return "", err
+	}
+
+	if response.ChildOrderAcceptanceID == "" {
+		return "", errors.New("order send, but child_order_acceptance_id is none")
+	}
+
+	return response.ChildOrderAcceptanceID, nil
+}
+
+func (bor *bitflyerOrderRepository) Find(productCode, acceptanceID string) (*model.Order, error) {
+	orders, err := bor.FetchById(productCode, acceptanceID)
+	if err != nil {
 		return nil, err
 	}
+	// 注文直後は一覧に反映されていないことがある
+	if len(orders) == 0 {
+		return nil, nil
+	}
+	return &orders[0], nil
+}
 
-	childOrderAcceptanceId := response.ChildOrderAcceptanceID
-	if childOrderAcceptanceId == "" {
-		return nil, errors.New("order send, but child_order_acceptance_id is none")
+func (bor *bitflyerOrderRepository) Cancel(productCode, acceptanceID string) error {
+	data, err := json.Marshal(RequestCancelChildOrder{
+		ProductCode:            productCode,
+		ChildOrderAcceptanceID: acceptanceID,
+	})
+	if err != nil {
+		return err
 	}
 
-	completedOrder := bor.waitUntilOrderComplete(order.ProductCode, childOrderAcceptanceId)
-	if completedOrder == nil {
-		return nil, errors.New("order is not completed")
+	// 成功したときはレスポンスが空になる
+	url := "me/cancelchildorder"
+	resp, err := bor.apiClient.doRequest("POST", url, map[string]string{}, data)
+	if err != nil {
+		return err
+	}
+	if len(resp) > 0 {
+		return errors.New(fmt.Sprint("cannot cancel order: ", string(resp)))
 	}
 
-	return completedOrder, nil
+	return nil
 }
 
 func (bor *bitflyerOrderRepository) waitUntilOrderComplete(productCode, orderId string) *model.Order {
@@ -1,6 +1,8 @@
 package bitflyer
 
 import (
+	"errors"
+	"fmt"
 	"math/rand"
 	"time"
 
@@ -9,10 +11,14 @@ import (
 )
 
 type bitflyerOrderMockRepository struct {
+	// Placeで出した注文
+	orders map[string]model.Order
 }
 
 func NewBitflyerOrderMockRepository() repository.OrderRepository {
-	return &bitflyerOrderMockRepository{}
+	return &bitflyerOrderMockRepository{
+		orders: make(map[string]model.Order),
+	}
 }
 
 func (bor *bitflyerOrderMockRepository) Send(order model.Order) (*model.Order, error) {
@@ -34,3 +40,44 @@ func (bor *bitflyerOrderMockRepository) Send(order model.Order) (*model.Order, e
 
 	return completedOrder, nil
 }
+
+// 出した注文は，Findで取得したときに指値で約定している
+func (bor *bitflyerOrderMockRepository) Place(order model.Order) (string, error) {
+	acceptanceID := fmt.Sprintf("MOCK%d", len(bor.orders)+1)
+
+	bor.orders[acceptanceID] = model.Order{
+		ProductCode:            order.ProductCode,
+		ChildOrderType:         order.ChildOrderType,
+		Side:                   order.Side,
+		Price:                  order.Price,
+		AveragePrice:           order.Price,
+		Size:                   order.Size,
+		MinuteToExpires:        order.MinuteToExpires,
+		TimeInForce:            order.TimeInForce,
+		ChildOrderState:        model.OrderState(OrderStateCompleted),
+		ChildOrderDate:         time.Now().Format(TimestampFormat),
+		ChildOrderAcceptanceID: acceptanceID,
+		ExecutedSize:           order.Size,
+		TotalCommission:        order.Size * 0.0015,
+	}
+
+	return acceptanceID, nil
+}
+
+func (bor *bitflyerOrderMockRepository) Find(productCode, acceptanceID string) (*model.Order, error) {
+	order, ok := bor.orders[acceptanceID]
+	if !ok || order.ProductCode != productCode {
+		return nil, nil
+	}
+	return &order, nil
+}
+
+func (bor *bitflyerOrderMockRepository) Cancel(productCode, acceptanceID string) error {
+	order, ok := bor.orders[acceptanceID]
+	if !ok || order.ProductCode != productCode {
+		return errors.New("order not found")
+	}
+	order.ChildOrderState = model.OrderState(OrderStateCanceled)
+	bor.orders[acceptanceID] = order
+	return nil
+}
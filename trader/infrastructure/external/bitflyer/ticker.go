@@ -3,6 +3,7 @@ package bitflyer
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
@@ -85,7 +86,7 @@ func (btr *bitflyerTickerRepository) Fetch(productCode string) (*model.Ticker, e
 		return nil, err
 	}
 	if ticker.State != BoardStateRunning {
-		return nil, errors.New("bitflyer is not running")
+		return nil, fmt.Errorf("bitflyer: %w", model.ErrBoardNotRunning)
 	}
 
 	domainModelTicker := ticker.toDomainModelTicker()
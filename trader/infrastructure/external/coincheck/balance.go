@@ -0,0 +1,89 @@
+package coincheck
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+// 通貨ごとの利用可能な残高（"jpy"）と，注文中の残高（"jpy_reserved"）などが1つのオブジェクトで返される
+type ResponseBalance map[string]interface{}
+
+func (rb ResponseBalance) toDomainModelBalances() []model.Balance {
+	currencies := make([]string, 0)
+	for key := range rb {
+		if key == "success" || strings.Contains(key, "_") {
+			continue
+		}
+		currencies = append(currencies, key)
+	}
+	sort.Strings(currencies)
+
+	balances := make([]model.Balance, 0, len(currencies))
+	for _, currency := range currencies {
+		available := rb.value(currency)
+		reserved := rb.value(currency + "_reserved")
+		balance := model.NewBalance(strings.ToUpper(currency), available+reserved, available)
+		if balance == nil {
+			continue
+		}
+		balances = append(balances, *balance)
+	}
+	return balances
+}
+
+func (rb ResponseBalance) value(key string) float64 {
+	s, ok := rb[key].(string)
+	if !ok {
+		return 0
+	}
+	return parseFloat(s)
+}
+
+type coincheckBalanceRepository struct {
+	apiClient *Client
+}
+
+func NewCoincheckBalanceRepository(apiClient *Client) repository.BalanceRepository {
+	return &coincheckBalanceRepository{
+		apiClient: apiClient,
+	}
+}
+
+func (cbr *coincheckBalanceRepository) FetchAll() ([]model.Balance, error) {
+	path := "api/accounts/balance"
+	resp, err := cbr.apiClient.doRequest("GET", path, map[string]string{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var balance ResponseBalance
+	err = json.Unmarshal(resp, &balance)
+	if err != nil {
+		return nil, err
+	}
+	if success, _ := balance["success"].(bool); !success {
+		return nil, errors.New("cannot fetch balance")
+	}
+
+	return balance.toDomainModelBalances(), nil
+}
+
+func (cbr *coincheckBalanceRepository) FetchByCurrencyCode(currencyCode string) (*model.Balance, error) {
+	balances, err := cbr.FetchAll()
+	if err != nil {
+		return nil, errors.New("cannot fetch balance")
+	}
+
+	for _, balance := range balances {
+		if balance.CurrencyCode() == currencyCode {
+			return &balance, nil
+		}
+	}
+
+	return nil, errors.New("invalid currencyCode")
+}
@@ -0,0 +1,106 @@
+package coincheck
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const baseURL = "https://coincheck.com/"
+
+// 2xx以外のステータスコードが返ったときのエラー
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("coincheck api error: status=%d, body=%s", e.StatusCode, e.Body)
+}
+
+type Client struct {
+	key        string
+	secret     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewClient(key, secret string) *Client {
+	return NewClientWithBaseURL(key, secret, baseURL)
+}
+
+// 接続先を変える（テスト用のサーバなど）
+func NewClientWithBaseURL(key, secret, baseURL string) *Client {
+	c := &Client{
+		key:        key,
+		secret:     secret,
+		baseURL:    baseURL,
+		httpClient: &http.Client{},
+	}
+	return c
+}
+
+// 署名はnonce，クエリを含むURL，ボディをつなげた文字列から作る
+func (c *Client) header(endpoint string, body []byte) map[string]string {
+	nonce := strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	text := nonce + endpoint + string(body)
+
+	mac := hmac.New(sha256.New, []byte(c.secret))
+	mac.Write([]byte(text))
+	sign := hex.EncodeToString(mac.Sum(nil))
+
+	return map[string]string{
+		"ACCESS-KEY":       c.key,
+		"ACCESS-NONCE":     nonce,
+		"ACCESS-SIGNATURE": sign,
+		"Content-Type":     "application/json",
+	}
+}
+
+func (c *Client) doRequest(method, path string, query map[string]string, body []byte) (respBody []byte, err error) {
+	baseUrl, err := url.Parse(c.baseURL)
+	if err != nil {
+		return
+	}
+	apiURL, err := url.Parse(path)
+	if err != nil {
+		return
+	}
+	endpoint := baseUrl.ResolveReference(apiURL).String()
+	fmt.Printf("[doRequest] %s %s\n", method, endpoint)
+
+	req, err := http.NewRequest(method, endpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return
+	}
+	q := req.URL.Query()
+	for key, value := range query {
+		q.Add(key, value)
+	}
+	req.URL.RawQuery = q.Encode()
+	for key, value := range c.header(req.URL.String(), body) {
+		req.Header.Add(key, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+	return respBody, nil
+}
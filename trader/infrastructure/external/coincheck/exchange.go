@@ -0,0 +1,39 @@
+package coincheck
+
+import "github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+
+type coincheckExchange struct {
+	tickerRepository    repository.TickerRepository
+	balanceRepository   repository.BalanceRepository
+	orderRepository     repository.OrderRepository
+	executionRepository repository.ExecutionRepository
+}
+
+func NewCoincheckExchange(apiClient *Client) repository.ExchangeRepository {
+	return &coincheckExchange{
+		tickerRepository:    NewCoincheckTickerRepository(apiClient),
+		balanceRepository:   NewCoincheckBalanceRepository(apiClient),
+		orderRepository:     NewCoincheckOrderRepository(apiClient),
+		executionRepository: NewCoincheckExecutionRepository(apiClient),
+	}
+}
+
+func (ce *coincheckExchange) Name() string {
+	return "coincheck"
+}
+
+func (ce *coincheckExchange) Ticker() repository.TickerRepository {
+	return ce.tickerRepository
+}
+
+func (ce *coincheckExchange) Balance() repository.BalanceRepository {
+	return ce.balanceRepository
+}
+
+func (ce *coincheckExchange) Order() repository.OrderRepository {
+	return ce.orderRepository
+}
+
+func (ce *coincheckExchange) Execution() repository.ExecutionRepository {
+	return ce.executionRepository
+}
@@ -0,0 +1,234 @@
+package coincheck_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/coincheck"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/exchangetest"
+)
+
+const (
+	standInKey    = "key"
+	standInSecret = "secret"
+)
+
+// CoincheckのAPIの代わりのサーバ
+// 指値注文は受け付けるだけで，成行注文は2回に分けてすぐに約定させる
+type coincheckStandIn struct {
+	t            *testing.T
+	orders       map[int64]coincheck.Order
+	transactions []coincheck.Transaction
+}
+
+func newCoincheckStandIn(t *testing.T) *httptest.Server {
+	s := &coincheckStandIn{
+		t:            t,
+		orders:       make(map[int64]coincheck.Order),
+		transactions: make([]coincheck.Transaction, 0),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/ticker", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pair") != "eth_jpy" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(coincheck.Ticker{
+			Last:      540284,
+			Bid:       540284,
+			Ask:       540437,
+			High:      550000,
+			Low:       530000,
+			Volume:    1250.5,
+			Timestamp: 1636457471,
+		})
+	})
+	mux.HandleFunc("/api/accounts/balance", s.private(func(w http.ResponseWriter, r *http.Request, body []byte) {
+		w.Write([]byte(`{"success":true,"jpy":"90000.0","eth":"0.5","jpy_reserved":"10000.0","eth_reserved":"0","jpy_lend_in_use":"0"}`))
+	}))
+	mux.HandleFunc("/api/exchange/orders", s.private(s.placeOrder))
+	mux.HandleFunc("/api/exchange/orders/transactions", s.private(func(w http.ResponseWriter, r *http.Request, body []byte) {
+		json.NewEncoder(w).Encode(coincheck.ResponseTransactions{Success: true, Transactions: s.transactions})
+	}))
+	mux.HandleFunc("/api/exchange/orders/", s.private(s.order))
+	mux.HandleFunc("/api/trades", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		startingAfter, _ := strconv.ParseInt(query.Get("starting_after"), 10, 64)
+		endingBefore, _ := strconv.ParseInt(query.Get("ending_before"), 10, 64)
+
+		// IDが1から10までの約定を新しい順に返す
+		trades := make([]coincheck.Trade, 0)
+		for id := int64(10); id >= 1 && len(trades) < limit; id-- {
+			if (startingAfter > 0 && id >= startingAfter) || (endingBefore > 0 && id <= endingBefore) {
+				continue
+			}
+			trades = append(trades, coincheck.Trade{
+				ID:        id,
+				Amount:    "0.1",
+				Rate:      fmt.Sprint(540000 + id),
+				Pair:      "eth_jpy",
+				OrderType: "buy",
+				CreatedAt: fmt.Sprintf("2021-11-09T00:00:%02d.000Z", id),
+			})
+		}
+		json.NewEncoder(w).Encode(coincheck.ResponseTrades{Success: true, Data: trades})
+	})
+
+	return httptest.NewServer(mux)
+}
+
+// 署名を検証してから処理する
+func (s *coincheckStandIn) private(handler func(w http.ResponseWriter, r *http.Request, body []byte)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			s.t.Fatal(err)
+		}
+
+		mac := hmac.New(sha256.New, []byte(standInSecret))
+		mac.Write([]byte(r.Header.Get("ACCESS-NONCE") + "http://" + r.Host + r.URL.RequestURI() + string(body)))
+		sign := hex.EncodeToString(mac.Sum(nil))
+		if r.Header.Get("ACCESS-KEY") != standInKey || r.Header.Get("ACCESS-SIGNATURE") != sign {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"error":"invalid authentication"}`))
+			return
+		}
+
+		handler(w, r, body)
+	}
+}
+
+func (s *coincheckStandIn) placeOrder(w http.ResponseWriter, r *http.Request, body []byte) {
+	var request coincheck.RequestOrder
+	if err := json.Unmarshal(body, &request); err != nil {
+		s.t.Fatal(err)
+	}
+
+	id := int64(len(s.orders) + 1)
+	order := coincheck.Order{
+		Success:   true,
+		ID:        id,
+		Pair:      request.Pair,
+		OrderType: request.OrderType,
+		Status:    coincheck.OrderStatusOpen,
+		CreatedAt: "2021-11-09T11:31:11.000Z",
+	}
+	if request.Rate > 0 {
+		order.Rate = fmt.Sprint(request.Rate)
+	}
+	if request.Amount > 0 {
+		order.Amount = fmt.Sprint(request.Amount)
+	}
+
+	// 成行注文は540000円と541000円で半分ずつ約定する
+	if strings.HasPrefix(string(request.OrderType), "market_") {
+		amount := request.Amount
+		if request.OrderType == coincheck.OrderTypeMarketBuy {
+			amount = request.MarketBuyAmount / 540500
+		}
+		for i, rate := range []float64{540000, 541000} {
+			s.transactions = append(s.transactions, coincheck.Transaction{
+				ID:      int64(len(s.transactions) + 1),
+				OrderID: id,
+				Pair:    request.Pair,
+				Rate:    fmt.Sprint(rate),
+				Funds:   map[string]string{"eth": fmt.Sprint(amount / 2)},
+				Fee:     fmt.Sprint(i + 1),
+			})
+		}
+		order.ExecutedAmount = fmt.Sprint(amount)
+		order.Status = coincheck.OrderStatusFilled
+	}
+
+	s.orders[id] = order
+	json.NewEncoder(w).Encode(coincheck.ResponseOrder{Success: true, ID: id})
+}
+
+func (s *coincheckStandIn) order(w http.ResponseWriter, r *http.Request, body []byte) {
+	id, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/api/exchange/orders/"), 10, 64)
+	order, ok := s.orders[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"success":false,"error":"not found"}`))
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		json.NewEncoder(w).Encode(order)
+	case "DELETE":
+		order.Status = coincheck.OrderStatusCanceled
+		s.orders[id] = order
+		json.NewEncoder(w).Encode(coincheck.ResponseOrder{Success: true, ID: id})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestCoincheckExchange(t *testing.T) {
+	server := newCoincheckStandIn(t)
+	defer server.Close()
+
+	apiClient := coincheck.NewClientWithBaseURL(standInKey, standInSecret, server.URL+"/")
+	exchange := coincheck.NewCoincheckExchange(apiClient)
+
+	exchangetest.Run(t, exchange, "ETH_JPY")
+
+	t.Run("balance", func(t *testing.T) {
+		balance, err := exchange.Balance().FetchByCurrencyCode("JPY")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 注文中の分も含める
+		if balance.Amount() != 100000 || balance.Available() != 90000 {
+			t.Fatalf("balance=%+v", balance)
+		}
+	})
+
+	t.Run("market order", func(t *testing.T) {
+		acceptanceID, err := exchange.Order().Place(*model.NewBuyOrder("ETH_JPY", 0.1))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		order, err := exchange.Order().Find("ETH_JPY", acceptanceID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.ChildOrderState != model.OrderStateCompleted || order.ChildOrderType != model.ChildOrderTypeMarket {
+			t.Fatalf("order=%+v", order)
+		}
+		if order.AveragePrice != 540500 || order.TotalCommission != 3 {
+			t.Fatalf("order=%+v", order)
+		}
+		if math.Abs(order.Size-0.1) > 0.001 {
+			t.Fatalf("order=%+v", order)
+		}
+	})
+
+	t.Run("unsupported product code", func(t *testing.T) {
+		if _, err := exchange.Ticker().Fetch("FX_BTC_JPY"); err == nil {
+			t.Fatal("Fetch(FX_BTC_JPY) returns no error")
+		}
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		invalid := coincheck.NewCoincheckExchange(coincheck.NewClientWithBaseURL(standInKey, "invalid", server.URL+"/"))
+		if _, err := invalid.Balance().FetchAll(); err == nil {
+			t.Fatal("FetchAll() returns no error")
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package coincheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+// 一度に取得できる約定の最大件数
+const maxTradesLimit = 100
+
+// 全体の約定履歴の1件（数値は文字列で返される）
+type Trade struct {
+	ID        int64  `json:"id"`
+	Amount    string `json:"amount"`
+	Rate      string `json:"rate"`
+	Pair      string `json:"pair"`
+	OrderType string `json:"order_type"`
+	CreatedAt string `json:"created_at"`
+}
+
+type ResponseTrades struct {
+	Success bool    `json:"success"`
+	Data    []Trade `json:"data"`
+}
+
+func (trade *Trade) toDomainModelExecution() *model.Execution {
+	createdAt, err := time.Parse(time.RFC3339, trade.CreatedAt)
+	if err != nil {
+		return nil
+	}
+
+	return model.NewExecution(
+		trade.ID,
+		model.OrderSide(strings.ToUpper(trade.OrderType)),
+		parseFloat(trade.Rate),
+		parseFloat(trade.Amount),
+		createdAt,
+	)
+}
+
+type coincheckExecutionRepository struct {
+	apiClient *Client
+}
+
+func NewCoincheckExecutionRepository(apiClient *Client) repository.ExecutionRepository {
+	return &coincheckExecutionRepository{
+		apiClient: apiClient,
+	}
+}
+
+// 新しい順に並べたときの，beforeより後ろ（IDが小さい）をstarting_after，afterより前（IDが大きい）をending_beforeで指定する
+// countはmaxTradesLimitまで
+func (cer *coincheckExecutionRepository) FetchAll(productCode string, count int, before, after int64) ([]model.Execution, error) {
+	pair, err := toPair(productCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > maxTradesLimit {
+		count = maxTradesLimit
+	}
+
+	path := "api/trades"
+	query := map[string]string{
+		"pair":  pair,
+		"limit": strconv.Itoa(count),
+		"order": "desc",
+	}
+	if before > 0 {
+		query["starting_after"] = strconv.FormatInt(before, 10)
+	}
+	if after > 0 {
+		query["ending_before"] = strconv.FormatInt(after, 10)
+	}
+	resp, err := cer.apiClient.doRequest("GET", path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response ResponseTrades
+	err = json.Unmarshal(resp, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, errors.New("cannot fetch trades")
+	}
+
+	domainModelExecutions := make([]model.Execution, 0, len(response.Data))
+	for i := range response.Data {
+		execution := response.Data[i].toDomainModelExecution()
+		if execution == nil {
+			return nil, errors.New(fmt.Sprint("invalid execution fetched:", response.Data[i]))
+		}
+		domainModelExecutions = append(domainModelExecutions, *execution)
+	}
+
+	return domainModelExecutions, nil
+}
@@ -0,0 +1,352 @@
+package coincheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+// 注文の種類
+// 成行の買い注文は，数量ではなく日本円の金額（market_buy_amount）で出す
+type OrderType string
+
+const (
+	OrderTypeBuy        OrderType = "buy"         // 指値の買い注文
+	OrderTypeSell       OrderType = "sell"        // 指値の売り注文
+	OrderTypeMarketBuy  OrderType = "market_buy"  // 成行の買い注文
+	OrderTypeMarketSell OrderType = "market_sell" // 成行の売り注文
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusNew                     OrderStatus = "NEW"
+	OrderStatusUntriggered             OrderStatus = "UNTRIGGERED"
+	OrderStatusOpen                    OrderStatus = "OPEN"
+	OrderStatusPartiallyFilled         OrderStatus = "PARTIALLY_FILLED"
+	OrderStatusFilled                  OrderStatus = "FILLED"
+	OrderStatusCanceled                OrderStatus = "CANCELED"
+	OrderStatusPartiallyFilledCanceled OrderStatus = "PARTIALLY_FILLED_CANCELED"
+	OrderStatusExpired                 OrderStatus = "EXPIRED"
+	OrderStatusPartiallyFilledExpired  OrderStatus = "PARTIALLY_FILLED_EXPIRED"
+)
+
+// bitFlyerの注文の状態に変換する
+func (status OrderStatus) toDomainModelOrderState() model.OrderState {
+	switch status {
+	case OrderStatusNew, OrderStatusUntriggered, OrderStatusOpen, OrderStatusPartiallyFilled:
+		return model.OrderStateActive
+	case OrderStatusFilled:
+		return model.OrderStateCompleted
+	case OrderStatusCanceled, OrderStatusPartiallyFilledCanceled:
+		return model.OrderStateCanceled
+	case OrderStatusExpired, OrderStatusPartiallyFilledExpired:
+		return model.OrderStateExpired
+	default:
+		return model.OrderStateRejected
+	}
+}
+
+type RequestOrder struct {
+	Pair            string    `json:"pair"`
+	OrderType       OrderType `json:"order_type"`
+	Rate            float64   `json:"rate,omitempty"`
+	Amount          float64   `json:"amount,omitempty"`
+	MarketBuyAmount float64   `json:"market_buy_amount,omitempty"`
+}
+
+type ResponseOrder struct {
+	Success bool  `json:"success"`
+	ID      int64 `json:"id"`
+}
+
+// 注文の状況（数値は文字列で返される）
+type Order struct {
+	Success         bool        `json:"success"`
+	ID              int64       `json:"id"`
+	Pair            string      `json:"pair"`
+	OrderType       OrderType   `json:"order_type"`
+	Rate            string      `json:"rate"`
+	Amount          string      `json:"amount"`
+	MarketBuyAmount string      `json:"market_buy_amount"`
+	ExecutedAmount  string      `json:"executed_amount"`
+	Status          OrderStatus `json:"status"`
+	CreatedAt       string      `json:"created_at"`
+}
+
+// 自分の注文の約定
+type Transaction struct {
+	ID      int64             `json:"id"`
+	OrderID int64             `json:"order_id"`
+	Pair    string            `json:"pair"`
+	Rate    string            `json:"rate"`
+	Funds   map[string]string `json:"funds"`
+	Fee     string            `json:"fee"`
+	Side    string            `json:"side"`
+}
+
+type ResponseTransactions struct {
+	Success      bool          `json:"success"`
+	Transactions []Transaction `json:"transactions"`
+}
+
+func (order *Order) toDomainModelOrder(transactions []Transaction) *model.Order {
+	productCode, err := toProductCode(order.Pair)
+	if err != nil {
+		return nil
+	}
+
+	childOrderType := model.ChildOrderTypeLimit
+	if strings.HasPrefix(string(order.OrderType), "market_") {
+		childOrderType = model.ChildOrderTypeMarket
+	}
+
+	side := model.OrderSideBuy
+	if strings.HasSuffix(string(order.OrderType), "sell") {
+		side = model.OrderSideSell
+	}
+
+	state := order.Status.toDomainModelOrderState()
+
+	// 成行の買い注文は数量が決まっていないので，約定した数量とする
+	size := parseFloat(order.Amount)
+	executedSize := parseFloat(order.ExecutedAmount)
+	if order.OrderType == OrderTypeMarketBuy {
+		size = executedSize
+	}
+	outstandingSize := 0.0
+	if state == model.OrderStateActive {
+		outstandingSize = size - executedSize
+	}
+
+	// 平均約定価格と手数料は，注文の約定から計算する
+	base := baseCurrency(order.Pair)
+	funds, amount, commission := 0.0, 0.0, 0.0
+	for _, transaction := range transactions {
+		if transaction.OrderID != order.ID {
+			continue
+		}
+		executed := math.Abs(parseFloat(transaction.Funds[base]))
+		funds += parseFloat(transaction.Rate) * executed
+		amount += executed
+		commission += parseFloat(transaction.Fee)
+	}
+	averagePrice := 0.0
+	if amount > 0 {
+		averagePrice = funds / amount
+	}
+
+	childOrderDate := order.CreatedAt
+	if createdAt, err := time.Parse(time.RFC3339, order.CreatedAt); err == nil {
+		childOrderDate = createdAt.UTC().Format(model.TimestampFormat)
+	}
+
+	id := strconv.FormatInt(order.ID, 10)
+
+	return &model.Order{
+		ProductCode:            productCode,
+		ChildOrderType:         childOrderType,
+		Side:                   side,
+		Price:                  parseFloat(order.Rate),
+		AveragePrice:           averagePrice,
+		Size:                   size,
+		ChildOrderID:           id,
+		ChildOrderState:        state,
+		ChildOrderDate:         childOrderDate,
+		ChildOrderAcceptanceID: id,
+		OutstandingSize:        outstandingSize,
+		ExecutedSize:           executedSize,
+		TotalCommission:        commission,
+	}
+}
+
+type coincheckOrderRepository struct {
+	apiClient *Client
+}
+
+func NewCoincheckOrderRepository(apiClient *Client) repository.OrderRepository {
+	return &coincheckOrderRepository{
+		apiClient: apiClient,
+	}
+}
+
+func (cor *coincheckOrderRepository) Send(order model.Order) (*model.Order, error) {
+	acceptanceID, err := cor.Place(order)
+	if err != nil {
+		return nil, err
+	}
+
+	completedOrder := cor.waitUntilOrderComplete(order.ProductCode, acceptanceID)
+	if completedOrder == nil {
+		return nil, errors.New("order is not completed")
+	}
+
+	return completedOrder, nil
+}
+
+// 注文IDを返す
+func (cor *coincheckOrderRepository) Place(order model.Order) (string, error) {
+	pair, err := toPair(order.ProductCode)
+	if err != nil {
+		return "", err
+	}
+
+	request := RequestOrder{
+		Pair: pair,
+	}
+	switch {
+	case order.ChildOrderType == model.ChildOrderTypeLimit && order.Side == model.OrderSideBuy:
+		request.OrderType = OrderTypeBuy
+		request.Rate = order.Price
+		request.Amount = order.Size
+	case order.ChildOrderType == model.ChildOrderTypeLimit && order.Side == model.OrderSideSell:
+		request.OrderType = OrderTypeSell
+		request.Rate = order.Price
+		request.Amount = order.Size
+	case order.ChildOrderType == model.ChildOrderTypeMarket && order.Side == model.OrderSideBuy:
+		// 数量を売りの最良気配値で日本円に換算する（約定する数量は多少ずれる）
+		ticker, err := fetchTicker(cor.apiClient, pair)
+		if err != nil {
+			return "", err
+		}
+		request.OrderType = OrderTypeMarketBuy
+		request.MarketBuyAmount = math.Ceil(order.Size * ticker.Ask)
+	case order.ChildOrderType == model.ChildOrderTypeMarket && order.Side == model.OrderSideSell:
+		request.OrderType = OrderTypeMarketSell
+		request.Amount = order.Size
+	default:
+		return "", errors.New(fmt.Sprintf("unsupported order: %s %s", order.ChildOrderType, order.Side))
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := cor.apiClient.doRequest("POST", "api/exchange/orders", map[string]string{}, data)
+	if err != nil {
+		return "", err
+	}
+
+	var response ResponseOrder
+	if err = json.Unmarshal(resp, &response); err != nil {
+		return "", err
+	}
+
+	if !response.Success || response.ID == 0 {
+		return "", errors.New("order send, but id is none")
+	}
+
+	return strconv.FormatInt(response.ID, 10), nil
+}
+
+func (cor *coincheckOrderRepository) Find(productCode, acceptanceID string) (*model.Order, error) {
+	path := fmt.Sprintf("api/exchange/orders/%s", acceptanceID)
+	resp, err := cor.apiClient.doRequest("GET", path, map[string]string{}, nil)
+	if err != nil {
+		var apiError *APIError
+		if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var order Order
+	if err = json.Unmarshal(resp, &order); err != nil {
+		return nil, err
+	}
+
+	pair, err := toPair(productCode)
+	if err != nil {
+		return nil, err
+	}
+	if order.Pair != pair {
+		return nil, nil
+	}
+
+	transactions := make([]Transaction, 0)
+	if parseFloat(order.ExecutedAmount) > 0 {
+		transactions, err = cor.fetchTransactions()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	domainModelOrder := order.toDomainModelOrder(transactions)
+	if domainModelOrder == nil {
+		return nil, errors.New(fmt.Sprint("invalid order fetched: ", order))
+	}
+
+	return domainModelOrder, nil
+}
+
+func (cor *coincheckOrderRepository) Cancel(productCode, acceptanceID string) error {
+	path := fmt.Sprintf("api/exchange/orders/%s", acceptanceID)
+	resp, err := cor.apiClient.doRequest("DELETE", path, map[string]string{}, nil)
+	if err != nil {
+		return err
+	}
+
+	var response ResponseOrder
+	if err = json.Unmarshal(resp, &response); err != nil {
+		return err
+	}
+	if !response.Success {
+		return errors.New(fmt.Sprint("cannot cancel order: ", string(resp)))
+	}
+
+	return nil
+}
+
+// 直近の自分の注文の約定
+func (cor *coincheckOrderRepository) fetchTransactions() ([]Transaction, error) {
+	resp, err := cor.apiClient.doRequest("GET", "api/exchange/orders/transactions", map[string]string{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response ResponseTransactions
+	if err = json.Unmarshal(resp, &response); err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, errors.New("cannot fetch transactions")
+	}
+
+	return response.Transactions, nil
+}
+
+func (cor *coincheckOrderRepository) waitUntilOrderComplete(productCode, acceptanceID string) *model.Order {
+	// 最長2分待つ
+	expire := time.After(2 * time.Minute)
+	// 15秒ごとに注文状況をポーリング
+	interval := time.Tick(15 * time.Second)
+
+	for {
+		select {
+		case <-expire:
+			return nil
+		case <-interval:
+			order, err := cor.Find(productCode, acceptanceID)
+			if err != nil || order == nil {
+				return nil
+			}
+			switch order.ChildOrderState {
+			case model.OrderStateCompleted:
+				return order
+			case model.OrderStateActive:
+				continue
+			default:
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package coincheck
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// bitFlyerの銘柄コードとCoincheckの取引ペアの対応
+// 取引所（板）で売買できるものだけ
+var pairs = map[string]string{
+	"BTC_JPY": "btc_jpy",
+	"ETH_JPY": "eth_jpy",
+	"ETC_JPY": "etc_jpy",
+	"XRP_JPY": "xrp_jpy",
+}
+
+func toPair(productCode string) (string, error) {
+	pair, ok := pairs[productCode]
+	if !ok {
+		return "", errors.New(fmt.Sprint("coincheck does not support product code: ", productCode))
+	}
+	return pair, nil
+}
+
+func toProductCode(pair string) (string, error) {
+	for productCode, p := range pairs {
+		if p == pair {
+			return productCode, nil
+		}
+	}
+	return "", errors.New(fmt.Sprint("unknown coincheck pair: ", pair))
+}
+
+// 取引ペアの基軸通貨（eth_jpyならeth）
+func baseCurrency(pair string) string {
+	return strings.Split(pair, "_")[0]
+}
+
+// Coincheckは数値を文字列で返すことが多い（nullは空文字になる）
+func parseFloat(s string) float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
@@ -0,0 +1,87 @@
+package coincheck
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type Ticker struct {
+	Last      float64 `json:"last"`
+	Bid       float64 `json:"bid"`
+	Ask       float64 `json:"ask"`
+	High      float64 `json:"high"`
+	Low       float64 `json:"low"`
+	Volume    float64 `json:"volume"`
+	Timestamp int64   `json:"timestamp"`
+}
+
+// 板の状態と板の厚さは返されないので，稼働中・0とする
+func (ticker *Ticker) toDomainModelTicker(productCode string) *model.Ticker {
+	return model.NewTicker(
+		productCode,
+		"RUNNING",
+		time.Unix(ticker.Timestamp, 0).UTC().Format(model.TimestampFormat),
+		0,
+		ticker.Bid,
+		ticker.Ask,
+		0,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ticker.Last,
+		ticker.Volume,
+		ticker.Volume,
+	)
+}
+
+type coincheckTickerRepository struct {
+	apiClient *Client
+}
+
+func NewCoincheckTickerRepository(apiClient *Client) repository.TickerRepository {
+	return &coincheckTickerRepository{
+		apiClient: apiClient,
+	}
+}
+
+func (ctr *coincheckTickerRepository) Fetch(productCode string) (*model.Ticker, error) {
+	pair, err := toPair(productCode)
+	if err != nil {
+		return nil, err
+	}
+
+	ticker, err := fetchTicker(ctr.apiClient, pair)
+	if err != nil {
+		return nil, err
+	}
+
+	domainModelTicker := ticker.toDomainModelTicker(productCode)
+	if domainModelTicker == nil {
+		return nil, errors.New("invalid ticker fetched")
+	}
+
+	return domainModelTicker, nil
+}
+
+func fetchTicker(apiClient *Client, pair string) (*Ticker, error) {
+	path := "api/ticker"
+	query := map[string]string{"pair": pair}
+	resp, err := apiClient.doRequest("GET", path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ticker Ticker
+	err = json.Unmarshal(resp, &ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ticker, nil
+}
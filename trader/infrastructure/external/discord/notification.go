@@ -0,0 +1,106 @@
+package discord
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/webhook"
+)
+
+// 埋め込みの色（重要度で分ける）
+const (
+	colorInfo  = 0x3498db
+	colorWarn  = 0xf1c40f
+	colorError = 0xe74c3c
+)
+
+// descriptionの上限は4096文字
+const maxDescriptionLength = 4096
+
+type embed struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Color       int    `json:"color"`
+	Timestamp   string `json:"timestamp"`
+}
+
+type message struct {
+	Embeds []embed `json:"embeds"`
+}
+
+type discordNotificationRepository struct {
+	client     *webhook.Client
+	webhookURL string
+}
+
+func NewDiscordNotificationRepository(client *webhook.Client, webhookURL string) repository.NotificationRepository {
+	if client == nil || webhookURL == "" {
+		return nil
+	}
+
+	return &discordNotificationRepository{
+		client:     client,
+		webhookURL: webhookURL,
+	}
+}
+
+func (dnr *discordNotificationRepository) NotifyOfTradingSuccess(event model.SignalEvent) error {
+	return dnr.Notify(*model.NewTradingSuccessNotification(event))
+}
+
+func (dnr *discordNotificationRepository) NotifyOfTradingFailure(productCode string, err error) error {
+	return dnr.Notify(*model.NewTradingFailureNotification(time.Now(), productCode, err))
+}
+
+func (dnr *discordNotificationRepository) NotifyOfSpread(spread model.Spread) error {
+	return dnr.Notify(*model.NewSpreadNotification(spread))
+}
+
+func (dnr *discordNotificationRepository) NotifyOfSummary(summary model.Summary) error {
+	return dnr.Notify(*model.NewSummaryNotification(summary))
+}
+
+func (dnr *discordNotificationRepository) Notify(notification model.Notification) error {
+	body, err := json.Marshal(buildMessage(notification))
+	if err != nil {
+		return err
+	}
+
+	return dnr.client.Post(dnr.webhookURL, nil, body)
+}
+
+func buildMessage(notification model.Notification) message {
+	title := notification.Title()
+	if notification.ProductCode() != "" {
+		title += "（" + notification.ProductCode() + "）"
+	}
+
+	description := []rune(notification.Message())
+	if len(description) > maxDescriptionLength {
+		description = description[:maxDescriptionLength]
+	}
+
+	return message{
+		Embeds: []embed{
+			{
+				Title:       title,
+				Description: string(description),
+				Color:       levelColor(notification.Level()),
+				Timestamp:   notification.Time().Format(time.RFC3339),
+			},
+		},
+	}
+}
+
+func levelColor(level model.NotificationLevel) int {
+	switch level {
+	case model.NotificationLevelWarn:
+		return colorWarn
+	case model.NotificationLevelError:
+		return colorError
+	default:
+		return colorInfo
+	}
+}
@@ -0,0 +1,64 @@
+package discord_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/discord"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/webhook"
+)
+
+type embed struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Color       int    `json:"color"`
+	Timestamp   string `json:"timestamp"`
+}
+
+func TestDiscordNotificationRepository(t *testing.T) {
+	var received struct {
+		Embeds []embed `json:"embeds"`
+	}
+	count := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		// 1回目はレート制限
+		if count == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Error(err.Error())
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client := webhook.NewClientWithBackoff(time.Second, 1, time.Millisecond)
+	notificationRepository := discord.NewDiscordNotificationRepository(client, ts.URL)
+
+	if err := notificationRepository.NotifyOfTradingFailure("ETH_JPY", errors.New("order failed")); err != nil {
+		t.Fatal(err.Error())
+	}
+	if count != 2 {
+		t.Fatalf("count=%d", count)
+	}
+	if len(received.Embeds) != 1 {
+		t.Fatalf("received=%+v", received)
+	}
+	e := received.Embeds[0]
+	if e.Title != "エラーが生じました（ETH_JPY）" || e.Description != "order failed" || e.Color != 0xe74c3c {
+		t.Fatalf("embed=%+v", e)
+	}
+	if _, err := time.Parse(time.RFC3339, e.Timestamp); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if discord.NewDiscordNotificationRepository(client, "") != nil {
+		t.Fatal("NewDiscordNotificationRepository() without url returns not nil")
+	}
+}
@@ -0,0 +1,146 @@
+// 取引所のAPIの実装が満たすべき振る舞い（契約）のテスト
+// 各取引所のテストから，httptestで立てた取引所の代わりのサーバに向けて実行する
+package exchangetest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+// 代わりのサーバは，productCodeの板と，JPYの残高と，3件以上の約定履歴を持っていること
+// 注文は約定させずに受け付けるだけでよい
+func Run(t *testing.T, exchange repository.ExchangeRepository, productCode string) {
+	t.Helper()
+
+	if exchange.Name() == "" {
+		t.Fatal("Name() is empty")
+	}
+
+	t.Run("ticker", func(t *testing.T) {
+		ticker, err := exchange.Ticker().Fetch(productCode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ticker.ProductCode() != productCode {
+			t.Fatalf("productCode=%s, want %s", ticker.ProductCode(), productCode)
+		}
+		if ticker.BestBid() > ticker.BestAsk() {
+			t.Fatalf("bestBid=%f > bestAsk=%f", ticker.BestBid(), ticker.BestAsk())
+		}
+		// キャンドルの時刻に変換できる形式であること
+		if model.NewCandleTimeByString(ticker.Timestamp()).Time().IsZero() {
+			t.Fatalf("invalid timestamp: %s", ticker.Timestamp())
+		}
+	})
+
+	t.Run("balance", func(t *testing.T) {
+		balances, err := exchange.Balance().FetchAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(balances) == 0 {
+			t.Fatal("no balances")
+		}
+		for _, balance := range balances {
+			if balance.CurrencyCode() != strings.ToUpper(balance.CurrencyCode()) {
+				t.Fatalf("currencyCode=%s is not upper case", balance.CurrencyCode())
+			}
+			if balance.Available() > balance.Amount() {
+				t.Fatalf("available=%f > amount=%f", balance.Available(), balance.Amount())
+			}
+		}
+
+		if _, err := exchange.Balance().FetchByCurrencyCode("JPY"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := exchange.Balance().FetchByCurrencyCode("UNKNOWN"); err == nil {
+			t.Fatal("FetchByCurrencyCode(UNKNOWN) returns no error")
+		}
+	})
+
+	t.Run("order", func(t *testing.T) {
+		ticker, err := exchange.Ticker().Fetch(productCode)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// 約定しない価格の指値注文
+		order := model.NewLimitBuyOrder(productCode, float64(int(ticker.BestBid()/2)), 0.01)
+		acceptanceID, err := exchange.Order().Place(*order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if acceptanceID == "" {
+			t.Fatal("acceptanceID is empty")
+		}
+
+		placed, err := exchange.Order().Find(productCode, acceptanceID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if placed == nil {
+			t.Fatal("placed order is not found")
+		}
+		if placed.ProductCode != productCode || placed.Side != model.OrderSideBuy || placed.ChildOrderType != model.ChildOrderTypeLimit {
+			t.Fatalf("placed=%+v", placed)
+		}
+		if placed.ChildOrderState != model.OrderStateActive || placed.Price != order.Price || placed.Size != order.Size {
+			t.Fatalf("placed=%+v", placed)
+		}
+
+		if err := exchange.Order().Cancel(productCode, acceptanceID); err != nil {
+			t.Fatal(err)
+		}
+		canceled, err := exchange.Order().Find(productCode, acceptanceID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if canceled == nil || canceled.ChildOrderState != model.OrderStateCanceled {
+			t.Fatalf("canceled=%+v", canceled)
+		}
+
+		// 見つからない注文
+		notFound, err := exchange.Order().Find(productCode, "999999999")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if notFound != nil {
+			t.Fatalf("notFound=%+v", notFound)
+		}
+	})
+
+	t.Run("execution", func(t *testing.T) {
+		executions, err := exchange.Execution().FetchAll(productCode, 3, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(executions) != 3 {
+			t.Fatalf("len(executions)=%d", len(executions))
+		}
+		// 新しい順
+		for i := 1; i < len(executions); i++ {
+			if executions[i-1].ID() <= executions[i].ID() {
+				t.Fatalf("executions are not in descending order: %+v", executions)
+			}
+		}
+
+		newest, oldest := executions[0].ID(), executions[len(executions)-1].ID()
+		before, err := exchange.Execution().FetchAll(productCode, 3, newest, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(before) == 0 || before[0].ID() >= newest {
+			t.Fatalf("before=%+v", before)
+		}
+		after, err := exchange.Execution().FetchAll(productCode, 3, 0, oldest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(after) == 0 || after[len(after)-1].ID() <= oldest {
+			t.Fatalf("after=%+v", after)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package fanout
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+// 複数の通知先に同じ通知を送る
+// 1つの通知先が失敗しても残りには送る．1つでも届けば送れたものとし，失敗した分はログに出す
+// 全ての通知先が失敗したときだけ，エラーをまとめて返す
+type fanoutNotificationRepository struct {
+	repositories []repository.NotificationRepository
+}
+
+func NewFanoutNotificationRepository(repositories ...repository.NotificationRepository) repository.NotificationRepository {
+	if len(repositories) == 0 {
+		return nil
+	}
+	for _, r := range repositories {
+		if r == nil {
+			return nil
+		}
+	}
+
+	// 通知先が1つならそのまま使う
+	if len(repositories) == 1 {
+		return repositories[0]
+	}
+
+	return &fanoutNotificationRepository{
+		repositories: repositories,
+	}
+}
+
+func (fnr *fanoutNotificationRepository) NotifyOfTradingSuccess(event model.SignalEvent) error {
+	return fnr.each(func(r repository.NotificationRepository) error {
+		return r.NotifyOfTradingSuccess(event)
+	})
+}
+
+func (fnr *fanoutNotificationRepository) NotifyOfTradingFailure(productCode string, err error) error {
+	return fnr.each(func(r repository.NotificationRepository) error {
+		return r.NotifyOfTradingFailure(productCode, err)
+	})
+}
+
+func (fnr *fanoutNotificationRepository) NotifyOfSpread(spread model.Spread) error {
+	return fnr.each(func(r repository.NotificationRepository) error {
+		return r.NotifyOfSpread(spread)
+	})
+}
+
+func (fnr *fanoutNotificationRepository) NotifyOfSummary(summary model.Summary) error {
+	return fnr.each(func(r repository.NotificationRepository) error {
+		return r.NotifyOfSummary(summary)
+	})
+}
+
+func (fnr *fanoutNotificationRepository) Notify(notification model.Notification) error {
+	return fnr.each(func(r repository.NotificationRepository) error {
+		return r.Notify(notification)
+	})
+}
+
+func (fnr *fanoutNotificationRepository) each(notify func(repository.NotificationRepository) error) error {
+	messages := make([]string, 0)
+	for _, r := range fnr.repositories {
+		if err := notify(r); err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+	if len(messages) == len(fnr.repositories) {
+		return errors.New(strings.Join(messages, "; "))
+	}
+	for _, message := range messages {
+		fmt.Println("[FanoutNotificationRepository]", message)
+	}
+	return nil
+}
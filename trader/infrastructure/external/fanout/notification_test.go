@@ -0,0 +1,80 @@
+package fanout_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/fanout"
+)
+
+// 受け取った通知を数える
+type countingNotificationRepository struct {
+	count int
+	err   error
+}
+
+func (cnr *countingNotificationRepository) NotifyOfTradingSuccess(event model.SignalEvent) error {
+	cnr.count++
+	return cnr.err
+}
+
+func (cnr *countingNotificationRepository) NotifyOfTradingFailure(productCode string, err error) error {
+	cnr.count++
+	return cnr.err
+}
+
+func (cnr *countingNotificationRepository) NotifyOfSpread(spread model.Spread) error {
+	cnr.count++
+	return cnr.err
+}
+
+func (cnr *countingNotificationRepository) NotifyOfSummary(summary model.Summary) error {
+	cnr.count++
+	return cnr.err
+}
+
+func (cnr *countingNotificationRepository) Notify(notification model.Notification) error {
+	cnr.count++
+	return cnr.err
+}
+
+func TestFanoutNotificationRepository(t *testing.T) {
+	if fanout.NewFanoutNotificationRepository() != nil {
+		t.Fatal("NewFanoutNotificationRepository() without repositories returns not nil")
+	}
+
+	single := &countingNotificationRepository{}
+	if fanout.NewFanoutNotificationRepository(single) != single {
+		t.Fatal("NewFanoutNotificationRepository() with a repository does not return it")
+	}
+
+	failing := &countingNotificationRepository{err: errors.New("discord: timeout")}
+	succeeding := &countingNotificationRepository{}
+	notificationRepository := fanout.NewFanoutNotificationRepository(failing, succeeding)
+
+	// 失敗した通知先があっても，残りに届けば送れたものとする
+	notification := model.NewNotification(time.Now(), model.NotificationLevelInfo, "ETH_JPY", "title", "")
+	if err := notificationRepository.Notify(*notification); err != nil {
+		t.Fatal(err.Error())
+	}
+	if failing.count != 1 || succeeding.count != 1 {
+		t.Fatalf("failing=%d, succeeding=%d", failing.count, succeeding.count)
+	}
+
+	if err := notificationRepository.NotifyOfTradingFailure("ETH_JPY", errors.New("order failed")); err != nil {
+		t.Fatal(err.Error())
+	}
+	if succeeding.count != 2 {
+		t.Fatalf("succeeding=%d", succeeding.count)
+	}
+
+	// 全ての通知先が失敗したらエラーをまとめて返す
+	another := &countingNotificationRepository{err: errors.New("line: status=500")}
+	allFailing := fanout.NewFanoutNotificationRepository(failing, another)
+	err := allFailing.Notify(*notification)
+	if err == nil || err.Error() != "discord: timeout; line: status=500" {
+		t.Fatalf("err=%v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package line
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/webhook"
+)
+
+// Messaging APIのプッシュメッセージ
+const pushURL = "https://api.line.me/v2/bot/message/push"
+
+// テキストメッセージの上限は5000文字
+const maxTextLength = 5000
+
+type textMessage struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type pushMessage struct {
+	To       string        `json:"to"`
+	Messages []textMessage `json:"messages"`
+}
+
+type lineNotificationRepository struct {
+	client             *webhook.Client
+	pushURL            string
+	channelAccessToken string
+	to                 string
+	timeLocation       *time.Location
+}
+
+// toは送り先のユーザID・グループID
+func NewLineNotificationRepository(client *webhook.Client, channelAccessToken, to string, timeLocation *time.Location) repository.NotificationRepository {
+	return NewLineNotificationRepositoryWithURL(client, pushURL, channelAccessToken, to, timeLocation)
+}
+
+// 接続先を変える（テスト用のサーバなど）
+func NewLineNotificationRepositoryWithURL(client *webhook.Client, pushURL, channelAccessToken, to string, timeLocation *time.Location) repository.NotificationRepository {
+	if client == nil || channelAccessToken == "" || to == "" {
+		return nil
+	}
+
+	return &lineNotificationRepository{
+		client:             client,
+		pushURL:            pushURL,
+		channelAccessToken: channelAccessToken,
+		to:                 to,
+		timeLocation:       timeLocation,
+	}
+}
+
+func (lnr *lineNotificationRepository) NotifyOfTradingSuccess(event model.SignalEvent) error {
+	return lnr.Notify(*model.NewTradingSuccessNotification(event))
+}
+
+func (lnr *lineNotificationRepository) NotifyOfTradingFailure(productCode string, err error) error {
+	return lnr.Notify(*model.NewTradingFailureNotification(time.Now(), productCode, err))
+}
+
+func (lnr *lineNotificationRepository) NotifyOfSpread(spread model.Spread) error {
+	return lnr.Notify(*model.NewSpreadNotification(spread))
+}
+
+func (lnr *lineNotificationRepository) NotifyOfSummary(summary model.Summary) error {
+	return lnr.Notify(*model.NewSummaryNotification(summary))
+}
+
+func (lnr *lineNotificationRepository) Notify(notification model.Notification) error {
+	body, err := json.Marshal(pushMessage{
+		To: lnr.to,
+		Messages: []textMessage{
+			{
+				Type: "text",
+				Text: buildText(notification, lnr.timeLocation),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// 再送しても同じメッセージが二重に届かないように，1回の通知ごとにリトライキーを付ける
+	retryKey, err := newRetryKey()
+	if err != nil {
+		return err
+	}
+	header := map[string]string{
+		"Authorization":    "Bearer " + lnr.channelAccessToken,
+		"X-Line-Retry-Key": retryKey,
+	}
+	return lnr.client.Post(lnr.pushURL, header, body)
+}
+
+// リトライキーはUUID（バージョン4）の形式
+func newRetryKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// LINEはマークダウンを使えないので，重要度を角括弧で添える
+func buildText(notification model.Notification, timeLocation *time.Location) string {
+	title := "[" + strings.ToUpper(string(notification.Level())) + "] " + notification.Title()
+	if notification.ProductCode() != "" {
+		title += "（" + notification.ProductCode() + "）"
+	}
+
+	lines := []string{
+		title,
+		"At: " + notification.Time().In(timeLocation).Format("2006-01-02 15:04:05"),
+	}
+	if notification.Message() != "" {
+		lines = append(lines, notification.Message())
+	}
+
+	text := []rune(strings.Join(lines, "\n"))
+	if len(text) > maxTextLength {
+		text = text[:maxTextLength]
+	}
+	return string(text)
+}
@@ -0,0 +1,117 @@
+package line_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/line"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/webhook"
+)
+
+type pushMessage struct {
+	To       string `json:"to"`
+	Messages []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"messages"`
+}
+
+func TestLineNotificationRepository(t *testing.T) {
+	var received pushMessage
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Error(err.Error())
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	client := webhook.NewClientWithBackoff(time.Second, 3, time.Millisecond)
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+
+	if line.NewLineNotificationRepositoryWithURL(client, ts.URL, "token", "", jst) != nil {
+		t.Fatal("NewLineNotificationRepository() without destination returns not nil")
+	}
+
+	t.Run("push", func(t *testing.T) {
+		notificationRepository := line.NewLineNotificationRepositoryWithURL(client, ts.URL, "token", "U1234", jst)
+
+		timeTime := time.Date(2021, 11, 9, 0, 0, 0, 0, time.UTC)
+		event := model.NewSignalEvent(timeTime, "ETH_JPY", model.OrderSideBuy, 500000, 0.01)
+		if err := notificationRepository.NotifyOfTradingSuccess(*event); err != nil {
+			t.Fatal(err.Error())
+		}
+		if received.To != "U1234" || len(received.Messages) != 1 || received.Messages[0].Type != "text" {
+			t.Fatalf("received=%+v", received)
+		}
+		text := received.Messages[0].Text
+		if !strings.HasPrefix(text, "[INFO] BUY（ETH_JPY）\nAt: 2021-11-09 09:00:00") {
+			t.Fatalf("text=%s", text)
+		}
+	})
+
+	t.Run("truncate long message", func(t *testing.T) {
+		notificationRepository := line.NewLineNotificationRepositoryWithURL(client, ts.URL, "token", "U1234", jst)
+
+		notification := model.NewNotification(time.Now(), model.NotificationLevelError, "", "title", strings.Repeat("あ", 6000))
+		if err := notificationRepository.Notify(*notification); err != nil {
+			t.Fatal(err.Error())
+		}
+		if n := len([]rune(received.Messages[0].Text)); n != 5000 {
+			t.Fatalf("len=%d", n)
+		}
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		notificationRepository := line.NewLineNotificationRepositoryWithURL(client, ts.URL, "invalid", "U1234", jst)
+
+		err := notificationRepository.Notify(*model.NewNotification(time.Now(), model.NotificationLevelInfo, "", "title", ""))
+		if apiErr, ok := err.(*webhook.APIError); !ok || apiErr.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("err=%v", err)
+		}
+	})
+
+	t.Run("retry with the same key", func(t *testing.T) {
+		// 1回目は500を返す
+		keys := make([]string, 0)
+		retryTs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keys = append(keys, r.Header.Get("X-Line-Retry-Key"))
+			if len(keys) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write([]byte("{}"))
+		}))
+		defer retryTs.Close()
+
+		notificationRepository := line.NewLineNotificationRepositoryWithURL(client, retryTs.URL, "token", "U1234", jst)
+		notification := model.NewNotification(time.Now(), model.NotificationLevelInfo, "", "title", "")
+		if err := notificationRepository.Notify(*notification); err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(keys) != 2 || keys[0] != keys[1] {
+			t.Fatalf("keys=%v", keys)
+		}
+		if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(keys[0]) {
+			t.Fatalf("key=%s", keys[0])
+		}
+
+		// 別の通知には別のキーを付ける
+		if err := notificationRepository.Notify(*notification); err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(keys) != 3 || keys[2] == keys[0] {
+			t.Fatalf("keys=%v", keys)
+		}
+	})
+}
@@ -1,20 +1,50 @@
 package slack
 
 import (
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
 	"github.com/slack-go/slack"
 )
 
 type Client struct {
 	token     string
 	channelId string
-	client    *slack.Client
+	// 重要度ごとの通知先（指定がなければchannelId）
+	levelChannelIds map[model.NotificationLevel]string
+	client          *slack.Client
 }
 
 func NewClient(token, channelId string) *Client {
-	client := slack.New(token)
+	return NewClientWithRouting(token, channelId, nil)
+}
+
+func NewClientWithRouting(token, channelId string, levelChannelIds map[model.NotificationLevel]string) *Client {
+	return newClient(token, channelId, levelChannelIds, slack.New(token))
+}
+
+// 接続先を変える（テスト用のサーバなど）
+func NewClientWithAPIURL(token, channelId, apiURL string) *Client {
+	return newClient(token, channelId, nil, slack.New(token, slack.OptionAPIURL(apiURL)))
+}
+
+func newClient(token, channelId string, levelChannelIds map[model.NotificationLevel]string, client *slack.Client) *Client {
+	channelIds := make(map[model.NotificationLevel]string)
+	for level, id := range levelChannelIds {
+		if id != "" {
+			channelIds[level] = id
+		}
+	}
 	return &Client{
-		token:     token,
-		channelId: channelId,
-		client:    client,
+		token:           token,
+		channelId:       channelId,
+		levelChannelIds: channelIds,
+		client:          client,
+	}
+}
+
+// 重要度levelの通知を送るチャンネル
+func (c *Client) channelIdFor(level model.NotificationLevel) string {
+	if id, ok := c.levelChannelIds[level]; ok {
+		return id
 	}
+	return c.channelId
 }
@@ -33,7 +33,7 @@ func (snr *slackNotificationRepository) NotifyOfTradingSuccess(event model.Signa
 	)
 
 	option := slack.MsgOptionText(msg, true)
-	_, _, err := snr.client.client.PostMessage(snr.client.channelId, option)
+	_, _, err := snr.client.client.PostMessage(snr.client.channelIdFor(model.NotificationLevelInfo), option)
 	return err
 }
 
@@ -47,10 +47,73 @@ func (snr *slackNotificationRepository) NotifyOfTradingFailure(productCode strin
 	)
 
 	option := slack.MsgOptionText(msg, true)
-	_, _, err = snr.client.client.PostMessage(snr.client.channelId, option)
+	_, _, err = snr.client.client.PostMessage(snr.client.channelIdFor(model.NotificationLevelError), option)
+	return err
+}
+
+func (snr *slackNotificationRepository) NotifyOfSpread(spread model.Spread) error {
+	timeString := spread.Time().In(snr.timeLocation).Format("2006-01-02 15:04:05")
+
+	msg := buildTextMessage(
+		fmt.Sprintf("%s *SPREAD*: %s", EmojiScales, spread.ProductCode()),
+		fmt.Sprintf("At: %s", timeString),
+		fmt.Sprintf("%s: %f", spread.BaseExchange(), spread.BasePrice()),
+		fmt.Sprintf("%s: %f", spread.Exchange(), spread.Price()),
+		fmt.Sprintf("Rate: %+.2f%%", spread.Rate()*100),
+	)
+
+	option := slack.MsgOptionText(msg, true)
+	_, _, err := snr.client.client.PostMessage(snr.client.channelIdFor(model.NotificationLevelWarn), option)
+	return err
+}
+
+func (snr *slackNotificationRepository) Notify(notification model.Notification) error {
+	msg := buildNotificationMessage(notification, snr.timeLocation)
+
+	option := slack.MsgOptionText(msg, true)
+	_, _, err := snr.client.client.PostMessage(snr.client.channelIdFor(notification.Level()), option)
+	return err
+}
+
+// Block Kitに対応していないクライアントではテキストを表示する
+func (snr *slackNotificationRepository) NotifyOfSummary(summary model.Summary) error {
+	fallback := buildNotificationMessage(*model.NewSummaryNotification(summary), snr.timeLocation)
+
+	options := []slack.MsgOption{
+		slack.MsgOptionText(fallback, false),
+		slack.MsgOptionBlocks(buildSummaryBlocks(summary, snr.timeLocation)...),
+	}
+	_, _, err := snr.client.client.PostMessage(snr.client.channelIdFor(model.NotificationLevelInfo), options...)
 	return err
 }
 
 func buildTextMessage(lines ...string) string {
 	return strings.Join(lines, "\n")
 }
+
+func buildNotificationMessage(notification model.Notification, timeLocation *time.Location) string {
+	title := fmt.Sprintf("%s *%s*", levelEmoji(notification.Level()), notification.Title())
+	if notification.ProductCode() != "" {
+		title += fmt.Sprintf("（%s）", notification.ProductCode())
+	}
+
+	lines := []string{
+		title,
+		fmt.Sprintf("At: %s", notification.Time().In(timeLocation).Format("2006-01-02 15:04:05")),
+	}
+	if notification.Message() != "" {
+		lines = append(lines, "```", notification.Message(), "```")
+	}
+	return buildTextMessage(lines...)
+}
+
+func levelEmoji(level model.NotificationLevel) Emoji {
+	switch level {
+	case model.NotificationLevelWarn:
+		return EmojiWarning
+	case model.NotificationLevelError:
+		return EmojiDizzyFace
+	default:
+		return EmojiInformationSource
+	}
+}
@@ -44,3 +44,32 @@ func (snr *slackNotificationMockRepository) NotifyOfTradingFailure(productCode s
 	fmt.Println(msg)
 	return nil
 }
+
+func (snr *slackNotificationMockRepository) NotifyOfSpread(spread model.Spread) error {
+	timeString := spread.Time().In(snr.timeLocation).Format("2006-01-02 15:04:05")
+
+	msg := buildTextMessage(
+		fmt.Sprintf("%s *SPREAD*: %s", EmojiScales, spread.ProductCode()),
+		fmt.Sprintf("At: %s", timeString),
+		fmt.Sprintf("%s: %f", spread.BaseExchange(), spread.BasePrice()),
+		fmt.Sprintf("%s: %f", spread.Exchange(), spread.Price()),
+		fmt.Sprintf("Rate: %+.2f%%", spread.Rate()*100),
+	)
+
+	fmt.Println(msg)
+	return nil
+}
+
+func (snr *slackNotificationMockRepository) Notify(notification model.Notification) error {
+	msg := buildNotificationMessage(notification, snr.timeLocation)
+
+	fmt.Println(msg)
+	return nil
+}
+
+func (snr *slackNotificationMockRepository) NotifyOfSummary(summary model.Summary) error {
+	msg := buildNotificationMessage(*model.NewSummaryNotification(summary), snr.timeLocation)
+
+	fmt.Println(msg)
+	return nil
+}
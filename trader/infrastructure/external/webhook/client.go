@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// 2xx以外のステータスコードが返ったときのエラー
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("webhook error: status=%d, body=%s", e.StatusCode, e.Body)
+}
+
+// 429と5xxは時間をおけば成功する見込みがあるので再送する
+func (e *APIError) retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// 通知先にJSONを送るクライアント
+// 通信エラー，429，5xxのときは間隔を倍にしながらretries回まで再送する
+type Client struct {
+	httpClient *http.Client
+	retries    int
+	backoff    time.Duration
+}
+
+func NewClient(timeout time.Duration, retries int) *Client {
+	return NewClientWithBackoff(timeout, retries, time.Second)
+}
+
+// 再送の間隔を変える（テストで待たないようにするなど）
+func NewClientWithBackoff(timeout time.Duration, retries int, backoff time.Duration) *Client {
+	if timeout <= 0 || retries < 0 || backoff < 0 {
+		return nil
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: timeout},
+		retries:    retries,
+		backoff:    backoff,
+	}
+}
+
+// 再送でも同じheaderとbodyを送る
+func (c *Client) Post(url string, header map[string]string, body []byte) error {
+	wait := c.backoff
+	var err error
+	for i := 0; i <= c.retries; i++ {
+		if i > 0 {
+			time.Sleep(wait)
+			wait *= 2
+		}
+
+		err = c.post(url, header, body)
+		if err == nil {
+			return nil
+		}
+		if apiErr, ok := err.(*APIError); ok && !apiErr.retryable() {
+			return err
+		}
+	}
+	return err
+}
+
+func (c *Client) post(url string, header map[string]string, body []byte) error {
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package webhook_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/webhook"
+)
+
+// failures回だけstatusを返し，その後は成功するサーバ
+func newFlakyServer(status, failures int, count *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*count++
+		if *count <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
+
+func TestClient(t *testing.T) {
+	if webhook.NewClient(0, 3) != nil {
+		t.Fatal("NewClient() without timeout returns not nil")
+	}
+
+	client := webhook.NewClientWithBackoff(time.Second, 2, time.Millisecond)
+
+	t.Run("retry on server error", func(t *testing.T) {
+		count := 0
+		ts := newFlakyServer(http.StatusInternalServerError, 2, &count)
+		defer ts.Close()
+
+		if err := client.Post(ts.URL, nil, []byte("{}")); err != nil {
+			t.Fatal(err.Error())
+		}
+		if count != 3 {
+			t.Fatalf("count=%d", count)
+		}
+	})
+
+	t.Run("give up after retries", func(t *testing.T) {
+		count := 0
+		ts := newFlakyServer(http.StatusTooManyRequests, 3, &count)
+		defer ts.Close()
+
+		err := client.Post(ts.URL, nil, []byte("{}"))
+		if apiErr, ok := err.(*webhook.APIError); !ok || apiErr.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("err=%v", err)
+		}
+		if count != 3 {
+			t.Fatalf("count=%d", count)
+		}
+	})
+
+	t.Run("no retry on client error", func(t *testing.T) {
+		count := 0
+		ts := newFlakyServer(http.StatusBadRequest, 1, &count)
+		defer ts.Close()
+
+		if err := client.Post(ts.URL, nil, []byte("{}")); err == nil {
+			t.Fatal("Post() returns nil")
+		}
+		if count != 1 {
+			t.Fatalf("count=%d", count)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		done := make(chan struct{})
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+			}
+		}))
+		defer ts.Close()
+		defer close(done)
+
+		client := webhook.NewClientWithBackoff(10*time.Millisecond, 0, 0)
+		if err := client.Post(ts.URL, nil, []byte("{}")); err == nil {
+			t.Fatal("Post() returns nil")
+		}
+	})
+}
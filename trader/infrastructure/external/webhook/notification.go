@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+// 任意の通知先に送るJSON
+type payload struct {
+	Time        time.Time `json:"time"`
+	Level       string    `json:"level"`
+	ProductCode string    `json:"product_code"`
+	Title       string    `json:"title"`
+	Message     string    `json:"message"`
+}
+
+type webhookNotificationRepository struct {
+	client *Client
+	url    string
+}
+
+func NewWebhookNotificationRepository(client *Client, url string) repository.NotificationRepository {
+	if client == nil || url == "" {
+		return nil
+	}
+
+	return &webhookNotificationRepository{
+		client: client,
+		url:    url,
+	}
+}
+
+func (wnr *webhookNotificationRepository) NotifyOfTradingSuccess(event model.SignalEvent) error {
+	return wnr.Notify(*model.NewTradingSuccessNotification(event))
+}
+
+func (wnr *webhookNotificationRepository) NotifyOfTradingFailure(productCode string, err error) error {
+	return wnr.Notify(*model.NewTradingFailureNotification(time.Now(), productCode, err))
+}
+
+func (wnr *webhookNotificationRepository) NotifyOfSpread(spread model.Spread) error {
+	return wnr.Notify(*model.NewSpreadNotification(spread))
+}
+
+func (wnr *webhookNotificationRepository) NotifyOfSummary(summary model.Summary) error {
+	return wnr.Notify(*model.NewSummaryNotification(summary))
+}
+
+func (wnr *webhookNotificationRepository) Notify(notification model.Notification) error {
+	body, err := json.Marshal(payload{
+		Time:        notification.Time(),
+		Level:       string(notification.Level()),
+		ProductCode: notification.ProductCode(),
+		Title:       notification.Title(),
+		Message:     notification.Message(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return wnr.client.Post(wnr.url, nil, body)
+}
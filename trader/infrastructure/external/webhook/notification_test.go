@@ -0,0 +1,48 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/webhook"
+)
+
+func TestWebhookNotificationRepository(t *testing.T) {
+	var received map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Content-Type=%s", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Error(err.Error())
+		}
+	}))
+	defer ts.Close()
+
+	client := webhook.NewClientWithBackoff(time.Second, 0, 0)
+	if webhook.NewWebhookNotificationRepository(client, "") != nil {
+		t.Fatal("NewWebhookNotificationRepository() without url returns not nil")
+	}
+	notificationRepository := webhook.NewWebhookNotificationRepository(client, ts.URL)
+
+	timeTime := time.Date(2021, 11, 9, 0, 0, 0, 0, time.UTC)
+	notification := model.NewNotification(timeTime, model.NotificationLevelWarn, "ETH_JPY", "取引を見送りました", "board is not running")
+	if err := notificationRepository.Notify(*notification); err != nil {
+		t.Fatal(err.Error())
+	}
+	if received["level"] != "warn" || received["product_code"] != "ETH_JPY" || received["message"] != "board is not running" || received["time"] != "2021-11-09T00:00:00Z" {
+		t.Fatalf("received=%v", received)
+	}
+
+	event := model.NewSignalEvent(timeTime, "ETH_JPY", model.OrderSideSell, 500000, 0.01)
+	if err := notificationRepository.NotifyOfTradingSuccess(*event); err != nil {
+		t.Fatal(err.Error())
+	}
+	if received["level"] != "info" || received["title"] != "SELL" {
+		t.Fatalf("received=%v", received)
+	}
+}
@@ -0,0 +1,135 @@
+package persistence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+)
+
+func TestAlertRule(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	alertRuleRepository := persistence.NewAlertRuleRepository(tx, config.TimeFormat)
+
+	var id int64
+
+	t.Run("save alert rule", func(t *testing.T) {
+		rule := model.NewAlertRule(0, config.ProductCode, model.AlertKindIndicator, 0, 0, "rsi(14) < 25", time.Hour, true, false, time.Time{})
+		var err error
+		id, err = alertRuleRepository.Save(*rule)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if id <= 0 {
+			t.Fatalf("id=%d", id)
+		}
+	})
+
+	t.Run("update state", func(t *testing.T) {
+		notifiedAt := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+		rule := model.NewAlertRule(id, config.ProductCode, model.AlertKindIndicator, 0, 0, "rsi(14) < 25", time.Hour, true, true, notifiedAt)
+		if err := alertRuleRepository.UpdateState(*rule); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		rules, err := alertRuleRepository.FindAll(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		found := false
+		for _, r := range rules {
+			if r.ID() != id {
+				continue
+			}
+			found = true
+			if !r.Active() || !r.NotifiedAt().Equal(notifiedAt) || r.Cooldown() != time.Hour || r.Expression() != rule.Expression() {
+				t.Fatalf("rule=%+v", r)
+			}
+		}
+		if !found {
+			t.Fatalf("rule %d is not found", id)
+		}
+	})
+
+	t.Run("update condition", func(t *testing.T) {
+		rule := model.NewAlertRule(id, config.ProductCode, model.AlertKindPriceAbove, 5000000, 0, "", 30*time.Minute, true, false, time.Time{})
+		if _, err := alertRuleRepository.Save(*rule); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		rules, err := alertRuleRepository.FindAll(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		for _, r := range rules {
+			if r.ID() == id && (r.Kind() != model.AlertKindPriceAbove || r.Active()) {
+				t.Fatalf("rule=%+v", r)
+			}
+		}
+	})
+
+	t.Run("delete alert rule", func(t *testing.T) {
+		if err := alertRuleRepository.Delete(id); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		rules, err := alertRuleRepository.FindAll(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		for _, r := range rules {
+			if r.ID() == id {
+				t.Fatalf("rule %d is not deleted", id)
+			}
+		}
+	})
+}
+
+func TestPricePoint(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	pricePointRepository := persistence.NewPricePointRepository(tx, config.TimeFormat)
+
+	// 日時は2100年1月1日以降かつ昇順
+	points := []model.PricePoint{
+		*model.NewPricePoint(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), config.ProductCode, 100),
+		*model.NewPricePoint(time.Date(2100, 1, 1, 1, 0, 0, 0, time.UTC), config.ProductCode, 110),
+	}
+
+	t.Run("save price point", func(t *testing.T) {
+		for _, point := range points {
+			if err := pricePointRepository.Save(point); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+	})
+
+	t.Run("find latest before", func(t *testing.T) {
+		point, err := pricePointRepository.FindLatestBefore(config.ProductCode, time.Date(2100, 1, 1, 0, 30, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if point == nil || point.Price() != 100 {
+			t.Fatalf("point=%+v", point)
+		}
+	})
+
+	t.Run("delete before", func(t *testing.T) {
+		if err := pricePointRepository.DeleteBefore(config.ProductCode, points[1].Time()); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		point, err := pricePointRepository.FindLatestBefore(config.ProductCode, time.Date(2100, 1, 1, 0, 30, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if point != nil {
+			t.Fatalf("point=%+v", point)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package persistence_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+)
+
+func TestCandleFileRepository(t *testing.T) {
+	// Cloud SQLのエクスポートと同じ形式
+	data := "2021-11-02 00:00:00,510000,520000,530000,500000,100\n" +
+		"2021-11-01 00:00:00,500000,510000,520000,490000,200\n"
+	filePath := filepath.Join(t.TempDir(), "candles.csv")
+	if err := os.WriteFile(filePath, []byte(data), 0666); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	cr := persistence.NewCandleFileRepository(filePath, config.TimeFormat, config.ProductCode, config.CandleDuration)
+	if cr == nil {
+		t.Fatal("NewCandleFileRepository() returns nil")
+	}
+
+	t.Run("find all candle", func(t *testing.T) {
+		candles, err := cr.FindAll(config.ProductCode, config.CandleDuration, 10)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(candles) != 2 {
+			t.Fatalf("%d != %d", len(candles), 2)
+		}
+		// 時刻の昇順に並ぶ
+		if !candles[0].Time().Time().Before(candles[1].Time().Time()) {
+			t.Fatal("candles are not sorted")
+		}
+	})
+
+	t.Run("save candle", func(t *testing.T) {
+		candleTime := model.NewCandleTime(time.Date(2021, 11, 2, 0, 0, 0, 0, time.UTC))
+		candle := model.NewCandle(config.ProductCode, config.CandleDuration, candleTime, 510000, 525000, 530000, 500000, 150)
+		if err := cr.Save(*candle); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		found, err := cr.FindByCandleTime(config.ProductCode, config.CandleDuration, candleTime)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if found == nil || *found != *candle {
+			t.Fatalf("%v != %v", found, candle)
+		}
+
+		candles, _ := cr.FindAll(config.ProductCode, config.CandleDuration, -1)
+		if len(candles) != 2 {
+			t.Fatalf("%d != %d", len(candles), 2)
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		if persistence.NewCandleFileRepository("candles.json", config.TimeFormat, config.ProductCode, config.CandleDuration) != nil {
+			t.Fatal("NewCandleFileRepository() returns not nil")
+		}
+	})
+}
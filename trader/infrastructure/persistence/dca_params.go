@@ -0,0 +1,78 @@
+package persistence
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type dcaParamsRepository struct {
+	db DB
+}
+
+func NewDCAParamsRepository(db DB) repository.DCAParamsRepository {
+	return &dcaParamsRepository{
+		db: db,
+	}
+}
+
+func (dr *dcaParamsRepository) Save(params model.DCAParams) error {
+	cmd := `
+        INSERT INTO dca_params
+            (product_code, enable, amount, interval_days, rsi_period, rsi_threshold, rsi_multiplier, min_size)
+        VALUES
+            (?, ?, ?, ?, ?, ?, ?, ?)
+        ON DUPLICATE KEY UPDATE
+            enable = VALUES(enable),
+            amount = VALUES(amount),
+            interval_days = VALUES(interval_days),
+            rsi_period = VALUES(rsi_period),
+            rsi_threshold = VALUES(rsi_threshold),
+            rsi_multiplier = VALUES(rsi_multiplier),
+            min_size = VALUES(min_size)
+        `
+	_, err := dr.db.Exec(cmd,
+		params.ProductCode(),
+		params.Enable(),
+		params.Amount(),
+		params.IntervalDays(),
+		params.RSIPeriod(),
+		params.RSIThreshold(),
+		params.RSIMultiplier(),
+		params.MinSize(),
+	)
+	return err
+}
+
+func (dr *dcaParamsRepository) Find(productCode string) (*model.DCAParams, error) {
+	cmd := `
+        SELECT
+            enable, amount, interval_days, rsi_period, rsi_threshold, rsi_multiplier, min_size
+        FROM
+            dca_params
+        WHERE
+            product_code = ?
+        `
+	row := dr.db.QueryRow(cmd, productCode)
+
+	var enable bool
+	var amount, rsiThreshold, rsiMultiplier, minSize float64
+	var intervalDays, rsiPeriod int
+	err := row.Scan(&enable, &amount, &intervalDays, &rsiPeriod, &rsiThreshold, &rsiMultiplier, &minSize)
+	// 発見できなかったらそのままnilを返す
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	params := model.NewDCAParams(productCode, enable, amount, intervalDays, rsiPeriod, rsiThreshold, rsiMultiplier, minSize)
+	if params == nil {
+		return nil, errors.New(fmt.Sprint("invalid dca_params:", productCode, enable, amount, intervalDays, rsiPeriod, rsiThreshold, rsiMultiplier, minSize))
+	}
+	return params, nil
+}
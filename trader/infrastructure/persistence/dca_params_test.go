@@ -0,0 +1,47 @@
+package persistence_test
+
+import (
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+)
+
+func TestDCAParams(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	dcaParamsRepository := persistence.NewDCAParamsRepository(tx)
+
+	params := model.NewDCAParams(config.ProductCode, true, 10000, 7, 14, 30, 2, 0.01)
+
+	t.Run("save dca_params", func(t *testing.T) {
+		if err := dcaParamsRepository.Save(*params); err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+
+	t.Run("find dca_params", func(t *testing.T) {
+		found, err := dcaParamsRepository.Find(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if found == nil {
+			t.Fatal("dca_params is not found")
+		}
+		if *found != *params {
+			t.Fatalf("%+v != %+v", *found, *params)
+		}
+	})
+
+	t.Run("find not existing dca_params", func(t *testing.T) {
+		found, err := dcaParamsRepository.Find("NOT_EXISTING")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if found != nil {
+			t.Fatal("dca_params is found")
+		}
+	})
+}
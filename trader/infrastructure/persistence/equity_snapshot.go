@@ -0,0 +1,96 @@
+package persistence
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type equitySnapshotRepository struct {
+	db         DB
+	timeFormat string
+}
+
+func NewEquitySnapshotRepository(db DB, timeFormat string) repository.EquitySnapshotRepository {
+	return &equitySnapshotRepository{
+		db:         db,
+		timeFormat: timeFormat,
+	}
+}
+
+func (er *equitySnapshotRepository) Save(snapshot model.EquitySnapshot) error {
+	cmd := `
+        INSERT INTO equity_snapshots
+            (time, product_code, equity, realized_pnl, unrealized_pnl, position, price)
+        VALUES
+            (?, ?, ?, ?, ?, ?, ?)
+        ON DUPLICATE KEY UPDATE
+            equity = VALUES(equity),
+            realized_pnl = VALUES(realized_pnl),
+            unrealized_pnl = VALUES(unrealized_pnl),
+            position = VALUES(position),
+            price = VALUES(price)
+        `
+	_, err := er.db.Exec(cmd,
+		snapshot.Time().Format(er.timeFormat),
+		snapshot.ProductCode(),
+		snapshot.Equity(),
+		snapshot.RealizedPnL(),
+		snapshot.UnrealizedPnL(),
+		snapshot.Position(),
+		snapshot.Price(),
+	)
+	return err
+}
+
+func (er *equitySnapshotRepository) FindAll(productCode string, limit int64) ([]model.EquitySnapshot, error) {
+	cmd := `
+        SELECT
+            *
+        FROM (
+            SELECT
+                time, product_code, equity, realized_pnl, unrealized_pnl, position, price
+            FROM
+                equity_snapshots
+            WHERE
+                product_code = ?
+            ORDER BY
+                time DESC
+            LIMIT ?
+        ) AS snapshot
+        ORDER BY
+            time ASC
+        `
+	rows, err := er.db.Query(cmd, productCode, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := make([]model.EquitySnapshot, 0)
+	for rows.Next() {
+		var timeTime time.Time
+		var productCode string
+		var equity, realizedPnL, unrealizedPnL, position, price float64
+		err := rows.Scan(&timeTime, &productCode, &equity, &realizedPnL, &unrealizedPnL, &position, &price)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot := model.NewEquitySnapshot(timeTime, productCode, equity, realizedPnL, unrealizedPnL, position, price)
+		if snapshot == nil {
+			return nil, errors.New(fmt.Sprint("invalid equity_snapshot:", timeTime, productCode, equity, realizedPnL, unrealizedPnL, position, price))
+		}
+
+		snapshots = append(snapshots, *snapshot)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
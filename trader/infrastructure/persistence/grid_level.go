@@ -0,0 +1,86 @@
+package persistence
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type gridLevelRepository struct {
+	db DB
+}
+
+func NewGridLevelRepository(db DB) repository.GridLevelRepository {
+	return &gridLevelRepository{
+		db: db,
+	}
+}
+
+func (gr *gridLevelRepository) Save(level model.GridLevel) error {
+	cmd := `
+        INSERT INTO grid_levels
+            (product_code, level_index, buy_price, sell_price, size, state, order_id)
+        VALUES
+            (?, ?, ?, ?, ?, ?, ?)
+        ON DUPLICATE KEY UPDATE
+            buy_price = VALUES(buy_price),
+            sell_price = VALUES(sell_price),
+            size = VALUES(size),
+            state = VALUES(state),
+            order_id = VALUES(order_id)
+        `
+	_, err := gr.db.Exec(cmd, level.ProductCode(), level.Index(), level.BuyPrice(), level.SellPrice(), level.Size(), level.State(), level.OrderID())
+	return err
+}
+
+func (gr *gridLevelRepository) FindAll(productCode string) ([]model.GridLevel, error) {
+	cmd := `
+        SELECT
+            level_index, buy_price, sell_price, size, state, order_id
+        FROM
+            grid_levels
+        WHERE
+            product_code = ?
+        ORDER BY
+            level_index ASC
+        `
+	rows, err := gr.db.Query(cmd, productCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	levels := make([]model.GridLevel, 0)
+	for rows.Next() {
+		var index int
+		var buyPrice, sellPrice, size float64
+		var state, orderID string
+		if err := rows.Scan(&index, &buyPrice, &sellPrice, &size, &state, &orderID); err != nil {
+			return nil, err
+		}
+
+		level := model.NewGridLevel(productCode, index, buyPrice, sellPrice, size, model.GridLevelState(state), orderID)
+		if level == nil {
+			return nil, errors.New(fmt.Sprint("invalid grid_level:", productCode, index, buyPrice, sellPrice, size, state, orderID))
+		}
+		levels = append(levels, *level)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return levels, nil
+}
+
+func (gr *gridLevelRepository) DeleteAll(productCode string) error {
+	cmd := `
+        DELETE FROM
+            grid_levels
+        WHERE
+            product_code = ?
+        `
+	_, err := gr.db.Exec(cmd, productCode)
+	return err
+}
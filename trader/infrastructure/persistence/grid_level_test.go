@@ -0,0 +1,66 @@
+package persistence_test
+
+import (
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+)
+
+func TestGridLevel(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	gridLevelRepository := persistence.NewGridLevelRepository(tx)
+
+	grid := model.NewGrid(config.ProductCode, 100, 200, 5, 0.01)
+	levels := grid.NewLevels()
+
+	t.Run("save grid_level", func(t *testing.T) {
+		if err := gridLevelRepository.DeleteAll(config.ProductCode); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		for _, level := range levels {
+			if err := gridLevelRepository.Save(level); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+
+		levels[1].PlaceBuy("JRF20220101-000000-000001")
+		if err := gridLevelRepository.Save(levels[1]); err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+
+	t.Run("find all grid_level", func(t *testing.T) {
+		found, err := gridLevelRepository.FindAll(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(found) != len(levels) {
+			t.Fatalf("len(found)=%d", len(found))
+		}
+		if !grid.Matches(found) {
+			t.Fatal("found levels do not match the grid")
+		}
+		if found[1].State() != model.GridLevelStateBuying || found[1].OrderID() != "JRF20220101-000000-000001" {
+			t.Fatalf("found[1]=%+v", found[1])
+		}
+	})
+
+	t.Run("delete all grid_level", func(t *testing.T) {
+		if err := gridLevelRepository.DeleteAll(config.ProductCode); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		found, err := gridLevelRepository.FindAll(config.ProductCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(found) != 0 {
+			t.Fatalf("len(found)=%d", len(found))
+		}
+	})
+}
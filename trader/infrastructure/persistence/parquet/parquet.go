@@ -0,0 +1,421 @@
+// Package parquet は価格データのエクスポート用に，Parquetファイルを読み書きする
+//
+// 対応しているのは，ネストのないREQUIREDな列だけからなるファイルで，
+// PLAINエンコーディング・無圧縮のデータページのみ
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+const magic = "PAR1"
+
+type Type int
+
+const (
+	TypeInt64     Type = iota // int64
+	TypeDouble                // float64
+	TypeString                // string
+	TypeTimestamp             // time.Time（ミリ秒，UTC）
+)
+
+// Parquetの物理型
+const (
+	physicalInt32     = 1
+	physicalInt64     = 2
+	physicalFloat     = 4
+	physicalDouble    = 5
+	physicalByteArray = 6
+)
+
+// Parquetの変換型
+const (
+	convertedUTF8            = 0
+	convertedTimestampMillis = 9
+	convertedTimestampMicros = 10
+)
+
+const (
+	repetitionRequired = 0
+	encodingPlain      = 0
+	encodingRLE        = 3
+	codecUncompressed  = 0
+	pageTypeData       = 0
+)
+
+type Column struct {
+	Name   string
+	Type   Type
+	Values []interface{}
+}
+
+func physicalType(typ Type) int32 {
+	switch typ {
+	case TypeDouble:
+		return physicalDouble
+	case TypeString:
+		return physicalByteArray
+	}
+	return physicalInt64
+}
+
+func encodeValues(column Column) ([]byte, error) {
+	var buf bytes.Buffer
+	b := make([]byte, 8)
+	for _, value := range column.Values {
+		switch column.Type {
+		case TypeInt64:
+			v, ok := value.(int64)
+			if !ok {
+				return nil, fmt.Errorf("column %s: %v is not int64", column.Name, value)
+			}
+			binary.LittleEndian.PutUint64(b, uint64(v))
+			buf.Write(b)
+		case TypeDouble:
+			v, ok := value.(float64)
+			if !ok {
+				return nil, fmt.Errorf("column %s: %v is not float64", column.Name, value)
+			}
+			binary.LittleEndian.PutUint64(b, math.Float64bits(v))
+			buf.Write(b)
+		case TypeString:
+			v, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("column %s: %v is not string", column.Name, value)
+			}
+			binary.LittleEndian.PutUint32(b, uint32(len(v)))
+			buf.Write(b[:4])
+			buf.WriteString(v)
+		case TypeTimestamp:
+			v, ok := value.(time.Time)
+			if !ok {
+				return nil, fmt.Errorf("column %s: %v is not time.Time", column.Name, value)
+			}
+			binary.LittleEndian.PutUint64(b, uint64(v.UnixNano()/int64(time.Millisecond)))
+			buf.Write(b)
+		default:
+			return nil, fmt.Errorf("column %s: unknown type", column.Name)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// 1つの行グループにすべての行を書き込む
+func Write(w io.Writer, columns []Column) error {
+	if len(columns) == 0 {
+		return errors.New("no columns")
+	}
+	numRows := len(columns[0].Values)
+	for _, column := range columns {
+		if len(column.Values) != numRows {
+			return fmt.Errorf("column %s: number of values differs", column.Name)
+		}
+	}
+
+	var body bytes.Buffer
+	body.WriteString(magic)
+
+	type chunk struct {
+		offset int64
+		size   int64
+	}
+	chunks := make([]chunk, 0, len(columns))
+	for _, column := range columns {
+		data, err := encodeValues(column)
+		if err != nil {
+			return err
+		}
+
+		header := newThriftWriter()
+		header.writeI32(1, pageTypeData)
+		header.writeI32(2, int32(len(data)))
+		header.writeI32(3, int32(len(data)))
+		header.beginStruct(5)
+		header.writeI32(1, int32(numRows))
+		header.writeI32(2, encodingPlain)
+		header.writeI32(3, encodingRLE)
+		header.writeI32(4, encodingRLE)
+		header.endStruct()
+		header.endStruct()
+
+		offset := int64(body.Len())
+		body.Write(header.Bytes())
+		body.Write(data)
+		chunks = append(chunks, chunk{offset: offset, size: int64(body.Len()) - offset})
+	}
+
+	meta := newThriftWriter()
+	meta.writeI32(1, 1)
+	// スキーマ: ルートと各列
+	meta.beginList(2, thriftTypeStruct, len(columns)+1)
+	meta.beginElement()
+	meta.writeString(4, "schema")
+	meta.writeI32(5, int32(len(columns)))
+	meta.endStruct()
+	for _, column := range columns {
+		meta.beginElement()
+		meta.writeI32(1, physicalType(column.Type))
+		meta.writeI32(3, repetitionRequired)
+		meta.writeString(4, column.Name)
+		switch column.Type {
+		case TypeString:
+			meta.writeI32(6, convertedUTF8)
+		case TypeTimestamp:
+			meta.writeI32(6, convertedTimestampMillis)
+		}
+		meta.endStruct()
+	}
+	meta.writeI64(3, int64(numRows))
+	// 行グループ
+	var totalSize int64
+	for _, c := range chunks {
+		totalSize += c.size
+	}
+	meta.beginList(4, thriftTypeStruct, 1)
+	meta.beginElement()
+	meta.beginList(1, thriftTypeStruct, len(columns))
+	for i, column := range columns {
+		meta.beginElement()
+		meta.writeI64(2, chunks[i].offset)
+		meta.beginStruct(3)
+		meta.writeI32(1, physicalType(column.Type))
+		meta.beginList(2, thriftTypeI32, 2)
+		meta.writeI32Element(encodingPlain)
+		meta.writeI32Element(encodingRLE)
+		meta.beginList(3, thriftTypeBinary, 1)
+		meta.writeStringElement(column.Name)
+		meta.writeI32(4, codecUncompressed)
+		meta.writeI64(5, int64(numRows))
+		meta.writeI64(6, chunks[i].size)
+		meta.writeI64(7, chunks[i].size)
+		meta.writeI64(9, chunks[i].offset)
+		meta.endStruct()
+		meta.endStruct()
+	}
+	meta.writeI64(2, totalSize)
+	meta.writeI64(3, int64(numRows))
+	meta.endStruct()
+	meta.endStruct()
+
+	metaBytes := meta.Bytes()
+	body.Write(metaBytes)
+	length := make([]byte, 4)
+	binary.LittleEndian.PutUint32(length, uint32(len(metaBytes)))
+	body.Write(length)
+	body.WriteString(magic)
+
+	_, err := w.Write(body.Bytes())
+	return err
+}
+
+type columnSchema struct {
+	name      string
+	physical  int64
+	converted int64
+}
+
+func (c columnSchema) columnType() (Type, error) {
+	switch c.physical {
+	case physicalInt32, physicalInt64:
+		if c.converted == convertedTimestampMillis || c.converted == convertedTimestampMicros {
+			return TypeTimestamp, nil
+		}
+		return TypeInt64, nil
+	case physicalFloat, physicalDouble:
+		return TypeDouble, nil
+	case physicalByteArray:
+		return TypeString, nil
+	}
+	return 0, fmt.Errorf("column %s: unsupported physical type %d", c.name, c.physical)
+}
+
+// ファイル全体を読んで列ごとの値を返す
+func Read(data []byte) ([]Column, error) {
+	if len(data) < 12 || string(data[:4]) != magic || string(data[len(data)-4:]) != magic {
+		return nil, errors.New("not a parquet file")
+	}
+	metaLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	metaStart := len(data) - 8 - metaLen
+	if metaStart < 4 {
+		return nil, errors.New("invalid parquet footer")
+	}
+
+	r := &thriftReader{data: data[:len(data)-8], pos: metaStart}
+	meta, err := r.readStruct()
+	if err != nil {
+		return nil, err
+	}
+
+	schemaList, ok := meta.list(2)
+	if !ok || len(schemaList) < 2 {
+		return nil, errors.New("invalid parquet schema")
+	}
+	schemas := make([]columnSchema, 0)
+	columns := make([]Column, 0)
+	for _, element := range schemaList[1:] {
+		s, ok := element.(thriftStruct)
+		if !ok {
+			return nil, errors.New("invalid parquet schema")
+		}
+		if children, ok := s.int64(5); ok && children > 0 {
+			return nil, errors.New("nested columns are not supported")
+		}
+		if repetition, ok := s.int64(3); ok && repetition != repetitionRequired {
+			return nil, errors.New("only required columns are supported")
+		}
+
+		schema := columnSchema{converted: -1}
+		schema.name, _ = s.string(4)
+		schema.physical, _ = s.int64(1)
+		if converted, ok := s.int64(6); ok {
+			schema.converted = converted
+		}
+		typ, err := schema.columnType()
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+		columns = append(columns, Column{Name: schema.name, Type: typ, Values: make([]interface{}, 0)})
+	}
+
+	rowGroups, _ := meta.list(4)
+	for _, rg := range rowGroups {
+		rowGroup, ok := rg.(thriftStruct)
+		if !ok {
+			return nil, errors.New("invalid parquet row group")
+		}
+		chunks, _ := rowGroup.list(1)
+		if len(chunks) != len(columns) {
+			return nil, errors.New("invalid parquet row group")
+		}
+		for i, c := range chunks {
+			chunk, ok := c.(thriftStruct)
+			if !ok {
+				return nil, errors.New("invalid parquet column chunk")
+			}
+			values, err := readColumnChunk(data, chunk, schemas[i])
+			if err != nil {
+				return nil, err
+			}
+			columns[i].Values = append(columns[i].Values, values...)
+		}
+	}
+
+	return columns, nil
+}
+
+func readColumnChunk(data []byte, chunk thriftStruct, schema columnSchema) ([]interface{}, error) {
+	meta, ok := chunk.structure(3)
+	if !ok {
+		return nil, errors.New("column chunk has no metadata")
+	}
+	if codec, _ := meta.int64(4); codec != codecUncompressed {
+		return nil, fmt.Errorf("column %s: compressed data is not supported", schema.name)
+	}
+	numValues, _ := meta.int64(5)
+	offset, _ := meta.int64(9)
+
+	values := make([]interface{}, 0, numValues)
+	r := &thriftReader{data: data, pos: int(offset)}
+	for int64(len(values)) < numValues {
+		header, err := r.readStruct()
+		if err != nil {
+			return nil, err
+		}
+		size, _ := header.int64(3)
+		if r.pos+int(size) > len(data) {
+			return nil, errors.New("invalid parquet page size")
+		}
+		page := data[r.pos : r.pos+int(size)]
+		r.pos += int(size)
+
+		if pageType, _ := header.int64(1); pageType != pageTypeData {
+			return nil, fmt.Errorf("column %s: unsupported page type %d", schema.name, pageType)
+		}
+		dataPage, ok := header.structure(5)
+		if !ok {
+			return nil, errors.New("invalid parquet data page")
+		}
+		if encoding, _ := dataPage.int64(2); encoding != encodingPlain {
+			return nil, fmt.Errorf("column %s: unsupported encoding %d", schema.name, encoding)
+		}
+		n, _ := dataPage.int64(1)
+
+		pageValues, err := decodeValues(page, int(n), schema)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, pageValues...)
+	}
+
+	return values, nil
+}
+
+func decodeValues(page []byte, n int, schema columnSchema) ([]interface{}, error) {
+	values := make([]interface{}, 0, n)
+	pos := 0
+	next := func(size int) ([]byte, error) {
+		if pos+size > len(page) {
+			return nil, fmt.Errorf("column %s: unexpected end of page", schema.name)
+		}
+		b := page[pos : pos+size]
+		pos += size
+		return b, nil
+	}
+
+	for i := 0; i < n; i++ {
+		var value interface{}
+		switch schema.physical {
+		case physicalInt32:
+			b, err := next(4)
+			if err != nil {
+				return nil, err
+			}
+			value = int64(int32(binary.LittleEndian.Uint32(b)))
+		case physicalInt64:
+			b, err := next(8)
+			if err != nil {
+				return nil, err
+			}
+			value = int64(binary.LittleEndian.Uint64(b))
+		case physicalFloat:
+			b, err := next(4)
+			if err != nil {
+				return nil, err
+			}
+			value = float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		case physicalDouble:
+			b, err := next(8)
+			if err != nil {
+				return nil, err
+			}
+			value = math.Float64frombits(binary.LittleEndian.Uint64(b))
+		case physicalByteArray:
+			b, err := next(4)
+			if err != nil {
+				return nil, err
+			}
+			s, err := next(int(binary.LittleEndian.Uint32(b)))
+			if err != nil {
+				return nil, err
+			}
+			value = string(s)
+		}
+
+		switch schema.converted {
+		case convertedTimestampMillis:
+			value = time.Unix(0, value.(int64)*int64(time.Millisecond)).UTC()
+		case convertedTimestampMicros:
+			value = time.Unix(0, value.(int64)*int64(time.Microsecond)).UTC()
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,293 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// Parquetのメタデータに使われるThrift Compact Protocolの最小限の実装
+
+const (
+	thriftTypeBoolTrue  = 1
+	thriftTypeBoolFalse = 2
+	thriftTypeByte      = 3
+	thriftTypeI16       = 4
+	thriftTypeI32       = 5
+	thriftTypeI64       = 6
+	thriftTypeDouble    = 7
+	thriftTypeBinary    = 8
+	thriftTypeList      = 9
+	thriftTypeSet       = 10
+	thriftTypeMap       = 11
+	thriftTypeStruct    = 12
+)
+
+var errInvalidThrift = errors.New("invalid thrift compact data")
+
+type thriftWriter struct {
+	buf       bytes.Buffer
+	lastField []int16
+}
+
+func newThriftWriter() *thriftWriter {
+	return &thriftWriter{
+		lastField: []int16{0},
+	}
+}
+
+func (w *thriftWriter) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+func (w *thriftWriter) writeVarint(v uint64) {
+	b := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(b, v)
+	w.buf.Write(b[:n])
+}
+
+func (w *thriftWriter) writeZigzag(v int64) {
+	w.writeVarint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (w *thriftWriter) fieldHeader(id int16, typ byte) {
+	last := w.lastField[len(w.lastField)-1]
+	if delta := id - last; 0 < delta && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.writeZigzag(int64(id))
+	}
+	w.lastField[len(w.lastField)-1] = id
+}
+
+func (w *thriftWriter) writeI32(id int16, v int32) {
+	w.fieldHeader(id, thriftTypeI32)
+	w.writeZigzag(int64(v))
+}
+
+func (w *thriftWriter) writeI64(id int16, v int64) {
+	w.fieldHeader(id, thriftTypeI64)
+	w.writeZigzag(v)
+}
+
+func (w *thriftWriter) writeString(id int16, v string) {
+	w.fieldHeader(id, thriftTypeBinary)
+	w.writeVarint(uint64(len(v)))
+	w.buf.WriteString(v)
+}
+
+func (w *thriftWriter) beginStruct(id int16) {
+	w.fieldHeader(id, thriftTypeStruct)
+	w.lastField = append(w.lastField, 0)
+}
+
+// リストの要素としての構造体
+func (w *thriftWriter) beginElement() {
+	w.lastField = append(w.lastField, 0)
+}
+
+func (w *thriftWriter) endStruct() {
+	w.buf.WriteByte(0)
+	w.lastField = w.lastField[:len(w.lastField)-1]
+}
+
+func (w *thriftWriter) beginList(id int16, elemType byte, size int) {
+	w.fieldHeader(id, thriftTypeList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		w.buf.WriteByte(0xf0 | elemType)
+		w.writeVarint(uint64(size))
+	}
+}
+
+func (w *thriftWriter) writeI32Element(v int32) {
+	w.writeZigzag(int64(v))
+}
+
+func (w *thriftWriter) writeStringElement(v string) {
+	w.writeVarint(uint64(len(v)))
+	w.buf.WriteString(v)
+}
+
+// 構造体はフィールドIDをキーとするmapとして読む
+type thriftStruct map[int16]interface{}
+
+func (s thriftStruct) int64(id int16) (int64, bool) {
+	v, ok := s[id].(int64)
+	return v, ok
+}
+
+func (s thriftStruct) string(id int16) (string, bool) {
+	v, ok := s[id].([]byte)
+	return string(v), ok
+}
+
+func (s thriftStruct) structure(id int16) (thriftStruct, bool) {
+	v, ok := s[id].(thriftStruct)
+	return v, ok
+}
+
+func (s thriftStruct) list(id int16) ([]interface{}, bool) {
+	v, ok := s[id].([]interface{})
+	return v, ok
+}
+
+type thriftReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) readByte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, errInvalidThrift
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *thriftReader) readVarint() (uint64, error) {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		return 0, errInvalidThrift
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *thriftReader) readZigzag() (int64, error) {
+	v, err := r.readVarint()
+	if err != nil {
+		return 0, err
+	}
+	return int64(v>>1) ^ -int64(v&1), nil
+}
+
+func (r *thriftReader) readStruct() (thriftStruct, error) {
+	s := make(thriftStruct)
+	var last int16
+	for {
+		b, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if b == 0 {
+			return s, nil
+		}
+
+		typ := b & 0x0f
+		id := last + int16(b>>4)
+		if b>>4 == 0 {
+			v, err := r.readZigzag()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		last = id
+
+		v, err := r.readValue(typ)
+		if err != nil {
+			return nil, err
+		}
+		s[id] = v
+	}
+}
+
+func (r *thriftReader) readValue(typ byte) (interface{}, error) {
+	switch typ {
+	case thriftTypeBoolTrue:
+		return true, nil
+	case thriftTypeBoolFalse:
+		return false, nil
+	case thriftTypeByte:
+		b, err := r.readByte()
+		return int64(int8(b)), err
+	case thriftTypeI16, thriftTypeI32, thriftTypeI64:
+		return r.readZigzag()
+	case thriftTypeDouble:
+		if r.pos+8 > len(r.data) {
+			return nil, errInvalidThrift
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(r.data[r.pos:]))
+		r.pos += 8
+		return v, nil
+	case thriftTypeBinary:
+		n, err := r.readVarint()
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(r.data)-r.pos) < n {
+			return nil, errInvalidThrift
+		}
+		v := r.data[r.pos : r.pos+int(n)]
+		r.pos += int(n)
+		return v, nil
+	case thriftTypeList, thriftTypeSet:
+		return r.readList()
+	case thriftTypeMap:
+		return r.readMap()
+	case thriftTypeStruct:
+		return r.readStruct()
+	}
+	return nil, errInvalidThrift
+}
+
+func (r *thriftReader) readList() ([]interface{}, error) {
+	b, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+	size := uint64(b >> 4)
+	elemType := b & 0x0f
+	if size == 15 {
+		size, err = r.readVarint()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	list := make([]interface{}, 0)
+	for i := uint64(0); i < size; i++ {
+		var v interface{}
+		// リスト中のboolは1バイトで表される
+		if elemType == thriftTypeBoolTrue || elemType == thriftTypeBoolFalse {
+			b, err := r.readByte()
+			if err != nil {
+				return nil, err
+			}
+			v = b == thriftTypeBoolTrue
+		} else {
+			v, err = r.readValue(elemType)
+			if err != nil {
+				return nil, err
+			}
+		}
+		list = append(list, v)
+	}
+	return list, nil
+}
+
+// 中身は使わないので読み飛ばすだけ
+func (r *thriftReader) readMap() (interface{}, error) {
+	size, err := r.readVarint()
+	if err != nil || size == 0 {
+		return nil, err
+	}
+	b, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+	for i := uint64(0); i < size; i++ {
+		if _, err := r.readValue(b >> 4); err != nil {
+			return nil, err
+		}
+		if _, err := r.readValue(b & 0x0f); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
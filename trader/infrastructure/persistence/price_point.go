@@ -0,0 +1,79 @@
+package persistence
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type pricePointRepository struct {
+	db         DB
+	timeFormat string
+}
+
+func NewPricePointRepository(db DB, timeFormat string) repository.PricePointRepository {
+	return &pricePointRepository{
+		db:         db,
+		timeFormat: timeFormat,
+	}
+}
+
+func (pr *pricePointRepository) Save(point model.PricePoint) error {
+	cmd := `
+        INSERT INTO price_points
+            (time, product_code, price)
+        VALUES
+            (?, ?, ?)
+        ON DUPLICATE KEY UPDATE
+            price = VALUES(price)
+        `
+	_, err := pr.db.Exec(cmd, point.Time().Format(pr.timeFormat), point.ProductCode(), point.Price())
+	return err
+}
+
+func (pr *pricePointRepository) FindLatestBefore(productCode string, timeTime time.Time) (*model.PricePoint, error) {
+	cmd := `
+        SELECT
+            time, price
+        FROM
+            price_points
+        WHERE
+            product_code = ? AND time <= ?
+        ORDER BY
+            time DESC
+        LIMIT 1
+        `
+	row := pr.db.QueryRow(cmd, productCode, timeTime.Format(pr.timeFormat))
+
+	var pointTime time.Time
+	var price float64
+	err := row.Scan(&pointTime, &price)
+	// 発見できなかったらそのままnilを返す
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	point := model.NewPricePoint(pointTime, productCode, price)
+	if point == nil {
+		return nil, errors.New(fmt.Sprint("invalid price_point:", pointTime, productCode, price))
+	}
+	return point, nil
+}
+
+func (pr *pricePointRepository) DeleteBefore(productCode string, timeTime time.Time) error {
+	cmd := `
+        DELETE FROM
+            price_points
+        WHERE
+            product_code = ? AND time < ?
+        `
+	_, err := pr.db.Exec(cmd, productCode, timeTime.Format(pr.timeFormat))
+	return err
+}
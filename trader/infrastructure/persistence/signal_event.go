@@ -24,13 +24,13 @@ func NewSignalEventRepository(db DB, timeFormat string) repository.SignalEventRe
 func (sr *signalEventRepository) Save(signal model.SignalEvent) error {
 	cmd := `
         INSERT INTO signal_events
-            (time, product_code, side, price, size)
+            (time, product_code, side, price, size, tag)
         VALUES
-            (?, ?, ?, ?, ?)
+            (?, ?, ?, ?, ?, ?)
         ON DUPLICATE KEY UPDATE
             time = VALUES(time)
         `
-	_, err := sr.db.Exec(cmd, signal.Time().Format(sr.timeFormat), signal.ProductCode(), signal.Side(), signal.Price(), signal.Size())
+	_, err := sr.db.Exec(cmd, signal.Time().Format(sr.timeFormat), signal.ProductCode(), signal.Side(), signal.Price(), signal.Size(), signal.Tag())
 
 	return err
 }
@@ -38,7 +38,7 @@ func (sr *signalEventRepository) Save(signal model.SignalEvent) error {
 func (sr *signalEventRepository) FindAll(productCode string) ([]model.SignalEvent, error) {
 	cmd := `
         SELECT
-            *
+            time, product_code, side, price, size, tag
         FROM signal_events
         WHERE
             product_code = ?
@@ -57,14 +57,15 @@ func (sr *signalEventRepository) FindAll(productCode string) ([]model.SignalEven
 		var productCode string
 		var side model.OrderSide
 		var price, size float64
-		err := rows.Scan(&timeTime, &productCode, &side, &price, &size)
+		var tag model.SignalEventTag
+		err := rows.Scan(&timeTime, &productCode, &side, &price, &size, &tag)
 		if err != nil {
 			return nil, err
 		}
 
-		signalEvent := model.NewSignalEvent(timeTime, productCode, side, price, size)
+		signalEvent := model.NewSignalEventWithTag(timeTime, productCode, side, price, size, tag)
 		if signalEvent == nil {
-			return nil, errors.New(fmt.Sprint("invalid signal_event:", timeTime, productCode, side, price, size))
+			return nil, errors.New(fmt.Sprint("invalid signal_event:", timeTime, productCode, side, price, size, tag))
 		}
 
 		signalEvents = append(signalEvents, *signalEvent)
@@ -80,7 +81,7 @@ func (sr *signalEventRepository) FindAll(productCode string) ([]model.SignalEven
 func (sr *signalEventRepository) FindAllAfterTime(productCode string, timeTime time.Time) ([]model.SignalEvent, error) {
 	cmd := `
         SELECT
-            *
+            time, product_code, side, price, size, tag
         FROM
             signal_events
         WHERE
@@ -101,14 +102,15 @@ func (sr *signalEventRepository) FindAllAfterTime(productCode string, timeTime t
 		var productCode string
 		var side model.OrderSide
 		var price, size float64
-		err := rows.Scan(&timeTime, &productCode, &side, &price, &size)
+		var tag model.SignalEventTag
+		err := rows.Scan(&timeTime, &productCode, &side, &price, &size, &tag)
 		if err != nil {
 			return nil, err
 		}
 
-		signalEvent := model.NewSignalEvent(timeTime, productCode, side, price, size)
+		signalEvent := model.NewSignalEventWithTag(timeTime, productCode, side, price, size, tag)
 		if signalEvent == nil {
-			return nil, errors.New(fmt.Sprint("invalid signal_event:", timeTime, productCode, side, price, size))
+			return nil, errors.New(fmt.Sprint("invalid signal_event:", timeTime, productCode, side, price, size, tag))
 		}
 
 		signalEvents = append(signalEvents, *signalEvent)
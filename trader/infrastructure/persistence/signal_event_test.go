@@ -82,4 +82,19 @@ func TestSignalEvent(t *testing.T) {
 			t.Fatal("FindAllAfterTime() returns incomplete data")
 		}
 	})
+
+	t.Run("save dca signal_event", func(t *testing.T) {
+		dca := model.NewSignalEventWithTag(time.Date(2100, 1, 3, 0, 0, 0, 0, time.UTC), config.ProductCode, model.OrderSideBuy, 1200.0, 0.02, model.SignalEventTagDCA)
+		if err := signalEventRepository.Save(*dca); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		ss, err := signalEventRepository.FindAllAfterTime(config.ProductCode, dca.Time())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(ss) != 1 || !ss[0].IsDCA() {
+			t.Fatalf("ss=%+v", ss)
+		}
+	})
 }
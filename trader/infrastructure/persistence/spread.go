@@ -0,0 +1,93 @@
+package persistence
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type spreadRepository struct {
+	db         DB
+	timeFormat string
+}
+
+func NewSpreadRepository(db DB, timeFormat string) repository.SpreadRepository {
+	return &spreadRepository{
+		db:         db,
+		timeFormat: timeFormat,
+	}
+}
+
+func (sr *spreadRepository) Save(spread model.Spread) error {
+	cmd := `
+        INSERT INTO spreads
+            (time, product_code, base_exchange, exchange, base_price, price)
+        VALUES
+            (?, ?, ?, ?, ?, ?)
+        ON DUPLICATE KEY UPDATE
+            base_exchange = VALUES(base_exchange),
+            base_price = VALUES(base_price),
+            price = VALUES(price)
+        `
+	_, err := sr.db.Exec(cmd,
+		spread.Time().Format(sr.timeFormat),
+		spread.ProductCode(),
+		spread.BaseExchange(),
+		spread.Exchange(),
+		spread.BasePrice(),
+		spread.Price(),
+	)
+	return err
+}
+
+func (sr *spreadRepository) FindAll(productCode string, limit int64) ([]model.Spread, error) {
+	cmd := `
+        SELECT
+            *
+        FROM (
+            SELECT
+                time, product_code, base_exchange, exchange, base_price, price
+            FROM
+                spreads
+            WHERE
+                product_code = ?
+            ORDER BY
+                time DESC, exchange DESC
+            LIMIT ?
+        ) AS spread
+        ORDER BY
+            time ASC, exchange ASC
+        `
+	rows, err := sr.db.Query(cmd, productCode, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	spreads := make([]model.Spread, 0)
+	for rows.Next() {
+		var timeTime time.Time
+		var productCode, baseExchange, exchange string
+		var basePrice, price float64
+		err := rows.Scan(&timeTime, &productCode, &baseExchange, &exchange, &basePrice, &price)
+		if err != nil {
+			return nil, err
+		}
+
+		spread := model.NewSpread(timeTime, productCode, baseExchange, exchange, basePrice, price)
+		if spread == nil {
+			return nil, errors.New(fmt.Sprint("invalid spread:", timeTime, productCode, baseExchange, exchange, basePrice, price))
+		}
+
+		spreads = append(spreads, *spread)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return spreads, nil
+}
@@ -0,0 +1,62 @@
+package persistence
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type strategyRuleRepository struct {
+	db DB
+}
+
+func NewStrategyRuleRepository(db DB) repository.StrategyRuleRepository {
+	return &strategyRuleRepository{
+		db: db,
+	}
+}
+
+func (sr *strategyRuleRepository) Save(rule model.StrategyRule) error {
+	cmd := `
+        INSERT INTO strategy_rules
+            (product_code, buy_rule, sell_rule)
+        VALUES
+            (?, ?, ?)
+        ON DUPLICATE KEY UPDATE
+            buy_rule = VALUES(buy_rule),
+            sell_rule = VALUES(sell_rule)
+        `
+	_, err := sr.db.Exec(cmd, rule.ProductCode(), rule.BuyRule().String(), rule.SellRule().String())
+	return err
+}
+
+func (sr *strategyRuleRepository) Find(productCode string) (*model.StrategyRule, error) {
+	cmd := `
+        SELECT
+            buy_rule, sell_rule
+        FROM
+            strategy_rules
+        WHERE
+            product_code = ?
+        `
+	row := sr.db.QueryRow(cmd, productCode)
+
+	var buyRule, sellRule string
+	err := row.Scan(&buyRule, &sellRule)
+	// 発見できなかったらそのままnilを返す
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rule := model.NewStrategyRule(productCode, buyRule, sellRule)
+	if rule == nil {
+		return nil, errors.New(fmt.Sprint("invalid strategy_rule:", productCode, buyRule, sellRule))
+	}
+	return rule, nil
+}
@@ -0,0 +1,80 @@
+package persistence
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type summaryReportRepository struct {
+	db         DB
+	timeFormat string
+}
+
+func NewSummaryReportRepository(db DB, timeFormat string) repository.SummaryReportRepository {
+	return &summaryReportRepository{
+		db:         db,
+		timeFormat: timeFormat,
+	}
+}
+
+// 売買パラメータは1行ずつ改行でつないで保存する
+func (sr *summaryReportRepository) Save(report model.SummaryReport) error {
+	cmd := `
+        INSERT INTO summary_reports
+            (time, product_code, period, params)
+        VALUES
+            (?, ?, ?, ?)
+        ON DUPLICATE KEY UPDATE
+            params = VALUES(params)
+        `
+	_, err := sr.db.Exec(cmd,
+		report.Time().Format(sr.timeFormat),
+		report.ProductCode(),
+		string(report.Period()),
+		strings.Join(report.ParamsLines(), "\n"),
+	)
+	return err
+}
+
+func (sr *summaryReportRepository) FindLatest(productCode string, period model.SummaryPeriod) (*model.SummaryReport, error) {
+	cmd := `
+        SELECT
+            time, params
+        FROM
+            summary_reports
+        WHERE
+            product_code = ? AND period = ?
+        ORDER BY
+            time DESC
+        LIMIT 1
+        `
+	row := sr.db.QueryRow(cmd, productCode, string(period))
+
+	var timeTime time.Time
+	var params string
+	err := row.Scan(&timeTime, &params)
+	// 発見できなかったらそのままnilを返す
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	paramsLines := make([]string, 0)
+	if params != "" {
+		paramsLines = strings.Split(params, "\n")
+	}
+
+	report := model.NewSummaryReport(timeTime, productCode, period, paramsLines)
+	if report == nil {
+		return nil, errors.New(fmt.Sprint("invalid summary_report:", timeTime, productCode, period))
+	}
+	return report, nil
+}
@@ -0,0 +1,55 @@
+package persistence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+)
+
+func TestSummaryReport(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	summaryReportRepository := persistence.NewSummaryReportRepository(tx, config.TimeFormat)
+
+	// 日時は2100年1月1日以降かつ昇順
+	reports := []model.SummaryReport{
+		*model.NewSummaryReport(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), config.ProductCode, model.SummaryPeriodDaily, []string{"EMA: 7, 14", "RSI: 14, 30.0, 70.0"}),
+		*model.NewSummaryReport(time.Date(2100, 1, 2, 0, 0, 0, 0, time.UTC), config.ProductCode, model.SummaryPeriodDaily, []string{"EMA: 7, 21", "RSI: 14, 30.0, 70.0"}),
+	}
+
+	t.Run("save summary report", func(t *testing.T) {
+		for _, report := range reports {
+			err := summaryReportRepository.Save(report)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+	})
+
+	t.Run("find latest summary report", func(t *testing.T) {
+		report, err := summaryReportRepository.FindLatest(config.ProductCode, model.SummaryPeriodDaily)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if report == nil || !report.Time().Equal(reports[1].Time()) {
+			t.Fatalf("report=%+v", report)
+		}
+		if lines := report.ParamsLines(); len(lines) != 2 || lines[0] != "EMA: 7, 21" {
+			t.Fatalf("lines=%v", lines)
+		}
+	})
+
+	t.Run("other period", func(t *testing.T) {
+		report, err := summaryReportRepository.FindLatest(config.ProductCode, model.SummaryPeriodWeekly)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if report != nil {
+			t.Fatalf("report=%+v", report)
+		}
+	})
+}
@@ -13,56 +13,124 @@ func newTradeParamsList() []model.TradeParams {
 	// trade_paramsをミリ秒単位で作成すると区別がつかずにテスト失敗する
 	// 作成するデータを1個だけにしてテスト
 	table := []struct {
-		tradeEnable      bool
-		productCode      string
-		size             float64
-		smaEnable        bool
-		smaPeriod1       int
-		smaPeriod2       int
-		smaPeriod3       int
-		emaEnable        bool
-		emaPeriod1       int
-		emaPeriod2       int
-		emaPeriod3       int
-		bbandsEnable     bool
-		bbandsN          int
-		bbandsK          float64
-		ichimokuEnable   bool
-		rsiEnable        bool
-		rsiPeriod        int
-		rsiBuyThread     float64
-		rsiSellThread    float64
-		macdEnable       bool
-		macdFastPeriod   int
-		macdSlowPeriod   int
-		macdSignalPeriod int
-		stopLimitPercent float64
+		tradeEnable           bool
+		productCode           string
+		size                  float64
+		smaEnable             bool
+		smaPeriod1            int
+		smaPeriod2            int
+		smaPeriod3            int
+		emaEnable             bool
+		emaPeriod1            int
+		emaPeriod2            int
+		emaPeriod3            int
+		bbandsEnable          bool
+		bbandsN               int
+		bbandsK               float64
+		ichimokuEnable        bool
+		ichimokuTenkanPeriod  int
+		ichimokuKijunPeriod   int
+		ichimokuSenkouBPeriod int
+		rsiEnable             bool
+		rsiPeriod             int
+		rsiBuyThread          float64
+		rsiSellThread         float64
+		macdEnable            bool
+		macdFastPeriod        int
+		macdSlowPeriod        int
+		macdSignalPeriod      int
+		atrEnable             bool
+		atrPeriod             int
+		atrMultiplier         float64
+		stochEnable           bool
+		stochFastKPeriod      int
+		stochSlowKPeriod      int
+		stochSlowDPeriod      int
+		stochBuyThread        float64
+		stochSellThread       float64
+		adxEnable             bool
+		adxPeriod             int
+		adxThread             float64
+		obvEnable             bool
+		obvPeriod             int
+		vwapEnable            bool
+		vwapPeriod            int
+		sarEnable             bool
+		sarAcceleration       float64
+		sarMaximum            float64
+		donchianEnable        bool
+		donchianPeriod        int
+		keltnerEnable         bool
+		keltnerPeriod         int
+		keltnerMultiplier     float64
+		heikinAshiEnable      bool
+		heikinAshiPeriod      int
+		signalWeights         model.SignalWeights
+		buyVoteThreshold      float64
+		sellVoteThreshold     float64
+		stopLimitPercent      float64
+		maxLots               int
+		lotMatching           model.LotMatching
 	}{
 		{
-			tradeEnable:      true,
-			productCode:      config.ProductCode,
-			size:             0.01,
-			smaEnable:        true,
-			smaPeriod1:       7,
-			smaPeriod2:       14,
-			smaPeriod3:       50,
-			emaEnable:        true,
-			emaPeriod1:       7,
-			emaPeriod2:       14,
-			emaPeriod3:       50,
-			bbandsEnable:     true,
-			bbandsN:          20,
-			bbandsK:          2.2,
-			ichimokuEnable:   true,
-			rsiEnable:        true,
-			rsiPeriod:        14,
-			rsiBuyThread:     30.5,
-			rsiSellThread:    70.5,
-			macdEnable:       true,
-			macdFastPeriod:   12,
-			macdSlowPeriod:   26,
-			macdSignalPeriod: 9,
-			stopLimitPercent: 0.75,
+			tradeEnable:           true,
+			productCode:           config.ProductCode,
+			size:                  0.01,
+			smaEnable:             true,
+			smaPeriod1:            7,
+			smaPeriod2:            14,
+			smaPeriod3:            50,
+			emaEnable:             true,
+			emaPeriod1:            7,
+			emaPeriod2:            14,
+			emaPeriod3:            50,
+			bbandsEnable:          true,
+			bbandsN:               20,
+			bbandsK:               2.2,
+			ichimokuEnable:        true,
+			ichimokuTenkanPeriod:  9,
+			ichimokuKijunPeriod:   26,
+			ichimokuSenkouBPeriod: 52,
+			rsiEnable:             true,
+			rsiPeriod:             14,
+			rsiBuyThread:          30.5,
+			rsiSellThread:         70.5,
+			macdEnable:            true,
+			macdFastPeriod:        12,
+			macdSlowPeriod:        26,
+			macdSignalPeriod:      9,
+			atrEnable:             true,
+			atrPeriod:             14,
+			atrMultiplier:         2.5,
+			stochEnable:           true,
+			stochFastKPeriod:      14,
+			stochSlowKPeriod:      3,
+			stochSlowDPeriod:      3,
+			stochBuyThread:        20.5,
+			stochSellThread:       80.5,
+			adxEnable:             true,
+			adxPeriod:             14,
+			adxThread:             25.5,
+			obvEnable:             true,
+			obvPeriod:             20,
+			vwapEnable:            true,
+			vwapPeriod:            20,
+			sarEnable:             true,
+			sarAcceleration:       0.02,
+			sarMaximum:            0.2,
+			donchianEnable:        true,
+			donchianPeriod:        20,
+			keltnerEnable:         true,
+			keltnerPeriod:         20,
+			keltnerMultiplier:     2.5,
+			heikinAshiEnable:      true,
+			heikinAshiPeriod:      3,
+			signalWeights:         *model.NewSignalWeights(1, 1.5, 1, 2, 2, 0.5, 1, 1, 1, 1, 1, 1, 1, 0),
+			buyVoteThreshold:      2.5,
+			sellVoteThreshold:     3.5,
+			stopLimitPercent:      0.75,
+			maxLots:               3,
+			lotMatching:           model.LotMatchingAverage,
 		},
 	}
 
@@ -84,6 +152,9 @@ func newTradeParamsList() []model.TradeParams {
 			t.bbandsN,
 			t.bbandsK,
 			t.ichimokuEnable,
+			t.ichimokuTenkanPeriod,
+			t.ichimokuKijunPeriod,
+			t.ichimokuSenkouBPeriod,
 			t.rsiEnable,
 			t.rsiPeriod,
 			t.rsiBuyThread,
@@ -92,7 +163,38 @@ func newTradeParamsList() []model.TradeParams {
 			t.macdFastPeriod,
 			t.macdSlowPeriod,
 			t.macdSignalPeriod,
+			t.atrEnable,
+			t.atrPeriod,
+			t.atrMultiplier,
+			t.stochEnable,
+			t.stochFastKPeriod,
+			t.stochSlowKPeriod,
+			t.stochSlowDPeriod,
+			t.stochBuyThread,
+			t.stochSellThread,
+			t.adxEnable,
+			t.adxPeriod,
+			t.adxThread,
+			t.obvEnable,
+			t.obvPeriod,
+			t.vwapEnable,
+			t.vwapPeriod,
+			t.sarEnable,
+			t.sarAcceleration,
+			t.sarMaximum,
+			t.donchianEnable,
+			t.donchianPeriod,
+			t.keltnerEnable,
+			t.keltnerPeriod,
+			t.keltnerMultiplier,
+			t.heikinAshiEnable,
+			t.heikinAshiPeriod,
+			t.signalWeights,
+			t.buyVoteThreshold,
+			t.sellVoteThreshold,
 			t.stopLimitPercent,
+			t.maxLots,
+			t.lotMatching,
 		)
 		if tradeParams == nil {
 			continue
@@ -131,4 +233,14 @@ func TestTradeParams(t *testing.T) {
 			t.Fatalf("%+v != %+v", *tradeParams, lastTradeParams)
 		}
 	})
+
+	t.Run("find not existing trade_params", func(t *testing.T) {
+		tradeParams, err := tradeParamsRepository.Find("NOT_EXISTING")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if tradeParams != nil {
+			t.Fatal("trade_params is found")
+		}
+	})
 }
@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"database/sql"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+type volumeCursorRepository struct {
+	db DB
+}
+
+func NewVolumeCursorRepository(db DB) repository.VolumeCursorRepository {
+	return &volumeCursorRepository{
+		db: db,
+	}
+}
+
+func (vr *volumeCursorRepository) Find(productCode string) (int64, error) {
+	cmd := `
+        SELECT
+            execution_id
+        FROM
+            volume_cursors
+        WHERE
+            product_code = ?
+        `
+	row := vr.db.QueryRow(cmd, productCode)
+
+	var executionID int64
+	err := row.Scan(&executionID)
+	// 発見できなかったら0を返す
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return executionID, nil
+}
+
+func (vr *volumeCursorRepository) Advance(productCode string, before, after int64) (bool, error) {
+	var result sql.Result
+	var err error
+	if before == 0 {
+		cmd := `
+            INSERT IGNORE INTO volume_cursors
+                (product_code, execution_id)
+            VALUES
+                (?, ?)
+            `
+		result, err = vr.db.Exec(cmd, productCode, after)
+	} else {
+		cmd := `
+            UPDATE
+                volume_cursors
+            SET
+                execution_id = ?
+            WHERE
+                product_code = ? AND execution_id = ?
+            `
+		result, err = vr.db.Exec(cmd, after, productCode, before)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
@@ -0,0 +1,56 @@
+package persistence_test
+
+import (
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+)
+
+func TestVolumeCursor(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	volumeCursorRepository := persistence.NewVolumeCursorRepository(tx)
+
+	// 他のテストと混ざらない銘柄
+	productCode := "VOLUME_TEST"
+
+	t.Run("not found", func(t *testing.T) {
+		executionID, err := volumeCursorRepository.Find(productCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if executionID != 0 {
+			t.Fatalf("executionID=%d", executionID)
+		}
+	})
+
+	t.Run("advance", func(t *testing.T) {
+		for _, c := range []struct {
+			before, after int64
+			ok            bool
+		}{
+			{0, 100, true},
+			{0, 200, false},
+			{100, 200, true},
+			{100, 300, false},
+		} {
+			ok, err := volumeCursorRepository.Advance(productCode, c.before, c.after)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if ok != c.ok {
+				t.Fatalf("Advance(%d, %d)=%t", c.before, c.after, ok)
+			}
+		}
+
+		executionID, err := volumeCursorRepository.Find(productCode)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if executionID != 200 {
+			t.Fatalf("executionID=%d", executionID)
+		}
+	})
+}
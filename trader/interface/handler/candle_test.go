@@ -9,6 +9,7 @@ import (
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/slack"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/interface/handler"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
@@ -20,10 +21,18 @@ func TestCandleHandler(t *testing.T) {
 
 	candleRepository := persistence.NewCandleRepository(tx, config.CandleTableName, config.TimeFormat)
 	tickerRepository := bitflyer.NewBitflyerTickerMockRepository()
+	executionRepository := bitflyer.NewBitflyerExecutionMockRepository(make([]bitflyer.Execution, 0))
+	volumeCursorRepository := persistence.NewVolumeCursorRepository(tx)
+	alertRuleRepository := persistence.NewAlertRuleRepository(tx, config.TimeFormat)
+	pricePointRepository := persistence.NewPricePointRepository(tx, config.TimeFormat)
+	notificationRepository := slack.NewSlackNotificationMockRepository(config.LocalTime)
 
 	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	volumeService := service.NewVolumeService(executionRepository, volumeCursorRepository)
+	alertService := service.NewAlertService(alertRuleRepository, pricePointRepository, candleService)
+	notificationService := service.NewNotificationService(notificationRepository)
 
-	candleUsecase := usecase.NewCandleUsecase(candleService, tickerRepository)
+	candleUsecase := usecase.NewCandleUsecase(candleService, volumeService, tickerRepository, alertService, notificationService, 365)
 
 	candleHandler := handler.NewCandleHandler(candleUsecase)
 
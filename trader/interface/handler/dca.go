@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+type DCAHandler interface {
+	Buy(productCode string) http.HandlerFunc
+}
+
+type dcaHandler struct {
+	dcaUsecase usecase.DCAUsecase
+}
+
+func NewDCAHandler(du usecase.DCAUsecase) DCAHandler {
+	return &dcaHandler{
+		dcaUsecase: du,
+	}
+}
+
+func (dh *dcaHandler) Buy(productCode string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := dh.dcaUsecase.Buy(productCode)
+
+		if err != nil {
+			fmt.Println(err)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "Failed to buy by dca")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "Success")
+	}
+}
@@ -0,0 +1,61 @@
+package handler_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/slack"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/interface/handler"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+func TestDCAHandler(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	balanceRepository := bitflyer.NewBitFlyerBalanceMockRepository()
+	tickerRepository := bitflyer.NewBitflyerTickerMockRepository()
+	orderRepository := bitflyer.NewBitflyerOrderMockRepository()
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	dcaParamsRepository := persistence.NewDCAParamsRepository(tx)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+	candleRepository := persistence.NewCandleMockRepository(config.CandleTableName, config.TimeFormat, config.ProductCode, config.CandleDuration)
+	notificationRepository := slack.NewSlackNotificationMockRepository(config.LocalTime)
+
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	dcaService := service.NewDCAService(balanceRepository, tickerRepository, orderRepository, signalEventRepository, dcaParamsRepository, tradeParamsRepository, candleService, config.LocalTime, config.TradeHour)
+	notificationService := service.NewNotificationService(notificationRepository)
+
+	dcaUsecase := usecase.NewDCAUsecase(dcaService, notificationService)
+
+	dcaHandler := handler.NewDCAHandler(dcaUsecase)
+
+	// 積立の設定を用意しておく
+	params := model.NewDCAParams(config.ProductCode, true, 6000, 7, 0, 30, 1, 0.01)
+	dcaParamsRepository.Save(*params)
+
+	t.Run("buy", func(t *testing.T) {
+		ts := httptest.NewServer(dcaHandler.Buy(config.ProductCode))
+		defer ts.Close()
+
+		rec := httptest.NewRecorder()
+
+		resp, err := http.Post(ts.URL, "text/plain", rec.Body)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		t.Log(string(respBody))
+	})
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+type FXHandler interface {
+	DeRisk(productCode string) http.HandlerFunc
+}
+
+type fxHandler struct {
+	fxUsecase usecase.FXUsecase
+}
+
+func NewFXHandler(fu usecase.FXUsecase) FXHandler {
+	return &fxHandler{
+		fxUsecase: fu,
+	}
+}
+
+func (fh *fxHandler) DeRisk(productCode string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := fh.fxUsecase.DeRisk(productCode)
+
+		if err != nil {
+			fmt.Println(err)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "Failed to de-risk")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "Success")
+	}
+}
@@ -0,0 +1,65 @@
+package handler_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/slack"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/interface/handler"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+func TestFXHandler(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	productCode := "FX_BTC_JPY"
+
+	orderRepository, fxRepository := bitflyer.NewBitflyerFXMockRepositories(5000000, 300000)
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	candleRepository := persistence.NewCandleMockRepository(config.CandleTableName, config.TimeFormat, productCode, config.CandleDuration)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+	notificationRepository := slack.NewSlackNotificationMockRepository(config.LocalTime)
+
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	indicatorService := service.NewIndicatorService()
+	dataFrameService := service.NewMRBaseDataFrameService(indicatorService)
+	tradeParamsService := service.NewTradeParamsService(tradeParamsRepository, dataFrameService)
+	notificationService := service.NewNotificationService(notificationRepository)
+	fxTradeService := service.NewFXTradeService(fxRepository, orderRepository, signalEventRepository, candleService, dataFrameService, tradeParamsService, notificationService, 1.5, 2)
+	signalEventService := service.NewSignalEventService(signalEventRepository)
+
+	fxUsecase := usecase.NewFXUsecase(signalEventService, fxTradeService, notificationService)
+
+	fxHandler := handler.NewFXHandler(fxUsecase)
+
+	// 証拠金維持率が1.2になるショートを建てておく
+	if _, err := orderRepository.Send(*model.NewSellOrder(productCode, 0.1)); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	t.Run("de-risk", func(t *testing.T) {
+		ts := httptest.NewServer(fxHandler.DeRisk(productCode))
+		defer ts.Close()
+
+		rec := httptest.NewRecorder()
+
+		resp, err := http.Post(ts.URL, "text/plain", rec.Body)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		t.Log(string(respBody))
+	})
+}
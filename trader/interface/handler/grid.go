@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+type GridHandler interface {
+	Sync(grid model.Grid) http.HandlerFunc
+}
+
+type gridHandler struct {
+	gridUsecase usecase.GridUsecase
+}
+
+func NewGridHandler(gu usecase.GridUsecase) GridHandler {
+	return &gridHandler{
+		gridUsecase: gu,
+	}
+}
+
+func (gh *gridHandler) Sync(grid model.Grid) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := gh.gridUsecase.Sync(grid)
+
+		if err != nil {
+			fmt.Println(err)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "Failed to sync grid")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "Success")
+	}
+}
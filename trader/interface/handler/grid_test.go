@@ -0,0 +1,58 @@
+package handler_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/slack"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/interface/handler"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+func TestGridHandler(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	tickerRepository := bitflyer.NewBitflyerTickerMockRepository()
+	orderRepository := bitflyer.NewBitflyerOrderMockRepository()
+	gridLevelRepository := persistence.NewGridLevelRepository(tx)
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+	notificationRepository := slack.NewSlackNotificationMockRepository(config.LocalTime)
+
+	gridService := service.NewGridService(tickerRepository, orderRepository, gridLevelRepository, signalEventRepository, tradeParamsRepository)
+	notificationService := service.NewNotificationService(notificationRepository)
+
+	gridUsecase := usecase.NewGridUsecase(gridService, notificationService)
+
+	gridHandler := handler.NewGridHandler(gridUsecase)
+
+	gridLevelRepository.DeleteAll(config.ProductCode)
+
+	grid := model.NewGrid(config.ProductCode, 500000, 600000, 5, 0.01)
+
+	t.Run("sync", func(t *testing.T) {
+		ts := httptest.NewServer(gridHandler.Sync(*grid))
+		defer ts.Close()
+
+		rec := httptest.NewRecorder()
+
+		resp, err := http.Post(ts.URL, "text/plain", rec.Body)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		t.Log(string(respBody))
+	})
+}
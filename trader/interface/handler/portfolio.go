@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+type PortfolioHandler interface {
+	SaveSnapshot(productCode string) http.HandlerFunc
+}
+
+type portfolioHandler struct {
+	portfolioUsecase usecase.PortfolioUsecase
+}
+
+func NewPortfolioHandler(pu usecase.PortfolioUsecase) PortfolioHandler {
+	return &portfolioHandler{
+		portfolioUsecase: pu,
+	}
+}
+
+func (ph *portfolioHandler) SaveSnapshot(productCode string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := ph.portfolioUsecase.SaveSnapshot(productCode)
+
+		if err != nil {
+			fmt.Println(err)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "Failed to save equity snapshot")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "Success")
+	}
+}
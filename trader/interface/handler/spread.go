@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+type SpreadHandler interface {
+	Monitor(productCode string) http.HandlerFunc
+}
+
+type spreadHandler struct {
+	spreadUsecase usecase.SpreadUsecase
+}
+
+func NewSpreadHandler(su usecase.SpreadUsecase) SpreadHandler {
+	return &spreadHandler{
+		spreadUsecase: su,
+	}
+}
+
+func (sh *spreadHandler) Monitor(productCode string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := sh.spreadUsecase.Monitor(productCode)
+
+		if err != nil {
+			fmt.Println(err)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "Failed to monitor spread")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "Success")
+	}
+}
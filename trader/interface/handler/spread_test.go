@@ -0,0 +1,74 @@
+package handler_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer/fake"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/slack"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/interface/handler"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+// 偽のbitFlyerを別の取引所として扱う
+type namedExchange struct {
+	repository.ExchangeRepository
+	name string
+}
+
+func (ne *namedExchange) Name() string {
+	return ne.name
+}
+
+func TestSpreadHandler(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	// 偽のbitFlyerで価格を決めた取引所
+	newExchange := func(price float64) repository.ExchangeRepository {
+		server := fake.NewServer(fake.Config{
+			Key:    "key",
+			Secret: "secret",
+			Prices: map[string][]float64{config.ProductCode: {price}},
+		})
+		ts := httptest.NewServer(server)
+		t.Cleanup(ts.Close)
+		return bitflyer.NewBitflyerExchange(bitflyer.NewClientWithBaseURL("key", "secret", ts.URL+"/v1/"))
+	}
+	baseExchange := newExchange(500000)
+	exchange := &namedExchange{ExchangeRepository: newExchange(550000), name: "other"}
+	spreadRepository := persistence.NewSpreadRepository(tx, config.TimeFormat)
+	notificationRepository := slack.NewSlackNotificationMockRepository(config.LocalTime)
+
+	spreadService := service.NewSpreadService(baseExchange, []repository.ExchangeRepository{exchange}, spreadRepository)
+	notificationService := service.NewNotificationService(notificationRepository)
+
+	spreadUsecase := usecase.NewSpreadUsecase(spreadService, notificationService, 0.01)
+
+	spreadHandler := handler.NewSpreadHandler(spreadUsecase)
+
+	t.Run("monitor", func(t *testing.T) {
+		ts := httptest.NewServer(spreadHandler.Monitor(config.ProductCode))
+		defer ts.Close()
+
+		rec := httptest.NewRecorder()
+
+		resp, err := http.Post(ts.URL, "text/plain", rec.Body)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		t.Log(string(respBody))
+	})
+}
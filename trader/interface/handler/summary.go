@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+type SummaryHandler interface {
+	Report(productCode string, period model.SummaryPeriod, pastPeriod int) http.HandlerFunc
+}
+
+type summaryHandler struct {
+	summaryUsecase usecase.SummaryUsecase
+}
+
+func NewSummaryHandler(su usecase.SummaryUsecase) SummaryHandler {
+	return &summaryHandler{
+		summaryUsecase: su,
+	}
+}
+
+func (sh *summaryHandler) Report(productCode string, period model.SummaryPeriod, pastPeriod int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := sh.summaryUsecase.Report(productCode, period, pastPeriod)
+
+		if err != nil {
+			fmt.Println(err)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "Failed to report summary")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "Success")
+	}
+}
@@ -0,0 +1,64 @@
+package handler_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/slack"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/interface/handler"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+func TestSummaryHandler(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	balanceRepository := bitflyer.NewBitFlyerBalanceMockRepository()
+	tickerRepository := bitflyer.NewBitflyerTickerMockRepository()
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	equitySnapshotRepository := persistence.NewEquitySnapshotRepository(tx, config.TimeFormat)
+	candleRepository := persistence.NewCandleRepository(tx, config.CandleTableName, config.TimeFormat)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+	summaryReportRepository := persistence.NewSummaryReportRepository(tx, config.TimeFormat)
+	notificationRepository := slack.NewSlackNotificationMockRepository(config.LocalTime)
+
+	portfolioService := service.NewPortfolioService(balanceRepository, tickerRepository, signalEventRepository, equitySnapshotRepository, config.CommissionRate)
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	indicatorService := service.NewIndicatorService()
+	tradeParamsService := service.NewTradeParamsService(tradeParamsRepository, service.NewDataFrameService(indicatorService))
+	summaryService := service.NewSummaryService(portfolioService, signalEventRepository, candleService, indicatorService, tradeParamsService, summaryReportRepository)
+	notificationService := service.NewNotificationService(notificationRepository)
+
+	summaryUsecase := usecase.NewSummaryUsecase(summaryService, notificationService)
+
+	summaryHandler := handler.NewSummaryHandler(summaryUsecase)
+
+	if err := tradeParamsService.Save(*model.NewBasicTradeParams(config.ProductCode, 0.01)); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	t.Run("report", func(t *testing.T) {
+		ts := httptest.NewServer(summaryHandler.Report(config.ProductCode, model.SummaryPeriodDaily, 365))
+		defer ts.Close()
+
+		rec := httptest.NewRecorder()
+
+		resp, err := http.Post(ts.URL, "text/plain", rec.Body)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("resp.StatusCode != http.StatusOK")
+		}
+
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		t.Log(string(respBody))
+	})
+}
@@ -33,8 +33,8 @@ func TestTradeHandler(t *testing.T) {
 	indicatorService := service.NewIndicatorService()
 	dataFrameService := service.NewMRBaseDataFrameService(indicatorService)
 	tradeParamsService := service.NewTradeParamsService(tradeParamsRepository, dataFrameService)
-	tradeService := service.NewTradeService(balanceRepository, tickerRepository, orderRepository, signalEventRepository, candleService, dataFrameService, tradeParamsService)
 	notificationService := service.NewNotificationService(notificationRepository)
+	tradeService := service.NewTradeService(balanceRepository, tickerRepository, orderRepository, signalEventRepository, candleService, dataFrameService, tradeParamsService, notificationService)
 
 	tradeUsecase := usecase.NewTradeUsecase(signalEventService, tradeService, notificationService)
 
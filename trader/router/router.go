@@ -6,9 +6,16 @@ import (
 	"os"
 
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/coincheck"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/discord"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/fanout"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/line"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/slack"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/webhook"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/interface/handler"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
@@ -16,37 +23,172 @@ import (
 
 func Run() {
 	// repository
-	candleRepository := persistence.NewCandleRepository(config.DB, config.CandleTableName, config.TimeFormat)
+	candleTableName := config.CandleTableName
+	if model.IsFXProduct(config.ProductCode) {
+		candleTableName = config.FXCandleTableName
+	}
+	candleRepository := persistence.NewCandleRepository(config.DB, candleTableName, config.TimeFormat)
 	signalEventRepository := persistence.NewSignalEventRepository(config.DB, config.TimeFormat)
 	tradeParamsRepository := persistence.NewTradeParamsRepository(config.DB)
-	// repository (bitflyer)
+	equitySnapshotRepository := persistence.NewEquitySnapshotRepository(config.DB, config.TimeFormat)
+	strategyRuleRepository := persistence.NewStrategyRuleRepository(config.DB)
+	gridLevelRepository := persistence.NewGridLevelRepository(config.DB)
+	dcaParamsRepository := persistence.NewDCAParamsRepository(config.DB)
+	spreadRepository := persistence.NewSpreadRepository(config.DB, config.TimeFormat)
+	summaryReportRepository := persistence.NewSummaryReportRepository(config.DB, config.TimeFormat)
+	alertRuleRepository := persistence.NewAlertRuleRepository(config.DB, config.TimeFormat)
+	pricePointRepository := persistence.NewPricePointRepository(config.DB, config.TimeFormat)
+	volumeCursorRepository := persistence.NewVolumeCursorRepository(config.DB)
+	// repository (exchange)
 	bitflyerClient := bitflyer.NewClient(config.APIKey, config.APISecret)
-	tickerRepository := bitflyer.NewBitflyerTickerRepository(bitflyerClient)
-	balanceRepository := bitflyer.NewBitFlyerBalanceRepository(bitflyerClient)
-	orderRepository := bitflyer.NewBitflyerOrderRepository(bitflyerClient)
+	if config.APIBaseURL != "" {
+		bitflyerClient = bitflyer.NewClientWithBaseURL(config.APIKey, config.APISecret, config.APIBaseURL)
+	}
+	newExchangeRepository := func(name string) (repository.ExchangeRepository, error) {
+		switch name {
+		case "bitflyer":
+			return bitflyer.NewBitflyerExchange(bitflyerClient), nil
+		case "coincheck":
+			return coincheck.NewCoincheckExchange(coincheck.NewClient(config.CoincheckAPIKey, config.CoincheckAPISecret)), nil
+		default:
+			return nil, fmt.Errorf("unknown exchange: %s", name)
+		}
+	}
+	// 取引する取引所を間違えたまま動かないように，知らない名前なら起動しない
+	exchangeRepository, err := newExchangeRepository(config.Exchange)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	tickerRepository := exchangeRepository.Ticker()
+	balanceRepository := exchangeRepository.Balance()
+	orderRepository := exchangeRepository.Order()
+	executionRepository := exchangeRepository.Execution()
+	// 証拠金取引はbitFlyerだけ
+	fxRepository := bitflyer.NewBitflyerFXRepository(bitflyerClient)
+	// 価格差を比べる取引所
+	spreadExchangeRepositories := make([]repository.ExchangeRepository, 0)
+	for _, name := range config.SpreadExchanges {
+		r, err := newExchangeRepository(name)
+		// 知らない取引所とは比べない
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		spreadExchangeRepositories = append(spreadExchangeRepositories, r)
+	}
 	// repository (slack)
-	slackClient := slack.NewClient(config.SlackBotToken, config.SlackChannelID)
-	notificationRepository := slack.NewSlackNotificationRepository(slackClient, config.LocalTime)
+	slackClient := slack.NewClientWithRouting(config.SlackBotToken, config.SlackChannelID, map[model.NotificationLevel]string{
+		model.NotificationLevelInfo:  config.SlackInfoChannelID,
+		model.NotificationLevelWarn:  config.SlackWarnChannelID,
+		model.NotificationLevelError: config.SlackErrorChannelID,
+	})
+	// repository (notification)
+	webhookClient := webhook.NewClient(config.NotificationTimeout, config.NotificationRetries)
+	notificationRepositories := make([]repository.NotificationRepository, 0)
+	for _, name := range config.Notifiers {
+		var r repository.NotificationRepository
+		switch name {
+		case "slack":
+			r = slack.NewSlackNotificationRepository(slackClient, config.LocalTime)
+		case "discord":
+			r = discord.NewDiscordNotificationRepository(webhookClient, config.DiscordWebhookURL)
+		case "line":
+			r = line.NewLineNotificationRepository(webhookClient, config.LineChannelAccessToken, config.LineTo, config.LocalTime)
+		case "webhook":
+			r = webhook.NewWebhookNotificationRepository(webhookClient, config.WebhookURL)
+		}
+		// 設定が足りない通知先は使わない
+		if r == nil {
+			fmt.Printf("notifier %s is not configured\n", name)
+			continue
+		}
+		notificationRepositories = append(notificationRepositories, r)
+	}
+	if len(notificationRepositories) == 0 {
+		notificationRepositories = append(notificationRepositories, slack.NewSlackNotificationRepository(slackClient, config.LocalTime))
+	}
+	notificationRepository := fanout.NewFanoutNotificationRepository(notificationRepositories...)
 
 	// service
 	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	volumeService := service.NewVolumeService(executionRepository, volumeCursorRepository)
 	signalEventService := service.NewSignalEventService(signalEventRepository)
 	indicatorService := service.NewIndicatorService()
-	dataFrameService := service.NewMRBaseDataFrameService(indicatorService)
+	var dataFrameService service.DataFrameService
+	switch config.Strategy {
+	case "default":
+		dataFrameService = service.NewDataFrameService(indicatorService)
+	case "weighted":
+		dataFrameService = service.NewWeightedDataFrameService(indicatorService)
+	case "rule":
+		dataFrameService = service.NewRuleDataFrameService(indicatorService, strategyRuleRepository)
+	default:
+		dataFrameService = service.NewMRBaseDataFrameService(indicatorService)
+	}
+	// 日足から作れない時間足では毎回の取引が失敗するので，起動しない
+	if config.TrendDuration < 0 || config.TrendDuration%config.CandleDuration != 0 {
+		fmt.Printf("TREND_DURATION must be a multiple of %s: %s\n", config.CandleDuration, config.TrendDuration)
+		os.Exit(1)
+	}
+	if config.TrendDuration > 0 {
+		dataFrameService = service.NewTrendFilterDataFrameService(dataFrameService, config.TrendDuration, config.TrendEMAPeriod)
+	}
 	tradeParamsService := service.NewTradeParamsService(tradeParamsRepository, dataFrameService)
-	tradeService := service.NewTradeService(balanceRepository, tickerRepository, orderRepository, signalEventRepository, candleService, dataFrameService, tradeParamsService)
-	notificationService := service.NewNotificationService(notificationRepository)
+	notificationService := service.NewNotificationServiceWithDedup(notificationRepository, config.NotificationDedupInterval)
+	// 証拠金取引の銘柄では，ショートも建てる
+	var tradeService service.TradeService
+	var fxTradeService service.FXTradeService
+	if model.IsFXProduct(config.ProductCode) {
+		fxTradeService = service.NewFXTradeService(fxRepository, orderRepository, signalEventRepository, candleService, dataFrameService, tradeParamsService, notificationService, config.FXMinKeepRate, config.FXTargetKeepRate)
+		tradeService = fxTradeService
+	} else {
+		tradeService = service.NewTradeService(balanceRepository, tickerRepository, orderRepository, signalEventRepository, candleService, dataFrameService, tradeParamsService, notificationService)
+	}
+	portfolioService := service.NewPortfolioService(balanceRepository, tickerRepository, signalEventRepository, equitySnapshotRepository, config.CommissionRate)
+	gridService := service.NewGridService(tickerRepository, orderRepository, gridLevelRepository, signalEventRepository, tradeParamsRepository)
+	dcaService := service.NewDCAService(balanceRepository, tickerRepository, orderRepository, signalEventRepository, dcaParamsRepository, tradeParamsRepository, candleService, config.LocalTime, config.TradeHour)
+	spreadService := service.NewSpreadService(exchangeRepository, spreadExchangeRepositories, spreadRepository)
+	summaryService := service.NewSummaryService(portfolioService, signalEventRepository, candleService, indicatorService, tradeParamsService, summaryReportRepository)
+	alertService := service.NewAlertService(alertRuleRepository, pricePointRepository, candleService)
 
 	// usecase
-	candleUsecase := usecase.NewCandleUsecase(candleService, tickerRepository)
+	candleUsecase := usecase.NewCandleUsecase(candleService, volumeService, tickerRepository, alertService, notificationService, 365)
 	tradeUsecase := usecase.NewTradeUsecase(signalEventService, tradeService, notificationService)
+	portfolioUsecase := usecase.NewPortfolioUsecase(portfolioService)
+	gridUsecase := usecase.NewGridUsecase(gridService, notificationService)
+	dcaUsecase := usecase.NewDCAUsecase(dcaService, notificationService)
+	spreadUsecase := usecase.NewSpreadUsecase(spreadService, notificationService, config.SpreadThreshold)
+	summaryUsecase := usecase.NewSummaryUsecase(summaryService, notificationService)
 
 	// handler
 	candleHandler := handler.NewCandleHandler(candleUsecase)
 	tradeHandler := handler.NewTradeHandler(tradeUsecase)
+	portfolioHandler := handler.NewPortfolioHandler(portfolioUsecase)
+	gridHandler := handler.NewGridHandler(gridUsecase)
+	dcaHandler := handler.NewDCAHandler(dcaUsecase)
+	spreadHandler := handler.NewSpreadHandler(spreadUsecase)
+	summaryHandler := handler.NewSummaryHandler(summaryUsecase)
 
 	http.HandleFunc("/fetch-ticker", candleHandler.UpdateCandle(config.ProductCode))
 	http.HandleFunc("/trade", tradeHandler.Trade(config.ProductCode, 365))
+	http.HandleFunc("/snapshot-equity", portfolioHandler.SaveSnapshot(config.ProductCode))
+	http.HandleFunc("/dca", dcaHandler.Buy(config.ProductCode))
+	http.HandleFunc("/summary/daily", summaryHandler.Report(config.ProductCode, model.SummaryPeriodDaily, 365))
+	http.HandleFunc("/summary/weekly", summaryHandler.Report(config.ProductCode, model.SummaryPeriodWeekly, 365))
+	if fxTradeService != nil {
+		fxUsecase := usecase.NewFXUsecase(signalEventService, fxTradeService, notificationService)
+		fxHandler := handler.NewFXHandler(fxUsecase)
+		http.HandleFunc("/fx-derisk", fxHandler.DeRisk(config.ProductCode))
+	}
+	// グリッド取引は設定されているときだけ行う
+	if grid := model.NewGrid(config.ProductCode, config.GridLowerPrice, config.GridUpperPrice, config.GridLevels, config.GridSize); grid != nil {
+		http.HandleFunc("/grid", gridHandler.Sync(*grid))
+	}
+	// 取引所間の価格差は比べる取引所が設定されているときだけ記録する
+	if len(spreadExchangeRepositories) > 0 {
+		http.HandleFunc("/spread", spreadHandler.Monitor(config.ProductCode))
+	}
 
 	// Determine port for HTTP service.
 	port := os.Getenv("PORT")
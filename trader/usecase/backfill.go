@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
+)
+
+// getexecutionsで一度に取得する件数の上限
+const executionPageSize = 500
+
+type BackfillUsecase interface {
+	Backfill(productCode string, start, end time.Time) (int, time.Time, error)
+	FindGaps(productCode string, start, end time.Time) ([]model.CandleTime, error)
+}
+
+type backfillUsecase struct {
+	executionRepository repository.ExecutionRepository
+	candleRepository    repository.CandleRepository
+	duration            time.Duration
+	origin              time.Time
+	requestInterval     time.Duration
+}
+
+// originはキャンドルの区切りの基準時刻（日足なら取引時刻）
+// requestIntervalはAPIのレート制限を避けるためのリクエスト間隔
+func NewBackfillUsecase(er repository.ExecutionRepository, cr repository.CandleRepository, duration time.Duration, origin time.Time, requestInterval time.Duration) BackfillUsecase {
+	return &backfillUsecase{
+		executionRepository: er,
+		candleRepository:    cr,
+		duration:            duration,
+		origin:              origin,
+		requestInterval:     requestInterval,
+	}
+}
+
+// [start, end)の約定履歴からキャンドルを作り直して保存する
+// startはキャンドルの時刻に切り捨てる
+// bitFlyerから取得できる約定履歴は直近31日分のみなので，それより古い期間は埋まらない
+// 保存したキャンドルの数と，実際に埋めた期間の始まりを返す
+func (bu *backfillUsecase) Backfill(productCode string, start, end time.Time) (int, time.Time, error) {
+	if bu.duration <= 0 {
+		return 0, time.Time{}, errors.New("invalid duration")
+	}
+	start = model.TruncateCandleTime(start, bu.duration, bu.origin).Time()
+	if !start.Before(end) {
+		return 0, time.Time{}, errors.New("invalid time range")
+	}
+
+	// 新しい方から古い方へページングする
+	executions := make([]model.Execution, 0)
+	var before int64
+	// 取得できた中で最も古い約定の時刻
+	var oldest time.Time
+	for {
+		page, err := bu.executionRepository.FetchAll(productCode, executionPageSize, before, 0)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		for _, execution := range page {
+			if before == 0 || execution.ID() < before {
+				before = execution.ID()
+			}
+			execDate := execution.ExecDate()
+			if oldest.IsZero() || execDate.Before(oldest) {
+				oldest = execDate
+			}
+			if execDate.Before(start) || !execDate.Before(end) {
+				continue
+			}
+			executions = append(executions, execution)
+		}
+
+		// 期間の始まりより古い約定まで到達したら終わり
+		if page[len(page)-1].ExecDate().Before(start) {
+			break
+		}
+		time.Sleep(bu.requestInterval)
+	}
+
+	if oldest.IsZero() {
+		return 0, end, nil
+	}
+
+	// startより前まで約定履歴を遡れなかった場合，最も古いキャンドルは期間の途中からの約定しか含まない
+	// 保存済みの完全なキャンドルを上書きしないように捨てる
+	covered := start
+	candles := model.NewCandlesFromExecutions(productCode, bu.duration, bu.origin, executions)
+	if !oldest.Before(start) {
+		covered = model.TruncateCandleTime(oldest, bu.duration, bu.origin).Time().Add(bu.duration)
+		for len(candles) > 0 && candles[0].Time().Time().Before(covered) {
+			candles = candles[1:]
+		}
+		if covered.After(end) {
+			covered = end
+		}
+	}
+
+	for _, candle := range candles {
+		if err := bu.candleRepository.Save(candle); err != nil {
+			return 0, time.Time{}, err
+		}
+	}
+
+	return len(candles), covered, nil
+}
+
+// [start, end]の範囲で保存されていないキャンドルの時刻を返す
+func (bu *backfillUsecase) FindGaps(productCode string, start, end time.Time) ([]model.CandleTime, error) {
+	if bu.duration <= 0 {
+		return nil, errors.New("invalid duration")
+	}
+	startTime := model.TruncateCandleTime(start, bu.duration, bu.origin)
+	endTime := model.TruncateCandleTime(end, bu.duration, bu.origin)
+	if endTime.Time().Before(startTime.Time()) {
+		return nil, errors.New("invalid time range")
+	}
+
+	// FindAllは最新から数えるので，startまで届く件数を取得する
+	limit := int64(time.Since(startTime.Time())/bu.duration) + 1
+	candles, err := bu.candleRepository.FindAll(productCode, bu.duration, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.MissingCandleTimes(candles, bu.duration, startTime, endTime), nil
+}
@@ -0,0 +1,105 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+func TestBackfillUsecase(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	origin := time.Date(2021, 1, 1, config.TradeHour, 0, 0, 0, config.LocalTime)
+	// 3日前の1時間前から1時間ごとに約定があったことにする（2日前の分は抜けている）
+	start := time.Now().Add(-3 * 24 * time.Hour).Truncate(time.Hour)
+	executions := make([]bitflyer.Execution, 0)
+	for i := -1; i < 3*24; i++ {
+		if 24 <= i && i < 48 {
+			continue
+		}
+		executions = append(executions, bitflyer.Execution{
+			ID:       int64(i + 2),
+			Side:     "BUY",
+			Price:    float64(500000 + i),
+			Size:     0.01,
+			ExecDate: start.Add(time.Duration(i) * time.Hour).UTC().Format(bitflyer.ExecDateFormat),
+		})
+	}
+
+	executionRepository := bitflyer.NewBitflyerExecutionMockRepository(executions)
+	candleRepository := persistence.NewCandleRepository(tx, config.CandleTableName, config.TimeFormat)
+	backfillUsecase := usecase.NewBackfillUsecase(executionRepository, candleRepository, time.Hour, origin, 0)
+
+	t.Run("backfill", func(t *testing.T) {
+		n, covered, err := backfillUsecase.Backfill(config.ProductCode, start, time.Now())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if n != 2*24 {
+			t.Fatalf("%d != %d", n, 2*24)
+		}
+		if !covered.Equal(start) {
+			t.Fatalf("%v != %v", covered, start)
+		}
+	})
+
+	t.Run("history ends inside a candle", func(t *testing.T) {
+		// 日足の途中から約定履歴が残っている
+		day := model.TruncateCandleTime(time.Now().Add(-5*24*time.Hour), 24*time.Hour, origin).Time()
+		executions := make([]bitflyer.Execution, 0)
+		for i := 12; i < 3*24+12; i++ {
+			executions = append(executions, bitflyer.Execution{
+				ID:       int64(i + 1),
+				Side:     "SELL",
+				Price:    float64(400000 + i),
+				Size:     0.01,
+				ExecDate: day.Add(time.Duration(i) * time.Hour).UTC().Format(bitflyer.ExecDateFormat),
+			})
+		}
+		// 保存済みの完全なキャンドル
+		saved := model.NewCandle(config.ProductCode, 24*time.Hour, model.NewCandleTime(day), 390000, 391000, 392000, 389000, 100)
+		if err := candleRepository.Save(*saved); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		executionRepository := bitflyer.NewBitflyerExecutionMockRepository(executions)
+		backfillUsecase := usecase.NewBackfillUsecase(executionRepository, candleRepository, 24*time.Hour, origin, 0)
+		n, covered, err := backfillUsecase.Backfill(config.ProductCode, day.Add(-24*time.Hour), day.Add(3*24*time.Hour+12*time.Hour))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if n != 3 {
+			t.Fatalf("%d != %d", n, 3)
+		}
+		if !covered.Equal(day.Add(24 * time.Hour)) {
+			t.Fatalf("%v != %v", covered, day.Add(24*time.Hour))
+		}
+
+		candle, err := candleRepository.FindByCandleTime(config.ProductCode, 24*time.Hour, model.NewCandleTime(day))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if candle == nil || candle.Volume() != 100 {
+			t.Fatalf("saved candle is overwritten: %+v", candle)
+		}
+	})
+
+	t.Run("find gaps", func(t *testing.T) {
+		gaps, err := backfillUsecase.FindGaps(config.ProductCode, start, start.Add(71*time.Hour))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(gaps) != 24 {
+			t.Fatalf("%d != %d", len(gaps), 24)
+		}
+		if !gaps[0].Time().Equal(start.Add(24 * time.Hour)) {
+			t.Fatalf("%v != %v", gaps[0].Time(), start.Add(24*time.Hour))
+		}
+	})
+}
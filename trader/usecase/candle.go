@@ -2,7 +2,10 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/repository"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
 )
@@ -13,13 +16,23 @@ type CandleUsecase interface {
 
 type candleUsecase struct {
 	candleService    service.CandleService
+	volumeService    service.VolumeService
 	tickerRepository repository.TickerRepository
+	// candleを更新するたびにアラートを評価する
+	alertService        service.AlertService
+	notificationService service.NotificationService
+	pastPeriod          int
 }
 
-func NewCandleUsecase(cs service.CandleService, tr repository.TickerRepository) CandleUsecase {
+// 指標のアラートは，過去pastPeriod本の足から計算する
+func NewCandleUsecase(cs service.CandleService, vs service.VolumeService, tr repository.TickerRepository, as service.AlertService, ns service.NotificationService, pastPeriod int) CandleUsecase {
 	return &candleUsecase{
-		candleService:    cs,
-		tickerRepository: tr,
+		candleService:       cs,
+		volumeService:       vs,
+		tickerRepository:    tr,
+		alertService:        as,
+		notificationService: ns,
+		pastPeriod:          pastPeriod,
 	}
 }
 
@@ -36,6 +49,18 @@ func (cu *candleUsecase) UpdateCandle(productCode string) error {
 		return errors.New("Failed to convert ticker into candle")
 	}
 
+	// 前回の更新以降の出来高
+	// 約定履歴が取れなくても価格は更新する（出来高は前回のまま）
+	volume, err := cu.volumeService.VolumeSince(productCode, candle.Time().Time())
+	if err != nil {
+		fmt.Println(err.Error())
+		volume = 0
+	}
+	candle = model.NewCandle(candle.ProductCode(), candle.Duration(), candle.Time(), candle.Open(), candle.Close(), candle.High(), candle.Low(), volume)
+	if candle == nil {
+		return errors.New("Failed to convert ticker into candle")
+	}
+
 	// 最新のcandle
 	currentCandle, err := cu.candleService.FindByTime(productCode, candle.Time().Time())
 	if err != nil {
@@ -47,5 +72,25 @@ func (cu *candleUsecase) UpdateCandle(productCode string) error {
 	if newCandle == nil {
 		return errors.New("Failed to update candle")
 	}
-	return cu.candleService.Save(*newCandle)
+	if err := cu.candleService.Save(*newCandle); err != nil {
+		return err
+	}
+
+	// アラートの失敗でcandleの更新を失敗にはしない
+	cu.evaluateAlerts(productCode, newCandle.Close())
+	return nil
+}
+
+func (cu *candleUsecase) evaluateAlerts(productCode string, price float64) {
+	alerts, err := cu.alertService.Evaluate(productCode, price, time.Now().UTC(), cu.pastPeriod)
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+
+	// 一部のルールで失敗しても，評価できたアラートは通知する
+	for _, alert := range alerts {
+		if err := cu.notificationService.NotifyOfAlert(alert); err != nil {
+			fmt.Println(err.Error())
+		}
+	}
 }
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+)
+
+type DCAUsecase interface {
+	Buy(productCode string) error
+}
+
+type dcaUsecase struct {
+	dcaService          service.DCAService
+	notificationService service.NotificationService
+}
+
+func NewDCAUsecase(ds service.DCAService, ns service.NotificationService) DCAUsecase {
+	return &dcaUsecase{
+		dcaService:          ds,
+		notificationService: ns,
+	}
+}
+
+func (du *dcaUsecase) Buy(productCode string) error {
+	event, err := du.dcaService.Buy(productCode, time.Now().UTC())
+	// 積立が無効なら何もしない
+	if errors.Is(err, model.ErrTradeDisabled) {
+		return nil
+	}
+	if err != nil {
+		if err := du.notificationService.NotifyOfTradingFailed(productCode, err); err != nil {
+			fmt.Println(err.Error())
+		}
+		// 積立を見送っただけなら失敗にしない
+		if _, skipped := model.TradeSkipReason(err); skipped {
+			fmt.Println(err.Error())
+			return nil
+		}
+		return err
+	}
+	// 積立の日ではない
+	if event == nil {
+		return nil
+	}
+
+	// 通知
+	if err := du.notificationService.NotifyOfTradingSuccess(*event); err != nil {
+		fmt.Println(err.Error())
+	}
+
+	return nil
+}
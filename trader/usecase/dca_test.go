@@ -0,0 +1,55 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/slack"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+func TestDCAUsecase(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	balanceRepository := bitflyer.NewBitFlyerBalanceMockRepository()
+	tickerRepository := bitflyer.NewBitflyerTickerMockRepository()
+	orderRepository := bitflyer.NewBitflyerOrderMockRepository()
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	dcaParamsRepository := persistence.NewDCAParamsRepository(tx)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+	candleRepository := persistence.NewCandleMockRepository(config.CandleTableName, config.TimeFormat, config.ProductCode, config.CandleDuration)
+	notificationRepository := slack.NewSlackNotificationMockRepository(config.LocalTime)
+
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	dcaService := service.NewDCAService(balanceRepository, tickerRepository, orderRepository, signalEventRepository, dcaParamsRepository, tradeParamsRepository, candleService, config.LocalTime, config.TradeHour)
+	notificationService := service.NewNotificationService(notificationRepository)
+
+	dcaUsecase := usecase.NewDCAUsecase(dcaService, notificationService)
+
+	// 積立の設定を用意しておく
+	params := model.NewDCAParams(config.ProductCode, true, 6000, 7, 0, 30, 1, 0.01)
+	dcaParamsRepository.Save(*params)
+
+	t.Run("buy", func(t *testing.T) {
+		// 2回目は積立の日ではないので何もしない
+		for i := 0; i < 2; i++ {
+			if err := dcaUsecase.Buy(config.ProductCode); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		disabled := model.NewDCAParams(config.ProductCode, false, 6000, 7, 0, 30, 1, 0.01)
+		dcaParamsRepository.Save(*disabled)
+
+		if err := dcaUsecase.Buy(config.ProductCode); err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+}
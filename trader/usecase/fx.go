@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+)
+
+type FXUsecase interface {
+	DeRisk(productCode string) error
+}
+
+type fxUsecase struct {
+	signalEventService  service.SignalEventService
+	fxTradeService      service.FXTradeService
+	notificationService service.NotificationService
+}
+
+func NewFXUsecase(ss service.SignalEventService, fs service.FXTradeService, ns service.NotificationService) FXUsecase {
+	return &fxUsecase{
+		signalEventService:  ss,
+		fxTradeService:      fs,
+		notificationService: ns,
+	}
+}
+
+func (fu *fxUsecase) DeRisk(productCode string) error {
+	// 通知発生基準にする
+	beforeTime := time.Now().UTC()
+
+	err := fu.fxTradeService.DeRisk(productCode)
+	if err != nil {
+		if err := fu.notificationService.NotifyOfTradingFailed(productCode, err); err != nil {
+			fmt.Println(err.Error())
+		}
+		return err
+	}
+
+	// 今回決済した建玉を取得
+	events, err := fu.signalEventService.FindAllAfterTime(productCode, beforeTime)
+	if err != nil {
+		return err
+	}
+
+	// 通知
+	for _, event := range events {
+		err := fu.notificationService.NotifyOfTradingSuccess(event)
+		if err != nil {
+			fmt.Println(err.Error())
+		}
+	}
+
+	return nil
+}
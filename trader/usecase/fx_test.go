@@ -0,0 +1,55 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/slack"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+func TestFXUsecase(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	productCode := "FX_BTC_JPY"
+
+	orderRepository, fxRepository := bitflyer.NewBitflyerFXMockRepositories(5000000, 300000)
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	candleRepository := persistence.NewCandleMockRepository(config.CandleTableName, config.TimeFormat, productCode, config.CandleDuration)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+	notificationRepository := slack.NewSlackNotificationMockRepository(config.LocalTime)
+
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	indicatorService := service.NewIndicatorService()
+	dataFrameService := service.NewMRBaseDataFrameService(indicatorService)
+	tradeParamsService := service.NewTradeParamsService(tradeParamsRepository, dataFrameService)
+	notificationService := service.NewNotificationService(notificationRepository)
+	fxTradeService := service.NewFXTradeService(fxRepository, orderRepository, signalEventRepository, candleService, dataFrameService, tradeParamsService, notificationService, 1.5, 2)
+	signalEventService := service.NewSignalEventService(signalEventRepository)
+
+	fxUsecase := usecase.NewFXUsecase(signalEventService, fxTradeService, notificationService)
+
+	// 証拠金維持率が1.2になるショートを建てておく
+	if _, err := orderRepository.Send(*model.NewSellOrder(productCode, 0.1)); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	t.Run("de-risk", func(t *testing.T) {
+		if err := fxUsecase.DeRisk(productCode); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		collateral, err := fxRepository.FetchCollateral()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if collateral.ShouldDeRisk(1.5) {
+			t.Fatalf("keepRate=%f", collateral.KeepRate())
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+)
+
+type GridUsecase interface {
+	Sync(grid model.Grid) error
+}
+
+type gridUsecase struct {
+	gridService         service.GridService
+	notificationService service.NotificationService
+}
+
+func NewGridUsecase(gs service.GridService, ns service.NotificationService) GridUsecase {
+	return &gridUsecase{
+		gridService:         gs,
+		notificationService: ns,
+	}
+}
+
+func (gu *gridUsecase) Sync(grid model.Grid) error {
+	fills, err := gu.gridService.Sync(grid)
+	// 取引を止めているなら何もしない
+	if errors.Is(err, model.ErrTradeDisabled) {
+		return nil
+	}
+
+	// 途中で失敗しても，それまでの約定は通知する
+	for _, fill := range fills {
+		event := fill.SignalEvent(grid.ProductCode())
+		if event == nil {
+			continue
+		}
+		if err := gu.notificationService.NotifyOfTradingSuccess(*event); err != nil {
+			fmt.Println(err.Error())
+		}
+	}
+
+	if err != nil {
+		if err := gu.notificationService.NotifyOfTradingFailed(grid.ProductCode(), err); err != nil {
+			fmt.Println(err.Error())
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,53 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/slack"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+func TestGridUsecase(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	tickerRepository := bitflyer.NewBitflyerTickerMockRepository()
+	orderRepository := bitflyer.NewBitflyerOrderMockRepository()
+	gridLevelRepository := persistence.NewGridLevelRepository(tx)
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+	notificationRepository := slack.NewSlackNotificationMockRepository(config.LocalTime)
+
+	gridService := service.NewGridService(tickerRepository, orderRepository, gridLevelRepository, signalEventRepository, tradeParamsRepository)
+	notificationService := service.NewNotificationService(notificationRepository)
+
+	gridUsecase := usecase.NewGridUsecase(gridService, notificationService)
+
+	gridLevelRepository.DeleteAll(config.ProductCode)
+
+	grid := model.NewGrid(config.ProductCode, 500000, 600000, 5, 0.01)
+
+	t.Run("sync", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if err := gridUsecase.Sync(*grid); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+	})
+
+	// 取引を止めているときは失敗にしない
+	t.Run("trade paused", func(t *testing.T) {
+		params := model.NewBasicTradeParams(config.ProductCode, 0.01).WithTradeEnable(false)
+		if err := tradeParamsRepository.Save(*params); err != nil {
+			t.Fatal(err.Error())
+		}
+		if err := gridUsecase.Sync(*grid); err != nil {
+			t.Fatal(err.Error())
+		}
+	})
+}
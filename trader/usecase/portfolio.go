@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+)
+
+type PortfolioUsecase interface {
+	SaveSnapshot(productCode string) error
+}
+
+type portfolioUsecase struct {
+	portfolioService service.PortfolioService
+}
+
+func NewPortfolioUsecase(ps service.PortfolioService) PortfolioUsecase {
+	return &portfolioUsecase{
+		portfolioService: ps,
+	}
+}
+
+func (pu *portfolioUsecase) SaveSnapshot(productCode string) error {
+	// 日次スナップショットなので日付で丸める
+	snapshotTime := time.Now().UTC().Truncate(24 * time.Hour)
+	return pu.portfolioService.SaveSnapshot(productCode, snapshotTime)
+}
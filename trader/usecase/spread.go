@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+)
+
+type SpreadUsecase interface {
+	Monitor(productCode string) error
+}
+
+type spreadUsecase struct {
+	spreadService       service.SpreadService
+	notificationService service.NotificationService
+	threshold           float64
+}
+
+// 価格差の割合の絶対値がthreshold以上なら通知する
+func NewSpreadUsecase(ss service.SpreadService, ns service.NotificationService, threshold float64) SpreadUsecase {
+	return &spreadUsecase{
+		spreadService:       ss,
+		notificationService: ns,
+		threshold:           threshold,
+	}
+}
+
+func (su *spreadUsecase) Monitor(productCode string) error {
+	// 取引所ごとのティッカーの時刻は揃わないので，分で丸めて同じ時刻として記録する
+	spreads, err := su.spreadService.Collect(productCode, time.Now().UTC().Truncate(time.Minute))
+
+	// 一部の取引所で失敗しても，取得できた価格差は通知する
+	for _, spread := range spreads {
+		if !spread.Exceeds(su.threshold) {
+			continue
+		}
+		if err := su.notificationService.NotifyOfSpread(spread); err != nil {
+			fmt.Println(err.Error())
+		}
+	}
+
+	return err
+}
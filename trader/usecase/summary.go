@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+)
+
+type SummaryUsecase interface {
+	Report(productCode string, period model.SummaryPeriod, pastPeriod int) error
+}
+
+type summaryUsecase struct {
+	summaryService      service.SummaryService
+	notificationService service.NotificationService
+}
+
+func NewSummaryUsecase(ss service.SummaryService, ns service.NotificationService) SummaryUsecase {
+	return &summaryUsecase{
+		summaryService:      ss,
+		notificationService: ns,
+	}
+}
+
+func (su *summaryUsecase) Report(productCode string, period model.SummaryPeriod, pastPeriod int) error {
+	summary, err := su.summaryService.Make(productCode, period, time.Now().UTC().Truncate(time.Minute), pastPeriod)
+	if err != nil {
+		return err
+	}
+	if summary == nil {
+		return errors.New("can't make a summary")
+	}
+
+	if err := su.notificationService.NotifyOfSummary(*summary); err != nil {
+		return err
+	}
+
+	// 送れたときだけ記録するので，失敗したら次のまとめに今回の分も含まれる
+	return su.summaryService.Save(*summary)
+}
@@ -0,0 +1,55 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/config"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/bitflyer"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/external/slack"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/infrastructure/persistence"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/usecase"
+)
+
+func TestSummaryUsecase(t *testing.T) {
+	tx := persistence.NewMySQLTransaction(config.DSN())
+	defer tx.Rollback()
+
+	balanceRepository := bitflyer.NewBitFlyerBalanceMockRepository()
+	tickerRepository := bitflyer.NewBitflyerTickerMockRepository()
+	signalEventRepository := persistence.NewSignalEventRepository(tx, config.TimeFormat)
+	equitySnapshotRepository := persistence.NewEquitySnapshotRepository(tx, config.TimeFormat)
+	candleRepository := persistence.NewCandleRepository(tx, config.CandleTableName, config.TimeFormat)
+	tradeParamsRepository := persistence.NewTradeParamsRepository(tx)
+	summaryReportRepository := persistence.NewSummaryReportRepository(tx, config.TimeFormat)
+	notificationRepository := slack.NewSlackNotificationMockRepository(config.LocalTime)
+
+	portfolioService := service.NewPortfolioService(balanceRepository, tickerRepository, signalEventRepository, equitySnapshotRepository, config.CommissionRate)
+	candleService := service.NewCandleServicePerDay(config.LocalTime, config.TradeHour, candleRepository)
+	indicatorService := service.NewIndicatorService()
+	tradeParamsService := service.NewTradeParamsService(tradeParamsRepository, service.NewDataFrameService(indicatorService))
+	summaryService := service.NewSummaryService(portfolioService, signalEventRepository, candleService, indicatorService, tradeParamsService, summaryReportRepository)
+	notificationService := service.NewNotificationService(notificationRepository)
+
+	summaryUsecase := usecase.NewSummaryUsecase(summaryService, notificationService)
+
+	if err := tradeParamsService.Save(*model.NewBasicTradeParams(config.ProductCode, 0.01)); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	t.Run("report", func(t *testing.T) {
+		if err := summaryUsecase.Report(config.ProductCode, model.SummaryPeriodWeekly, 365); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		// 送ったまとめは次の起点として記録される
+		report, err := summaryReportRepository.FindLatest(config.ProductCode, model.SummaryPeriodWeekly)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if report == nil {
+			t.Fatal("report is nil")
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
+)
+
+type TaxReportUsecase interface {
+	Get(productCode string, method model.CostMethod) ([]model.TaxReport, error)
+	WriteCSV(w io.Writer, productCode string, method model.CostMethod) error
+}
+
+type taxReportUsecase struct {
+	signalEventService service.SignalEventService
+	commissionRate     float64
+	location           *time.Location
+}
+
+func NewTaxReportUsecase(ss service.SignalEventService, commissionRate float64, location *time.Location) TaxReportUsecase {
+	return &taxReportUsecase{
+		signalEventService: ss,
+		commissionRate:     commissionRate,
+		location:           location,
+	}
+}
+
+func (tu *taxReportUsecase) Get(productCode string, method model.CostMethod) ([]model.TaxReport, error) {
+	if !method.IsValid() {
+		return nil, errors.New("invalid cost method: " + string(method))
+	}
+
+	signals, err := tu.signalEventService.FindAll(productCode)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := model.NewTaxReports(productCode, signals, tu.commissionRate, method, tu.location)
+	if reports == nil {
+		return nil, errors.New("failed to create tax reports")
+	}
+
+	return reports, nil
+}
+
+var taxReportCSVHeader = []string{
+	"年",
+	"通貨ペア",
+	"計算方法",
+	"購入数量",
+	"購入額",
+	"売却数量",
+	"売却額",
+	"売却原価",
+	"手数料",
+	"所得金額",
+	"年末数量",
+	"年末取得価額",
+}
+
+var costMethodLabel = map[model.CostMethod]string{
+	model.CostMethodMovingAverage: "移動平均法",
+	model.CostMethodTotalAverage:  "総平均法",
+}
+
+func (tu *taxReportUsecase) WriteCSV(w io.Writer, productCode string, method model.CostMethod) error {
+	reports, err := tu.Get(productCode, method)
+	if err != nil {
+		return err
+	}
+
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(taxReportCSVHeader); err != nil {
+		return err
+	}
+	for _, report := range reports {
+		record := []string{
+			strconv.Itoa(report.Year()),
+			report.ProductCode(),
+			costMethodLabel[report.Method()],
+			formatFloat(report.BuySize()),
+			formatFloat(report.BuyAmount()),
+			formatFloat(report.SellSize()),
+			formatFloat(report.SellAmount()),
+			formatFloat(report.Cost()),
+			formatFloat(report.Commission()),
+			formatFloat(report.Gain()),
+			formatFloat(report.EndPosition()),
+			formatFloat(report.EndCostAmount()),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
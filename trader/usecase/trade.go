@@ -1,9 +1,11 @@
 package usecase
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/model"
 	"github.com/Fukkatsuso/cryptocurrency-trading-bot/trader/domain/service"
 )
 
@@ -31,7 +33,19 @@ func (tu *tradeUsecase) Trade(productCode string, pastPeriod int) error {
 	beforeTradeTime := time.Now().UTC()
 
 	err := tu.tradeService.Trade(productCode, pastPeriod)
+	// 取引が無効なら何もしない
+	if errors.Is(err, model.ErrTradeDisabled) {
+		return nil
+	}
 	if err != nil {
+		if err := tu.notificationService.NotifyOfTradingFailed(productCode, err); err != nil {
+			fmt.Println(err.Error())
+		}
+		// 取引を見送っただけなら失敗にしない
+		if _, skipped := model.TradeSkipReason(err); skipped {
+			fmt.Println(err.Error())
+			return nil
+		}
 		return err
 	}
 
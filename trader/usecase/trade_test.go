@@ -29,8 +29,8 @@ func TestTradeUsecase(t *testing.T) {
 	indicatorService := service.NewIndicatorService()
 	dataFrameService := service.NewDataFrameService(indicatorService)
 	tradeParamsService := service.NewTradeParamsService(tradeParamsRepository, dataFrameService)
-	tradeService := service.NewTradeService(balanceRepository, tickerRepository, orderRepository, signalEventRepository, candleService, dataFrameService, tradeParamsService)
 	notificationService := service.NewNotificationService(notificationRepository)
+	tradeService := service.NewTradeService(balanceRepository, tickerRepository, orderRepository, signalEventRepository, candleService, dataFrameService, tradeParamsService, notificationService)
 
 	tradeUsecase := usecase.NewTradeUsecase(signalEventService, tradeService, notificationService)
 